@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/fardream/gen-move-container/gen"
+	"github.com/spf13/cobra"
+)
+
+func GetBTreeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "btree",
+		Short: "generate b+ tree",
+		Long: `generate b+ tree, where each node holds up to order - 1 keys.
+
+Packing many keys in one node reduces the number of nodes visited,
+which is the number of table items read with a table backend.`,
+	}
+
+	btree := gen.NewBTreeData()
+
+	setSharedCmd(cmd, btree.Shared)
+	cmd.Flags().IntVar(&btree.KeyIntWidth, "key-width", btree.KeyIntWidth, "int width for keys")
+	cmd.Flags().IntVar(&btree.Order, "order", btree.Order, "max number of children of a node")
+
+	setGeneratorRun(cmd, btree)
+
+	return cmd
+}
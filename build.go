@@ -0,0 +1,72 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/fardream/gen-move-container/gen"
+	"github.com/spf13/cobra"
+)
+
+func GetBuildCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "generate all containers listed in a manifest",
+		Long: `Generate all containers and random operation tests listed in a toml manifest.
+
+Each [[container]] table takes the same options as the command of its kind,
+and output paths are relative to the directory of the manifest.
+
+    address = "container"
+
+    [[container]]
+    kind = "red-black"       # red-black, avl, bst, critbit, btree, skip-list, heap, or linked-list
+    module = "red_black"     # default to the module name of the command
+    key-width = 128
+    key-count = 1            # trees only
+    with-spec = false        # trees with vector backend only
+    order = 16               # btree only
+    max-level = 16           # skip-list only
+    max-heap = false         # heap only
+    stable-index = false     # trees, critbit and linked-list only
+    backend = "vector"       # vector, aptos-table, aptos-smart-table, aptos-smart-vector, sui-table, sui-object-table or sui-dynamic-field
+    bucket-size = 16         # aptos-smart-vector only
+    output = "sources/red-black.move"
+
+Each [[random-test]] table takes the same options as the gen-tests command.
+
+    [[random-test]]
+    kind = "red-black"       # red-black, avl, bst or critbit
+    container-module = "red_black"
+    key-width = 128
+    key-count = 1
+    seed = 1
+    ops = 5000
+    checkpoint = 500
+    output = "tests/red_black_random_test.move"
+`,
+		Args: cobra.NoArgs,
+	}
+
+	manifestFile := "containers.toml"
+	check := false
+
+	cmd.Flags().StringVarP(&manifestFile, "file", "f", manifestFile, "manifest file")
+	cmd.MarkFlagFilename("file", "toml")
+	cmd.Flags().BoolVar(&check, "check", check, "instead of writing the output files, check they are up to date and print the diffs if not.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		manifest, err := gen.LoadManifest(manifestFile)
+		if err != nil {
+			return err
+		}
+
+		generators, err := manifest.Generators(filepath.Dir(manifestFile))
+		if err != nil {
+			return err
+		}
+
+		return runGenerators(cmd, check, generators...)
+	}
+
+	return cmd
+}
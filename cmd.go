@@ -1,12 +1,18 @@
 package main
 
-import "github.com/spf13/cobra"
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
 
 func main() {
 	cmd := &cobra.Command{
 		Use:   "gen-move-container",
 		Short: "generate container types for move",
 		Long:  longDescription,
+
+		SilenceUsage: true,
 	}
 
 	cmd.AddCommand(
@@ -14,8 +20,15 @@ func main() {
 		GetAvlCmd(),
 		GetVanillaBinarySearchTreeCmd(),
 		GetCritbitTreeCmd(),
+		GetBTreeCmd(),
+		GetSkipListCmd(),
+		GetHeapCmd(),
 		GetLinkedListCmd(),
+		GetBuildCmd(),
+		GetGenTestsCmd(),
 	)
 
-	cmd.Execute()
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
 }
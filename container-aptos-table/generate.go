@@ -2,8 +2,4 @@ package container_aptos_table
 
 // Run go generate to generate the files.
 
-//go:generate go run .. red-black --use-aptos-table
-//go:generate go run .. avl --use-aptos-table
-//go:generate go run .. bst --use-aptos-table
-//go:generate go run .. critbit --use-aptos-table
-//go:generate go run .. linked-list --use-aptos-table
+//go:generate go run .. build -f containers.toml
@@ -0,0 +1,1346 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Tree based on GNU libavl https://adtinfo.org/
+module container::avl_stable {
+    use aptos_std::table_with_length::{Self as table, TableWithLength as Table};
+    use std::vector;
+
+    /// Slots stores the elements without moving them: an element keeps its index until it is taken out.
+    /// The slot of a taken element becomes vacant and is reused by the next add.
+    struct Slots<T> has store {
+        items: Table<u64, T>,
+        // indices of the vacant slots, the last one is reused first.
+        vacant: vector<u64>,
+        // number of slots including the vacant ones. compact drops vacant slots from the vacant list, so it cannot be derived from it.
+        capacity: u64,
+    }
+
+    fun new_slots<T: store>(): Slots<T> {
+        Slots<T> {
+            items: table::new(),
+            vacant: vector::empty(),
+            capacity: 0,
+        }
+    }
+
+    /// length is the number of elements in the slots.
+    fun length<T>(slots: &Slots<T>): u64 {
+        table::length(&slots.items)
+    }
+
+    /// capacity is the number of slots, including the vacant ones. All indices are less than the capacity.
+    fun capacity<T>(slots: &Slots<T>): u64 {
+        slots.capacity
+    }
+
+    /// is_occupied checks if there is an element at index.
+    fun is_occupied<T>(slots: &Slots<T>, index: u64): bool {
+        table::contains(&slots.items, index)
+    }
+
+    fun borrow<T>(slots: &Slots<T>, index: u64): &T {
+        table::borrow(&slots.items, index)
+    }
+
+    fun borrow_mut<T>(slots: &mut Slots<T>, index: u64): &mut T {
+        table::borrow_mut(&mut slots.items, index)
+    }
+
+    /// next_slot is the index the next add will put the element at.
+    fun next_slot<T>(slots: &Slots<T>): u64 {
+        if (vector::is_empty(&slots.vacant)) {
+            capacity(slots)
+        } else {
+            *vector::borrow(&slots.vacant, vector::length(&slots.vacant) - 1)
+        }
+    }
+
+    /// add puts the element into the last vacated slot, or a new slot if none is vacant, and returns its index.
+    fun add<T>(slots: &mut Slots<T>, item: T): u64 {
+        if (vector::is_empty(&slots.vacant)) {
+            let index = capacity(slots);
+            table::add(&mut slots.items, index, item);
+            slots.capacity = index + 1;
+            index
+        } else {
+            let index = vector::pop_back(&mut slots.vacant);
+            fill(slots, index, item);
+            index
+        }
+    }
+
+    /// take removes the element at index and leaves the slot vacant.
+    fun take<T>(slots: &mut Slots<T>, index: u64): T {
+        let item = table::remove(&mut slots.items, index);
+        vector::push_back(&mut slots.vacant, index);
+        item
+    }
+
+    /// fill puts the element into the slot at index, which must be vacant and already off the vacant list.
+    fun fill<T>(slots: &mut Slots<T>, index: u64, item: T) {
+        table::add(&mut slots.items, index, item);
+    }
+
+    /// relocate moves the element at index to new_index, which must be vacant and already off the vacant list.
+    /// the slot at index is left vacant, but not put on the vacant list.
+    fun relocate<T>(slots: &mut Slots<T>, index: u64, new_index: u64) {
+        let item = table::remove(&mut slots.items, index);
+        fill(slots, new_index, item);
+    }
+
+    /// pop_vacant_below takes a vacant slot with index less than bound off the vacant list.
+    /// vacant slots at or above bound are dropped from the vacant list on the way.
+    /// aborts if there is no such slot.
+    fun pop_vacant_below<T>(slots: &mut Slots<T>, bound: u64): u64 {
+        loop {
+            let index = vector::pop_back(&mut slots.vacant);
+            if (index < bound) {
+                return index
+            };
+        }
+    }
+
+    /// shrink drops all the vacant slots, which must all be after the elements, so the capacity equals the length.
+    fun shrink<T>(slots: &mut Slots<T>) {
+        slots.capacity = length(slots);
+        slots.vacant = vector::empty();
+    }
+
+    /// destroy_slots destroys the slots, aborts if there is any element left.
+    fun destroy_slots<T>(slots: Slots<T>) {
+        let Slots { items, vacant: _, capacity: _ } = slots;
+        table::destroy_empty(items);
+    }
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_KEY_ALREADY_EXIST: u64 = 2;
+    const E_EMPTY_TREE: u64 = 3;
+    const E_INVALID_INDEX: u64 = 4;
+    const E_TREE_TOO_BIG: u64 = 5;
+    const E_TREE_NOT_EMPTY: u64 = 6;
+    const E_PARENT_NULL: u64 = 7;
+    const E_PARENT_INDEX_OUT_OF_RANGE: u64 = 8;
+    const E_RIGHT_ROTATE_LEFT_CHILD_NULL: u64 = 9;
+    const E_LEFT_ROTATE_RIGHT_CHILD_NULL: u64 = 10;
+
+    const E_AVL_REMOVAL_NOT_DECREASE: u64 = 11;
+    const E_AVL_NOT_IMBALANCED: u64 = 12;
+    const E_AVL_SUBTREE_IMBALANCED: u64 = 13;
+    const E_AVL_BAD_STATE: u64 = 14;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+
+    const AVL_ZERO: u8 = 128;
+    const AVL_RIGHT_HIGH: u8 = 129;
+    const AVL_RIGHT_HIGH_2: u8 = 130;
+    const AVL_LEFT_HIGH: u8 = 127;
+    const AVL_LEFT_HIGH_2: u8 = 126;
+
+    const METADATA_DEFAULT: u8 = 128;
+
+    /// Entry is the internal AvlTree element.
+    struct Entry<V> has store, copy, drop {
+        // key
+        key: u128,
+        // value
+        value: V,
+        // parent
+        parent: u64,
+        // left child
+        left_child: u64,
+        // right child.
+        right_child: u64,
+        // metadata
+        metadata: u8,
+    }
+
+    fun new_entry<V>(key: u128, value: V): Entry<V> {
+        Entry<V> {
+            key,
+            value,
+            parent: NULL_INDEX,
+            left_child: NULL_INDEX,
+            right_child: NULL_INDEX,
+            metadata: METADATA_DEFAULT,
+        }
+    }
+
+    #[test_only]
+    fun new_entry_for_test<V>(key: u128, value: V, parent: u64, left_child: u64, right_child: u64, metadata: u8): Entry<V> {
+        Entry {
+            key,
+            value,
+            parent,
+            left_child,
+            right_child,
+            metadata,
+        }
+    }
+
+    /// AvlTree contains a vector of Entry<V>, which is triple-linked binary search tree.
+    struct AvlTree<V> has store {
+        root: u64,
+        entries: Slots<Entry<V>>,
+        min_index: u64,
+        max_index: u64,
+    }
+
+    /// create new tree
+    public fun new<V: store>(): AvlTree<V> {
+        AvlTree {
+            root: NULL_INDEX,
+            entries: new_slots(),
+            min_index: NULL_INDEX,
+            max_index: NULL_INDEX,
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// find returns the element index in the AvlTree, or none if not found.
+    public fun find<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            if (node.key == key) {
+                return current
+            };
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        NULL_INDEX
+    }
+
+    /// lower_bound returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                result = current;
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// upper_bound returns the index of the first element with keys greater than the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                result = current;
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// floor returns the index of the last element with keys less than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                current = node.left_child;
+            } else {
+                result = current;
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// ceiling returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &AvlTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V>(tree: &AvlTree<V>, index: u64): (u128, &V) {
+        let entry = Self::borrow(&tree.entries, index);
+        (entry.key, &entry.value)
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V>(tree: &mut AvlTree<V>, index: u64): (u128, &mut V) {
+        let entry = Self::borrow_mut(&mut tree.entries, index);
+        (entry.key, &mut entry.value)
+    }
+
+    /// size returns the number of elements in the AvlTree.
+    public fun size<V>(tree: &AvlTree<V>): u64 {
+        Self::length(&tree.entries)
+    }
+
+    /// empty returns true if the AvlTree is empty.
+    public fun empty<V>(tree: &AvlTree<V>): bool {
+        Self::length(&tree.entries) == 0
+    }
+
+    /// get index of the min of the tree.
+    public fun get_min_index<V>(tree: &AvlTree<V>): u64 {
+        let current = tree.min_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the min of the subtree with root at index.
+    public fun get_min_index_from<V>(tree: &AvlTree<V>, index: u64): u64 {
+        let current = index;
+        let left_child = Self::borrow(&tree.entries, current).left_child;
+
+        while (left_child != NULL_INDEX) {
+            current = left_child;
+            left_child = Self::borrow(&tree.entries, current).left_child;
+        };
+
+        current
+    }
+
+    /// get index of the max of the tree.
+    public fun get_max_index<V>(tree: &AvlTree<V>): u64 {
+        let current = tree.max_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the max of the subtree with root at index.
+    public fun get_max_index_from<V>(tree: &AvlTree<V>, index: u64): u64 {
+        let current = index;
+        let right_child = Self::borrow(&tree.entries, current).right_child;
+
+        while (right_child != NULL_INDEX) {
+            current = right_child;
+            right_child = Self::borrow(&tree.entries, current).right_child;
+        };
+
+        current
+    }
+
+    /// find next value in order (the key is increasing)
+    public fun next_in_order<V>(tree: &AvlTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = Self::borrow(&tree.entries, index);
+        let right_child = node.right_child;
+        let parent = node.parent;
+
+        if (right_child != NULL_INDEX) {
+            // first, check if right child is null.
+            // then go to right child, and check if there is left child.
+            let next = right_child;
+            let next_left = Self::borrow(&tree.entries, next).left_child;
+            while (next_left != NULL_INDEX) {
+                next = next_left;
+                next_left = Self::borrow(&tree.entries, next).left_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no right child, check parent.
+            // if current is the left child of the parent, parent is then next.
+            // if current is the right child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_right_child(tree, current, parent)) {
+                current = parent;
+                parent = Self::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// find next value in reverse order (the key is decreasing)
+    public fun next_in_reverse_order<V>(tree: &AvlTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = Self::borrow(&tree.entries, index);
+        let left_child = node.left_child;
+        let parent = node.parent;
+        if (left_child != NULL_INDEX) {
+            // first, check if left child is null.
+            // then go to left child, and check if there is right child.
+            let next = left_child;
+            let next_right = Self::borrow(&tree.entries, next).right_child;
+            while (next_right != NULL_INDEX) {
+                next = next_right;
+                next_right = Self::borrow(&tree.entries, next).right_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no left child, check parent.
+            // if current is the right child of the parent, parent is then next.
+            // if current is the left child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_left_child(tree, current, parent)) {
+                current = parent;
+                parent = Self::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert puts the value keyed at the input keys into the AvlTree.
+    /// aborts if the key is already in the tree.
+    public fun insert<V>(tree: &mut AvlTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the AvlTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V>(tree: &mut AvlTree<V>, key: u128, value: V): u64 {
+        // the max size of the tree is NULL_INDEX.
+        assert!(size(tree) < NULL_INDEX, E_TREE_TOO_BIG);
+        let node = add(
+            &mut tree.entries,
+            new_entry(key, value)
+        );
+
+        let parent = NULL_INDEX;
+        let insert = tree.root;
+        let is_right_child = false;
+
+        while (insert != NULL_INDEX) {
+            let insert_node = Self::borrow(&tree.entries, insert);
+            assert!((insert_node.key != key), E_KEY_ALREADY_EXIST);
+            parent = insert;
+            is_right_child = ((insert_node.key < key));
+            insert = if (is_right_child) {
+                insert_node.right_child
+            } else {
+                insert_node.left_child
+            };
+        };
+
+        replace_parent(tree, node, parent);
+
+        if (parent != NULL_INDEX) {
+            if (is_right_child) {
+                replace_right_child(tree, parent, node);
+            } else {
+                replace_left_child(tree, parent, node);
+            };
+            let max_node = Self::borrow(&tree.entries, tree.max_index);
+            let is_max_smaller = ((max_node.key < key));
+            if (is_max_smaller) {
+                tree.max_index = node;
+            };
+            let min_node = Self::borrow(&tree.entries, tree.min_index);
+            let is_min_bigger = ((min_node.key > key));
+            if (is_min_bigger) {
+                tree.min_index = node;
+            };
+        } else {
+            tree.root = node;
+            tree.min_index = node;
+            tree.max_index = node;
+        };
+
+        // update avl metadata
+        while (parent != NULL_INDEX) {
+            let (increased, new_parent) = avl_update_insert(tree, parent, is_right_child);
+            if (!increased) {
+                break
+            };
+            parent = Self::borrow(&tree.entries, new_parent).parent;
+            if (parent == NULL_INDEX) {
+                break
+            };
+            is_right_child = is_right_child(tree, new_parent, parent);
+        };
+
+        node
+    }
+
+    /// remove deletes and returns the element from the AvlTree.
+    public fun remove<V>(tree: &mut AvlTree<V>, index: u64): (u128, V) {
+        if (tree.max_index == index) {
+            tree.max_index = next_in_reverse_order(tree, index);
+        };
+        if (tree.min_index == index) {
+            tree.min_index = next_in_order(tree, index);
+        };
+
+        let node = Self::borrow(&tree.entries, index);
+        let parent = node.parent;
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        let is_right = if (parent != NULL_INDEX) {
+            is_right_child(tree, index, parent)
+        } else {
+            false
+        };
+
+        let (rebalance_start, is_new_right) =
+        if (right_child == NULL_INDEX) {
+            // right child is null
+            // replace with left child.
+            // No need to swap metadata
+            // - in AVL, left is balanced and new value is also balanced.
+            // - in RB, left must be red and index must be black.
+            //         index
+            //       /       \
+            //     left
+            //  --
+            //        left
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, left_child, NULL_INDEX);
+                tree.root = left_child;
+            } else {
+                replace_child(tree, parent, index, left_child);
+            };
+            (parent, is_right)
+        } else if (left_child == NULL_INDEX){
+            // left child is null.
+            // replace with right child.
+            // No need to swap metadata.
+            // - in AVL, right is balanced and the new value is also balanced.
+            // - in RB, right must be red and index must be black.
+            //         index
+            //       /       \
+            //               right
+            //  --
+            //        right
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, right_child, NULL_INDEX);
+                tree.root = right_child;
+            } else {
+                replace_child(tree, parent, index, right_child);
+            };
+            (parent, is_right)
+        } else {
+            let right_child_s_left = Self::borrow(&tree.entries, right_child).left_child;
+            if (right_child_s_left == NULL_INDEX) {
+                // right child is not null, and right child's left child is null
+                //              index
+                //           /         \
+                //        left         right
+                //                        \
+                //                         a
+                // -------------
+                //               right
+                //            /       \
+                //          left       a
+                replace_left_child(tree, right_child, left_child);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, right_child, NULL_INDEX);
+                    tree.root = right_child;
+                } else {
+                    replace_child(tree, parent, index, right_child);
+                };
+
+                let old_metadata = Self::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = Self::borrow(&tree.entries, right_child).metadata;
+                Self::borrow_mut(&mut tree.entries, right_child).metadata = old_metadata;
+                Self::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (right_child, true)
+            } else {
+                // right child is not null, and right child's left child is not null either
+                //                 index
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    min
+                //                     \
+                //                      a
+                // -------------------------------------------------
+                //                   min
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    a
+                let next_successor = get_min_index_from(tree, right_child_s_left);
+                let next_successor_node = Self::borrow(&tree.entries, next_successor);
+                let successor_parent = next_successor_node.parent;
+                let next_successor_right = next_successor_node.right_child;
+
+                replace_left_child(tree, successor_parent, next_successor_right);
+                replace_left_child(tree, next_successor, left_child);
+                replace_right_child(tree, next_successor, right_child,);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, next_successor, NULL_INDEX);
+                    tree.root = next_successor;
+                } else {
+                    replace_child(tree, parent, index, next_successor);
+                };
+
+                let old_metadata = Self::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = Self::borrow(&tree.entries, next_successor).metadata;
+                Self::borrow_mut(&mut tree.entries, next_successor).metadata = old_metadata;
+                Self::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (successor_parent, false)
+            }
+        };
+
+        while (rebalance_start != NULL_INDEX) {
+            let (decreased, new_start) = avl_update_remove(tree, rebalance_start, is_new_right);
+            if (!decreased) {
+                break
+            };
+            rebalance_start = Self::borrow(&tree.entries, new_start).parent;
+            if (rebalance_start == NULL_INDEX) {
+                break
+            };
+
+            is_new_right = is_right_child(tree, new_start, rebalance_start);
+        };
+
+        ////////// now clear up, the slot of index is left vacant.
+        let Entry { key,  value, parent: _, left_child: _, right_child: _, metadata: _ } = take(&mut tree.entries, index);
+
+        if (size(tree) == 0) {
+            tree.root = NULL_INDEX;
+        };
+
+        (key,  value)
+    }
+
+    /// destroys the tree if it's empty.
+    public fun destroy_empty<V>(tree: AvlTree<V>) {
+        let AvlTree { entries, root: _, min_index: _, max_index: _ } = tree;
+        assert!(Self::length(&entries) == 0, E_TREE_NOT_EMPTY);
+        destroy_slots(entries);
+    }
+
+    /// compact moves the entries into the lowest indices, so no vacant slot is left behind by the removals.
+    /// Indices of the moved entries change, so any index held outside of the tree must be looked up again.
+    public fun compact<V>(tree: &mut AvlTree<V>) {
+        let count = size(tree);
+        let index = capacity(&tree.entries);
+        while (index > count) {
+            index = index - 1;
+            if (is_occupied(&tree.entries, index)) {
+                let new_index = pop_vacant_below(&mut tree.entries, count);
+                move_entry(tree, index, new_index);
+            };
+        };
+        shrink(&mut tree.entries);
+    }
+
+    /// move_entry moves the entry at index to the vacant new_index, and updates the links to it.
+    fun move_entry<V>(tree: &mut AvlTree<V>, index: u64, new_index: u64) {
+        relocate(&mut tree.entries, index, new_index);
+        if (tree.root == index) {
+            tree.root = new_index;
+        };
+        if (tree.max_index == index) {
+            tree.max_index = new_index;
+        };
+        if (tree.min_index == index) {
+            tree.min_index = new_index;
+        };
+        let node = borrow(&tree.entries, new_index);
+        let parent = node.parent;
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        replace_child(tree, parent, index, new_index);
+        replace_parent(tree, left_child, new_index);
+        replace_parent(tree, right_child, new_index);
+    }
+
+    /// check if index is the right child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_right_child<V>(tree: &AvlTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < capacity(&tree.entries), E_PARENT_INDEX_OUT_OF_RANGE);
+        Self::borrow(&tree.entries, parent_index).right_child == index
+    }
+
+    /// check if index is the left child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_left_child<V>(tree: &AvlTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < capacity(&tree.entries), E_PARENT_INDEX_OUT_OF_RANGE);
+        Self::borrow(&tree.entries, parent_index).left_child == index
+    }
+
+    /// Replace the child of parent if parent_index is not NULL_INDEX.
+    /// also replace parent index of the child.
+    fun replace_child<V>(tree: &mut AvlTree<V>, parent_index: u64, original_child: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            if (is_right_child(tree, original_child, parent_index)) {
+                replace_right_child(tree, parent_index, new_child);
+            } else if (is_left_child(tree, original_child, parent_index)) {
+                replace_left_child(tree, parent_index, new_child);
+            }
+        }
+    }
+
+    /// replace left child.
+    /// also replace parent index of the child.
+    fun replace_left_child<V>(tree: &mut AvlTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            Self::borrow_mut(&mut tree.entries, parent_index).left_child = new_child;
+            if (new_child != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace right child.
+    /// also replace parent index of the child.
+    fun replace_right_child<V>(tree: &mut AvlTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            Self::borrow_mut(&mut tree.entries, parent_index).right_child = new_child;
+                if (new_child != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace parent of index if index is not NULL_INDEX.
+    fun replace_parent<V>(tree: &mut AvlTree<V>, index: u64, parent_index: u64) {
+        if (index != NULL_INDEX) {
+            Self::borrow_mut(&mut tree.entries, index).parent = parent_index;
+        }
+    }
+
+
+    /// rotate_right (clockwise rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///        x      y
+    /// -----------------------------------------------------
+    ///                  left
+    ///              x          index
+    ///                       y       right
+    fun rotate_right<V>(tree: &mut AvlTree<V>, index: u64) {
+        let node = Self::borrow(&tree.entries, index);
+        let left = node.left_child;
+        assert!(
+            left != NULL_INDEX,
+            E_RIGHT_ROTATE_LEFT_CHILD_NULL
+        );
+        let y = Self::borrow(&tree.entries, left).right_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_left_child(tree, index, y);
+
+        // update left
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, left);
+        } else {
+            tree.root = left;
+            replace_parent(tree, left, NULL_INDEX);
+        };
+        replace_right_child(tree, left, index);
+    }
+
+    /// rotate_left (counter-clockwis rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///                       x          y
+    /// -----------------------------------------------------
+    ///                  right
+    ///          index             y
+    ///      left        x
+    fun rotate_left<V>(tree: &mut AvlTree<V>, index: u64) {
+        let node = Self::borrow(&tree.entries, index);
+        let right = node.right_child;
+        assert!(
+            right != NULL_INDEX,
+            E_INVALID_ARGUMENT,
+        );
+        let x = Self::borrow(&tree.entries, right).left_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_right_child(tree, index, x);
+
+        // update right
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, right);
+        } else {
+            tree.root = right;
+            replace_parent(tree, right, NULL_INDEX);
+        };
+        replace_left_child(tree, right, index);
+    }
+
+    // update the avl after an insertion resulted in height increase of sub tree of this sub tree at index.
+    // - index is the element to be updated.
+    // - is_right indicates if the insertion is from the right tree or left tree.
+    // returns
+    // - if the height of this sub tree is increased.
+    // - the new index of the sub tree at this point.
+    fun avl_update_insert<V>(tree: &mut AvlTree<V>, index: u64, is_right: bool): (bool, u64) {
+        if (index == NULL_INDEX) {
+            return (false, index)
+        };
+        let node = Self::borrow(&tree.entries, index);
+        let metadata = node.metadata;
+
+        // if the subtree is balanced, the height of the subtree is increased and the subtree becomes unbalance.
+        if (metadata == AVL_ZERO) {
+             let new_metadata = if (is_right) {
+                AVL_RIGHT_HIGH
+            } else {
+                AVL_LEFT_HIGH
+            };
+
+            Self::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+
+            return (true, index)
+        };
+
+        // if the left tree of this subtree is higher and the right sub tree is increased,
+        // the subtree here is now balanced and the height stays the same.
+        if (metadata == AVL_LEFT_HIGH && is_right) {
+            Self::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (false, index)
+        };
+
+        // similarly if the right sub tree of the this sub tree is higher and the left sub tree is increased,
+        // the subtree here is now balanced and the height stays the same.
+        if (metadata == AVL_RIGHT_HIGH && !is_right) {
+            Self::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (false, index)
+        };
+
+        // now the tree is unbalanced too much
+        let new_metadata = if (metadata == AVL_LEFT_HIGH) {
+            AVL_LEFT_HIGH_2
+        } else {
+            AVL_RIGHT_HIGH_2
+        };
+
+        Self::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+
+        let (decreased, new_index) = avl_rebalance(tree, index, false);
+        assert!(decreased, E_AVL_REMOVAL_NOT_DECREASE);
+
+        (false, new_index)
+    }
+
+    // update the avl after a removal resulted in height decrease of sub tree of this sub tree at index.
+    // - index is the element to be updated.
+    // - is_right indicates if the removal is from the right tree or left tree.
+    // returns
+    // - if the height of this sub tree is decreased.
+    // - the new index of the sub tree at this point.
+    fun avl_update_remove<V>(tree: &mut AvlTree<V>, index: u64, is_right: bool): (bool, u64) {
+        if (index == NULL_INDEX) {
+            return (false, index)
+        };
+
+        let metadata = Self::borrow(&tree.entries, index).metadata;
+
+        // sub tree is balanced, it becomes unbalanced but upper tree height doesn't decrease
+        if (metadata == AVL_ZERO) {
+            let new_metadata = if (is_right) {
+                AVL_LEFT_HIGH
+            } else {
+                AVL_RIGHT_HIGH
+            };
+
+            Self::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+            return (false, index)
+        };
+
+        // sub tree's left sub tree is high, decreasing its height set the sub tree to balanced.
+        // but parent tree height decreases
+        if (metadata == AVL_LEFT_HIGH && !is_right) {
+            Self::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (true, index)
+        };
+
+        // sub tree's right sub tree is high, decreasing its height set the sub tree to balanced.
+        // but parent tree height decreases
+        if (metadata == AVL_RIGHT_HIGH && is_right) {
+            Self::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (true, index)
+        };
+
+        let new_metadata = if (metadata == AVL_RIGHT_HIGH) {
+            AVL_RIGHT_HIGH_2
+        } else {
+            AVL_LEFT_HIGH_2
+        };
+
+        Self::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+
+        avl_rebalance(tree, index, true)
+    }
+
+    // AVL rebalances the sub tree at index.
+    // returns:
+    // - if the height of the subtree is decreased.
+    // - the index of the new subtree.
+    fun avl_rebalance<V>(tree: &mut AvlTree<V>, index: u64, is_remove: bool): (bool, u64) {
+        let node = Self::borrow(&tree.entries, index);
+        let metadata = node.metadata;
+
+        assert!(metadata == AVL_LEFT_HIGH_2 || metadata == AVL_RIGHT_HIGH_2, E_AVL_NOT_IMBALANCED);
+
+
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+
+        if (metadata == AVL_LEFT_HIGH_2) {
+            // left subtree is higher
+            let left_metadata = Self::borrow(&tree.entries, left_child).metadata;
+
+            assert!(left_metadata != AVL_RIGHT_HIGH_2 && left_metadata != AVL_LEFT_HIGH_2, E_AVL_SUBTREE_IMBALANCED);
+            assert!(is_remove || left_metadata != AVL_ZERO, E_AVL_BAD_STATE);
+
+            if (left_metadata != AVL_RIGHT_HIGH) {
+                // case 1:
+                //              index --
+                //            /           \
+                //         left (-/0)        right
+                //        /   \
+                //       a     b
+                //      /     /
+                //     c     (/e)
+                // -------
+                //               left (0/+)
+                //              /      \
+                //             a     index (0/-)
+                //            /    /         \
+                //           c    b          right
+                //               /
+                //              (/e)
+                let old_left_meta = left_metadata;
+                rotate_right(tree, index);
+                if (old_left_meta == AVL_ZERO) {
+                    Self::borrow_mut(&mut tree.entries, left_child).metadata = AVL_RIGHT_HIGH;
+                    Self::borrow_mut(&mut tree.entries, index).metadata = AVL_LEFT_HIGH;
+                } else {
+                    Self::borrow_mut(&mut tree.entries, left_child).metadata = AVL_ZERO;
+                    Self::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+                };
+
+                (old_left_meta != AVL_ZERO, left_child)
+            } else {
+                // case 2:
+                //              index --
+                //            /          \
+                //         left +       right
+                //       /    \
+                //      a      w (+/0/-)
+                //           /   \
+                //       (/b/b)  (c/c/)
+                // --------
+                //                   w 0
+                //                /       \
+                //       left (-1/0/0)    index (0/0/1)
+                //       /    \           /     \
+                //      a   (/b/b)   (c/c/)      right
+                let w = Self::borrow(&tree.entries, left_child).right_child;
+                let w_meta = Self::borrow(&tree.entries, w).metadata;
+                rotate_left(tree, left_child);
+                rotate_right(tree, index);
+                Self::borrow_mut(&mut tree.entries, w).metadata = AVL_ZERO;
+                Self::borrow_mut(&mut tree.entries, left_child).metadata = if(w_meta == AVL_RIGHT_HIGH) { AVL_LEFT_HIGH } else {AVL_ZERO};
+                Self::borrow_mut(&mut tree.entries, index).metadata = if(w_meta == AVL_LEFT_HIGH) {AVL_RIGHT_HIGH} else {AVL_ZERO};
+
+                (true, w)
+            }
+        } else {
+            let right_metadata = Self::borrow(&tree.entries, right_child).metadata;
+
+            assert!(right_metadata != AVL_RIGHT_HIGH_2 && right_metadata != AVL_LEFT_HIGH_2, E_AVL_SUBTREE_IMBALANCED);
+            assert!(is_remove || right_metadata != AVL_ZERO, E_AVL_BAD_STATE);
+
+            if (right_metadata != AVL_LEFT_HIGH) {
+                // case 1:
+                //              index ++
+                //            /           \
+                //         left         right +/0
+                //                       /   \
+                //                      a     b
+                //                     /       \
+                //                    (/c)      d
+                // -------
+                //                 right 0/-1
+                //              /          \
+                //           index 0/1       b
+                //         /        \         \
+                //       left        a         d
+                //                    \
+                //                    (/c)
+                let old_right_meta = right_metadata;
+                rotate_left(tree, index);
+                if (old_right_meta == AVL_ZERO) {
+                    Self::borrow_mut(&mut tree.entries, right_child).metadata = AVL_LEFT_HIGH;
+                    Self::borrow_mut(&mut tree.entries, index).metadata = AVL_RIGHT_HIGH;
+                } else {
+                    Self::borrow_mut(&mut tree.entries, right_child).metadata = AVL_ZERO;
+                    Self::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+                };
+                (old_right_meta != AVL_ZERO, right_child)
+            } else {
+                // case 2:
+                //                index ++
+                //            /             \
+                //         left            right -
+                //                     /          \
+                //                   w (-/0/+)      a
+                //                  /   \
+                //               (b/b/) (/c/c)
+                // --------
+                //                    w 0
+                //            /             \
+                //      index (0/0/-1)    right (1/0/0)
+                //       /    \           /     \
+                //      left  (b/b/)  (/c/c)     a
+                let w = Self::borrow(&tree.entries, right_child).left_child;
+                let w_meta = Self::borrow(&tree.entries, w).metadata;
+                rotate_right(tree, right_child);
+                rotate_left(tree, index);
+                Self::borrow_mut(&mut tree.entries, w).metadata = AVL_ZERO;
+                Self::borrow_mut(&mut tree.entries, right_child).metadata = if (w_meta == AVL_LEFT_HIGH) {AVL_RIGHT_HIGH} else {AVL_ZERO};
+                Self::borrow_mut(&mut tree.entries, index).metadata = if (w_meta == AVL_RIGHT_HIGH) {AVL_LEFT_HIGH} else {AVL_ZERO};
+
+                (true, w)
+            }
+        }
+    }
+
+    #[test]
+    fun test_bounds() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 3, 8, 13, ..., 98 out of order.
+            let v = ((idx * 7) % 20) * 5 + 3;
+            let index = insert_and_get_index(&mut tree, v, v);
+            assert!(find(&tree, v) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        let k: u128 = 0;
+        while (k < 110) {
+            let expected_lower = NULL_INDEX;
+            let expected_upper = NULL_INDEX;
+            let expected_floor = NULL_INDEX;
+            let iter = get_min_index(&tree);
+            while (iter != NULL_INDEX) {
+                let (key, _) = borrow_at_index(&tree, iter);
+                if (key <= k) {
+                    expected_floor = iter;
+                };
+                if (key >= k && expected_lower == NULL_INDEX) {
+                    expected_lower = iter;
+                };
+                if (key > k && expected_upper == NULL_INDEX) {
+                    expected_upper = iter;
+                };
+                iter = next_in_order(&tree, iter);
+            };
+
+            assert!(lower_bound(&tree, k) == expected_lower, (k as u64));
+            assert!(upper_bound(&tree, k) == expected_upper, (k as u64));
+            assert!(floor(&tree, k) == expected_floor, (k as u64));
+            assert!(ceiling(&tree, k) == expected_lower, (k as u64));
+            k = k + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_stable_index() {
+        let tree = new<u128>();
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 0, 1, ..., 19 out of order.
+            let v = (idx * 7) % 20;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let indices = vector::empty<u64>();
+        let idx: u128 = 0;
+        while (idx < 20) {
+            vector::push_back(&mut indices, find(&tree, idx));
+            idx = idx + 1;
+        };
+
+        let removed = vector<u128>[3, 10, 0, 19, 7];
+        let i = 0;
+        while (i < vector::length(&removed)) {
+            let key = *vector::borrow(&removed, i);
+            remove(&mut tree, *vector::borrow(&indices, (key as u64)));
+            i = i + 1;
+        };
+        assert!(size(&tree) == 15, size(&tree));
+
+        // the other elements are still at their indices.
+        let idx: u128 = 0;
+        while (idx < 20) {
+            let expected = if (vector::contains(&removed, &idx)) {
+                NULL_INDEX
+            } else {
+                *vector::borrow(&indices, (idx as u64))
+            };
+            assert!(find(&tree, idx) == expected, (idx as u64));
+            idx = idx + 1;
+        };
+
+        // the last vacated slot is reused first.
+        assert!(insert_and_get_index(&mut tree, 25, 25) == *vector::borrow(&indices, 7), 0);
+
+        compact(&mut tree);
+        assert!(capacity(&tree.entries) == 16, capacity(&tree.entries));
+        let count = 0;
+        let iter = get_min_index(&tree);
+        let last_key: u128 = 0;
+        while (iter != NULL_INDEX) {
+            assert!(iter < 16, iter);
+            let (key, value) = borrow_at_index(&tree, iter);
+            assert!(key == *value, count);
+            assert!(count == 0 || key > last_key, count);
+            assert!(find(&tree, key) == iter, count);
+            last_key = key;
+            count = count + 1;
+            iter = next_in_order(&tree, iter);
+        };
+        assert!(count == 16, count);
+        let (max_key, _) = borrow_at_index(&tree, get_max_index(&tree));
+        assert!(max_key == 25, 0);
+
+        while (!empty(&tree)) {
+            let index = get_max_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_compact_drops_vacant_slots() {
+        let tree = new<u128>();
+        // 10 to 16 are at 0 to 6, and 1, 2, 3 are at 7, 8, 9.
+        let keys = vector<u128>[10, 11, 12, 13, 14, 15, 16, 1, 2, 3];
+        let i = 0;
+        while (i < vector::length(&keys)) {
+            let key = *vector::borrow(&keys, i);
+            assert!(insert_and_get_index(&mut tree, key, key) == i, i);
+            i = i + 1;
+        };
+        let key: u128 = 10;
+        while (key < 17) {
+            let index = find(&tree, key);
+            remove(&mut tree, index);
+            key = key + 1;
+        };
+
+        // 2 at 8 is the parent of 3 at 9. Moving 3 first drops the vacant slots 6 to 3 from the vacant list,
+        // and the parent must still be a valid index.
+        assert!(Self::borrow(&tree.entries, 9).parent == 8, 0);
+        compact(&mut tree);
+        assert!(capacity(&tree.entries) == 3, capacity(&tree.entries));
+        let key: u128 = 1;
+        let iter = get_min_index(&tree);
+        while (key < 4) {
+            assert!(iter < 3, iter);
+            assert!(find(&tree, key) == iter, (key as u64));
+            key = key + 1;
+            iter = next_in_order(&tree, iter);
+        };
+        assert!(iter == NULL_INDEX, iter);
+
+        while (!empty(&tree)) {
+            let index = get_max_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_min_iter_avl() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 0;
+        let iter = get_min_index(&tree);
+        while (idx < 20) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx, (v as u64));
+            idx = idx + 1;
+            iter = next_in_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let min_index = get_min_index(&tree);
+        remove(&mut tree, min_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let min_index = get_min_index(&tree);
+            let (key, value) = borrow_at_index(&tree, min_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, min_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+
+
+    #[test]
+    fun test_max_iter_avl() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 20;
+        let iter = get_max_index(&tree);
+        while (idx > 0) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx - 1, (v as u64));
+            idx = idx - 1;
+            iter = next_in_reverse_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let max_index = get_max_index(&tree);
+        remove(&mut tree, max_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let max_index = get_max_index(&tree);
+            let (key, value) = borrow_at_index(&tree, max_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, max_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+}
@@ -0,0 +1,438 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Binary Min Heap
+module container::heap {
+    use std::vector;
+    use aptos_std::table_with_length::{Self as table, TableWithLength as Table};
+    fun swap<V>(table: &mut Table<u64, V>, i: u64, j: u64) {
+        let i_item = table::remove(table, i);
+        let j_item = table::remove(table, j);
+        table::add(table, j, i_item);
+        table::add(table, i, j_item);
+    }
+    fun push_back<V>(t: &mut Table<u64, V>, v: V) {
+        let i = table::length(t);
+        table::add(t, i, v)
+    }
+    fun pop_back<V>(t: &mut Table<u64, V>): V {
+        let i = table::length(t) - 1;
+        table::remove(t, i)
+    }
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_EMPTY_HEAP: u64 = 2;
+    const E_INDEX_OUT_OF_RANGE: u64 = 5;
+    const E_CANNOT_DESTRORY_NON_EMPTY: u64 = 7;
+    const E_EXCEED_CAPACITY: u64 = 8;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    const MAX_CAPACITY: u64 = 18446744073709551614; // NULL_INDEX - 1
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+    /// Node is an element in the heap.
+    struct Node<V> has store, copy, drop {
+        key: u128,
+        value: V,
+        // handle of the element, which doesn't change when the element moves in the heap.
+        handle: u64,
+    }
+
+    /// Heap is a binary min heap, the element with the smallest key is at the top.
+    /// Elements are referenced by handles returned from push, which stay valid until the element is removed.
+    struct Heap<V> has store {
+        nodes: Table<u64, Node<V>>,
+        // positions[handle] is the position of the element in nodes, or NULL_INDEX if the handle is not in use.
+        positions: Table<u64, u64>,
+        // handles not in use.
+        free: vector<u64>,
+    }
+
+    public fun new<V: store>(): Heap<V> {
+        Heap<V> {
+            nodes: table::new(),
+            positions: table::new(),
+            free: vector::empty(),
+        }
+    }
+
+    /// from_vectors creates a heap from the keys and values in O(n).
+    /// The handle of each element is its index in the input vectors.
+    public fun from_vectors<V: store>(keys: vector<u128>, values: vector<V>): Heap<V> {
+        assert!(vector::length(&keys) == vector::length(&values), E_INVALID_ARGUMENT);
+
+        let heap = new<V>();
+        vector::reverse(&mut keys);
+        vector::reverse(&mut values);
+        while (!vector::is_empty(&keys)) {
+            let handle = table::length(&heap.nodes);
+            assert!(
+                handle < MAX_CAPACITY,
+                E_EXCEED_CAPACITY
+            );
+            push_back(&mut heap.nodes, Node {
+                key: vector::pop_back(&mut keys),
+                value: vector::pop_back(&mut values),
+                handle,
+            });
+            push_back(&mut heap.positions, handle);
+        };
+        vector::destroy_empty(keys);
+        vector::destroy_empty(values);
+
+        let position = table::length(&heap.nodes) / 2;
+        while (position > 0) {
+            position = position - 1;
+            sift_down(&mut heap, position);
+        };
+
+        heap
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// size returns the number of elements in the Heap.
+    public fun size<V>(heap: &Heap<V>): u64 {
+        table::length(&heap.nodes)
+    }
+
+    /// empty returns true if the Heap is empty.
+    public fun empty<V>(heap: &Heap<V>): bool {
+        table::length(&heap.nodes) == 0
+    }
+
+    /// peek returns the key and a reference to the value of the element at the top of the heap.
+    public fun peek<V>(heap: &Heap<V>): (u128, &V) {
+        assert!(!empty(heap), E_EMPTY_HEAP);
+        let node = table::borrow(&heap.nodes, 0);
+        (node.key, &node.value)
+    }
+
+    /// peek_handle returns the handle of the element at the top of the heap.
+    public fun peek_handle<V>(heap: &Heap<V>): u64 {
+        assert!(!empty(heap), E_EMPTY_HEAP);
+        table::borrow(&heap.nodes, 0).handle
+    }
+
+    /// contains returns true if the handle refers to an element in the heap.
+    public fun contains<V>(heap: &Heap<V>, handle: u64): bool {
+        handle < table::length(&heap.positions) && *table::borrow(&heap.positions, handle) != NULL_INDEX
+    }
+
+    /// borrow returns the key and a reference to the value of the element with the handle.
+    public fun borrow<V>(heap: &Heap<V>, handle: u64): (u128, &V) {
+        let node = table::borrow(&heap.nodes, position_of(heap, handle));
+        (node.key, &node.value)
+    }
+
+    /// borrow_mut returns the key and a mutable reference to the value of the element with the handle.
+    public fun borrow_mut<V>(heap: &mut Heap<V>, handle: u64): (u128, &mut V) {
+        let position = position_of(heap, handle);
+        let node = table::borrow_mut(&mut heap.nodes, position);
+        (node.key, &mut node.value)
+    }
+
+    fun position_of<V>(heap: &Heap<V>, handle: u64): u64 {
+        assert!(contains(heap, handle), E_INDEX_OUT_OF_RANGE);
+        *table::borrow(&heap.positions, handle)
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// push adds the value keyed at the input key to the heap, and returns the handle of the new element.
+    public fun push<V>(heap: &mut Heap<V>, key: u128, value: V): u64 {
+        let position = table::length(&heap.nodes);
+        assert!(
+            position < MAX_CAPACITY,
+            E_EXCEED_CAPACITY
+        );
+
+        let handle = if (vector::is_empty(&heap.free)) {
+            let handle = table::length(&heap.positions);
+            push_back(&mut heap.positions, position);
+            handle
+        } else {
+            let handle = vector::pop_back(&mut heap.free);
+            *table::borrow_mut(&mut heap.positions, handle) = position;
+            handle
+        };
+
+        push_back(&mut heap.nodes, Node {
+            key,
+            value,
+            handle,
+        });
+        sift_up(heap, position);
+
+        handle
+    }
+
+    /// pop removes the element at the top of the heap, and returns its key and value.
+    public fun pop<V>(heap: &mut Heap<V>): (u128, V) {
+        assert!(!empty(heap), E_EMPTY_HEAP);
+        let Node {
+            key,
+            value,
+            handle: _,
+        } = remove_at(heap, 0);
+
+        (key, value)
+    }
+
+    /// remove deletes the element with the handle from the heap, and returns its value.
+    public fun remove<V>(heap: &mut Heap<V>, handle: u64): V {
+        let position = position_of(heap, handle);
+        let Node {
+            key: _,
+            value,
+            handle: _,
+        } = remove_at(heap, position);
+
+        value
+    }
+
+    /// update_key changes the key of the element with the handle.
+    public fun update_key<V>(heap: &mut Heap<V>, handle: u64, key: u128) {
+        let position = position_of(heap, handle);
+        table::borrow_mut(&mut heap.nodes, position).key = key;
+        restore(heap, position);
+    }
+
+    /// decrease_key changes the key of the element with the handle to a key that is not larger.
+    public fun decrease_key<V>(heap: &mut Heap<V>, handle: u64, key: u128) {
+        let (current, _) = borrow(heap, handle);
+        assert!(key <= current, E_INVALID_ARGUMENT);
+        update_key(heap, handle, key);
+    }
+
+    /// increase_key changes the key of the element with the handle to a key that is not smaller.
+    public fun increase_key<V>(heap: &mut Heap<V>, handle: u64, key: u128) {
+        let (current, _) = borrow(heap, handle);
+        assert!(key >= current, E_INVALID_ARGUMENT);
+        update_key(heap, handle, key);
+    }
+
+    /// destroys the heap if it's empty.
+    public fun destroy_empty<V>(heap: Heap<V>) {
+        assert!(table::length(&heap.nodes) == 0, E_CANNOT_DESTRORY_NON_EMPTY);
+
+        let Heap<V> {
+            nodes,
+            positions,
+            free: _,
+        } = heap;
+
+        // positions may still hold the freed handles.
+        while (table::length(&positions) > 0) {
+            pop_back(&mut positions);
+        };
+
+        table::destroy_empty(nodes);
+        table::destroy_empty(positions);
+    }
+
+    /// remove_at removes the element at the position of the nodes and frees its handle.
+    /// the element is first swapped to the end of the nodes, then popped out.
+    fun remove_at<V>(heap: &mut Heap<V>, position: u64): Node<V> {
+        let last_position = table::length(&heap.nodes) - 1;
+        if (position != last_position) {
+            swap_nodes(heap, position, last_position);
+        };
+
+        let node = pop_back(&mut heap.nodes);
+        *table::borrow_mut(&mut heap.positions, node.handle) = NULL_INDEX;
+        vector::push_back(&mut heap.free, node.handle);
+
+        if (position < last_position) {
+            restore(heap, position);
+        };
+
+        node
+    }
+
+    /// restore moves the element at the position up or down to restore the heap order.
+    fun restore<V>(heap: &mut Heap<V>, position: u64) {
+        if (position > 0 && table::borrow(&heap.nodes, position).key < table::borrow(&heap.nodes, (position - 1) / 2).key) {
+            sift_up(heap, position);
+        } else {
+            sift_down(heap, position);
+        };
+    }
+
+    /// sift_up moves the element at the position up until its parent is before it.
+    fun sift_up<V>(heap: &mut Heap<V>, position: u64) {
+        let key = table::borrow(&heap.nodes, position).key;
+        while (position > 0) {
+            let parent = (position - 1) / 2;
+            if (!(key < table::borrow(&heap.nodes, parent).key)) {
+                break
+            };
+            swap_nodes(heap, position, parent);
+            position = parent;
+        };
+    }
+
+    /// sift_down moves the element at the position down until none of its children is before it.
+    fun sift_down<V>(heap: &mut Heap<V>, position: u64) {
+        let length = table::length(&heap.nodes);
+        loop {
+            let child = position * 2 + 1;
+            if (child >= length) {
+                break
+            };
+            let right = child + 1;
+            if (right < length && table::borrow(&heap.nodes, right).key < table::borrow(&heap.nodes, child).key) {
+                child = right;
+            };
+            if (!(table::borrow(&heap.nodes, child).key < table::borrow(&heap.nodes, position).key)) {
+                break
+            };
+            swap_nodes(heap, position, child);
+            position = child;
+        };
+    }
+
+    /// swap_nodes swaps the elements at the two positions and updates their positions.
+    fun swap_nodes<V>(heap: &mut Heap<V>, i: u64, j: u64) {
+        swap(&mut heap.nodes, i, j);
+        let i_handle = table::borrow(&heap.nodes, i).handle;
+        let j_handle = table::borrow(&heap.nodes, j).handle;
+        *table::borrow_mut(&mut heap.positions, i_handle) = i;
+        *table::borrow_mut(&mut heap.positions, j_handle) = j;
+    }
+
+    #[test_only]
+    fun check_heap<V>(heap: &Heap<V>) {
+        let position = 0;
+        let length = table::length(&heap.nodes);
+        while (position < length) {
+            let node = table::borrow(&heap.nodes, position);
+            if (position > 0) {
+                assert!(!(node.key < table::borrow(&heap.nodes, (position - 1) / 2).key), position);
+            };
+            assert!(*table::borrow(&heap.positions, node.handle) == position, position);
+            position = position + 1;
+        };
+        assert!(table::length(&heap.positions) == length + vector::length(&heap.free), length);
+    }
+
+    #[test]
+    fun test_heap() {
+        let heap = new<u128>();
+        let handles = vector::empty<u64>();
+        let i = 0;
+        while (i < 100) {
+            let key = (((i * 37) % 100) as u128);
+            vector::push_back(&mut handles, push(&mut heap, key, key));
+            check_heap(&heap);
+            i = i + 1;
+        };
+        assert!(size(&heap) == 100, size(&heap));
+
+        // handles are not changed by the pushes.
+        let i = 0;
+        while (i < 100) {
+            let (key, value) = borrow(&heap, *vector::borrow(&handles, i));
+            assert!(key == (((i * 37) % 100) as u128), i);
+            assert!(*value == key, i);
+            i = i + 1;
+        };
+
+        let i = 0;
+        while (i < 50) {
+            let (top, _) = peek(&heap);
+            let (key, value) = pop(&mut heap);
+            assert!(key == top, i);
+            assert!(key == (i as u128), i);
+            assert!(value == key, i);
+            check_heap(&heap);
+            i = i + 1;
+        };
+        assert!(size(&heap) == 50, size(&heap));
+
+        // handles of the popped elements are freed, and the others are not changed.
+        let i = 0;
+        while (i < 100) {
+            let handle = *vector::borrow(&handles, i);
+            let key = (i * 37) % 100;
+            assert!(contains(&heap, handle) == (key >= 50), i);
+            if (contains(&heap, handle)) {
+                let (current, _) = borrow(&heap, handle);
+                assert!(current == (key as u128), i);
+            };
+            i = i + 1;
+        };
+
+        // move an element to the top, the element at 1 is keyed 37, and the element at 2 is keyed 74.
+        let handle = *vector::borrow(&handles, 2);
+        decrease_key(&mut heap, handle, 0);
+        check_heap(&heap);
+        assert!(peek_handle(&heap) == handle, handle);
+
+        // move it back.
+        update_key(&mut heap, handle, 74);
+        check_heap(&heap);
+        assert!(peek_handle(&heap) != handle, handle);
+
+        let value = remove(&mut heap, handle);
+        assert!(value == 74, (value as u64));
+        assert!(!contains(&heap, handle), handle);
+        check_heap(&heap);
+
+        // freed handles are reused.
+        let new_handle = push(&mut heap, 100, 100);
+        assert!(new_handle < 100, new_handle);
+        check_heap(&heap);
+
+        let last = 0;
+        let count = 0;
+        while (!empty(&heap)) {
+            let (key, _) = pop(&mut heap);
+            assert!(key >= last, count);
+            last = key;
+            check_heap(&heap);
+            count = count + 1;
+        };
+        assert!(count == 50, count);
+
+        destroy_empty(heap);
+    }
+
+    #[test]
+    fun test_heap_from_vectors() {
+        let heap = from_vectors<u128>(
+            vector<u128>[5, 3, 8, 1, 9, 2, 7],
+            vector<u128>[50, 30, 80, 10, 90, 20, 70],
+        );
+        check_heap(&heap);
+        assert!(size(&heap) == 7, size(&heap));
+
+        let (key, value) = borrow(&heap, 2);
+        assert!(key == 8 && *value == 80, (key as u64));
+
+        let (key, value) = pop(&mut heap);
+        assert!(key == 1 && value == key * 10, (key as u64));
+        let (key, _) = pop(&mut heap);
+        assert!(key == 2, (key as u64));
+        check_heap(&heap);
+
+        while (!empty(&heap)) {
+            pop(&mut heap);
+        };
+
+        destroy_empty(heap);
+    }
+}
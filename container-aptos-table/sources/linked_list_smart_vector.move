@@ -0,0 +1,308 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Double Linked List
+module container::linked_list_smart_vector {
+    use aptos_std::smart_vector::{Self, SmartVector, swap, push_back, pop_back};
+
+    // BUCKET_SIZE is the number of elements in each bucket of the smart vector, which is stored in one table item.
+    // The smart vector is created without inline capacity, so all the elements are in the buckets.
+    const BUCKET_SIZE: u64 = 64;
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_EMPTY_TREE: u64 = 2;
+    const E_KEY_ALREADY_EXIST: u64 = 4;
+    const E_INDEX_OUT_OF_RANGE: u64 = 5;
+    const E_CANNOT_DESTRORY_NON_EMPTY: u64 = 7;
+    const E_EXCEED_CAPACITY: u64 = 8;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    const MAX_CAPACITY: u64 = 18446744073709551614; // NULL_INDEX - 1
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+    // Node is a node in the linked list
+    struct Node<V> has store, copy, drop {
+        value: V,
+
+        prev: u64,
+        next: u64,
+    }
+
+    /// LinkedList is a double linked list.
+    struct LinkedList<V: store> has store {
+        head: u64,
+        tail: u64,
+        entries: SmartVector<Node<V>>,
+    }
+
+    public fun new<V: store>(): LinkedList<V> {
+        LinkedList<V> {
+            head: NULL_INDEX,
+            tail: NULL_INDEX,
+            entries: smart_vector::empty_with_config(0, BUCKET_SIZE),
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V: store>(list: &LinkedList<V>, index: u64): &V {
+        let entry = smart_vector::borrow(&list.entries, index);
+        &entry.value
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V: store>(list: &mut LinkedList<V>, index: u64): &mut V {
+        let entry = smart_vector::borrow_mut(&mut list.entries, index);
+        &mut entry.value
+    }
+
+    /// size returns the number of elements in the LinkedList.
+    public fun size<V: store>(list: &LinkedList<V>): u64 {
+        smart_vector::length(&list.entries)
+    }
+
+    /// empty returns true if the LinkedList is empty.
+    public fun empty<V: store>(list: &LinkedList<V>): bool {
+        smart_vector::length(&list.entries) == 0
+    }
+
+    /// get next entry in linkedlist
+    public fun next<V: store>(list: &LinkedList<V>, index: u64): u64 {
+        smart_vector::borrow(&list.entries, index).next
+    }
+
+    /// get previous entry in linkedlist
+    public fun previous<V: store>(list: &LinkedList<V>, index: u64): u64 {
+        smart_vector::borrow(&list.entries, index).prev
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert
+    public fun insert<V: store>(list: &mut LinkedList<V>, value: V) {
+        let index = list.tail;
+        insert_after(list, index, value)
+    }
+
+    /// insert at the end of the list, and return the index of the new element.
+    public fun insert_and_get_index<V: store>(list: &mut LinkedList<V>, value: V): u64 {
+        let index = list.tail;
+        insert_after_and_get_index(list, index, value)
+    }
+
+    /// insert after index. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after<V: store>(list: &mut LinkedList<V>, index: u64, value: V) {
+        insert_after_and_get_index(list, index, value);
+    }
+
+    /// insert after index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after_and_get_index<V: store>(list: &mut LinkedList<V>, index: u64, value: V): u64 {
+        let new_index = smart_vector::length(&list.entries);
+        assert!(
+            new_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY,
+        );
+
+        let node = Node<V>{
+            value,
+            prev: index,
+            next: NULL_INDEX,
+        };
+
+        if (new_index == 0 && index == NULL_INDEX) {
+            list.head = new_index;
+            list.tail = new_index;
+            push_back(&mut list.entries, node);
+            return new_index
+        };
+
+        assert!(
+            index != NULL_INDEX && index < new_index,
+            E_INDEX_OUT_OF_RANGE,
+        );
+
+        let prev = smart_vector::borrow_mut(&mut list.entries, index);
+        node.next = prev.next;
+        prev.next = new_index;
+        if (node.next != NULL_INDEX) {
+            smart_vector::borrow_mut(&mut list.entries, node.next).prev = new_index;
+        } else {
+            list.tail = new_index;
+        };
+
+        push_back(&mut list.entries, node);
+
+        new_index
+    }
+
+    /// isnert before index. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before<V: store>(list: &mut LinkedList<V>, index: u64, value: V) {
+        insert_before_and_get_index(list, index, value);
+    }
+
+    /// insert before index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before_and_get_index<V: store>(list: &mut LinkedList<V>, index: u64, value: V): u64 {
+        let new_index = smart_vector::length(&list.entries);
+        assert!(
+            new_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY,
+        );
+
+        let node = Node<V>{
+            value,
+            prev: NULL_INDEX,
+            next: index,
+        };
+
+        if (new_index == 0 && index == NULL_INDEX) {
+            list.head = new_index;
+            list.tail = new_index;
+            push_back(&mut list.entries, node);
+            return new_index
+        };
+
+        assert!(
+            index != NULL_INDEX && index < new_index,
+            E_INDEX_OUT_OF_RANGE,
+        );
+        let next = smart_vector::borrow_mut(&mut list.entries, index);
+        node.prev = next.prev;
+        next.prev = new_index;
+        if (node.prev != NULL_INDEX) {
+            smart_vector::borrow_mut(&mut list.entries, node.prev).next = new_index;
+        } else {
+            list.head = new_index;
+        };
+
+        push_back(&mut list.entries, node);
+
+        new_index
+    }
+
+    /// remove deletes and returns the element from the LinkedList.
+    /// element is first swapped to the end of the container, then popped out.
+    public fun remove<V: store>(list: &mut LinkedList<V>, index: u64): V {
+        let to_remove = smart_vector::borrow(&list.entries, index);
+        let prev = to_remove.prev;
+        let next = to_remove.next;
+        if (prev != NULL_INDEX) {
+            smart_vector::borrow_mut(&mut list.entries, prev).next = next;
+        } else {
+            list.head = next;
+        };
+        if (next != NULL_INDEX) {
+            smart_vector::borrow_mut(&mut list.entries, next).prev = prev;
+        } else {
+            list.tail = next;
+        };
+
+        // swap the element to be removed with the last element
+        if (index + 1 != smart_vector::length(&list.entries)) {
+            let tail_index = smart_vector::length(&list.entries) - 1;
+            swap(&mut list.entries, index, tail_index);
+            let swapped = smart_vector::borrow(&list.entries, index);
+            let prev = swapped.prev;
+            let next = swapped.next;
+            if (prev != NULL_INDEX) {
+                smart_vector::borrow_mut(&mut list.entries, prev).next = index;
+            } else {
+                list.head = index;
+            };
+            if (next != NULL_INDEX) {
+                smart_vector::borrow_mut(&mut list.entries, next).prev = index;
+            } else {
+                list.tail = index;
+            };
+        };
+
+        // pop
+        let Node {
+            value,
+            next: _,
+            prev: _,
+        } = pop_back(&mut list.entries);
+
+        value
+    }
+
+    /// destroys the linked list if it's empty.
+    public fun destroy_empty<V: store>(tree: LinkedList<V>) {
+        assert!(smart_vector::length(&tree.entries) == 0, E_CANNOT_DESTRORY_NON_EMPTY);
+
+        let LinkedList<V> {
+            entries,
+            head: _,
+            tail: _,
+        } = tree;
+
+        smart_vector::destroy_empty(entries);
+    }
+
+    #[test]
+    public fun test_insert_remove_linked_list() {
+        let l = new<u128>();
+        let i = 0;
+        while (i < 10) {
+            // 1, 3, ..., 19 at indices 0, 1, ..., 9.
+            insert(&mut l, (i as u128) * 2 + 1);
+            i = i + 1;
+        };
+        let i = 0;
+        while (i < 10) {
+            // 2, 4, ..., 20 right after 1, 3, ..., 19.
+            assert!(insert_after_and_get_index(&mut l, i, (i as u128) * 2 + 2) == i + 10, i);
+            i = i + 1;
+        };
+        let head = l.head;
+        insert_before(&mut l, head, 0);
+        assert!(size(&l) == 21, size(&l));
+
+        let expected: u128 = 0;
+        let index = l.head;
+        while (index != NULL_INDEX) {
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            expected = expected + 1;
+            index = next(&l, index);
+        };
+        assert!(expected == 21, (expected as u64));
+        let index = l.tail;
+        while (index != NULL_INDEX) {
+            expected = expected - 1;
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            index = previous(&l, index);
+        };
+        assert!(expected == 0, (expected as u64));
+
+        while (!empty(&l)) {
+            let head = l.head;
+            assert!(remove(&mut l, head) == expected, (expected as u64));
+            expected = expected + 1;
+            // the rest is still linked in order.
+            let count = 0;
+            let index = l.head;
+            while (index != NULL_INDEX) {
+                assert!(*borrow_at_index(&l, index) == expected + (count as u128), count);
+                count = count + 1;
+                index = next(&l, index);
+            };
+            assert!(count == size(&l), count);
+        };
+        assert!(expected == 21, (expected as u64));
+
+        destroy_empty(l);
+    }
+}
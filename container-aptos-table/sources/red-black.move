@@ -17,9 +17,6 @@ module container::red_black {
         let i = table::length(t) - 1;
         table::remove(t, i)
     }
-    fun is_empty<V>(t: &Table<u64, V>): bool {
-        table::length(t) == 0
-    }
 
     const E_INVALID_ARGUMENT: u64 = 1;
     const E_KEY_ALREADY_EXIST: u64 = 2;
@@ -137,6 +134,72 @@ module container::red_black {
         NULL_INDEX
     }
 
+    /// lower_bound returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = table::borrow(&tree.entries, current);
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                result = current;
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// upper_bound returns the index of the first element with keys greater than the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = table::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                result = current;
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// floor returns the index of the last element with keys less than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = table::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                current = node.left_child;
+            } else {
+                result = current;
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// ceiling returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
     /// borrow returns a reference to the element with its key at the given index
     public fun borrow_at_index<V>(tree: &RedBlackTree<V>, index: u64): (u128, &V) {
         let entry = table::borrow(&tree.entries, index);
@@ -273,6 +336,12 @@ module container::red_black {
     /// insert puts the value keyed at the input keys into the RedBlackTree.
     /// aborts if the key is already in the tree.
     public fun insert<V>(tree: &mut RedBlackTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the RedBlackTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V>(tree: &mut RedBlackTree<V>, key: u128, value: V): u64 {
         // the max size of the tree is NULL_INDEX.
         assert!(size(tree) < NULL_INDEX, E_TREE_TOO_BIG);
 		push_back(
@@ -346,6 +415,8 @@ module container::red_black {
             let root = tree.root;
             table::borrow_mut(&mut tree.entries, root).metadata = RB_BLACK;
         };
+
+        node
     }
 
     /// remove deletes and returns the element from the RedBlackTree.
@@ -529,7 +600,7 @@ module container::red_black {
     /// destroys the tree if it's empty.
     public fun destroy_empty<V>(tree: RedBlackTree<V>) {
         let RedBlackTree { entries, root: _, min_index: _, max_index: _ } = tree;
-        assert!(is_empty(&entries), E_TREE_NOT_EMPTY);
+        assert!(table::length(&entries) == 0, E_TREE_NOT_EMPTY);
         table::destroy_empty(entries);
     }
 
@@ -1040,4 +1111,178 @@ module container::red_black {
             }
         }
     }
+
+    #[test]
+    fun test_bounds() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 3, 8, 13, ..., 98 out of order.
+            let v = ((idx * 7) % 20) * 5 + 3;
+            let index = insert_and_get_index(&mut tree, v, v);
+            assert!(find(&tree, v) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        let k: u128 = 0;
+        while (k < 110) {
+            let expected_lower = NULL_INDEX;
+            let expected_upper = NULL_INDEX;
+            let expected_floor = NULL_INDEX;
+            let iter = get_min_index(&tree);
+            while (iter != NULL_INDEX) {
+                let (key, _) = borrow_at_index(&tree, iter);
+                if (key <= k) {
+                    expected_floor = iter;
+                };
+                if (key >= k && expected_lower == NULL_INDEX) {
+                    expected_lower = iter;
+                };
+                if (key > k && expected_upper == NULL_INDEX) {
+                    expected_upper = iter;
+                };
+                iter = next_in_order(&tree, iter);
+            };
+
+            assert!(lower_bound(&tree, k) == expected_lower, (k as u64));
+            assert!(upper_bound(&tree, k) == expected_upper, (k as u64));
+            assert!(floor(&tree, k) == expected_floor, (k as u64));
+            assert!(ceiling(&tree, k) == expected_lower, (k as u64));
+            k = k + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_min_iter_redblack() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 0;
+        let iter = get_min_index(&tree);
+        while (idx < 20) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx, (v as u64));
+            idx = idx + 1;
+            iter = next_in_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let min_index = get_min_index(&tree);
+        remove(&mut tree, min_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let min_index = get_min_index(&tree);
+            let (key, value) = borrow_at_index(&tree, min_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, min_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_max_iter_redblack() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 20;
+        let iter = get_max_index(&tree);
+        while (idx > 0) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx - 1, (v as u64));
+            idx = idx - 1;
+            iter = next_in_reverse_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let max_index = get_max_index(&tree);
+        remove(&mut tree, max_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let max_index = get_max_index(&tree);
+            let (key, value) = borrow_at_index(&tree, max_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, max_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
 }
@@ -0,0 +1,5 @@
+package container_sui
+
+// Run go generate to generate the files.
+
+//go:generate go run .. build -f containers.toml
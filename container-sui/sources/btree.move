@@ -0,0 +1,843 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// B+ tree with up to 15 keys in each node.
+module container::btree {
+    use std::vector;
+    use sui::dynamic_field as field;
+    use sui::object::{Self, UID};
+    use sui::tx_context::TxContext;
+    #[test_only]
+    use sui::tx_context;
+
+    /// Fields stores the elements as dynamic fields of its object, keyed by the indices from 0 to length - 1.
+    struct Fields<phantom V> has store {
+        id: UID,
+        length: u64,
+    }
+    fun new_fields<V: store>(ctx: &mut TxContext): Fields<V> {
+        Fields { id: object::new(ctx), length: 0 }
+    }
+    fun destroy_fields<V: store>(t: Fields<V>) {
+        let Fields { id, length: _ } = t;
+        object::delete(id);
+    }
+    fun length<V: store>(t: &Fields<V>): u64 {
+        t.length
+    }
+    fun borrow<V: store>(t: &Fields<V>, i: u64): &V {
+        field::borrow(&t.id, i)
+    }
+    fun borrow_mut<V: store>(t: &mut Fields<V>, i: u64): &mut V {
+        field::borrow_mut(&mut t.id, i)
+    }
+    fun swap<V: store>(t: &mut Fields<V>, i: u64, j: u64) {
+        let i_item: V = field::remove(&mut t.id, i);
+        let j_item: V = field::remove(&mut t.id, j);
+        field::add(&mut t.id, j, i_item);
+        field::add(&mut t.id, i, j_item);
+    }
+    fun push_back<V: store>(t: &mut Fields<V>, v: V) {
+        field::add(&mut t.id, t.length, v);
+        t.length = t.length + 1;
+    }
+    fun pop_back<V: store>(t: &mut Fields<V>): V {
+        t.length = t.length - 1;
+        field::remove(&mut t.id, t.length)
+    }
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_EMPTY_TREE: u64 = 2;
+    const E_KEY_ALREADY_EXIST: u64 = 4;
+    const E_INDEX_OUT_OF_RANGE: u64 = 5;
+    const E_CANNOT_DESTRORY_NON_EMPTY: u64 = 7;
+    const E_EXCEED_CAPACITY: u64 = 8;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    const MAX_CAPACITY: u64 = 18446744073709551614; // NULL_INDEX - 1
+
+    // MAX_KEYS is the max number of keys in a node, which is the order of the tree - 1.
+    const MAX_KEYS: u64 = 15;
+    // MIN_KEYS is the min number of keys in a node other than the root.
+    const MIN_KEYS: u64 = 7;
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+    /// Entry is an element in the tree.
+    struct Entry<V> has store, copy, drop {
+        key: u128,
+        value: V,
+        // the leaf node holding the key of the entry.
+        leaf: u64,
+    }
+
+    /// Node is a node in the tree.
+    /// For an internal node, children are the indices of the child nodes, and there is one more child than keys.
+    /// The keys in the subtree at children[i] are greater than or equal to keys[i-1] and less than keys[i].
+    /// For a leaf node, children are the indices of the entries of the keys.
+    struct Node has store, copy, drop {
+        is_leaf: bool,
+        parent: u64,
+        keys: vector<u128>,
+        children: vector<u64>,
+        // previous and next leaf nodes, always NULL_INDEX for internal nodes.
+        prev: u64,
+        next: u64,
+    }
+
+    /// BTree is a B+ tree. The nodes are stored separately from the entries,
+    /// and each node holds up to MAX_KEYS keys.
+    struct BTree<V: store> has store {
+        root: u64,
+        nodes: Fields<Node>,
+        entries: Fields<Entry<V>>,
+    }
+
+    public fun new<V: store>(ctx: &mut TxContext): BTree<V> {
+        BTree<V> {
+            root: NULL_INDEX,
+            nodes: new_fields(ctx),
+            entries: new_fields(ctx),
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// find returns the element index in the tree, or NULL_INDEX if not found.
+    public fun find<V: store>(tree: &BTree<V>, key: u128): u64 {
+        let leaf = find_leaf(tree, key);
+        if (leaf == NULL_INDEX) {
+            return NULL_INDEX
+        };
+
+        let node = Self::borrow(&tree.nodes, leaf);
+        let position = lower_position(&node.keys, key);
+        if (position < vector::length(&node.keys) && *vector::borrow(&node.keys, position) == key) {
+            *vector::borrow(&node.children, position)
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// lower_bound returns the index of the first element with key greater than or equal to the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V: store>(tree: &BTree<V>, key: u128): u64 {
+        let leaf = find_leaf(tree, key);
+        if (leaf == NULL_INDEX) {
+            return NULL_INDEX
+        };
+
+        let position = lower_position(&Self::borrow(&tree.nodes, leaf).keys, key);
+        entry_at_or_after(tree, leaf, position)
+    }
+
+    /// upper_bound returns the index of the first element with key greater than the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V: store>(tree: &BTree<V>, key: u128): u64 {
+        let leaf = find_leaf(tree, key);
+        if (leaf == NULL_INDEX) {
+            return NULL_INDEX
+        };
+
+        let position = upper_position(&Self::borrow(&tree.nodes, leaf).keys, key);
+        entry_at_or_after(tree, leaf, position)
+    }
+
+    /// floor returns the index of the last element with key less than or equal to the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V: store>(tree: &BTree<V>, key: u128): u64 {
+        let leaf = find_leaf(tree, key);
+        if (leaf == NULL_INDEX) {
+            return NULL_INDEX
+        };
+
+        let position = upper_position(&Self::borrow(&tree.nodes, leaf).keys, key);
+        entry_before(tree, leaf, position)
+    }
+
+    /// ceiling returns the index of the first element with key greater than or equal to the input key,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V: store>(tree: &BTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V: store>(tree: &BTree<V>, index: u64): (u128, &V) {
+        let entry = Self::borrow(&tree.entries, index);
+        (entry.key, &entry.value)
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V: store>(tree: &mut BTree<V>, index: u64): (u128, &mut V) {
+        let entry = Self::borrow_mut(&mut tree.entries, index);
+        (entry.key, &mut entry.value)
+    }
+
+    /// size returns the number of elements in the BTree.
+    public fun size<V: store>(tree: &BTree<V>): u64 {
+        Self::length(&tree.entries)
+    }
+
+    /// empty returns true if the BTree is empty.
+    public fun empty<V: store>(tree: &BTree<V>): bool {
+        Self::length(&tree.entries) == 0
+    }
+
+    /// get index of the min of the tree.
+    public fun get_min_index<V: store>(tree: &BTree<V>): u64 {
+        let current = tree.root;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        loop {
+            let node = Self::borrow(&tree.nodes, current);
+            let child = *vector::borrow(&node.children, 0);
+            if (node.is_leaf) {
+                return child
+            };
+            current = child;
+        }
+    }
+
+    /// get index of the max of the tree.
+    public fun get_max_index<V: store>(tree: &BTree<V>): u64 {
+        let current = tree.root;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        loop {
+            let node = Self::borrow(&tree.nodes, current);
+            let child = *vector::borrow(&node.children, vector::length(&node.children) - 1);
+            if (node.is_leaf) {
+                return child
+            };
+            current = child;
+        }
+    }
+
+    /// find next value in order (the key is increasing)
+    public fun next_in_order<V: store>(tree: &BTree<V>, index: u64): u64 {
+        let entry = Self::borrow(&tree.entries, index);
+        let leaf = entry.leaf;
+        let position = lower_position(&Self::borrow(&tree.nodes, leaf).keys, entry.key);
+        entry_at_or_after(tree, leaf, position + 1)
+    }
+
+    /// find next value in reverse order (the key is decreasing)
+    public fun next_in_reverse_order<V: store>(tree: &BTree<V>, index: u64): u64 {
+        let entry = Self::borrow(&tree.entries, index);
+        let leaf = entry.leaf;
+        let position = lower_position(&Self::borrow(&tree.nodes, leaf).keys, entry.key);
+        entry_before(tree, leaf, position)
+    }
+
+    /// find_leaf returns the leaf node where the key is or should be, or NULL_INDEX if the tree is empty.
+    fun find_leaf<V: store>(tree: &BTree<V>, key: u128): u64 {
+        let current = tree.root;
+        while (current != NULL_INDEX) {
+            let node = Self::borrow(&tree.nodes, current);
+            if (node.is_leaf) {
+                break
+            };
+            current = *vector::borrow(&node.children, upper_position(&node.keys, key));
+        };
+
+        current
+    }
+
+    /// entry_at_or_after returns the entry at the position of the leaf node,
+    /// or the first entry of the next leaf node if the position is at the end of the leaf node.
+    fun entry_at_or_after<V: store>(tree: &BTree<V>, leaf: u64, position: u64): u64 {
+        let node = Self::borrow(&tree.nodes, leaf);
+        if (position < vector::length(&node.children)) {
+            *vector::borrow(&node.children, position)
+        } else if (node.next != NULL_INDEX) {
+            *vector::borrow(&Self::borrow(&tree.nodes, node.next).children, 0)
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// entry_before returns the entry before the position of the leaf node,
+    /// or the last entry of the previous leaf node if the position is at the start of the leaf node.
+    fun entry_before<V: store>(tree: &BTree<V>, leaf: u64, position: u64): u64 {
+        let node = Self::borrow(&tree.nodes, leaf);
+        if (position > 0) {
+            *vector::borrow(&node.children, position - 1)
+        } else if (node.prev != NULL_INDEX) {
+            let prev = Self::borrow(&tree.nodes, node.prev);
+            *vector::borrow(&prev.children, vector::length(&prev.children) - 1)
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// lower_position returns the number of keys less than the key.
+    fun lower_position(keys: &vector<u128>, key: u128): u64 {
+        let low = 0;
+        let high = vector::length(keys);
+        while (low < high) {
+            let middle = (low + high) / 2;
+            if (*vector::borrow(keys, middle) < key) {
+                low = middle + 1;
+            } else {
+                high = middle;
+            };
+        };
+
+        low
+    }
+
+    /// upper_position returns the number of keys less than or equal to the key.
+    fun upper_position(keys: &vector<u128>, key: u128): u64 {
+        let low = 0;
+        let high = vector::length(keys);
+        while (low < high) {
+            let middle = (low + high) / 2;
+            if (*vector::borrow(keys, middle) <= key) {
+                low = middle + 1;
+            } else {
+                high = middle;
+            };
+        };
+
+        low
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert puts the value keyed at the input key into the tree.
+    /// aborts if the key is already in the tree.
+    public fun insert<V: store>(tree: &mut BTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input key into the tree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V: store>(tree: &mut BTree<V>, key: u128, value: V): u64 {
+        let entry_index = Self::length(&tree.entries);
+        assert!(
+            entry_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY
+        );
+
+        if (tree.root == NULL_INDEX) {
+            let leaf = Self::length(&tree.nodes);
+            push_back(&mut tree.nodes, Node {
+                is_leaf: true,
+                parent: NULL_INDEX,
+                keys: vector::singleton(key),
+                children: vector::singleton(entry_index),
+                prev: NULL_INDEX,
+                next: NULL_INDEX,
+            });
+            push_back(&mut tree.entries, Entry { key, value, leaf });
+            tree.root = leaf;
+            return entry_index
+        };
+
+        let leaf = find_leaf(tree, key);
+        let node = Self::borrow_mut(&mut tree.nodes, leaf);
+        let position = lower_position(&node.keys, key);
+        assert!(
+            position == vector::length(&node.keys) || *vector::borrow(&node.keys, position) != key,
+            E_KEY_ALREADY_EXIST,
+        );
+        vector_insert(&mut node.keys, position, key);
+        vector_insert(&mut node.children, position, entry_index);
+        let is_full = vector::length(&node.keys) > MAX_KEYS;
+
+        push_back(&mut tree.entries, Entry { key, value, leaf });
+
+        if (is_full) {
+            split_node(tree, leaf);
+        };
+
+        entry_index
+    }
+
+    /// remove deletes and returns the key and value of the element from the tree.
+    /// element is first swapped to the end of the entries, then popped out.
+    public fun remove<V: store>(tree: &mut BTree<V>, index: u64): (u128, V) {
+        let entry = Self::borrow(&tree.entries, index);
+        let leaf = entry.leaf;
+        let position = lower_position(&Self::borrow(&tree.nodes, leaf).keys, entry.key);
+        let node = Self::borrow_mut(&mut tree.nodes, leaf);
+        vector::remove(&mut node.keys, position);
+        vector::remove(&mut node.children, position);
+
+        rebalance(tree, leaf);
+
+        // swap the element to be removed with the last element
+        let last_index = Self::length(&tree.entries) - 1;
+        if (index != last_index) {
+            swap(&mut tree.entries, index, last_index);
+            let swapped = Self::borrow(&tree.entries, index);
+            let swapped_leaf = swapped.leaf;
+            let position = lower_position(&Self::borrow(&tree.nodes, swapped_leaf).keys, swapped.key);
+            let node = Self::borrow_mut(&mut tree.nodes, swapped_leaf);
+            *vector::borrow_mut(&mut node.children, position) = index;
+        };
+
+        // pop
+        let Entry {
+            key,
+            value,
+            leaf: _,
+        } = pop_back(&mut tree.entries);
+
+        (key, value)
+    }
+
+    /// destroys the tree if it's empty.
+    public fun destroy_empty<V: store>(tree: BTree<V>) {
+        assert!(Self::length(&tree.entries) == 0, E_CANNOT_DESTRORY_NON_EMPTY);
+
+        let BTree<V> {
+            root: _,
+            nodes,
+            entries,
+        } = tree;
+
+        destroy_fields(entries);
+        destroy_fields(nodes);
+    }
+
+    /// split_node splits the node if it has more than MAX_KEYS keys, and inserts the separating key into the parent,
+    /// which may in turn split the parent.
+    fun split_node<V: store>(tree: &mut BTree<V>, node_index: u64) {
+        let current = node_index;
+        while (current != NULL_INDEX && vector::length(&Self::borrow(&tree.nodes, current).keys) > MAX_KEYS) {
+            let new_index = Self::length(&tree.nodes);
+            let node = Self::borrow_mut(&mut tree.nodes, current);
+            let is_leaf = node.is_leaf;
+            let parent = node.parent;
+            let middle = vector::length(&node.keys) / 2;
+            let keys = split_off(&mut node.keys, middle);
+            // the first key of the new leaf is copied to the parent,
+            // the first key of the new internal node is moved to the parent.
+            let separator = if (is_leaf) {
+                *vector::borrow(&keys, 0)
+            } else {
+                vector::remove(&mut keys, 0)
+            };
+            let children = split_off(&mut node.children, if (is_leaf) { middle } else { middle + 1 });
+            let prev = NULL_INDEX;
+            let next = NULL_INDEX;
+            if (is_leaf) {
+                prev = current;
+                next = node.next;
+                node.next = new_index;
+            };
+
+            push_back(&mut tree.nodes, Node {
+                is_leaf,
+                parent,
+                keys,
+                children,
+                prev,
+                next,
+            });
+            if (next != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.nodes, next).prev = new_index;
+            };
+            set_parent_of_children(tree, new_index);
+
+            if (parent == NULL_INDEX) {
+                let root = new_index + 1;
+                push_back(&mut tree.nodes, Node {
+                    is_leaf: false,
+                    parent: NULL_INDEX,
+                    keys: vector::singleton(separator),
+                    children: vector<u64>[current, new_index],
+                    prev: NULL_INDEX,
+                    next: NULL_INDEX,
+                });
+                Self::borrow_mut(&mut tree.nodes, current).parent = root;
+                Self::borrow_mut(&mut tree.nodes, new_index).parent = root;
+                tree.root = root;
+            } else {
+                let parent_node = Self::borrow_mut(&mut tree.nodes, parent);
+                let position = child_position(&parent_node.children, current);
+                vector_insert(&mut parent_node.keys, position, separator);
+                vector_insert(&mut parent_node.children, position + 1, new_index);
+            };
+
+            current = parent;
+        };
+    }
+
+    /// rebalance fixes the node with less than MIN_KEYS keys after a removal by borrowing a key from a sibling,
+    /// or merging with a sibling, which may in turn leave the parent with too few keys.
+    fun rebalance<V: store>(tree: &mut BTree<V>, node_index: u64) {
+        let current = node_index;
+        loop {
+            let node = Self::borrow(&tree.nodes, current);
+            let is_leaf = node.is_leaf;
+            let parent = node.parent;
+            let key_count = vector::length(&node.keys);
+
+            if (parent == NULL_INDEX) {
+                // an empty leaf root means the tree is empty,
+                // and an internal root without keys has a single child, which becomes the new root.
+                if (key_count == 0) {
+                    let new_root = if (is_leaf) {
+                        NULL_INDEX
+                    } else {
+                        *vector::borrow(&node.children, 0)
+                    };
+                    if (new_root != NULL_INDEX) {
+                        Self::borrow_mut(&mut tree.nodes, new_root).parent = NULL_INDEX;
+                    };
+                    tree.root = new_root;
+                    remove_node(tree, current);
+                };
+                break
+            };
+
+            if (key_count >= MIN_KEYS) {
+                break
+            };
+
+            let parent_node = Self::borrow(&tree.nodes, parent);
+            let position = child_position(&parent_node.children, current);
+            let left = if (position > 0) {
+                *vector::borrow(&parent_node.children, position - 1)
+            } else {
+                NULL_INDEX
+            };
+            let right = if (position + 1 < vector::length(&parent_node.children)) {
+                *vector::borrow(&parent_node.children, position + 1)
+            } else {
+                NULL_INDEX
+            };
+
+            if (left != NULL_INDEX && key_count_of(tree, left) > MIN_KEYS) {
+                borrow_from_left(tree, parent, position);
+                break
+            };
+            if (right != NULL_INDEX && key_count_of(tree, right) > MIN_KEYS) {
+                borrow_from_right(tree, parent, position);
+                break
+            };
+
+            current = if (left != NULL_INDEX) {
+                merge_children(tree, parent, position - 1)
+            } else {
+                merge_children(tree, parent, position)
+            };
+        };
+    }
+
+    /// borrow_from_left moves the last key of the left sibling to the child at the position of the parent.
+    fun borrow_from_left<V: store>(tree: &mut BTree<V>, parent: u64, position: u64) {
+        let parent_node = Self::borrow(&tree.nodes, parent);
+        let left = *vector::borrow(&parent_node.children, position - 1);
+        let current = *vector::borrow(&parent_node.children, position);
+        let separator = *vector::borrow(&parent_node.keys, position - 1);
+
+        let left_node = Self::borrow_mut(&mut tree.nodes, left);
+        let is_leaf = left_node.is_leaf;
+        let key = vector::pop_back(&mut left_node.keys);
+        let child = vector::pop_back(&mut left_node.children);
+
+        // for leaf nodes, the key itself moves to the node.
+        // for internal nodes, the separator moves down to the node.
+        // either way, the key becomes the new separator.
+        let node = Self::borrow_mut(&mut tree.nodes, current);
+        vector_insert(&mut node.keys, 0, if (is_leaf) { key } else { separator });
+        vector_insert(&mut node.children, 0, child);
+
+        *vector::borrow_mut(&mut Self::borrow_mut(&mut tree.nodes, parent).keys, position - 1) = key;
+        set_parent(tree, child, current, is_leaf);
+    }
+
+    /// borrow_from_right moves the first key of the right sibling to the child at the position of the parent.
+    fun borrow_from_right<V: store>(tree: &mut BTree<V>, parent: u64, position: u64) {
+        let parent_node = Self::borrow(&tree.nodes, parent);
+        let current = *vector::borrow(&parent_node.children, position);
+        let right = *vector::borrow(&parent_node.children, position + 1);
+        let separator = *vector::borrow(&parent_node.keys, position);
+
+        let right_node = Self::borrow_mut(&mut tree.nodes, right);
+        let is_leaf = right_node.is_leaf;
+        let key = vector::remove(&mut right_node.keys, 0);
+        let child = vector::remove(&mut right_node.children, 0);
+        // for leaf nodes, the next key of the right sibling becomes the new separator.
+        // for internal nodes, the separator moves down to the node, and the key becomes the new separator.
+        let new_separator = if (is_leaf) {
+            *vector::borrow(&right_node.keys, 0)
+        } else {
+            key
+        };
+
+        let node = Self::borrow_mut(&mut tree.nodes, current);
+        vector::push_back(&mut node.keys, if (is_leaf) { key } else { separator });
+        vector::push_back(&mut node.children, child);
+
+        *vector::borrow_mut(&mut Self::borrow_mut(&mut tree.nodes, parent).keys, position) = new_separator;
+        set_parent(tree, child, current, is_leaf);
+    }
+
+    /// merge_children merges the child at position + 1 of the parent into the child at position,
+    /// and returns the index of the parent, which may have been moved by the removal of the merged child.
+    fun merge_children<V: store>(tree: &mut BTree<V>, parent: u64, position: u64): u64 {
+        let parent_node = Self::borrow_mut(&mut tree.nodes, parent);
+        let separator = vector::remove(&mut parent_node.keys, position);
+        let right = vector::remove(&mut parent_node.children, position + 1);
+        let left = *vector::borrow(&parent_node.children, position);
+
+        let right_node = Self::borrow(&tree.nodes, right);
+        let is_leaf = right_node.is_leaf;
+        let keys = right_node.keys;
+        let children = right_node.children;
+        let next = right_node.next;
+
+        let left_node = Self::borrow_mut(&mut tree.nodes, left);
+        if (!is_leaf) {
+            vector::push_back(&mut left_node.keys, separator);
+        };
+        vector::append(&mut left_node.keys, keys);
+        vector::append(&mut left_node.children, children);
+        if (is_leaf) {
+            left_node.next = next;
+            if (next != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.nodes, next).prev = left;
+            };
+        };
+        set_parent_of_children(tree, left);
+
+        remove_node(tree, right);
+
+        // the last node is swapped into the place of the removed node.
+        if (parent == Self::length(&tree.nodes)) {
+            right
+        } else {
+            parent
+        }
+    }
+
+    /// remove_node deletes the node that is no longer referenced by other nodes.
+    /// node is first swapped to the end of the nodes, then popped out.
+    fun remove_node<V: store>(tree: &mut BTree<V>, index: u64) {
+        let last_index = Self::length(&tree.nodes) - 1;
+        if (index != last_index) {
+            swap(&mut tree.nodes, index, last_index);
+            let node = Self::borrow(&tree.nodes, index);
+            let parent = node.parent;
+            let prev = node.prev;
+            let next = node.next;
+            if (parent == NULL_INDEX) {
+                tree.root = index;
+            } else {
+                let parent_node = Self::borrow_mut(&mut tree.nodes, parent);
+                let position = child_position(&parent_node.children, last_index);
+                *vector::borrow_mut(&mut parent_node.children, position) = index;
+            };
+            if (prev != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.nodes, prev).next = index;
+            };
+            if (next != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.nodes, next).prev = index;
+            };
+            set_parent_of_children(tree, index);
+        };
+
+        pop_back(&mut tree.nodes);
+    }
+
+    /// set_parent_of_children points the children of the node back to the node.
+    fun set_parent_of_children<V: store>(tree: &mut BTree<V>, node_index: u64) {
+        let node = Self::borrow(&tree.nodes, node_index);
+        let is_leaf = node.is_leaf;
+        let children = node.children;
+        let i = 0;
+        let count = vector::length(&children);
+        while (i < count) {
+            set_parent(tree, *vector::borrow(&children, i), node_index, is_leaf);
+            i = i + 1;
+        };
+    }
+
+    /// set_parent sets the parent of the child, which is an entry if the parent is a leaf node.
+    fun set_parent<V: store>(tree: &mut BTree<V>, child: u64, parent: u64, is_leaf: bool) {
+        if (is_leaf) {
+            Self::borrow_mut(&mut tree.entries, child).leaf = parent;
+        } else {
+            Self::borrow_mut(&mut tree.nodes, child).parent = parent;
+        };
+    }
+
+    fun key_count_of<V: store>(tree: &BTree<V>, index: u64): u64 {
+        vector::length(&Self::borrow(&tree.nodes, index).keys)
+    }
+
+    fun child_position(children: &vector<u64>, child: u64): u64 {
+        let (found, position) = vector::index_of(children, &child);
+        assert!(found, E_INVALID_ARGUMENT);
+        position
+    }
+
+    /// vector_insert inserts the element at position i of the vector, and shifts the elements after it to the right.
+    fun vector_insert<T>(v: &mut vector<T>, i: u64, e: T) {
+        vector::push_back(v, e);
+        let j = vector::length(v) - 1;
+        while (j > i) {
+            vector::swap(v, j - 1, j);
+            j = j - 1;
+        };
+    }
+
+    /// split_off removes the elements starting at position i from the vector, and returns them.
+    fun split_off<T>(v: &mut vector<T>, i: u64): vector<T> {
+        let result = vector::empty<T>();
+        while (vector::length(v) > i) {
+            vector::push_back(&mut result, vector::pop_back(v));
+        };
+        vector::reverse(&mut result);
+        result
+    }
+
+    #[test_only]
+    fun check_tree<V: store>(tree: &BTree<V>) {
+        let entry_count = 0;
+        let i = 0;
+        let node_count = Self::length(&tree.nodes);
+        while (i < node_count) {
+            let node = Self::borrow(&tree.nodes, i);
+            let key_count = vector::length(&node.keys);
+            assert!(key_count <= MAX_KEYS, i);
+            if (node.parent == NULL_INDEX) {
+                assert!(tree.root == i, i);
+            } else {
+                assert!(key_count >= MIN_KEYS, i);
+                assert!(vector::contains(&Self::borrow(&tree.nodes, node.parent).children, &i), i);
+            };
+
+            let j = 1;
+            while (j < key_count) {
+                assert!(*vector::borrow(&node.keys, j - 1) < *vector::borrow(&node.keys, j), i);
+                j = j + 1;
+            };
+
+            let j = 0;
+            if (node.is_leaf) {
+                assert!(vector::length(&node.children) == key_count, i);
+                while (j < key_count) {
+                    let entry = Self::borrow(&tree.entries, *vector::borrow(&node.children, j));
+                    assert!(entry.leaf == i, i);
+                    assert!(entry.key == *vector::borrow(&node.keys, j), i);
+                    j = j + 1;
+                };
+                entry_count = entry_count + key_count;
+            } else {
+                assert!(vector::length(&node.children) == key_count + 1, i);
+                while (j <= key_count) {
+                    assert!(Self::borrow(&tree.nodes, *vector::borrow(&node.children, j)).parent == i, i);
+                    j = j + 1;
+                };
+            };
+
+            i = i + 1;
+        };
+
+        assert!(entry_count == Self::length(&tree.entries), entry_count);
+    }
+
+    #[test]
+    fun test_btree() {
+        let ctx = tx_context::dummy();
+        let tree = new<u128>(&mut ctx);
+        assert!(find(&tree, 5) == NULL_INDEX, 0);
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+
+        // insert 0 to 199 out of order.
+        let i = 0;
+        while (i < 200) {
+            let key = (((i * 37) % 200) as u128);
+            assert!(insert_and_get_index(&mut tree, key, key) == i, i);
+            check_tree(&tree);
+            i = i + 1;
+        };
+        assert!(size(&tree) == 200, size(&tree));
+
+        let index = get_min_index(&tree);
+        let i = 0;
+        while (index != NULL_INDEX) {
+            let (key, value) = borrow_at_index(&tree, index);
+            assert!(key == (i as u128), i);
+            assert!(*value == key, i);
+            index = next_in_order(&tree, index);
+            i = i + 1;
+        };
+        assert!(i == 200, i);
+
+        let index = get_max_index(&tree);
+        while (index != NULL_INDEX) {
+            i = i - 1;
+            let (key, _) = borrow_at_index(&tree, index);
+            assert!(key == (i as u128), i);
+            index = next_in_reverse_order(&tree, index);
+        };
+        assert!(i == 0, i);
+
+        // remove the even keys.
+        let i = 0;
+        while (i < 200) {
+            let key = (((i * 37) % 200) as u128);
+            if (key % 2 == 0) {
+                let index = find(&tree, key);
+                let (removed_key, value) = remove(&mut tree, index);
+                assert!(removed_key == key, i);
+                assert!(value == key, i);
+                assert!(find(&tree, key) == NULL_INDEX, i);
+                check_tree(&tree);
+            };
+            i = i + 1;
+        };
+        assert!(size(&tree) == 100, size(&tree));
+
+        let i = 0;
+        while (i < 200) {
+            let key = (i as u128);
+            let expected_lower = if (i % 2 == 1) {
+                find(&tree, key)
+            } else {
+                find(&tree, key + 1)
+            };
+            let expected_upper = if (i % 2 == 1) {
+                if (i + 2 < 200) { find(&tree, key + 2) } else { NULL_INDEX }
+            } else {
+                find(&tree, key + 1)
+            };
+            let expected_floor = if (i % 2 == 1) {
+                find(&tree, key)
+            } else if (i > 0) {
+                find(&tree, key - 1)
+            } else {
+                NULL_INDEX
+            };
+            assert!(lower_bound(&tree, key) == expected_lower, i);
+            assert!(upper_bound(&tree, key) == expected_upper, i);
+            assert!(floor(&tree, key) == expected_floor, i);
+            assert!(ceiling(&tree, key) == expected_lower, i);
+            i = i + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+            check_tree(&tree);
+        };
+        assert!(tree.root == NULL_INDEX, tree.root);
+        destroy_empty(tree);
+    }
+}
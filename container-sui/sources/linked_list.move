@@ -0,0 +1,340 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Double Linked List
+module container::linked_list {
+    use sui::object::{Self, UID};
+    use sui::object_table::{Self, ObjectTable};
+    use sui::tx_context::TxContext;
+    #[test_only]
+    use sui::tx_context;
+
+    /// Item wraps an element into an object, which is what object table can hold.
+    struct Item<V: store> has key, store {
+        id: UID,
+        value: V,
+    }
+    fun length<V: store>(t: &ObjectTable<u64, Item<V>>): u64 {
+        object_table::length(t)
+    }
+    fun borrow<V: store>(t: &ObjectTable<u64, Item<V>>, i: u64): &V {
+        &object_table::borrow(t, i).value
+    }
+    fun borrow_mut<V: store>(t: &mut ObjectTable<u64, Item<V>>, i: u64): &mut V {
+        &mut object_table::borrow_mut(t, i).value
+    }
+    fun swap<V: store>(t: &mut ObjectTable<u64, Item<V>>, i: u64, j: u64) {
+        let i_item = object_table::remove(t, i);
+        let j_item = object_table::remove(t, j);
+        object_table::add(t, j, i_item);
+        object_table::add(t, i, j_item);
+    }
+    fun push_back<V: store>(t: &mut ObjectTable<u64, Item<V>>, v: V, ctx: &mut TxContext) {
+        let i = object_table::length(t);
+        object_table::add(t, i, Item { id: object::new(ctx), value: v })
+    }
+    fun pop_back<V: store>(t: &mut ObjectTable<u64, Item<V>>): V {
+        let i = object_table::length(t) - 1;
+        let Item { id, value } = object_table::remove(t, i);
+        object::delete(id);
+        value
+    }
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_EMPTY_TREE: u64 = 2;
+    const E_KEY_ALREADY_EXIST: u64 = 4;
+    const E_INDEX_OUT_OF_RANGE: u64 = 5;
+    const E_CANNOT_DESTRORY_NON_EMPTY: u64 = 7;
+    const E_EXCEED_CAPACITY: u64 = 8;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    const MAX_CAPACITY: u64 = 18446744073709551614; // NULL_INDEX - 1
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+    // Node is a node in the linked list
+    struct Node<V> has store, copy, drop {
+        value: V,
+
+        prev: u64,
+        next: u64,
+    }
+
+    /// LinkedList is a double linked list.
+    struct LinkedList<V: store> has store {
+        head: u64,
+        tail: u64,
+        entries: ObjectTable<u64, Item<Node<V>>>,
+    }
+
+    public fun new<V: store>(ctx: &mut TxContext): LinkedList<V> {
+        LinkedList<V> {
+            head: NULL_INDEX,
+            tail: NULL_INDEX,
+            entries: object_table::new(ctx),
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V: store>(list: &LinkedList<V>, index: u64): &V {
+        let entry = Self::borrow(&list.entries, index);
+        &entry.value
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V: store>(list: &mut LinkedList<V>, index: u64): &mut V {
+        let entry = Self::borrow_mut(&mut list.entries, index);
+        &mut entry.value
+    }
+
+    /// size returns the number of elements in the LinkedList.
+    public fun size<V: store>(list: &LinkedList<V>): u64 {
+        Self::length(&list.entries)
+    }
+
+    /// empty returns true if the LinkedList is empty.
+    public fun empty<V: store>(list: &LinkedList<V>): bool {
+        Self::length(&list.entries) == 0
+    }
+
+    /// get next entry in linkedlist
+    public fun next<V: store>(list: &LinkedList<V>, index: u64): u64 {
+        Self::borrow(&list.entries, index).next
+    }
+
+    /// get previous entry in linkedlist
+    public fun previous<V: store>(list: &LinkedList<V>, index: u64): u64 {
+        Self::borrow(&list.entries, index).prev
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert
+    public fun insert<V: store>(list: &mut LinkedList<V>, value: V, ctx: &mut TxContext) {
+        let index = list.tail;
+        insert_after(list, index, value, ctx)
+    }
+
+    /// insert at the end of the list, and return the index of the new element.
+    public fun insert_and_get_index<V: store>(list: &mut LinkedList<V>, value: V, ctx: &mut TxContext): u64 {
+        let index = list.tail;
+        insert_after_and_get_index(list, index, value, ctx)
+    }
+
+    /// insert after index. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after<V: store>(list: &mut LinkedList<V>, index: u64, value: V, ctx: &mut TxContext) {
+        insert_after_and_get_index(list, index, value, ctx);
+    }
+
+    /// insert after index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after_and_get_index<V: store>(list: &mut LinkedList<V>, index: u64, value: V, ctx: &mut TxContext): u64 {
+        let new_index = Self::length(&list.entries);
+        assert!(
+            new_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY,
+        );
+
+        let node = Node<V>{
+            value,
+            prev: index,
+            next: NULL_INDEX,
+        };
+
+        if (new_index == 0 && index == NULL_INDEX) {
+            list.head = new_index;
+            list.tail = new_index;
+            push_back(&mut list.entries, node, ctx);
+            return new_index
+        };
+
+        assert!(
+            index != NULL_INDEX && index < new_index,
+            E_INDEX_OUT_OF_RANGE,
+        );
+
+        let prev = Self::borrow_mut(&mut list.entries, index);
+        node.next = prev.next;
+        prev.next = new_index;
+        if (node.next != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, node.next).prev = new_index;
+        } else {
+            list.tail = new_index;
+        };
+
+        push_back(&mut list.entries, node, ctx);
+
+        new_index
+    }
+
+    /// isnert before index. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before<V: store>(list: &mut LinkedList<V>, index: u64, value: V, ctx: &mut TxContext) {
+        insert_before_and_get_index(list, index, value, ctx);
+    }
+
+    /// insert before index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before_and_get_index<V: store>(list: &mut LinkedList<V>, index: u64, value: V, ctx: &mut TxContext): u64 {
+        let new_index = Self::length(&list.entries);
+        assert!(
+            new_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY,
+        );
+
+        let node = Node<V>{
+            value,
+            prev: NULL_INDEX,
+            next: index,
+        };
+
+        if (new_index == 0 && index == NULL_INDEX) {
+            list.head = new_index;
+            list.tail = new_index;
+            push_back(&mut list.entries, node, ctx);
+            return new_index
+        };
+
+        assert!(
+            index != NULL_INDEX && index < new_index,
+            E_INDEX_OUT_OF_RANGE,
+        );
+        let next = Self::borrow_mut(&mut list.entries, index);
+        node.prev = next.prev;
+        next.prev = new_index;
+        if (node.prev != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, node.prev).next = new_index;
+        } else {
+            list.head = new_index;
+        };
+
+        push_back(&mut list.entries, node, ctx);
+
+        new_index
+    }
+
+    /// remove deletes and returns the element from the LinkedList.
+    /// element is first swapped to the end of the container, then popped out.
+    public fun remove<V: store>(list: &mut LinkedList<V>, index: u64): V {
+        let to_remove = Self::borrow(&list.entries, index);
+        let prev = to_remove.prev;
+        let next = to_remove.next;
+        if (prev != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, prev).next = next;
+        } else {
+            list.head = next;
+        };
+        if (next != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, next).prev = prev;
+        } else {
+            list.tail = next;
+        };
+
+        // swap the element to be removed with the last element
+        if (index + 1 != Self::length(&list.entries)) {
+            let tail_index = Self::length(&list.entries) - 1;
+            swap(&mut list.entries, index, tail_index);
+            let swapped = Self::borrow(&list.entries, index);
+            let prev = swapped.prev;
+            let next = swapped.next;
+            if (prev != NULL_INDEX) {
+                Self::borrow_mut(&mut list.entries, prev).next = index;
+            } else {
+                list.head = index;
+            };
+            if (next != NULL_INDEX) {
+                Self::borrow_mut(&mut list.entries, next).prev = index;
+            } else {
+                list.tail = index;
+            };
+        };
+
+        // pop
+        let Node {
+            value,
+            next: _,
+            prev: _,
+        } = pop_back(&mut list.entries);
+
+        value
+    }
+
+    /// destroys the linked list if it's empty.
+    public fun destroy_empty<V: store>(tree: LinkedList<V>) {
+        assert!(Self::length(&tree.entries) == 0, E_CANNOT_DESTRORY_NON_EMPTY);
+
+        let LinkedList<V> {
+            entries,
+            head: _,
+            tail: _,
+        } = tree;
+
+        object_table::destroy_empty(entries);
+    }
+
+    #[test]
+    public fun test_insert_remove_linked_list() {
+        let ctx = tx_context::dummy();
+        let l = new<u128>(&mut ctx);
+        let i = 0;
+        while (i < 10) {
+            // 1, 3, ..., 19 at indices 0, 1, ..., 9.
+            insert(&mut l, (i as u128) * 2 + 1, &mut ctx);
+            i = i + 1;
+        };
+        let i = 0;
+        while (i < 10) {
+            // 2, 4, ..., 20 right after 1, 3, ..., 19.
+            assert!(insert_after_and_get_index(&mut l, i, (i as u128) * 2 + 2, &mut ctx) == i + 10, i);
+            i = i + 1;
+        };
+        let head = l.head;
+        insert_before(&mut l, head, 0, &mut ctx);
+        assert!(size(&l) == 21, size(&l));
+
+        let expected: u128 = 0;
+        let index = l.head;
+        while (index != NULL_INDEX) {
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            expected = expected + 1;
+            index = next(&l, index);
+        };
+        assert!(expected == 21, (expected as u64));
+        let index = l.tail;
+        while (index != NULL_INDEX) {
+            expected = expected - 1;
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            index = previous(&l, index);
+        };
+        assert!(expected == 0, (expected as u64));
+
+        while (!empty(&l)) {
+            let head = l.head;
+            assert!(remove(&mut l, head) == expected, (expected as u64));
+            expected = expected + 1;
+            // the rest is still linked in order.
+            let count = 0;
+            let index = l.head;
+            while (index != NULL_INDEX) {
+                assert!(*borrow_at_index(&l, index) == expected + (count as u128), count);
+                count = count + 1;
+                index = next(&l, index);
+            };
+            assert!(count == size(&l), count);
+        };
+        assert!(expected == 21, (expected as u64));
+
+        destroy_empty(l);
+    }
+}
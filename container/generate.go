@@ -2,8 +2,4 @@ package container
 
 // Run go generate to generate the files.
 
-//go:generate go run .. red-black
-//go:generate go run .. avl
-//go:generate go run .. bst
-//go:generate go run .. critbit
-//go:generate go run .. linked-list
+//go:generate go run .. build -f containers.toml
@@ -2,7 +2,7 @@
 // Caution when editing manually.
 // Tree based on GNU libavl https://adtinfo.org/
 module container::avl {
-    use std::vector::{Self, swap, is_empty, push_back, pop_back};
+    use std::vector::{Self, swap, push_back, pop_back};
 
     const E_INVALID_ARGUMENT: u64 = 1;
     const E_KEY_ALREADY_EXIST: u64 = 2;
@@ -92,7 +92,7 @@ module container::avl {
     public fun new<V>(): AvlTree<V> {
         AvlTree {
             root: NULL_INDEX,
-            entries: vector::empty<Entry<V>>(),
+            entries: vector::empty(),
             min_index: NULL_INDEX,
             max_index: NULL_INDEX,
         }
@@ -122,6 +122,72 @@ module container::avl {
         NULL_INDEX
     }
 
+    /// lower_bound returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                result = current;
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// upper_bound returns the index of the first element with keys greater than the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                result = current;
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// floor returns the index of the last element with keys less than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                current = node.left_child;
+            } else {
+                result = current;
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// ceiling returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &AvlTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
     /// borrow returns a reference to the element with its key at the given index
     public fun borrow_at_index<V>(tree: &AvlTree<V>, index: u64): (u128, &V) {
         let entry = vector::borrow(&tree.entries, index);
@@ -258,6 +324,12 @@ module container::avl {
     /// insert puts the value keyed at the input keys into the AvlTree.
     /// aborts if the key is already in the tree.
     public fun insert<V>(tree: &mut AvlTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the AvlTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V>(tree: &mut AvlTree<V>, key: u128, value: V): u64 {
         // the max size of the tree is NULL_INDEX.
         assert!(size(tree) < NULL_INDEX, E_TREE_TOO_BIG);
 		push_back(
@@ -318,7 +390,9 @@ module container::avl {
                 break
             };
             is_right_child = is_right_child(tree, new_parent, parent);
-        }
+        };
+
+        node
     }
 
     /// remove deletes and returns the element from the AvlTree.
@@ -497,7 +571,7 @@ module container::avl {
     /// destroys the tree if it's empty.
     public fun destroy_empty<V>(tree: AvlTree<V>) {
         let AvlTree { entries, root: _, min_index: _, max_index: _ } = tree;
-        assert!(is_empty(&entries), E_TREE_NOT_EMPTY);
+        assert!(vector::length(&entries) == 0, E_TREE_NOT_EMPTY);
         vector::destroy_empty(entries);
     }
 
@@ -865,6 +939,58 @@ module container::avl {
         }
     }
 
+    #[test]
+    fun test_bounds() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 3, 8, 13, ..., 98 out of order.
+            let v = ((idx * 7) % 20) * 5 + 3;
+            let index = insert_and_get_index(&mut tree, v, v);
+            assert!(find(&tree, v) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        let k: u128 = 0;
+        while (k < 110) {
+            let expected_lower = NULL_INDEX;
+            let expected_upper = NULL_INDEX;
+            let expected_floor = NULL_INDEX;
+            let iter = get_min_index(&tree);
+            while (iter != NULL_INDEX) {
+                let (key, _) = borrow_at_index(&tree, iter);
+                if (key <= k) {
+                    expected_floor = iter;
+                };
+                if (key >= k && expected_lower == NULL_INDEX) {
+                    expected_lower = iter;
+                };
+                if (key > k && expected_upper == NULL_INDEX) {
+                    expected_upper = iter;
+                };
+                iter = next_in_order(&tree, iter);
+            };
+
+            assert!(lower_bound(&tree, k) == expected_lower, (k as u64));
+            assert!(upper_bound(&tree, k) == expected_upper, (k as u64));
+            assert!(floor(&tree, k) == expected_floor, (k as u64));
+            assert!(ceiling(&tree, k) == expected_lower, (k as u64));
+            k = k + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
     #[test]
     fun test_avl() {
         let tree = new<u128>();
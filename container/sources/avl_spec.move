@@ -0,0 +1,1453 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Tree based on GNU libavl https://adtinfo.org/
+module container::avl_spec {
+    use std::vector::{Self, swap, push_back, pop_back};
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_KEY_ALREADY_EXIST: u64 = 2;
+    const E_EMPTY_TREE: u64 = 3;
+    const E_INVALID_INDEX: u64 = 4;
+    const E_TREE_TOO_BIG: u64 = 5;
+    const E_TREE_NOT_EMPTY: u64 = 6;
+    const E_PARENT_NULL: u64 = 7;
+    const E_PARENT_INDEX_OUT_OF_RANGE: u64 = 8;
+    const E_RIGHT_ROTATE_LEFT_CHILD_NULL: u64 = 9;
+    const E_LEFT_ROTATE_RIGHT_CHILD_NULL: u64 = 10;
+
+    const E_AVL_REMOVAL_NOT_DECREASE: u64 = 11;
+    const E_AVL_NOT_IMBALANCED: u64 = 12;
+    const E_AVL_SUBTREE_IMBALANCED: u64 = 13;
+    const E_AVL_BAD_STATE: u64 = 14;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+
+    const AVL_ZERO: u8 = 128;
+    const AVL_RIGHT_HIGH: u8 = 129;
+    const AVL_RIGHT_HIGH_2: u8 = 130;
+    const AVL_LEFT_HIGH: u8 = 127;
+    const AVL_LEFT_HIGH_2: u8 = 126;
+
+    const METADATA_DEFAULT: u8 = 128;
+
+    /// Entry is the internal AvlTree element.
+    struct Entry<V> has store, copy, drop {
+        // key
+        key: u128,
+        // value
+        value: V,
+        // parent
+        parent: u64,
+        // left child
+        left_child: u64,
+        // right child.
+        right_child: u64,
+        // metadata
+        metadata: u8,
+    }
+
+    fun new_entry<V>(key: u128, value: V): Entry<V> {
+        Entry<V> {
+            key,
+            value,
+            parent: NULL_INDEX,
+            left_child: NULL_INDEX,
+            right_child: NULL_INDEX,
+            metadata: METADATA_DEFAULT,
+        }
+    }
+
+    #[test_only]
+    fun new_entry_for_test<V>(key: u128, value: V, parent: u64, left_child: u64, right_child: u64, metadata: u8): Entry<V> {
+        Entry {
+            key,
+            value,
+            parent,
+            left_child,
+            right_child,
+            metadata,
+        }
+    }
+
+    /// AvlTree contains a vector of Entry<V>, which is triple-linked binary search tree.
+    struct AvlTree<V> has store, copy, drop {
+        root: u64,
+        entries: vector<Entry<V>>,
+        min_index: u64,
+        max_index: u64,
+    }
+
+    /// create new tree
+    public fun new<V>(): AvlTree<V> {
+        AvlTree {
+            root: NULL_INDEX,
+            entries: vector::empty(),
+            min_index: NULL_INDEX,
+            max_index: NULL_INDEX,
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// find returns the element index in the AvlTree, or none if not found.
+    public fun find<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let current = tree.root;
+
+        while({
+            spec {
+                invariant spec_is_valid_index(tree, current);
+                // an entry with the keys can only be in the subtree at current.
+                invariant forall i in 0..len(tree.entries): (tree.entries[i].key == key) ==> spec_in_subtree(tree, i, current);
+            };
+            current != NULL_INDEX
+        }) {
+            let node = vector::borrow(&tree.entries, current);
+            if (node.key == key) {
+                return current
+            };
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        NULL_INDEX
+    }
+
+    /// lower_bound returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                result = current;
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// upper_bound returns the index of the first element with keys greater than the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                result = current;
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// floor returns the index of the last element with keys less than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &AvlTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                current = node.left_child;
+            } else {
+                result = current;
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// ceiling returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &AvlTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V>(tree: &AvlTree<V>, index: u64): (u128, &V) {
+        let entry = vector::borrow(&tree.entries, index);
+        (entry.key, &entry.value)
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V>(tree: &mut AvlTree<V>, index: u64): (u128, &mut V) {
+        let entry = vector::borrow_mut(&mut tree.entries, index);
+        (entry.key, &mut entry.value)
+    }
+
+    /// size returns the number of elements in the AvlTree.
+    public fun size<V>(tree: &AvlTree<V>): u64 {
+        vector::length(&tree.entries)
+    }
+
+    /// empty returns true if the AvlTree is empty.
+    public fun empty<V>(tree: &AvlTree<V>): bool {
+        vector::length(&tree.entries) == 0
+    }
+
+    /// get index of the min of the tree.
+    public fun get_min_index<V>(tree: &AvlTree<V>): u64 {
+        let current = tree.min_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the min of the subtree with root at index.
+    public fun get_min_index_from<V>(tree: &AvlTree<V>, index: u64): u64 {
+        let current = index;
+        let left_child = vector::borrow(&tree.entries, current).left_child;
+
+        while (left_child != NULL_INDEX) {
+            current = left_child;
+            left_child = vector::borrow(&tree.entries, current).left_child;
+        };
+
+        current
+    }
+
+    /// get index of the max of the tree.
+    public fun get_max_index<V>(tree: &AvlTree<V>): u64 {
+        let current = tree.max_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the max of the subtree with root at index.
+    public fun get_max_index_from<V>(tree: &AvlTree<V>, index: u64): u64 {
+        let current = index;
+        let right_child = vector::borrow(&tree.entries, current).right_child;
+
+        while (right_child != NULL_INDEX) {
+            current = right_child;
+            right_child = vector::borrow(&tree.entries, current).right_child;
+        };
+
+        current
+    }
+
+    /// find next value in order (the key is increasing)
+    public fun next_in_order<V>(tree: &AvlTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = vector::borrow(&tree.entries, index);
+        let right_child = node.right_child;
+        let parent = node.parent;
+
+        if (right_child != NULL_INDEX) {
+            // first, check if right child is null.
+            // then go to right child, and check if there is left child.
+            let next = right_child;
+            let next_left = vector::borrow(&tree.entries, next).left_child;
+            while (next_left != NULL_INDEX) {
+                next = next_left;
+                next_left = vector::borrow(&tree.entries, next).left_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no right child, check parent.
+            // if current is the left child of the parent, parent is then next.
+            // if current is the right child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_right_child(tree, current, parent)) {
+                current = parent;
+                parent = vector::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// find next value in reverse order (the key is decreasing)
+    public fun next_in_reverse_order<V>(tree: &AvlTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = vector::borrow(&tree.entries, index);
+        let left_child = node.left_child;
+        let parent = node.parent;
+        if (left_child != NULL_INDEX) {
+            // first, check if left child is null.
+            // then go to left child, and check if there is right child.
+            let next = left_child;
+            let next_right = vector::borrow(&tree.entries, next).right_child;
+            while (next_right != NULL_INDEX) {
+                next = next_right;
+                next_right = vector::borrow(&tree.entries, next).right_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no left child, check parent.
+            // if current is the right child of the parent, parent is then next.
+            // if current is the left child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_left_child(tree, current, parent)) {
+                current = parent;
+                parent = vector::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert puts the value keyed at the input keys into the AvlTree.
+    /// aborts if the key is already in the tree.
+    public fun insert<V>(tree: &mut AvlTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the AvlTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V>(tree: &mut AvlTree<V>, key: u128, value: V): u64 {
+        // the max size of the tree is NULL_INDEX.
+        assert!(size(tree) < NULL_INDEX, E_TREE_TOO_BIG);
+		push_back(
+            &mut tree.entries,
+            new_entry(key, value)
+        );
+
+        let node = size(tree) - 1;
+
+        let parent = NULL_INDEX;
+        let insert = tree.root;
+        let is_right_child = false;
+
+        while ({
+            spec {
+                invariant spec_is_valid_index(tree, parent) && spec_is_valid_index(tree, insert) && insert != node;
+                invariant parent == NULL_INDEX ==> insert == tree.root;
+                invariant parent != NULL_INDEX ==> insert == spec_child(tree, parent, is_right_child);
+                // an entry with the same keys can only be in the subtree at insert.
+                invariant forall i in 0..node: spec_same_keys(tree.entries[i], tree.entries[node]) ==> spec_in_subtree(tree, i, insert);
+                // the new entry goes to the left subtree of an entry if and only if its keys are smaller.
+                invariant forall i in 0..node: parent != NULL_INDEX
+                    && ((i == parent && !is_right_child) || spec_in_subtree(tree, parent, tree.entries[i].left_child))
+                    ==> spec_less(tree.entries[node], tree.entries[i]);
+                invariant forall i in 0..node: parent != NULL_INDEX
+                    && ((i == parent && is_right_child) || spec_in_subtree(tree, parent, tree.entries[i].right_child))
+                    ==> spec_less(tree.entries[i], tree.entries[node]);
+            };
+            insert != NULL_INDEX
+        }) {
+            let insert_node = vector::borrow(&tree.entries, insert);
+            assert!((insert_node.key != key), E_KEY_ALREADY_EXIST);
+            parent = insert;
+            is_right_child = ((insert_node.key < key));
+            insert = if (is_right_child) {
+                insert_node.right_child
+            } else {
+                insert_node.left_child
+            };
+        };
+
+        replace_parent(tree, node, parent);
+
+        if (parent != NULL_INDEX) {
+            if (is_right_child) {
+                replace_right_child(tree, parent, node);
+            } else {
+                replace_left_child(tree, parent, node);
+            };
+            let max_node = vector::borrow(&tree.entries, tree.max_index);
+            let is_max_smaller = ((max_node.key < key));
+            if (is_max_smaller) {
+                tree.max_index = node;
+            };
+            let min_node = vector::borrow(&tree.entries, tree.min_index);
+            let is_min_bigger = ((min_node.key > key));
+            if (is_min_bigger) {
+                tree.min_index = node;
+            };
+        } else {
+            tree.root = node;
+            tree.min_index = node;
+            tree.max_index = node;
+        };
+
+        // update avl metadata
+        while ({
+            spec {
+                invariant len(tree.entries) == len(old(tree.entries)) + 1;
+                invariant forall i in 0..node: spec_same_data(tree.entries[i], old(tree.entries[i]));
+                invariant tree.entries[node].key == key && tree.entries[node].value == value;
+                invariant spec_is_valid_structure(tree, NULL_INDEX);
+                invariant spec_is_valid_index(tree, parent);
+                // the subtree on the is_right_child side of parent grew by one,
+                // and the balance factors of parent and its ancestors are not updated yet.
+                invariant parent == NULL_INDEX || spec_child(tree, parent, is_right_child) != NULL_INDEX;
+                invariant forall i in 0..len(tree.entries): spec_is_avl_balanced(tree, i, parent, is_right_child, true);
+            };
+            parent != NULL_INDEX
+        }) {
+            let (increased, new_parent) = avl_update_insert(tree, parent, is_right_child);
+            if (!increased) {
+                break
+            };
+            parent = vector::borrow(&tree.entries, new_parent).parent;
+            if (parent == NULL_INDEX) {
+                break
+            };
+            is_right_child = is_right_child(tree, new_parent, parent);
+        };
+
+        node
+    }
+
+    /// remove deletes and returns the element from the AvlTree.
+    public fun remove<V>(tree: &mut AvlTree<V>, index: u64): (u128, V) {
+        if (tree.max_index == index) {
+            tree.max_index = next_in_reverse_order(tree, index);
+        };
+        if (tree.min_index == index) {
+            tree.min_index = next_in_order(tree, index);
+        };
+
+        let node = vector::borrow(&tree.entries, index);
+        let parent = node.parent;
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        let is_right = if (parent != NULL_INDEX) {
+            is_right_child(tree, index, parent)
+        } else {
+            false
+        };
+
+        let (rebalance_start, is_new_right) =
+        if (right_child == NULL_INDEX) {
+            // right child is null
+            // replace with left child.
+            // No need to swap metadata
+            // - in AVL, left is balanced and new value is also balanced.
+            // - in RB, left must be red and index must be black.
+            //         index
+            //       /       \
+            //     left
+            //  --
+            //        left
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, left_child, NULL_INDEX);
+                tree.root = left_child;
+            } else {
+                replace_child(tree, parent, index, left_child);
+            };
+            (parent, is_right)
+        } else if (left_child == NULL_INDEX){
+            // left child is null.
+            // replace with right child.
+            // No need to swap metadata.
+            // - in AVL, right is balanced and the new value is also balanced.
+            // - in RB, right must be red and index must be black.
+            //         index
+            //       /       \
+            //               right
+            //  --
+            //        right
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, right_child, NULL_INDEX);
+                tree.root = right_child;
+            } else {
+                replace_child(tree, parent, index, right_child);
+            };
+            (parent, is_right)
+        } else {
+            let right_child_s_left = vector::borrow(&tree.entries, right_child).left_child;
+            if (right_child_s_left == NULL_INDEX) {
+                // right child is not null, and right child's left child is null
+                //              index
+                //           /         \
+                //        left         right
+                //                        \
+                //                         a
+                // -------------
+                //               right
+                //            /       \
+                //          left       a
+                replace_left_child(tree, right_child, left_child);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, right_child, NULL_INDEX);
+                    tree.root = right_child;
+                } else {
+                    replace_child(tree, parent, index, right_child);
+                };
+
+                let old_metadata = vector::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = vector::borrow(&tree.entries, right_child).metadata;
+                vector::borrow_mut(&mut tree.entries, right_child).metadata = old_metadata;
+                vector::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (right_child, true)
+            } else {
+                // right child is not null, and right child's left child is not null either
+                //                 index
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    min
+                //                     \
+                //                      a
+                // -------------------------------------------------
+                //                   min
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    a
+                let next_successor = get_min_index_from(tree, right_child_s_left);
+                let next_successor_node = vector::borrow(&tree.entries, next_successor);
+                let successor_parent = next_successor_node.parent;
+                let next_successor_right = next_successor_node.right_child;
+
+                replace_left_child(tree, successor_parent, next_successor_right);
+                replace_left_child(tree, next_successor, left_child);
+                replace_right_child(tree, next_successor, right_child,);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, next_successor, NULL_INDEX);
+                    tree.root = next_successor;
+                } else {
+                    replace_child(tree, parent, index, next_successor);
+                };
+
+                let old_metadata = vector::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = vector::borrow(&tree.entries, next_successor).metadata;
+                vector::borrow_mut(&mut tree.entries, next_successor).metadata = old_metadata;
+                vector::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (successor_parent, false)
+            }
+        };
+
+        while ({
+            spec {
+                invariant len(tree.entries) == len(old(tree.entries));
+                invariant forall i in 0..len(tree.entries): spec_same_data(tree.entries[i], old(tree.entries[i]));
+                invariant spec_is_valid_structure(tree, index);
+                invariant spec_is_valid_index(tree, rebalance_start) && rebalance_start != index;
+                // the subtree on the is_new_right side of rebalance_start shrank by one,
+                // and the balance factors of rebalance_start and its ancestors are not updated yet.
+                invariant forall i in 0..len(tree.entries): i != index ==> spec_is_avl_balanced(tree, i, rebalance_start, is_new_right, false);
+            };
+            rebalance_start != NULL_INDEX
+        }) {
+            let (decreased, new_start) = avl_update_remove(tree, rebalance_start, is_new_right);
+            if (!decreased) {
+                break
+            };
+            rebalance_start = vector::borrow(&tree.entries, new_start).parent;
+            if (rebalance_start == NULL_INDEX) {
+                break
+            };
+
+            is_new_right = is_right_child(tree, new_start, rebalance_start);
+        };
+
+        // swap index for pop out.
+        let last_index = size(tree) -1;
+        if (index != last_index) {
+            swap(&mut tree.entries, last_index, index);
+            if (tree.root == last_index) {
+                tree.root = index;
+            };
+            if (tree.max_index == last_index) {
+                tree.max_index = index;
+            };
+            if (tree.min_index == last_index) {
+                tree.min_index = index;
+            };
+            let node = vector::borrow(&tree.entries, index);
+            let parent = node.parent;
+            let left_child = node.left_child;
+            let right_child = node.right_child;
+            replace_child(tree, parent, last_index, index);
+            replace_parent(tree, left_child, index);
+            replace_parent(tree, right_child, index);
+        };
+
+        ////////// now clear up.
+        let Entry { key,  value, parent: _, left_child: _, right_child: _, metadata: _ } = pop_back(&mut tree.entries);
+
+        if (size(tree) == 0) {
+            tree.root = NULL_INDEX;
+        };
+
+        (key,  value)
+    }
+
+    /// destroys the tree if it's empty.
+    public fun destroy_empty<V>(tree: AvlTree<V>) {
+        let AvlTree { entries, root: _, min_index: _, max_index: _ } = tree;
+        assert!(vector::length(&entries) == 0, E_TREE_NOT_EMPTY);
+        vector::destroy_empty(entries);
+    }
+
+    /// check if index is the right child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_right_child<V>(tree: &AvlTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < size(tree), E_PARENT_INDEX_OUT_OF_RANGE);
+        vector::borrow(&tree.entries, parent_index).right_child == index
+    }
+
+    /// check if index is the left child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_left_child<V>(tree: &AvlTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < size(tree), E_PARENT_INDEX_OUT_OF_RANGE);
+        vector::borrow(&tree.entries, parent_index).left_child == index
+    }
+
+    /// Replace the child of parent if parent_index is not NULL_INDEX.
+    /// also replace parent index of the child.
+    fun replace_child<V>(tree: &mut AvlTree<V>, parent_index: u64, original_child: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            if (is_right_child(tree, original_child, parent_index)) {
+                replace_right_child(tree, parent_index, new_child);
+            } else if (is_left_child(tree, original_child, parent_index)) {
+                replace_left_child(tree, parent_index, new_child);
+            }
+        }
+    }
+
+    /// replace left child.
+    /// also replace parent index of the child.
+    fun replace_left_child<V>(tree: &mut AvlTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            vector::borrow_mut(&mut tree.entries, parent_index).left_child = new_child;
+            if (new_child != NULL_INDEX) {
+                vector::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace right child.
+    /// also replace parent index of the child.
+    fun replace_right_child<V>(tree: &mut AvlTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            vector::borrow_mut(&mut tree.entries, parent_index).right_child = new_child;
+                if (new_child != NULL_INDEX) {
+                vector::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace parent of index if index is not NULL_INDEX.
+    fun replace_parent<V>(tree: &mut AvlTree<V>, index: u64, parent_index: u64) {
+        if (index != NULL_INDEX) {
+            vector::borrow_mut(&mut tree.entries, index).parent = parent_index;
+        }
+    }
+
+
+    /// rotate_right (clockwise rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///        x      y
+    /// -----------------------------------------------------
+    ///                  left
+    ///              x          index
+    ///                       y       right
+    fun rotate_right<V>(tree: &mut AvlTree<V>, index: u64) {
+        let node = vector::borrow(&tree.entries, index);
+        let left = node.left_child;
+        assert!(
+            left != NULL_INDEX,
+            E_RIGHT_ROTATE_LEFT_CHILD_NULL
+        );
+        let y = vector::borrow(&tree.entries, left).right_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_left_child(tree, index, y);
+
+        // update left
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, left);
+        } else {
+            tree.root = left;
+            replace_parent(tree, left, NULL_INDEX);
+        };
+        replace_right_child(tree, left, index);
+    }
+
+    /// rotate_left (counter-clockwis rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///                       x          y
+    /// -----------------------------------------------------
+    ///                  right
+    ///          index             y
+    ///      left        x
+    fun rotate_left<V>(tree: &mut AvlTree<V>, index: u64) {
+        let node = vector::borrow(&tree.entries, index);
+        let right = node.right_child;
+        assert!(
+            right != NULL_INDEX,
+            E_INVALID_ARGUMENT,
+        );
+        let x = vector::borrow(&tree.entries, right).left_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_right_child(tree, index, x);
+
+        // update right
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, right);
+        } else {
+            tree.root = right;
+            replace_parent(tree, right, NULL_INDEX);
+        };
+        replace_left_child(tree, right, index);
+    }
+
+    // update the avl after an insertion resulted in height increase of sub tree of this sub tree at index.
+    // - index is the element to be updated.
+    // - is_right indicates if the insertion is from the right tree or left tree.
+    // returns
+    // - if the height of this sub tree is increased.
+    // - the new index of the sub tree at this point.
+    fun avl_update_insert<V>(tree: &mut AvlTree<V>, index: u64, is_right: bool): (bool, u64) {
+        if (index == NULL_INDEX) {
+            return (false, index)
+        };
+        let node = vector::borrow(&tree.entries, index);
+        let metadata = node.metadata;
+
+        // if the subtree is balanced, the height of the subtree is increased and the subtree becomes unbalance.
+        if (metadata == AVL_ZERO) {
+             let new_metadata = if (is_right) {
+                AVL_RIGHT_HIGH
+            } else {
+                AVL_LEFT_HIGH
+            };
+
+            vector::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+
+            return (true, index)
+        };
+
+        // if the left tree of this subtree is higher and the right sub tree is increased,
+        // the subtree here is now balanced and the height stays the same.
+        if (metadata == AVL_LEFT_HIGH && is_right) {
+            vector::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (false, index)
+        };
+
+        // similarly if the right sub tree of the this sub tree is higher and the left sub tree is increased,
+        // the subtree here is now balanced and the height stays the same.
+        if (metadata == AVL_RIGHT_HIGH && !is_right) {
+            vector::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (false, index)
+        };
+
+        // now the tree is unbalanced too much
+        let new_metadata = if (metadata == AVL_LEFT_HIGH) {
+            AVL_LEFT_HIGH_2
+        } else {
+            AVL_RIGHT_HIGH_2
+        };
+
+        vector::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+
+        let (decreased, new_index) = avl_rebalance(tree, index, false);
+        assert!(decreased, E_AVL_REMOVAL_NOT_DECREASE);
+
+        (false, new_index)
+    }
+
+    // update the avl after a removal resulted in height decrease of sub tree of this sub tree at index.
+    // - index is the element to be updated.
+    // - is_right indicates if the removal is from the right tree or left tree.
+    // returns
+    // - if the height of this sub tree is decreased.
+    // - the new index of the sub tree at this point.
+    fun avl_update_remove<V>(tree: &mut AvlTree<V>, index: u64, is_right: bool): (bool, u64) {
+        if (index == NULL_INDEX) {
+            return (false, index)
+        };
+
+        let metadata = vector::borrow(&tree.entries, index).metadata;
+
+        // sub tree is balanced, it becomes unbalanced but upper tree height doesn't decrease
+        if (metadata == AVL_ZERO) {
+            let new_metadata = if (is_right) {
+                AVL_LEFT_HIGH
+            } else {
+                AVL_RIGHT_HIGH
+            };
+
+            vector::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+            return (false, index)
+        };
+
+        // sub tree's left sub tree is high, decreasing its height set the sub tree to balanced.
+        // but parent tree height decreases
+        if (metadata == AVL_LEFT_HIGH && !is_right) {
+            vector::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (true, index)
+        };
+
+        // sub tree's right sub tree is high, decreasing its height set the sub tree to balanced.
+        // but parent tree height decreases
+        if (metadata == AVL_RIGHT_HIGH && is_right) {
+            vector::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+            return (true, index)
+        };
+
+        let new_metadata = if (metadata == AVL_RIGHT_HIGH) {
+            AVL_RIGHT_HIGH_2
+        } else {
+            AVL_LEFT_HIGH_2
+        };
+
+        vector::borrow_mut(&mut tree.entries, index).metadata = new_metadata;
+
+        avl_rebalance(tree, index, true)
+    }
+
+    // AVL rebalances the sub tree at index.
+    // returns:
+    // - if the height of the subtree is decreased.
+    // - the index of the new subtree.
+    fun avl_rebalance<V>(tree: &mut AvlTree<V>, index: u64, is_remove: bool): (bool, u64) {
+        let node = vector::borrow(&tree.entries, index);
+        let metadata = node.metadata;
+
+        assert!(metadata == AVL_LEFT_HIGH_2 || metadata == AVL_RIGHT_HIGH_2, E_AVL_NOT_IMBALANCED);
+
+
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+
+        if (metadata == AVL_LEFT_HIGH_2) {
+            // left subtree is higher
+            let left_metadata = vector::borrow(&tree.entries, left_child).metadata;
+
+            assert!(left_metadata != AVL_RIGHT_HIGH_2 && left_metadata != AVL_LEFT_HIGH_2, E_AVL_SUBTREE_IMBALANCED);
+            assert!(is_remove || left_metadata != AVL_ZERO, E_AVL_BAD_STATE);
+
+            if (left_metadata != AVL_RIGHT_HIGH) {
+                // case 1:
+                //              index --
+                //            /           \
+                //         left (-/0)        right
+                //        /   \
+                //       a     b
+                //      /     /
+                //     c     (/e)
+                // -------
+                //               left (0/+)
+                //              /      \
+                //             a     index (0/-)
+                //            /    /         \
+                //           c    b          right
+                //               /
+                //              (/e)
+                let old_left_meta = left_metadata;
+                rotate_right(tree, index);
+                if (old_left_meta == AVL_ZERO) {
+                    vector::borrow_mut(&mut tree.entries, left_child).metadata = AVL_RIGHT_HIGH;
+                    vector::borrow_mut(&mut tree.entries, index).metadata = AVL_LEFT_HIGH;
+                } else {
+                    vector::borrow_mut(&mut tree.entries, left_child).metadata = AVL_ZERO;
+                    vector::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+                };
+
+                (old_left_meta != AVL_ZERO, left_child)
+            } else {
+                // case 2:
+                //              index --
+                //            /          \
+                //         left +       right
+                //       /    \
+                //      a      w (+/0/-)
+                //           /   \
+                //       (/b/b)  (c/c/)
+                // --------
+                //                   w 0
+                //                /       \
+                //       left (-1/0/0)    index (0/0/1)
+                //       /    \           /     \
+                //      a   (/b/b)   (c/c/)      right
+                let w = vector::borrow(&tree.entries, left_child).right_child;
+                let w_meta = vector::borrow(&tree.entries, w).metadata;
+                rotate_left(tree, left_child);
+                rotate_right(tree, index);
+                vector::borrow_mut(&mut tree.entries, w).metadata = AVL_ZERO;
+                vector::borrow_mut(&mut tree.entries, left_child).metadata = if(w_meta == AVL_RIGHT_HIGH) { AVL_LEFT_HIGH } else {AVL_ZERO};
+                vector::borrow_mut(&mut tree.entries, index).metadata = if(w_meta == AVL_LEFT_HIGH) {AVL_RIGHT_HIGH} else {AVL_ZERO};
+
+                (true, w)
+            }
+        } else {
+            let right_metadata = vector::borrow(&tree.entries, right_child).metadata;
+
+            assert!(right_metadata != AVL_RIGHT_HIGH_2 && right_metadata != AVL_LEFT_HIGH_2, E_AVL_SUBTREE_IMBALANCED);
+            assert!(is_remove || right_metadata != AVL_ZERO, E_AVL_BAD_STATE);
+
+            if (right_metadata != AVL_LEFT_HIGH) {
+                // case 1:
+                //              index ++
+                //            /           \
+                //         left         right +/0
+                //                       /   \
+                //                      a     b
+                //                     /       \
+                //                    (/c)      d
+                // -------
+                //                 right 0/-1
+                //              /          \
+                //           index 0/1       b
+                //         /        \         \
+                //       left        a         d
+                //                    \
+                //                    (/c)
+                let old_right_meta = right_metadata;
+                rotate_left(tree, index);
+                if (old_right_meta == AVL_ZERO) {
+                    vector::borrow_mut(&mut tree.entries, right_child).metadata = AVL_LEFT_HIGH;
+                    vector::borrow_mut(&mut tree.entries, index).metadata = AVL_RIGHT_HIGH;
+                } else {
+                    vector::borrow_mut(&mut tree.entries, right_child).metadata = AVL_ZERO;
+                    vector::borrow_mut(&mut tree.entries, index).metadata = AVL_ZERO;
+                };
+                (old_right_meta != AVL_ZERO, right_child)
+            } else {
+                // case 2:
+                //                index ++
+                //            /             \
+                //         left            right -
+                //                     /          \
+                //                   w (-/0/+)      a
+                //                  /   \
+                //               (b/b/) (/c/c)
+                // --------
+                //                    w 0
+                //            /             \
+                //      index (0/0/-1)    right (1/0/0)
+                //       /    \           /     \
+                //      left  (b/b/)  (/c/c)     a
+                let w = vector::borrow(&tree.entries, right_child).left_child;
+                let w_meta = vector::borrow(&tree.entries, w).metadata;
+                rotate_right(tree, right_child);
+                rotate_left(tree, index);
+                vector::borrow_mut(&mut tree.entries, w).metadata = AVL_ZERO;
+                vector::borrow_mut(&mut tree.entries, right_child).metadata = if (w_meta == AVL_LEFT_HIGH) {AVL_RIGHT_HIGH} else {AVL_ZERO};
+                vector::borrow_mut(&mut tree.entries, index).metadata = if (w_meta == AVL_RIGHT_HIGH) {AVL_LEFT_HIGH} else {AVL_ZERO};
+
+                (true, w)
+            }
+        }
+    }
+
+    ///////////
+    // Specs //
+    ///////////
+
+    /// spec_is_valid_index checks the index is either NULL_INDEX or an element of the tree.
+    spec fun spec_is_valid_index<V>(tree: AvlTree<V>, index: u64): bool {
+        index == NULL_INDEX || index < len(tree.entries)
+    }
+
+    /// spec_less checks the keys of entry a are smaller than the keys of entry b.
+    spec fun spec_less<V>(a: Entry<V>, b: Entry<V>): bool {
+        ((a.key < b.key))
+    }
+
+    /// spec_same_keys checks entry a and entry b have the same keys.
+    spec fun spec_same_keys<V>(a: Entry<V>, b: Entry<V>): bool {
+        a.key == b.key
+    }
+
+    /// spec_same_data checks entry a and entry b have the same keys and value.
+    spec fun spec_same_data<V>(a: Entry<V>, b: Entry<V>): bool {
+        spec_same_keys(a, b) && a.value == b.value
+    }
+
+    /// spec_child is the right child of the entry at index if is_right, otherwise the left child.
+    spec fun spec_child<V>(tree: AvlTree<V>, index: u64, is_right: bool): u64 {
+        if (is_right) tree.entries[index].right_child else tree.entries[index].left_child
+    }
+
+    /// spec_ancestor is the index reached from index by following the parent links for the given steps.
+    spec fun spec_ancestor<V>(tree: AvlTree<V>, index: u64, steps: num): u64 {
+        if (steps <= 0 || index == NULL_INDEX) index else spec_ancestor(tree, tree.entries[index].parent, steps - 1)
+    }
+
+    /// spec_in_subtree checks the entry at index is in the subtree rooted at root, including root itself.
+    spec fun spec_in_subtree<V>(tree: AvlTree<V>, index: u64, root: u64): bool {
+        root != NULL_INDEX && index != NULL_INDEX && (exists steps in 0..len(tree.entries): spec_ancestor(tree, index, steps) == root)
+    }
+
+    /// spec_child_height is the height of the subtree at the child on the is_right side of the entry at index,
+    /// as it was before the subtree at the child on the at_right side of at grew by one (or shrank by one if !grew).
+    /// The heights are the current ones if at is NULL_INDEX.
+    /// depth bounds the recursion, which a valid tree never reaches when it starts at the number of entries.
+    spec fun spec_child_height<V>(tree: AvlTree<V>, index: u64, is_right: bool, at: u64, at_right: bool, grew: bool, depth: num): num {
+        let child = spec_child(tree, index, is_right);
+        let height = if (child == NULL_INDEX || depth <= 0) {
+            0
+        } else {
+            let left = spec_child_height(tree, child, false, at, at_right, grew, depth - 1);
+            let right = spec_child_height(tree, child, true, at, at_right, grew, depth - 1);
+            if (left > right) left + 1 else right + 1
+        };
+        if (index != at || is_right != at_right) height else if (grew) height - 1 else height + 1
+    }
+
+    /// spec_is_avl_balanced checks the balance factor in the metadata of the entry at index is the difference
+    /// between the heights of its right and left subtrees, which are the heights given by spec_child_height.
+    spec fun spec_is_avl_balanced<V>(tree: AvlTree<V>, index: u64, at: u64, at_right: bool, grew: bool): bool {
+        tree.entries[index].metadata + spec_child_height(tree, index, false, at, at_right, grew, len(tree.entries))
+            == AVL_ZERO + spec_child_height(tree, index, true, at, at_right, grew, len(tree.entries))
+    }
+
+    /// spec_is_valid_entry checks the links of the entry at index, which never point to the excluded entry,
+    /// and the order of its keys against its children.
+    spec fun spec_is_valid_entry<V>(tree: AvlTree<V>, index: u64, excluded: u64): bool {
+        let entry = tree.entries[index];
+        spec_is_valid_index(tree, entry.parent)
+            && spec_is_valid_index(tree, entry.left_child)
+            && spec_is_valid_index(tree, entry.right_child)
+            && (excluded == NULL_INDEX || (entry.parent != excluded && entry.left_child != excluded && entry.right_child != excluded))
+            && (entry.parent == NULL_INDEX) == (index == tree.root)
+            && (entry.left_child == NULL_INDEX || (tree.entries[entry.left_child].parent == index && spec_less(tree.entries[entry.left_child], entry)))
+            && (entry.right_child == NULL_INDEX || (tree.entries[entry.right_child].parent == index && spec_less(entry, tree.entries[entry.right_child])))
+            && entry.metadata >= AVL_LEFT_HIGH && entry.metadata <= AVL_RIGHT_HIGH
+    }
+
+    /// spec_is_valid_structure checks the entries other than excluded form a binary search tree:
+    /// all of them are reachable from the root, the keys in the left subtree of an entry are smaller than its keys,
+    /// and the keys in the right subtree are bigger. min_index and max_index are the entries with the smallest
+    /// and the biggest keys. excluded is the entry being removed, or NULL_INDEX.
+    spec fun spec_is_valid_structure<V>(tree: AvlTree<V>, excluded: u64): bool {
+        let count = len(tree.entries) - (if (excluded == NULL_INDEX) 0 else 1);
+        spec_is_valid_index(tree, tree.root)
+            && spec_is_valid_index(tree, tree.min_index)
+            && spec_is_valid_index(tree, tree.max_index)
+            && (excluded == NULL_INDEX || (tree.root != excluded && tree.min_index != excluded && tree.max_index != excluded))
+            && (tree.root == NULL_INDEX) == (count == 0)
+            && (tree.min_index == NULL_INDEX) == (count == 0)
+            && (tree.max_index == NULL_INDEX) == (count == 0)
+            && (tree.min_index == NULL_INDEX || (forall i in 0..len(tree.entries): i != excluded && i != tree.min_index ==> spec_less(tree.entries[tree.min_index], tree.entries[i])))
+            && (tree.max_index == NULL_INDEX || (forall i in 0..len(tree.entries): i != excluded && i != tree.max_index ==> spec_less(tree.entries[i], tree.entries[tree.max_index])))
+            && (forall i in 0..len(tree.entries): i != excluded ==> spec_is_valid_entry(tree, i, excluded))
+            && (forall i in 0..len(tree.entries): i != excluded ==> spec_in_subtree(tree, i, tree.root))
+            && (forall i in 0..len(tree.entries), j in 0..len(tree.entries): i != excluded && j != excluded ==>
+                (spec_in_subtree(tree, j, tree.entries[i].left_child) ==> spec_less(tree.entries[j], tree.entries[i]))
+                    && (spec_in_subtree(tree, j, tree.entries[i].right_child) ==> spec_less(tree.entries[i], tree.entries[j])))
+            && (forall i in 0..len(tree.entries), j in 0..len(tree.entries): i != excluded && j != excluded && i != j ==> !spec_same_keys(tree.entries[i], tree.entries[j]))
+    }
+
+    /// spec_is_valid_tree is the invariant of the AvlTree.
+    /// It holds between calls to the public functions, but not in the middle of insertion or removal
+    /// (the new entry is pushed before it is linked, and the rotations relink the entries one by one),
+    /// so it is required and ensured by the public functions instead of declared as a struct invariant.
+    spec fun spec_is_valid_tree<V>(tree: AvlTree<V>): bool {
+        spec_is_valid_structure(tree, NULL_INDEX)
+            && (forall i in 0..len(tree.entries): spec_is_avl_balanced(tree, i, NULL_INDEX, false, false))
+    }
+
+    /// spec_contains checks if the keys are in the AvlTree.
+    spec fun spec_contains<V>(tree: AvlTree<V>, key: u128): bool {
+        exists i in 0..len(tree.entries): tree.entries[i].key == key
+    }
+
+    spec new {
+        aborts_if false;
+        ensures result.root == NULL_INDEX;
+        ensures result.min_index == NULL_INDEX;
+        ensures result.max_index == NULL_INDEX;
+        ensures len(result.entries) == 0;
+    }
+
+    spec find {
+        requires spec_is_valid_tree(tree);
+        ensures result == NULL_INDEX || (result < len(tree.entries) && tree.entries[result].key == key);
+        ensures spec_contains(tree, key) ==> result != NULL_INDEX;
+    }
+
+    spec borrow_at_index {
+        aborts_if index >= len(tree.entries);
+        ensures result_1 == tree.entries[index].key;
+        ensures result_2 == tree.entries[index].value;
+    }
+
+    spec borrow_at_index_mut {
+        aborts_if index >= len(tree.entries);
+        ensures result_1 == tree.entries[index].key;
+    }
+
+    spec size {
+        aborts_if false;
+        ensures result == len(tree.entries);
+    }
+
+    spec empty {
+        aborts_if false;
+        ensures result == (len(tree.entries) == 0);
+    }
+
+    spec get_min_index {
+        aborts_if tree.min_index == NULL_INDEX with E_EMPTY_TREE;
+        ensures result == tree.min_index;
+    }
+
+    spec get_max_index {
+        aborts_if tree.max_index == NULL_INDEX with E_EMPTY_TREE;
+        ensures result == tree.max_index;
+    }
+
+    spec schema InsertAbortsIf<V> {
+        tree: AvlTree<V>;
+        key: u128;
+        aborts_if len(tree.entries) >= NULL_INDEX with E_TREE_TOO_BIG;
+        aborts_if spec_contains(tree, key) with E_KEY_ALREADY_EXIST;
+    }
+
+    spec schema InsertEnsures<V> {
+        tree: AvlTree<V>;
+        key: u128;
+        value: V;
+        ensures spec_is_valid_tree(tree);
+        ensures len(tree.entries) == len(old(tree.entries)) + 1;
+        ensures spec_contains(tree, key);
+        // existing elements stay at their indices.
+        ensures forall i in 0..len(old(tree.entries)): tree.entries[i].key == old(tree.entries[i].key) && tree.entries[i].value == old(tree.entries[i].value);
+    }
+
+    spec insert {
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_tree(tree);
+        include InsertAbortsIf<V>;
+        include InsertEnsures<V>;
+    }
+
+    spec insert_and_get_index {
+        pragma opaque;
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_tree(tree);
+        include InsertAbortsIf<V>;
+        include InsertEnsures<V>;
+        ensures result == len(old(tree.entries));
+        ensures tree.entries[result].key == key;
+        ensures tree.entries[result].value == value;
+    }
+
+    spec remove {
+        pragma opaque;
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_tree(tree);
+        aborts_if index >= len(tree.entries);
+        ensures spec_is_valid_tree(tree);
+        ensures len(tree.entries) == len(old(tree.entries)) - 1;
+        ensures result_1 == old(tree.entries[index].key);
+        ensures result_2 == old(tree.entries[index].value);
+        ensures !spec_contains(tree, result_1);
+    }
+
+    spec destroy_empty {
+        aborts_if len(tree.entries) != 0 with E_TREE_NOT_EMPTY;
+    }
+
+    #[test]
+    fun test_bounds() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 3, 8, 13, ..., 98 out of order.
+            let v = ((idx * 7) % 20) * 5 + 3;
+            let index = insert_and_get_index(&mut tree, v, v);
+            assert!(find(&tree, v) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        let k: u128 = 0;
+        while (k < 110) {
+            let expected_lower = NULL_INDEX;
+            let expected_upper = NULL_INDEX;
+            let expected_floor = NULL_INDEX;
+            let iter = get_min_index(&tree);
+            while (iter != NULL_INDEX) {
+                let (key, _) = borrow_at_index(&tree, iter);
+                if (key <= k) {
+                    expected_floor = iter;
+                };
+                if (key >= k && expected_lower == NULL_INDEX) {
+                    expected_lower = iter;
+                };
+                if (key > k && expected_upper == NULL_INDEX) {
+                    expected_upper = iter;
+                };
+                iter = next_in_order(&tree, iter);
+            };
+
+            assert!(lower_bound(&tree, k) == expected_lower, (k as u64));
+            assert!(upper_bound(&tree, k) == expected_upper, (k as u64));
+            assert!(floor(&tree, k) == expected_floor, (k as u64));
+            assert!(ceiling(&tree, k) == expected_lower, (k as u64));
+            k = k + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_avl() {
+        let tree = new<u128>();
+        insert(&mut tree, 6, 6);
+        insert(&mut tree, 5, 5);
+        insert(&mut tree, 4, 4);
+        let v = vector<Entry<u128>> [
+            new_entry_for_test<u128>(6, 6, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(5, 5, NULL_INDEX, 2, 0, AVL_ZERO),
+            new_entry_for_test<u128>(4, 4, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+        ];
+
+        assert!(tree.root == 1, tree.root);
+        assert!(&tree.entries == &v, 2);
+
+        let v = vector<Entry<u128>> [
+            new_entry_for_test<u128>(6, 6, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(5, 5, NULL_INDEX, 4, 0, AVL_LEFT_HIGH),
+            new_entry_for_test<u128>(4, 4, 4, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(1, 1, 4, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(3, 3, 1, 3, 2, AVL_ZERO),
+        ];
+
+        insert(&mut tree, 1, 1);
+        insert(&mut tree, 3, 3);
+        assert!(&tree.entries == &v, 3);
+
+        let v = vector<Entry<u128>> [
+            new_entry_for_test<u128>(6, 6, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO), // 0
+            new_entry_for_test<u128>(5, 5, 4, 2, 0, AVL_ZERO), // 1
+            new_entry_for_test<u128>(4, 4, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO), // 2
+            new_entry_for_test<u128>(1, 1, 4, NULL_INDEX, 5, AVL_RIGHT_HIGH), // 3
+            new_entry_for_test<u128>(3, 3, NULL_INDEX, 3, 1, AVL_ZERO), // 4
+            new_entry_for_test<u128>(2, 2, 3, NULL_INDEX, NULL_INDEX, AVL_ZERO), // 5
+        ];
+
+        insert(&mut tree, 2, 2);
+        assert!(&tree.entries == &v, 4);
+    }
+
+    #[test]
+    fun test_avl_reverse() {
+        let tree = new<u128>();
+        insert(&mut tree, 6, 6);
+        insert(&mut tree, 7, 7);
+        insert(&mut tree, 8, 8);
+        let v = vector<Entry<u128>> [
+            new_entry_for_test<u128>(6, 6, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(7, 7, NULL_INDEX, 0, 2, AVL_ZERO),
+            new_entry_for_test<u128>(8, 8, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+        ];
+
+        assert!(tree.root == 1, tree.root);
+        assert!(&tree.entries == &v, 2);
+
+        let v = vector<Entry<u128>> [
+            new_entry_for_test<u128>(6, 6, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(7, 7, NULL_INDEX, 0, 4, AVL_RIGHT_HIGH),
+            new_entry_for_test<u128>(8, 8, 4, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(11, 11, 4, NULL_INDEX, NULL_INDEX, AVL_ZERO),
+            new_entry_for_test<u128>(9, 9, 1, 2, 3, AVL_ZERO),
+        ];
+
+        insert(&mut tree, 11, 11);
+        insert(&mut tree, 9, 9);
+        assert!(&tree.entries == &v, 3);
+
+        let v = vector<Entry<u128>> [
+            new_entry_for_test<u128>(6, 6, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO), // 0
+            new_entry_for_test<u128>(7, 7, 4, 0, 2, AVL_ZERO), // 1
+            new_entry_for_test<u128>(8, 8, 1, NULL_INDEX, NULL_INDEX, AVL_ZERO), // 2
+            new_entry_for_test<u128>(11, 11, 4, 5, NULL_INDEX, AVL_LEFT_HIGH), // 3
+            new_entry_for_test<u128>(9, 9, NULL_INDEX, 1, 3, AVL_ZERO), // 4
+            new_entry_for_test<u128>(10, 10, 3, NULL_INDEX, NULL_INDEX, AVL_ZERO), // 5
+        ];
+
+        insert(&mut tree, 10, 10);
+        assert!(&tree.entries == &v, 4);
+    }
+
+    #[test]
+    fun test_min_iter_avl() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 0;
+        let iter = get_min_index(&tree);
+        while (idx < 20) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx, (v as u64));
+            idx = idx + 1;
+            iter = next_in_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let min_index = get_min_index(&tree);
+        remove(&mut tree, min_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let min_index = get_min_index(&tree);
+            let (key, value) = borrow_at_index(&tree, min_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, min_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+
+
+    #[test]
+    fun test_max_iter_avl() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 20;
+        let iter = get_max_index(&tree);
+        while (idx > 0) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx - 1, (v as u64));
+            idx = idx - 1;
+            iter = next_in_reverse_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let max_index = get_max_index(&tree);
+        remove(&mut tree, max_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let max_index = get_max_index(&tree);
+            let (key, value) = borrow_at_index(&tree, max_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, max_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+}
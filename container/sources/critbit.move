@@ -89,6 +89,53 @@ module container::critbit {
         }
     }
 
+    /// lower_bound returns the index of the first element with key greater than or equal to the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &CritbitTree<V>, key: u128): u64 {
+        let (closest_index, subtree, is_bigger) = find_bound(tree, key);
+        if (closest_index == NULL_INDEX || subtree == NULL_INDEX) {
+            closest_index
+        } else if (is_bigger) {
+            next_in_order(tree, get_max_index_from(tree, subtree))
+        } else {
+            get_min_index_from(tree, subtree)
+        }
+    }
+
+    /// upper_bound returns the index of the first element with key greater than the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &CritbitTree<V>, key: u128): u64 {
+        let (closest_index, subtree, is_bigger) = find_bound(tree, key);
+        if (closest_index == NULL_INDEX) {
+            NULL_INDEX
+        } else if (subtree == NULL_INDEX) {
+            next_in_order(tree, closest_index)
+        } else if (is_bigger) {
+            next_in_order(tree, get_max_index_from(tree, subtree))
+        } else {
+            get_min_index_from(tree, subtree)
+        }
+    }
+
+    /// floor returns the index of the last element with key less than or equal to the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &CritbitTree<V>, key: u128): u64 {
+        let (closest_index, subtree, is_bigger) = find_bound(tree, key);
+        if (closest_index == NULL_INDEX || subtree == NULL_INDEX) {
+            closest_index
+        } else if (is_bigger) {
+            get_max_index_from(tree, subtree)
+        } else {
+            next_in_reverse_order(tree, get_min_index_from(tree, subtree))
+        }
+    }
+
+    /// ceiling returns the index of the first element with key greater than or equal to the input key,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &CritbitTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
     /// borrow returns a reference to the element with its key at the given index
     public fun borrow_at_index<V>(tree: &CritbitTree<V>, index: u64): (u128, &V) {
         let entry = vector::borrow(&tree.entries, index);
@@ -218,15 +265,58 @@ module container::critbit {
         NULL_INDEX
     }
 
+    /// find_bound locates the key in the tree.
+    /// returns
+    /// - the index of the element closest to the key, or NULL_INDEX if the tree is empty.
+    /// - NULL_INDEX if the key is in the tree. Otherwise the subtree where the key would be inserted,
+    ///   all elements of which share the bits above the critbit with the key.
+    /// - if the key is bigger than all the elements in the subtree.
+    fun find_bound<V>(tree: &CritbitTree<V>, key: u128): (u64, u64, bool) {
+        let closest_index = find_closest_key(tree, key, tree.root);
+        if (closest_index == NULL_INDEX) {
+            return (NULL_INDEX, NULL_INDEX, false)
+        };
+
+        let closest_key = vector::borrow(&tree.entries, closest_index).key;
+        if (closest_key == key) {
+            return (closest_index, NULL_INDEX, false)
+        };
+
+        let n = critbit(closest_key, key);
+        let mask = 1u128 << (n as u8);
+
+        let current = tree.root;
+        while (!is_data_index(current)) {
+            let node = vector::borrow(&tree.tree, current);
+            if (mask > node.mask) {
+                break
+            };
+            let m = node.mask & key;
+            if (m != node.mask) {
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            }
+        };
+
+        (closest_index, current, (mask & key) == mask)
+    }
+
     /// insert puts the value keyed at the input keys into the CritbitTree.
     /// aborts if the key is already in the tree.
+    public fun insert<V>(tree: &mut CritbitTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the CritbitTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
     ///
     /// Process is as follows
     /// - if the tree is empty, insert the node directly.
     /// - if the tree is not empty, try to find the key in the tree. The look up will eventually reach a data node.
     ///   - if the key of the data node equals the input key, the key already exists, the process if abort.
     ///   - otherwise, rewalk the tree and find the insertion point (which is the most significant different between the key)
-    public fun insert<V>(tree: &mut CritbitTree<V>, key: u128, value: V) {
+    public fun insert_and_get_index<V>(tree: &mut CritbitTree<V>, key: u128, value: V): u64 {
         let data_node = DataNode<V>{
             key,
             value,
@@ -250,7 +340,7 @@ module container::critbit {
             tree.root = convert_data_index(data_index);
             tree.min_index = data_index;
             tree.max_index = data_index;
-            return
+            return data_index
         };
 
         // now the tree is not empty.
@@ -320,6 +410,8 @@ module container::critbit {
         if (vector::borrow(&tree.entries, max_index).key < key) {
             tree.max_index = data_index;
         };
+
+        data_index
     }
 
     /// remove deletes and returns the element from the CritbitTree.
@@ -690,6 +782,58 @@ module container::critbit {
         assert!(current_key == 1, (current_key as u64));
     }
 
+    #[test]
+    fun test_bounds_critbit() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 3, 8, 13, ..., 98 out of order.
+            let v = ((idx * 7) % 20) * 5 + 3;
+            let index = insert_and_get_index(&mut tree, v, v);
+            assert!(find(&tree, v) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        let k: u128 = 0;
+        while (k < 110) {
+            let expected_lower = NULL_INDEX;
+            let expected_upper = NULL_INDEX;
+            let expected_floor = NULL_INDEX;
+            let iter = get_min_index(&tree);
+            while (iter != NULL_INDEX) {
+                let (key, _) = borrow_at_index(&tree, iter);
+                if (key <= k) {
+                    expected_floor = iter;
+                };
+                if (key >= k && expected_lower == NULL_INDEX) {
+                    expected_lower = iter;
+                };
+                if (key > k && expected_upper == NULL_INDEX) {
+                    expected_upper = iter;
+                };
+                iter = next_in_order(&tree, iter);
+            };
+
+            assert!(lower_bound(&tree, k) == expected_lower, (k as u64));
+            assert!(upper_bound(&tree, k) == expected_upper, (k as u64));
+            assert!(floor(&tree, k) == expected_floor, (k as u64));
+            assert!(ceiling(&tree, k) == expected_lower, (k as u64));
+            k = k + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
     #[test]
     fun test_remove_critbit() {
         let bst = CritbitTree<u128> {
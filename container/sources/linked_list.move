@@ -94,8 +94,19 @@ module container::linked_list {
         insert_after(list, index, value)
     }
 
+    /// insert at the end of the list, and return the index of the new element.
+    public fun insert_and_get_index<V>(list: &mut LinkedList<V>, value: V): u64 {
+        let index = list.tail;
+        insert_after_and_get_index(list, index, value)
+    }
+
     /// insert after index. If the list is empty, the index can be NULL_INDEX.
     public fun insert_after<V>(list: &mut LinkedList<V>, index: u64, value: V) {
+        insert_after_and_get_index(list, index, value);
+    }
+
+    /// insert after index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after_and_get_index<V>(list: &mut LinkedList<V>, index: u64, value: V): u64 {
         let new_index = vector::length(&list.entries);
         assert!(
             new_index < MAX_CAPACITY,
@@ -112,7 +123,7 @@ module container::linked_list {
             list.head = new_index;
             list.tail = new_index;
             push_back(&mut list.entries, node);
-            return
+            return new_index
         };
 
         assert!(
@@ -130,10 +141,17 @@ module container::linked_list {
         };
 
         push_back(&mut list.entries, node);
+
+        new_index
     }
 
     /// isnert before index. If the list is empty, the index can be NULL_INDEX.
     public fun insert_before<V>(list: &mut LinkedList<V>, index: u64, value: V) {
+        insert_before_and_get_index(list, index, value);
+    }
+
+    /// insert before index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before_and_get_index<V>(list: &mut LinkedList<V>, index: u64, value: V): u64 {
         let new_index = vector::length(&list.entries);
         assert!(
             new_index < MAX_CAPACITY,
@@ -150,7 +168,7 @@ module container::linked_list {
             list.head = new_index;
             list.tail = new_index;
             push_back(&mut list.entries, node);
-            return
+            return new_index
         };
 
         assert!(
@@ -167,6 +185,8 @@ module container::linked_list {
         };
 
         push_back(&mut list.entries, node);
+
+        new_index
     }
 
     /// remove deletes and returns the element from the LinkedList.
@@ -270,7 +290,7 @@ module container::linked_list {
         };
         assert!(l == expected, 1);
 
-        insert_after(&mut l, 1, 11);
+        assert!(insert_after_and_get_index(&mut l, 1, 11) == 3, 3);
         let expected = LinkedList<u128> {
             head: 0,
             tail: 2,
@@ -283,7 +303,7 @@ module container::linked_list {
         };
         assert!(l == expected, 1);
 
-        insert_before(&mut l, 0, 13);
+        assert!(insert_before_and_get_index(&mut l, 0, 13) == 4, 4);
         let expected = LinkedList<u128> {
             head: 4,
             tail: 2,
@@ -358,4 +378,58 @@ module container::linked_list {
         };
         assert!(l == expected, 1);
     }
+
+    #[test]
+    public fun test_insert_remove_linked_list() {
+        let l = new<u128>();
+        let i = 0;
+        while (i < 10) {
+            // 1, 3, ..., 19 at indices 0, 1, ..., 9.
+            insert(&mut l, (i as u128) * 2 + 1);
+            i = i + 1;
+        };
+        let i = 0;
+        while (i < 10) {
+            // 2, 4, ..., 20 right after 1, 3, ..., 19.
+            assert!(insert_after_and_get_index(&mut l, i, (i as u128) * 2 + 2) == i + 10, i);
+            i = i + 1;
+        };
+        let head = l.head;
+        insert_before(&mut l, head, 0);
+        assert!(size(&l) == 21, size(&l));
+
+        let expected: u128 = 0;
+        let index = l.head;
+        while (index != NULL_INDEX) {
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            expected = expected + 1;
+            index = next(&l, index);
+        };
+        assert!(expected == 21, (expected as u64));
+        let index = l.tail;
+        while (index != NULL_INDEX) {
+            expected = expected - 1;
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            index = previous(&l, index);
+        };
+        assert!(expected == 0, (expected as u64));
+
+        while (!empty(&l)) {
+            let head = l.head;
+            assert!(remove(&mut l, head) == expected, (expected as u64));
+            expected = expected + 1;
+            // the rest is still linked in order.
+            let count = 0;
+            let index = l.head;
+            while (index != NULL_INDEX) {
+                assert!(*borrow_at_index(&l, index) == expected + (count as u128), count);
+                count = count + 1;
+                index = next(&l, index);
+            };
+            assert!(count == size(&l), count);
+        };
+        assert!(expected == 21, (expected as u64));
+
+        destroy_empty(l);
+    }
 }
@@ -0,0 +1,480 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Double Linked List
+module container::linked_list_stable {
+    use std::option::{Self, Option};
+    use std::vector;
+
+    /// Slots stores the elements without moving them: an element keeps its index until it is taken out.
+    /// The slot of a taken element becomes vacant and is reused by the next add.
+    struct Slots<T> has store, copy, drop {
+        items: vector<Option<T>>,
+        // indices of the vacant slots, the last one is reused first.
+        vacant: vector<u64>,
+    }
+
+    fun new_slots<T>(): Slots<T> {
+        Slots<T> {
+            items: vector::empty(),
+            vacant: vector::empty(),
+        }
+    }
+
+    /// length is the number of elements in the slots.
+    fun length<T>(slots: &Slots<T>): u64 {
+        vector::length(&slots.items) - vector::length(&slots.vacant)
+    }
+
+    /// capacity is the number of slots, including the vacant ones. All indices are less than the capacity.
+    fun capacity<T>(slots: &Slots<T>): u64 {
+        vector::length(&slots.items)
+    }
+
+    /// is_occupied checks if there is an element at index.
+    fun is_occupied<T>(slots: &Slots<T>, index: u64): bool {
+        index < vector::length(&slots.items) && option::is_some(vector::borrow(&slots.items, index))
+    }
+
+    fun borrow<T>(slots: &Slots<T>, index: u64): &T {
+        option::borrow(vector::borrow(&slots.items, index))
+    }
+
+    fun borrow_mut<T>(slots: &mut Slots<T>, index: u64): &mut T {
+        option::borrow_mut(vector::borrow_mut(&mut slots.items, index))
+    }
+
+    /// next_slot is the index the next add will put the element at.
+    fun next_slot<T>(slots: &Slots<T>): u64 {
+        if (vector::is_empty(&slots.vacant)) {
+            capacity(slots)
+        } else {
+            *vector::borrow(&slots.vacant, vector::length(&slots.vacant) - 1)
+        }
+    }
+
+    /// add puts the element into the last vacated slot, or a new slot if none is vacant, and returns its index.
+    fun add<T>(slots: &mut Slots<T>, item: T): u64 {
+        if (vector::is_empty(&slots.vacant)) {
+            let index = capacity(slots);
+            vector::push_back(&mut slots.items, option::some(item));
+            index
+        } else {
+            let index = vector::pop_back(&mut slots.vacant);
+            fill(slots, index, item);
+            index
+        }
+    }
+
+    /// take removes the element at index and leaves the slot vacant.
+    fun take<T>(slots: &mut Slots<T>, index: u64): T {
+        let item = option::extract(vector::borrow_mut(&mut slots.items, index));
+        vector::push_back(&mut slots.vacant, index);
+        item
+    }
+
+    /// fill puts the element into the slot at index, which must be vacant and already off the vacant list.
+    fun fill<T>(slots: &mut Slots<T>, index: u64, item: T) {
+        option::fill(vector::borrow_mut(&mut slots.items, index), item);
+    }
+
+    /// relocate moves the element at index to new_index, which must be vacant and already off the vacant list.
+    /// the slot at index is left vacant, but not put on the vacant list.
+    fun relocate<T>(slots: &mut Slots<T>, index: u64, new_index: u64) {
+        let item = option::extract(vector::borrow_mut(&mut slots.items, index));
+        fill(slots, new_index, item);
+    }
+
+    /// pop_vacant_below takes a vacant slot with index less than bound off the vacant list.
+    /// vacant slots at or above bound are dropped from the vacant list on the way.
+    /// aborts if there is no such slot.
+    fun pop_vacant_below<T>(slots: &mut Slots<T>, bound: u64): u64 {
+        loop {
+            let index = vector::pop_back(&mut slots.vacant);
+            if (index < bound) {
+                return index
+            };
+        }
+    }
+
+    /// shrink drops all the vacant slots, which must all be after the elements, so the capacity equals the length.
+    fun shrink<T>(slots: &mut Slots<T>) {
+        let count = length(slots);
+        while (vector::length(&slots.items) > count) {
+            option::destroy_none(vector::pop_back(&mut slots.items));
+        };
+        slots.vacant = vector::empty();
+    }
+
+    /// destroy_slots destroys the slots, aborts if there is any element left.
+    fun destroy_slots<T>(slots: Slots<T>) {
+        let Slots { items, vacant: _ } = slots;
+        while (!vector::is_empty(&items)) {
+            option::destroy_none(vector::pop_back(&mut items));
+        };
+        vector::destroy_empty(items);
+    }
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_EMPTY_TREE: u64 = 2;
+    const E_KEY_ALREADY_EXIST: u64 = 4;
+    const E_INDEX_OUT_OF_RANGE: u64 = 5;
+    const E_CANNOT_DESTRORY_NON_EMPTY: u64 = 7;
+    const E_EXCEED_CAPACITY: u64 = 8;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    const MAX_CAPACITY: u64 = 18446744073709551614; // NULL_INDEX - 1
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+    // Node is a node in the linked list
+    struct Node<V> has store, copy, drop {
+        value: V,
+
+        prev: u64,
+        next: u64,
+    }
+
+    /// LinkedList is a double linked list.
+    struct LinkedList<V> has store, copy, drop {
+        head: u64,
+        tail: u64,
+        entries: Slots<Node<V>>,
+    }
+
+    public fun new<V>(): LinkedList<V> {
+        LinkedList<V> {
+            head: NULL_INDEX,
+            tail: NULL_INDEX,
+            entries: new_slots(),
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V>(list: &LinkedList<V>, index: u64): &V {
+        let entry = Self::borrow(&list.entries, index);
+        &entry.value
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V>(list: &mut LinkedList<V>, index: u64): &mut V {
+        let entry = Self::borrow_mut(&mut list.entries, index);
+        &mut entry.value
+    }
+
+    /// size returns the number of elements in the LinkedList.
+    public fun size<V>(list: &LinkedList<V>): u64 {
+        Self::length(&list.entries)
+    }
+
+    /// empty returns true if the LinkedList is empty.
+    public fun empty<V>(list: &LinkedList<V>): bool {
+        Self::length(&list.entries) == 0
+    }
+
+    /// get next entry in linkedlist
+    public fun next<V>(list: &LinkedList<V>, index: u64): u64 {
+        Self::borrow(&list.entries, index).next
+    }
+
+    /// get previous entry in linkedlist
+    public fun previous<V>(list: &LinkedList<V>, index: u64): u64 {
+        Self::borrow(&list.entries, index).prev
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert
+    public fun insert<V>(list: &mut LinkedList<V>, value: V) {
+        let index = list.tail;
+        insert_after(list, index, value)
+    }
+
+    /// insert at the end of the list, and return the index of the new element.
+    public fun insert_and_get_index<V>(list: &mut LinkedList<V>, value: V): u64 {
+        let index = list.tail;
+        insert_after_and_get_index(list, index, value)
+    }
+
+    /// insert after index. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after<V>(list: &mut LinkedList<V>, index: u64, value: V) {
+        insert_after_and_get_index(list, index, value);
+    }
+
+    /// insert after index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_after_and_get_index<V>(list: &mut LinkedList<V>, index: u64, value: V): u64 {
+        let new_index = next_slot(&list.entries);
+        assert!(
+            new_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY,
+        );
+
+        let node = Node<V>{
+            value,
+            prev: index,
+            next: NULL_INDEX,
+        };
+
+        if (empty(list) && index == NULL_INDEX) {
+            list.head = new_index;
+            list.tail = new_index;
+            add(&mut list.entries, node);
+            return new_index
+        };
+
+        assert!(
+            is_occupied(&list.entries, index),
+            E_INDEX_OUT_OF_RANGE,
+        );
+
+        let prev = Self::borrow_mut(&mut list.entries, index);
+        node.next = prev.next;
+        prev.next = new_index;
+        if (node.next != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, node.next).prev = new_index;
+        } else {
+            list.tail = new_index;
+        };
+
+        add(&mut list.entries, node);
+
+        new_index
+    }
+
+    /// isnert before index. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before<V>(list: &mut LinkedList<V>, index: u64, value: V) {
+        insert_before_and_get_index(list, index, value);
+    }
+
+    /// insert before index, and return the index of the new element. If the list is empty, the index can be NULL_INDEX.
+    public fun insert_before_and_get_index<V>(list: &mut LinkedList<V>, index: u64, value: V): u64 {
+        let new_index = next_slot(&list.entries);
+        assert!(
+            new_index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY,
+        );
+
+        let node = Node<V>{
+            value,
+            prev: NULL_INDEX,
+            next: index,
+        };
+
+        if (empty(list) && index == NULL_INDEX) {
+            list.head = new_index;
+            list.tail = new_index;
+            add(&mut list.entries, node);
+            return new_index
+        };
+
+        assert!(
+            is_occupied(&list.entries, index),
+            E_INDEX_OUT_OF_RANGE,
+        );
+        let next = Self::borrow_mut(&mut list.entries, index);
+        node.prev = next.prev;
+        next.prev = new_index;
+        if (node.prev != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, node.prev).next = new_index;
+        } else {
+            list.head = new_index;
+        };
+
+        add(&mut list.entries, node);
+
+        new_index
+    }
+
+    /// remove deletes and returns the element from the LinkedList.
+    /// the slot of the element is left vacant.
+    public fun remove<V>(list: &mut LinkedList<V>, index: u64): V {
+        let to_remove = Self::borrow(&list.entries, index);
+        let prev = to_remove.prev;
+        let next = to_remove.next;
+        if (prev != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, prev).next = next;
+        } else {
+            list.head = next;
+        };
+        if (next != NULL_INDEX) {
+            Self::borrow_mut(&mut list.entries, next).prev = prev;
+        } else {
+            list.tail = next;
+        };
+
+        let Node {
+            value,
+            next: _,
+            prev: _,
+        } = take(&mut list.entries, index);
+
+        value
+    }
+
+    /// destroys the linked list if it's empty.
+    public fun destroy_empty<V>(tree: LinkedList<V>) {
+        assert!(Self::length(&tree.entries) == 0, E_CANNOT_DESTRORY_NON_EMPTY);
+
+        let LinkedList<V> {
+            entries,
+            head: _,
+            tail: _,
+        } = tree;
+
+        destroy_slots(entries);
+    }
+
+    /// compact moves the elements into the lowest indices, so no vacant slot is left behind by the removals.
+    /// Indices of the moved elements change, so any index held outside of the list must be looked up again.
+    public fun compact<V>(list: &mut LinkedList<V>) {
+        let count = size(list);
+        let index = capacity(&list.entries);
+        while (index > count) {
+            index = index - 1;
+            if (is_occupied(&list.entries, index)) {
+                let new_index = pop_vacant_below(&mut list.entries, count);
+                move_node(list, index, new_index);
+            };
+        };
+        shrink(&mut list.entries);
+    }
+
+    /// move_node moves the node at index to the vacant new_index, and updates the links to it.
+    fun move_node<V>(list: &mut LinkedList<V>, index: u64, new_index: u64) {
+        relocate(&mut list.entries, index, new_index);
+        let node = borrow(&list.entries, new_index);
+        let prev = node.prev;
+        let next = node.next;
+        if (prev != NULL_INDEX) {
+            borrow_mut(&mut list.entries, prev).next = new_index;
+        } else {
+            list.head = new_index;
+        };
+        if (next != NULL_INDEX) {
+            borrow_mut(&mut list.entries, next).prev = new_index;
+        } else {
+            list.tail = new_index;
+        };
+    }
+
+    #[test]
+    public fun test_stable_index_linked_list() {
+        let l = new<u128>();
+        let i = 0;
+        while (i < 10) {
+            insert(&mut l, (i as u128));
+            i = i + 1;
+        };
+
+        // removal leaves the other elements at their indices.
+        assert!(remove(&mut l, 3) == 3, 3);
+        assert!(remove(&mut l, 0) == 0, 0);
+        assert!(remove(&mut l, 9) == 9, 9);
+        assert!(size(&l) == 7, size(&l));
+        assert!(l.head == 1 && l.tail == 8, l.tail);
+        assert!(next(&l, 2) == 4 && previous(&l, 4) == 2, 2);
+        let i = 1;
+        while (i < 9) {
+            if (i != 3) {
+                assert!(*borrow_at_index(&l, i) == (i as u128), i);
+            };
+            i = i + 1;
+        };
+
+        // the last vacated slot is reused first.
+        assert!(insert_before_and_get_index(&mut l, 1, 100) == 9, 9);
+        assert!(l.head == 9 && *borrow_at_index(&l, 9) == 100, l.head);
+        assert!(insert_after_and_get_index(&mut l, 4, 101) == 0, 0);
+        assert!(next(&l, 4) == 0 && previous(&l, 5) == 0, 0);
+
+        compact(&mut l);
+        assert!(capacity(&l.entries) == 9, capacity(&l.entries));
+        let expected = vector<u128>[100, 1, 2, 4, 101, 5, 6, 7, 8];
+        let iter = l.head;
+        let prev = NULL_INDEX;
+        let i = 0;
+        while (iter != NULL_INDEX) {
+            assert!(iter < 9, iter);
+            assert!(previous(&l, iter) == prev, i);
+            assert!(*borrow_at_index(&l, iter) == *vector::borrow(&expected, i), i);
+            prev = iter;
+            iter = next(&l, iter);
+            i = i + 1;
+        };
+        assert!(i == 9 && l.tail == prev, i);
+
+        while (!empty(&l)) {
+            let head = l.head;
+            remove(&mut l, head);
+        };
+        destroy_empty(l);
+    }
+
+    #[test]
+    public fun test_insert_remove_linked_list() {
+        let l = new<u128>();
+        let i = 0;
+        while (i < 10) {
+            // 1, 3, ..., 19 at indices 0, 1, ..., 9.
+            insert(&mut l, (i as u128) * 2 + 1);
+            i = i + 1;
+        };
+        let i = 0;
+        while (i < 10) {
+            // 2, 4, ..., 20 right after 1, 3, ..., 19.
+            assert!(insert_after_and_get_index(&mut l, i, (i as u128) * 2 + 2) == i + 10, i);
+            i = i + 1;
+        };
+        let head = l.head;
+        insert_before(&mut l, head, 0);
+        assert!(size(&l) == 21, size(&l));
+
+        let expected: u128 = 0;
+        let index = l.head;
+        while (index != NULL_INDEX) {
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            expected = expected + 1;
+            index = next(&l, index);
+        };
+        assert!(expected == 21, (expected as u64));
+        let index = l.tail;
+        while (index != NULL_INDEX) {
+            expected = expected - 1;
+            assert!(*borrow_at_index(&l, index) == expected, (expected as u64));
+            index = previous(&l, index);
+        };
+        assert!(expected == 0, (expected as u64));
+
+        while (!empty(&l)) {
+            let head = l.head;
+            assert!(remove(&mut l, head) == expected, (expected as u64));
+            expected = expected + 1;
+            // the rest is still linked in order.
+            let count = 0;
+            let index = l.head;
+            while (index != NULL_INDEX) {
+                assert!(*borrow_at_index(&l, index) == expected + (count as u128), count);
+                count = count + 1;
+                index = next(&l, index);
+            };
+            assert!(count == size(&l), count);
+        };
+        assert!(expected == 21, (expected as u64));
+
+        destroy_empty(l);
+    }
+}
@@ -0,0 +1,1722 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Tree based on GNU libavl https://adtinfo.org/
+module container::red_black_spec {
+    use std::vector::{Self, swap, push_back, pop_back};
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_KEY_ALREADY_EXIST: u64 = 2;
+    const E_EMPTY_TREE: u64 = 3;
+    const E_INVALID_INDEX: u64 = 4;
+    const E_TREE_TOO_BIG: u64 = 5;
+    const E_TREE_NOT_EMPTY: u64 = 6;
+    const E_PARENT_NULL: u64 = 7;
+    const E_PARENT_INDEX_OUT_OF_RANGE: u64 = 8;
+    const E_RIGHT_ROTATE_LEFT_CHILD_NULL: u64 = 9;
+    const E_LEFT_ROTATE_RIGHT_CHILD_NULL: u64 = 10;
+
+    const E_RB_NOT_RED_NODE: u64 = 15;
+    const E_RB_RED_HAS_RED_PARENT: u64 = 16;
+    const E_RB_RED_HAS_NO_PARENT: u64 = 17;
+    const E_RB_SIBLING_NOT_EXIST: u64 = 18;
+    const E_RB_SIBLING_FAIL_BLACK: u64 = 19;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+
+    const RB_RED: u8 = 128;
+    const RB_BLACK: u8 = 129;
+
+    const METADATA_DEFAULT: u8 = 128;
+
+    /// Entry is the internal RedBlackTree element.
+    struct Entry<V> has store, copy, drop {
+        // key
+        key0_2: u128,
+        // key
+        key1_2: u128,
+        // value
+        value: V,
+        // parent
+        parent: u64,
+        // left child
+        left_child: u64,
+        // right child.
+        right_child: u64,
+        // metadata
+        metadata: u8,
+        // number of elements in the subtree rooted at this entry
+        size: u64,
+    }
+
+    fun new_entry<V>(key0_2: u128, key1_2: u128, value: V): Entry<V> {
+        Entry<V> {
+            key0_2,
+            key1_2,
+            value,
+            parent: NULL_INDEX,
+            left_child: NULL_INDEX,
+            right_child: NULL_INDEX,
+            metadata: METADATA_DEFAULT,
+            size: 1,
+        }
+    }
+
+    #[test_only]
+    fun new_entry_for_test<V>(key0_2: u128, key1_2: u128, value: V, parent: u64, left_child: u64, right_child: u64, metadata: u8, size: u64): Entry<V> {
+        Entry {
+            key0_2,
+            key1_2,
+            value,
+            parent,
+            left_child,
+            right_child,
+            metadata,
+            size,
+        }
+    }
+
+    /// RedBlackTree contains a vector of Entry<V>, which is triple-linked binary search tree.
+    struct RedBlackTree<V> has store, copy, drop {
+        root: u64,
+        entries: vector<Entry<V>>,
+        min_index: u64,
+        max_index: u64,
+    }
+
+    /// create new tree
+    public fun new<V>(): RedBlackTree<V> {
+        RedBlackTree {
+            root: NULL_INDEX,
+            entries: vector::empty(),
+            min_index: NULL_INDEX,
+            max_index: NULL_INDEX,
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// find returns the element index in the RedBlackTree, or none if not found.
+    public fun find<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        let current = tree.root;
+
+        while({
+            spec {
+                invariant spec_is_valid_index(tree, current);
+                // an entry with the keys can only be in the subtree at current.
+                invariant forall i in 0..len(tree.entries): (tree.entries[i].key0_2 == key0_2 && tree.entries[i].key1_2 == key1_2) ==> spec_in_subtree(tree, i, current);
+            };
+            current != NULL_INDEX
+        }) {
+            let node = vector::borrow(&tree.entries, current);
+            if (node.key0_2 == key0_2 && node.key1_2 == key1_2) {
+                return current
+            };
+            let is_smaller = ((node.key0_2 < key0_2)) || ((node.key0_2 == key0_2) && (node.key1_2 < key1_2));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        NULL_INDEX
+    }
+
+    /// lower_bound returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_smaller = ((node.key0_2 < key0_2)) || ((node.key0_2 == key0_2) && (node.key1_2 < key1_2));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                result = current;
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// upper_bound returns the index of the first element with keys greater than the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key0_2 > key0_2)) || ((node.key0_2 == key0_2) && (node.key1_2 > key1_2));
+            if(is_bigger) {
+                result = current;
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// floor returns the index of the last element with keys less than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key0_2 > key0_2)) || ((node.key0_2 == key0_2) && (node.key1_2 > key1_2));
+            if(is_bigger) {
+                current = node.left_child;
+            } else {
+                result = current;
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// ceiling returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        lower_bound(tree, key0_2, key1_2)
+    }
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V>(tree: &RedBlackTree<V>, index: u64): (u128, u128, &V) {
+        let entry = vector::borrow(&tree.entries, index);
+        (entry.key0_2, entry.key1_2, &entry.value)
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V>(tree: &mut RedBlackTree<V>, index: u64): (u128, u128, &mut V) {
+        let entry = vector::borrow_mut(&mut tree.entries, index);
+        (entry.key0_2, entry.key1_2, &mut entry.value)
+    }
+
+    /// size returns the number of elements in the RedBlackTree.
+    public fun size<V>(tree: &RedBlackTree<V>): u64 {
+        vector::length(&tree.entries)
+    }
+
+    /// empty returns true if the RedBlackTree is empty.
+    public fun empty<V>(tree: &RedBlackTree<V>): bool {
+        vector::length(&tree.entries) == 0
+    }
+
+    /// get index of the min of the tree.
+    public fun get_min_index<V>(tree: &RedBlackTree<V>): u64 {
+        let current = tree.min_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the min of the subtree with root at index.
+    public fun get_min_index_from<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        let current = index;
+        let left_child = vector::borrow(&tree.entries, current).left_child;
+
+        while (left_child != NULL_INDEX) {
+            current = left_child;
+            left_child = vector::borrow(&tree.entries, current).left_child;
+        };
+
+        current
+    }
+
+    /// get index of the max of the tree.
+    public fun get_max_index<V>(tree: &RedBlackTree<V>): u64 {
+        let current = tree.max_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the max of the subtree with root at index.
+    public fun get_max_index_from<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        let current = index;
+        let right_child = vector::borrow(&tree.entries, current).right_child;
+
+        while (right_child != NULL_INDEX) {
+            current = right_child;
+            right_child = vector::borrow(&tree.entries, current).right_child;
+        };
+
+        current
+    }
+
+    /// find next value in order (the key is increasing)
+    public fun next_in_order<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = vector::borrow(&tree.entries, index);
+        let right_child = node.right_child;
+        let parent = node.parent;
+
+        if (right_child != NULL_INDEX) {
+            // first, check if right child is null.
+            // then go to right child, and check if there is left child.
+            let next = right_child;
+            let next_left = vector::borrow(&tree.entries, next).left_child;
+            while (next_left != NULL_INDEX) {
+                next = next_left;
+                next_left = vector::borrow(&tree.entries, next).left_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no right child, check parent.
+            // if current is the left child of the parent, parent is then next.
+            // if current is the right child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_right_child(tree, current, parent)) {
+                current = parent;
+                parent = vector::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// find next value in reverse order (the key is decreasing)
+    public fun next_in_reverse_order<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = vector::borrow(&tree.entries, index);
+        let left_child = node.left_child;
+        let parent = node.parent;
+        if (left_child != NULL_INDEX) {
+            // first, check if left child is null.
+            // then go to left child, and check if there is right child.
+            let next = left_child;
+            let next_right = vector::borrow(&tree.entries, next).right_child;
+            while (next_right != NULL_INDEX) {
+                next = next_right;
+                next_right = vector::borrow(&tree.entries, next).right_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no left child, check parent.
+            // if current is the right child of the parent, parent is then next.
+            // if current is the left child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_left_child(tree, current, parent)) {
+                current = parent;
+                parent = vector::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// rank returns the number of elements with keys less than the input keys,
+    /// which is the 0-based position of the keys in order if they are in the tree.
+    public fun rank<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        let result = 0;
+        let current = tree.root;
+
+        while (current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_smaller = ((node.key0_2 < key0_2)) || ((node.key0_2 == key0_2) && (node.key1_2 < key1_2));
+            if (is_smaller) {
+                result = result + subtree_size(tree, node.left_child) + 1;
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// select returns the index of the element at the 0-based position k in order.
+    /// aborts if k is not less than the size of the tree.
+    public fun select<V>(tree: &RedBlackTree<V>, k: u64): u64 {
+        assert!(k < size(tree), E_INVALID_ARGUMENT);
+        let current = tree.root;
+
+        loop {
+            let node = vector::borrow(&tree.entries, current);
+            let left_size = subtree_size(tree, node.left_child);
+            if (k < left_size) {
+                current = node.left_child;
+            } else if (k == left_size) {
+                return current
+            } else {
+                k = k - left_size - 1;
+                current = node.right_child;
+            };
+        }
+    }
+
+    /// count_in_range returns the number of elements with keys between lo and hi, inclusive on both ends.
+    public fun count_in_range<V>(tree: &RedBlackTree<V>, lo_key0_2: u128, lo_key1_2: u128, hi_key0_2: u128, hi_key1_2: u128): u64 {
+        let lower = rank(tree, lo_key0_2, lo_key1_2);
+        let upper = rank_upper(tree, hi_key0_2, hi_key1_2);
+        if (upper > lower) {
+            upper - lower
+        } else {
+            0
+        }
+    }
+
+    /// rank_upper returns the number of elements with keys less than or equal to the input keys.
+    fun rank_upper<V>(tree: &RedBlackTree<V>, key0_2: u128, key1_2: u128): u64 {
+        let result = 0;
+        let current = tree.root;
+
+        while (current != NULL_INDEX) {
+            let node = vector::borrow(&tree.entries, current);
+            let is_bigger = ((node.key0_2 > key0_2)) || ((node.key0_2 == key0_2) && (node.key1_2 > key1_2));
+            if (!is_bigger) {
+                result = result + subtree_size(tree, node.left_child) + 1;
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// get the number of elements in the subtree with root at index, 0 if index is NULL_INDEX.
+    fun subtree_size<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        if (index == NULL_INDEX) {
+            0
+        } else {
+            vector::borrow(&tree.entries, index).size
+        }
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert puts the value keyed at the input keys into the RedBlackTree.
+    /// aborts if the key is already in the tree.
+    public fun insert<V>(tree: &mut RedBlackTree<V>, key0_2: u128, key1_2: u128, value: V) {
+        insert_and_get_index(tree, key0_2, key1_2, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the RedBlackTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V>(tree: &mut RedBlackTree<V>, key0_2: u128, key1_2: u128, value: V): u64 {
+        // the max size of the tree is NULL_INDEX.
+        assert!(size(tree) < NULL_INDEX, E_TREE_TOO_BIG);
+		push_back(
+            &mut tree.entries,
+            new_entry(key0_2, key1_2, value)
+        );
+
+        let node = size(tree) - 1;
+
+        let parent = NULL_INDEX;
+        let insert = tree.root;
+        let is_right_child = false;
+
+        while ({
+            spec {
+                invariant spec_is_valid_index(tree, parent) && spec_is_valid_index(tree, insert) && insert != node;
+                invariant parent == NULL_INDEX ==> insert == tree.root;
+                invariant parent != NULL_INDEX ==> insert == spec_child(tree, parent, is_right_child);
+                // an entry with the same keys can only be in the subtree at insert.
+                invariant forall i in 0..node: spec_same_keys(tree.entries[i], tree.entries[node]) ==> spec_in_subtree(tree, i, insert);
+                // the new entry goes to the left subtree of an entry if and only if its keys are smaller.
+                invariant forall i in 0..node: parent != NULL_INDEX
+                    && ((i == parent && !is_right_child) || spec_in_subtree(tree, parent, tree.entries[i].left_child))
+                    ==> spec_less(tree.entries[node], tree.entries[i]);
+                invariant forall i in 0..node: parent != NULL_INDEX
+                    && ((i == parent && is_right_child) || spec_in_subtree(tree, parent, tree.entries[i].right_child))
+                    ==> spec_less(tree.entries[i], tree.entries[node]);
+            };
+            insert != NULL_INDEX
+        }) {
+            let insert_node = vector::borrow(&tree.entries, insert);
+            assert!((insert_node.key0_2 != key0_2)||(insert_node.key1_2 != key1_2), E_KEY_ALREADY_EXIST);
+            parent = insert;
+            is_right_child = ((insert_node.key0_2 < key0_2)) || ((insert_node.key0_2 == key0_2) && (insert_node.key1_2 < key1_2));
+            insert = if (is_right_child) {
+                insert_node.right_child
+            } else {
+                insert_node.left_child
+            };
+        };
+
+        replace_parent(tree, node, parent);
+
+        if (parent != NULL_INDEX) {
+            if (is_right_child) {
+                replace_right_child(tree, parent, node);
+            } else {
+                replace_left_child(tree, parent, node);
+            };
+            let max_node = vector::borrow(&tree.entries, tree.max_index);
+            let is_max_smaller = ((max_node.key0_2 < key0_2)) || ((max_node.key0_2 == key0_2) && (max_node.key1_2 < key1_2));
+            if (is_max_smaller) {
+                tree.max_index = node;
+            };
+            let min_node = vector::borrow(&tree.entries, tree.min_index);
+            let is_min_bigger = ((min_node.key0_2 > key0_2)) || ((min_node.key0_2 == key0_2) && (min_node.key1_2 > key1_2));
+            if (is_min_bigger) {
+                tree.min_index = node;
+            };
+        } else {
+            tree.root = node;
+            tree.min_index = node;
+            tree.max_index = node;
+        };
+
+        // the new node is added to the subtrees of all its ancestors.
+        increase_size_to_root(tree, parent);
+
+        // updat red black tree metadata
+        while ({
+            spec {
+                invariant len(tree.entries) == len(old(tree.entries)) + 1;
+                invariant forall i in 0..node: spec_same_data(tree.entries[i], old(tree.entries[i]));
+                invariant tree.entries[node].key0_2 == key0_2 && tree.entries[node].key1_2 == key1_2 && tree.entries[node].value == value;
+                invariant spec_is_valid_structure(tree, NULL_INDEX);
+                invariant spec_is_valid_index(tree, parent);
+                invariant forall i in 0..len(tree.entries): spec_is_rb_balanced(tree, i, NULL_INDEX, false);
+                // the child on the is_right_child side of parent is red, and it is the only red entry that may have a red parent.
+                invariant parent == NULL_INDEX || !spec_is_black(tree, spec_child(tree, parent, is_right_child));
+                invariant forall i in 0..len(tree.entries): spec_is_rb_red_ok(tree, i, parent, is_right_child);
+                invariant parent == NULL_INDEX || tree.entries[tree.root].metadata == RB_BLACK;
+            };
+            parent != NULL_INDEX
+        }) {
+            let parent_metadata = vector::borrow(&tree.entries, parent).metadata;
+            if (parent_metadata == RB_BLACK) {
+                break
+            };
+
+            parent = rb_update_insert(tree, parent, is_right_child);
+            parent_metadata = vector::borrow(&tree.entries, parent).metadata;
+            if (parent_metadata == RB_BLACK) {
+                break
+            };
+            let new_parent = vector::borrow(&tree.entries, parent).parent;
+            if (new_parent == NULL_INDEX) {
+                break
+            };
+            is_right_child = is_right_child(tree, parent, new_parent);
+            parent = new_parent;
+        };
+
+        if (tree.root != NULL_INDEX) {
+            let root = tree.root;
+            vector::borrow_mut(&mut tree.entries, root).metadata = RB_BLACK;
+        };
+
+        node
+    }
+
+    /// remove deletes and returns the element from the RedBlackTree.
+    public fun remove<V>(tree: &mut RedBlackTree<V>, index: u64): (u128, u128, V) {
+        if (tree.max_index == index) {
+            tree.max_index = next_in_reverse_order(tree, index);
+        };
+        if (tree.min_index == index) {
+            tree.min_index = next_in_order(tree, index);
+        };
+
+        let node = vector::borrow(&tree.entries, index);
+        let parent = node.parent;
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        // index and all its ancestors lose one element.
+        // if index is replaced by another node, the replacement takes over the decreased size of index.
+        decrease_size_until(tree, index, NULL_INDEX);
+        let is_right = if (parent != NULL_INDEX) {
+            is_right_child(tree, index, parent)
+        } else {
+            false
+        };
+
+        let (rebalance_start, is_new_right) =
+        if (right_child == NULL_INDEX) {
+            // right child is null
+            // replace with left child.
+            // No need to swap metadata
+            // - in AVL, left is balanced and new value is also balanced.
+            // - in RB, left must be red and index must be black.
+            //         index
+            //       /       \
+            //     left
+            //  --
+            //        left
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, left_child, NULL_INDEX);
+                tree.root = left_child;
+            } else {
+                replace_child(tree, parent, index, left_child);
+            };
+            (parent, is_right)
+        } else if (left_child == NULL_INDEX){
+            // left child is null.
+            // replace with right child.
+            // No need to swap metadata.
+            // - in AVL, right is balanced and the new value is also balanced.
+            // - in RB, right must be red and index must be black.
+            //         index
+            //       /       \
+            //               right
+            //  --
+            //        right
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, right_child, NULL_INDEX);
+                tree.root = right_child;
+            } else {
+                replace_child(tree, parent, index, right_child);
+            };
+            (parent, is_right)
+        } else {
+            let right_child_s_left = vector::borrow(&tree.entries, right_child).left_child;
+            if (right_child_s_left == NULL_INDEX) {
+                // right child is not null, and right child's left child is null
+                //              index
+                //           /         \
+                //        left         right
+                //                        \
+                //                         a
+                // -------------
+                //               right
+                //            /       \
+                //          left       a
+                replace_left_child(tree, right_child, left_child);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, right_child, NULL_INDEX);
+                    tree.root = right_child;
+                } else {
+                    replace_child(tree, parent, index, right_child);
+                };
+
+                let index_size = vector::borrow(&tree.entries, index).size;
+                vector::borrow_mut(&mut tree.entries, right_child).size = index_size;
+
+                let old_metadata = vector::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = vector::borrow(&tree.entries, right_child).metadata;
+                vector::borrow_mut(&mut tree.entries, right_child).metadata = old_metadata;
+                vector::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (right_child, true)
+            } else {
+                // right child is not null, and right child's left child is not null either
+                //                 index
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    min
+                //                     \
+                //                      a
+                // -------------------------------------------------
+                //                   min
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    a
+                let next_successor = get_min_index_from(tree, right_child_s_left);
+                let next_successor_node = vector::borrow(&tree.entries, next_successor);
+                let successor_parent = next_successor_node.parent;
+                let next_successor_right = next_successor_node.right_child;
+                // subtrees between the successor and index lose the successor.
+                decrease_size_until(tree, successor_parent, index);
+
+                replace_left_child(tree, successor_parent, next_successor_right);
+                replace_left_child(tree, next_successor, left_child);
+                replace_right_child(tree, next_successor, right_child,);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, next_successor, NULL_INDEX);
+                    tree.root = next_successor;
+                } else {
+                    replace_child(tree, parent, index, next_successor);
+                };
+
+                let index_size = vector::borrow(&tree.entries, index).size;
+                vector::borrow_mut(&mut tree.entries, next_successor).size = index_size;
+
+                let old_metadata = vector::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = vector::borrow(&tree.entries, next_successor).metadata;
+                vector::borrow_mut(&mut tree.entries, next_successor).metadata = old_metadata;
+                vector::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (successor_parent, false)
+            }
+        };
+
+        let removal_metadata = vector::borrow(&tree.entries, index).metadata;
+        while ({
+            spec {
+                invariant len(tree.entries) == len(old(tree.entries));
+                invariant forall i in 0..len(tree.entries): spec_same_data(tree.entries[i], old(tree.entries[i]));
+                invariant spec_is_valid_structure(tree, index);
+                invariant spec_is_valid_index(tree, rebalance_start) && rebalance_start != index;
+                // if a black entry is removed, the subtree on the is_new_right side of rebalance_start lost one black entry on all its paths,
+                // and its root may be red with a red parent or a red child, which is fixed by recoloring it black.
+                invariant forall i in 0..len(tree.entries): i != index
+                    ==> spec_is_rb_balanced(tree, i, if (removal_metadata == RB_BLACK) rebalance_start else NULL_INDEX, is_new_right);
+                invariant forall i in 0..len(tree.entries): i != index
+                    && (removal_metadata != RB_BLACK || rebalance_start == NULL_INDEX || i != spec_child(tree, rebalance_start, is_new_right))
+                    ==> spec_is_rb_red_ok(tree, i, if (removal_metadata == RB_BLACK) rebalance_start else NULL_INDEX, is_new_right);
+            };
+            rebalance_start != NULL_INDEX
+        }) {
+            let (do_continue, new_start) = rb_update_remove(tree, rebalance_start, is_new_right, removal_metadata);
+            if (!do_continue) {
+                break
+            };
+            if (new_start == NULL_INDEX) {
+                break
+            };
+            is_new_right = is_right_child(tree, rebalance_start, new_start);
+            rebalance_start = new_start;
+        };
+
+        if (tree.root != NULL_INDEX) {
+            let root = tree.root;
+            vector::borrow_mut(&mut tree.entries, root).metadata = RB_BLACK;
+        };
+
+        // swap index for pop out.
+        let last_index = size(tree) -1;
+        if (index != last_index) {
+            swap(&mut tree.entries, last_index, index);
+            if (tree.root == last_index) {
+                tree.root = index;
+            };
+            if (tree.max_index == last_index) {
+                tree.max_index = index;
+            };
+            if (tree.min_index == last_index) {
+                tree.min_index = index;
+            };
+            let node = vector::borrow(&tree.entries, index);
+            let parent = node.parent;
+            let left_child = node.left_child;
+            let right_child = node.right_child;
+            replace_child(tree, parent, last_index, index);
+            replace_parent(tree, left_child, index);
+            replace_parent(tree, right_child, index);
+        };
+
+        ////////// now clear up.
+        let Entry { key0_2, key1_2,  value, parent: _, left_child: _, right_child: _, metadata: _, size: _ } = pop_back(&mut tree.entries);
+
+        if (size(tree) == 0) {
+            tree.root = NULL_INDEX;
+        };
+
+        (key0_2, key1_2,  value)
+    }
+
+    /// destroys the tree if it's empty.
+    public fun destroy_empty<V>(tree: RedBlackTree<V>) {
+        let RedBlackTree { entries, root: _, min_index: _, max_index: _ } = tree;
+        assert!(vector::length(&entries) == 0, E_TREE_NOT_EMPTY);
+        vector::destroy_empty(entries);
+    }
+
+    /// check if index is the right child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_right_child<V>(tree: &RedBlackTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < size(tree), E_PARENT_INDEX_OUT_OF_RANGE);
+        vector::borrow(&tree.entries, parent_index).right_child == index
+    }
+
+    /// check if index is the left child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_left_child<V>(tree: &RedBlackTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < size(tree), E_PARENT_INDEX_OUT_OF_RANGE);
+        vector::borrow(&tree.entries, parent_index).left_child == index
+    }
+
+    /// Replace the child of parent if parent_index is not NULL_INDEX.
+    /// also replace parent index of the child.
+    fun replace_child<V>(tree: &mut RedBlackTree<V>, parent_index: u64, original_child: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            if (is_right_child(tree, original_child, parent_index)) {
+                replace_right_child(tree, parent_index, new_child);
+            } else if (is_left_child(tree, original_child, parent_index)) {
+                replace_left_child(tree, parent_index, new_child);
+            }
+        }
+    }
+
+    /// replace left child.
+    /// also replace parent index of the child.
+    fun replace_left_child<V>(tree: &mut RedBlackTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            vector::borrow_mut(&mut tree.entries, parent_index).left_child = new_child;
+            if (new_child != NULL_INDEX) {
+                vector::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace right child.
+    /// also replace parent index of the child.
+    fun replace_right_child<V>(tree: &mut RedBlackTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            vector::borrow_mut(&mut tree.entries, parent_index).right_child = new_child;
+                if (new_child != NULL_INDEX) {
+                vector::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace parent of index if index is not NULL_INDEX.
+    fun replace_parent<V>(tree: &mut RedBlackTree<V>, index: u64, parent_index: u64) {
+        if (index != NULL_INDEX) {
+            vector::borrow_mut(&mut tree.entries, index).parent = parent_index;
+        }
+    }
+
+    /// increase the subtree size of index and all its ancestors by 1.
+    fun increase_size_to_root<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let current = index;
+        while ({
+            spec {
+                invariant len(tree.entries) == len(old(tree.entries));
+                invariant spec_is_valid_index(tree, current);
+                // the sizes of index and its ancestors below current are increased.
+                invariant forall i in 0..len(tree.entries): tree.entries[i] == update_field(old(tree.entries[i]), size, old(tree.entries[i]).size
+                    + (if (spec_in_subtree(old(tree), index, i) && !spec_in_subtree(old(tree), current, i)) 1 else 0));
+            };
+            current != NULL_INDEX
+        }) {
+            let node = vector::borrow_mut(&mut tree.entries, current);
+            node.size = node.size + 1;
+            current = node.parent;
+        };
+    }
+
+    /// decrease the subtree size of index and its ancestors by 1, stopping before stop_index.
+    fun decrease_size_until<V>(tree: &mut RedBlackTree<V>, index: u64, stop_index: u64) {
+        let current = index;
+        while ({
+            spec {
+                invariant len(tree.entries) == len(old(tree.entries));
+                invariant spec_is_valid_index(tree, current);
+                // the sizes of index and its ancestors below current are decreased.
+                invariant forall i in 0..len(tree.entries): tree.entries[i] == update_field(old(tree.entries[i]), size, old(tree.entries[i]).size
+                    - (if (spec_in_subtree(old(tree), index, i) && !spec_in_subtree(old(tree), current, i)) 1 else 0));
+            };
+            current != stop_index && current != NULL_INDEX
+        }) {
+            let node = vector::borrow_mut(&mut tree.entries, current);
+            node.size = node.size - 1;
+            current = node.parent;
+        };
+    }
+
+    /// recompute the subtree size of index from its children.
+    fun update_subtree_size<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let node = vector::borrow(&tree.entries, index);
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        let new_size = subtree_size(tree, left_child) + subtree_size(tree, right_child) + 1;
+        vector::borrow_mut(&mut tree.entries, index).size = new_size;
+    }
+
+
+    /// rotate_right (clockwise rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///        x      y
+    /// -----------------------------------------------------
+    ///                  left
+    ///              x          index
+    ///                       y       right
+    fun rotate_right<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let node = vector::borrow(&tree.entries, index);
+        let left = node.left_child;
+        assert!(
+            left != NULL_INDEX,
+            E_RIGHT_ROTATE_LEFT_CHILD_NULL
+        );
+        let y = vector::borrow(&tree.entries, left).right_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_left_child(tree, index, y);
+
+        // update left
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, left);
+        } else {
+            tree.root = left;
+            replace_parent(tree, left, NULL_INDEX);
+        };
+        replace_right_child(tree, left, index);
+
+        // index is now the child of left.
+        update_subtree_size(tree, index);
+        update_subtree_size(tree, left);
+    }
+
+    /// rotate_left (counter-clockwis rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///                       x          y
+    /// -----------------------------------------------------
+    ///                  right
+    ///          index             y
+    ///      left        x
+    fun rotate_left<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let node = vector::borrow(&tree.entries, index);
+        let right = node.right_child;
+        assert!(
+            right != NULL_INDEX,
+            E_INVALID_ARGUMENT,
+        );
+        let x = vector::borrow(&tree.entries, right).left_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_right_child(tree, index, x);
+
+        // update right
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, right);
+        } else {
+            tree.root = right;
+            replace_parent(tree, right, NULL_INDEX);
+        };
+        replace_left_child(tree, right, index);
+
+        // index is now the child of right.
+        update_subtree_size(tree, index);
+        update_subtree_size(tree, right);
+    }
+
+    // update red black tree after an insertion of node as red.
+    // - is_right indicates if right child is red, otherwise left child is red.
+    // - index is a red node.
+    // returns
+    // - the parent tree.
+    fun rb_update_insert<V>(tree: &mut RedBlackTree<V>, index: u64, is_right: bool): u64 {
+        let node = vector::borrow(&tree.entries, index);
+        // make sure the index right now is red
+        assert!(
+            node.metadata == RB_RED,
+            E_RB_NOT_RED_NODE,
+        );
+
+        // get the red child.
+        let red_child = if (is_right) {
+            node.right_child
+        } else {
+            node.left_child
+        };
+
+        assert!(
+            vector::borrow(&tree.entries, red_child).metadata == RB_RED,
+            E_RB_NOT_RED_NODE,
+        );
+
+        // get the parent
+        // since index is red, the parent must be black
+        let parent = node.parent;
+        assert!(
+            parent != NULL_INDEX,
+            E_RB_RED_HAS_NO_PARENT,
+        );
+
+        assert!(
+            vector::borrow(&tree.entries, parent).metadata == RB_BLACK,
+            E_RB_RED_HAS_RED_PARENT,
+        );
+
+        let is_index_right = is_right_child(tree, index, parent);
+
+        if (!is_index_right) {
+            // index is the left child of parent
+            //
+            let uncle = vector::borrow(&tree.entries, parent).right_child;
+            if (uncle != NULL_INDEX && vector::borrow(&tree.entries, uncle).metadata == RB_RED) {
+                // case 1, uncle is red
+                // recolor parent, index, and uncle.
+                //
+                //        parent (b)
+                //     /          \
+                //  index (r)     uncle(r)
+                //   /
+                //  rec_child
+                // --------------
+                //        parent (r)
+                //     /          \
+                //  index (b)     uncle(b)
+                //   /
+                //  rec_child (r)
+                vector::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, uncle).metadata = RB_BLACK;
+                parent
+            } else if (!is_right) {
+                // case 2, red_child is left child of index
+                // rotate right at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //       index(r)
+                //       /      \
+                // red_child(r)
+                // ---------------
+                //             index(b)
+                //          /           \
+                //     red_child(r)     parent(r)
+                rotate_right(tree, parent);
+                vector::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                index
+            } else {
+                // case 3, red_child is right child of the index
+                // rotate left at index, the rotate right at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //       index(r)
+                //       /      \
+                //           red_child(r)
+                // ---------------
+                //          red_child(b)
+                //          /           \
+                //     index(r)     parent(r)
+                rotate_left(tree, index);
+                rotate_right(tree, parent);
+                vector::borrow_mut(&mut tree.entries, red_child).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                red_child
+            }
+        } else {
+            let uncle = vector::borrow(&tree.entries, parent).left_child;
+            if (uncle != NULL_INDEX && vector::borrow(&tree.entries, uncle).metadata == RB_RED) {
+                // case 1, uncle is red
+                // recolor parent, index, and uncle.
+                //
+                //        parent (b)
+                //     /          \
+                //  uncle(r)    index (r)
+                //                /
+                //            rec_child
+                // --------------
+                //        parent (r)
+                //     /          \
+                //  uncle(b)     index (b)
+                //                 /
+                //            rec_child (r)
+                vector::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, uncle).metadata = RB_BLACK;
+                parent
+            } else if (is_right) {
+                // case 2, red_child is right child of index
+                // rotate left at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //                    index(r)
+                //                    /      \
+                //                        red_child(r)
+                // ---------------
+                //             index(b)
+                //          /           \
+                //      parent(r)      red_child(r)
+                rotate_left(tree, parent);
+                vector::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                index
+            } else {
+                // case 3, red_child is left child of the index
+                // rotate right at index, the rotate left at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //                   index(r)
+                //       /            /     \
+                //           red_child(r)
+                // ---------------
+                //          red_child(b)
+                //          /           \
+                //     parent(r)       index(r)
+                rotate_right(tree, index);
+                rotate_left(tree, parent);
+                vector::borrow_mut(&mut tree.entries, red_child).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                red_child
+            }
+        }
+    }
+
+    // update red black tree after a removal of a node.
+    fun rb_update_remove<V>(tree: &mut RedBlackTree<V>, index: u64, is_right: bool, metadata_removed: u8): (bool, u64) {
+        // if the removed node is RED, we are good.
+        if (metadata_removed == RB_RED) {
+            return (false, index)
+        };
+
+        let node = vector::borrow(&tree.entries, index);
+        // get the new child.
+        let child = if (is_right) {
+            node.right_child
+        } else {
+            node.left_child
+        };
+
+        // sibling
+        let w = if (is_right) {
+            node.left_child
+        } else {
+            node.right_child
+        };
+
+        let index_color = node.metadata;
+
+        if (child != NULL_INDEX && vector::borrow(&tree.entries, child).metadata == RB_RED) {
+            vector::borrow_mut(&mut tree.entries, child).metadata = RB_BLACK;
+            return (false, index)
+        };
+
+        // Now child is either black or null.
+        // recall a black node is removed from child side.
+        // so the sibling must has at least one black node.
+        // therefore sibling must exist.
+        // w is sibling
+
+        assert!(
+            w != NULL_INDEX,
+            E_RB_SIBLING_NOT_EXIST,
+        );
+        if (!is_right) {
+            // if sibling (w) is red
+            // rotate left at index.
+            //                index (b)
+            //            /            \
+            // child (null or b)        sibling (r)
+            //                          /      \
+            //                         B(b)     D(b)
+            // ---------------
+            //              sibling (b)
+            //             /           \
+            //          index(r)     D(b)
+            //          /         \
+            //   child(null or b) B(b)
+            let sibling_color = vector::borrow(&tree.entries, w).metadata;
+            if (sibling_color == RB_RED) {
+                assert!(
+                    index_color == RB_BLACK,
+                    E_RB_RED_HAS_RED_PARENT,
+                );
+
+                rotate_left(tree, index);
+                vector::borrow_mut(&mut tree.entries, w).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_RED;
+                index_color = RB_RED;
+
+                w = vector::borrow(&tree.entries, index).right_child;
+                assert!(
+                    vector::borrow(&tree.entries, w).metadata == RB_BLACK,
+                    E_RB_SIBLING_FAIL_BLACK,
+                );
+            };
+
+            // Now both siblings are black
+            let w_node = vector::borrow(&tree.entries, w);
+            let w_left = w_node.left_child;
+            let w_right = w_node.right_child;
+            let w_left_not_red = w_left == NULL_INDEX || vector::borrow(&tree.entries, w_left).metadata == RB_BLACK;
+            let w_right_not_red = w_right == NULL_INDEX || vector::borrow(&tree.entries, w_right).metadata == RB_BLACK;
+            if (w_left_not_red && w_right_not_red) {
+                // case 1, if both of w's child are not red, color it red
+                //            index
+                //           /     \
+                //         child   w (b)
+                vector::borrow_mut(&mut tree.entries, w).metadata = RB_RED;
+                (true, vector::borrow(&tree.entries, index).parent)
+            } else if (!w_right_not_red) {
+                // case 2, w's right child is red, left rotate at index
+                //           index
+                //         /       \
+                //      child     w(b)
+                //                /  \
+                //               E   D(r)
+                // ----------------
+                //           w ()
+                //         /       \
+                //     index(b)   D(b)
+                //      /    \
+                //    child  E
+                rotate_left(tree, index);
+                vector::borrow_mut(&mut tree.entries, w).metadata = index_color;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, w_right).metadata = RB_BLACK;
+                (false, index)
+            } else {
+                // case 3, w's left child is red,
+                // rotate right at w
+                // then treat as case 2, rotate left at index
+                //           index
+                //          /      \
+                //        child       w(b)
+                //                /    \
+                //              wl(r)   D
+                // ---
+                //            index
+                //          /       \
+                //       child     wl(b)
+                //                    \
+                //                   w(r)
+                //                      \
+                //                      D
+                // ---
+                //            wl ()
+                //          /       \
+                //       index (b)  w(b)
+                //      /             \
+                //   child              D
+                rotate_right(tree, w);
+                rotate_left(tree, index);
+                vector::borrow_mut(&mut tree.entries, w_left).metadata = index_color;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                (false, index)
+            }
+        } else {
+            // if sibling (w) is red
+            // rotate right at index.
+            //                index (b)
+            //            /            \
+            //       sibling (r)     child (null or b)
+            //        /      \
+            //      B(b)     D(b)
+            // ---------------
+            //              sibling (b)
+            //             /           \
+            //         B(b)           index(r)
+            //                        /      \
+            //                      D(b)    child(null or b)
+             let sibling_color = vector::borrow(&tree.entries, w).metadata;
+             if (sibling_color == RB_RED) {
+                assert!(
+                    index_color == RB_BLACK,
+                    E_RB_RED_HAS_RED_PARENT,
+                );
+
+                rotate_right(tree, index);
+                vector::borrow_mut(&mut tree.entries, w).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_RED;
+                index_color = RB_RED;
+
+                w = vector::borrow(&tree.entries, index).left_child;
+
+                assert!(
+                    vector::borrow(&tree.entries, w).metadata == RB_BLACK,
+                    E_RB_SIBLING_FAIL_BLACK,
+                );
+             };
+
+            // Now both siblings are black
+            let w_node = vector::borrow(&tree.entries, w);
+            let w_left = w_node.left_child;
+            let w_right = w_node.right_child;
+            let w_left_not_red = w_left == NULL_INDEX || vector::borrow(&tree.entries, w_left).metadata == RB_BLACK;
+            let w_right_not_red = w_right == NULL_INDEX || vector::borrow(&tree.entries, w_right).metadata == RB_BLACK;
+            if (w_left_not_red && w_right_not_red) {
+                // case 1, if both of w's child are not red, color it red
+                //            index
+                //           /     \
+                //        w (b)    child
+                vector::borrow_mut(&mut tree.entries, w).metadata = RB_RED;
+                (true, vector::borrow(&tree.entries, index).parent)
+            } else if (!w_left_not_red) {
+                // case 2, w's left child is red, right rotate at index
+                //           index
+                //         /       \
+                //      w(b)       child
+                //     /  \
+                //   D(r)  E
+                // ----------------
+                //           w ()
+                //         /       \
+                //      D(b)      index(b)
+                //                /   \
+                //               E   child
+                rotate_right(tree, index);
+                vector::borrow_mut(&mut tree.entries, w).metadata = index_color;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                vector::borrow_mut(&mut tree.entries, w_left).metadata = RB_BLACK;
+                (false, index)
+            } else {
+                // case 3, w's right child is red,
+                // rotate left at w
+                // then treat as case 2, rotate right at index
+                //           index
+                //          /      \
+                //       w(b)      child
+                //     /    \
+                //    D     wr(r)
+                // ---
+                //            index
+                //          /       \
+                //        wr(b)     child
+                //       /
+                //     w(r)
+                //    /
+                //   D
+                // ---
+                //            wr ()
+                //          /       \
+                //       w (b)   index(b)
+                //      /             \
+                //    D               child
+                rotate_left(tree, w);
+                rotate_right(tree, index);
+                vector::borrow_mut(&mut tree.entries, w_right).metadata = index_color;
+                vector::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                (false, index)
+            }
+        }
+    }
+
+    ///////////
+    // Specs //
+    ///////////
+
+    /// spec_is_valid_index checks the index is either NULL_INDEX or an element of the tree.
+    spec fun spec_is_valid_index<V>(tree: RedBlackTree<V>, index: u64): bool {
+        index == NULL_INDEX || index < len(tree.entries)
+    }
+
+    /// spec_less checks the keys of entry a are smaller than the keys of entry b.
+    spec fun spec_less<V>(a: Entry<V>, b: Entry<V>): bool {
+        ((a.key0_2 < b.key0_2)) || ((a.key0_2 == b.key0_2) && (a.key1_2 < b.key1_2))
+    }
+
+    /// spec_same_keys checks entry a and entry b have the same keys.
+    spec fun spec_same_keys<V>(a: Entry<V>, b: Entry<V>): bool {
+        a.key0_2 == b.key0_2 && a.key1_2 == b.key1_2
+    }
+
+    /// spec_same_data checks entry a and entry b have the same keys and value.
+    spec fun spec_same_data<V>(a: Entry<V>, b: Entry<V>): bool {
+        spec_same_keys(a, b) && a.value == b.value
+    }
+
+    /// spec_child is the right child of the entry at index if is_right, otherwise the left child.
+    spec fun spec_child<V>(tree: RedBlackTree<V>, index: u64, is_right: bool): u64 {
+        if (is_right) tree.entries[index].right_child else tree.entries[index].left_child
+    }
+
+    /// spec_ancestor is the index reached from index by following the parent links for the given steps.
+    spec fun spec_ancestor<V>(tree: RedBlackTree<V>, index: u64, steps: num): u64 {
+        if (steps <= 0 || index == NULL_INDEX) index else spec_ancestor(tree, tree.entries[index].parent, steps - 1)
+    }
+
+    /// spec_in_subtree checks the entry at index is in the subtree rooted at root, including root itself.
+    spec fun spec_in_subtree<V>(tree: RedBlackTree<V>, index: u64, root: u64): bool {
+        root != NULL_INDEX && index != NULL_INDEX && (exists steps in 0..len(tree.entries): spec_ancestor(tree, index, steps) == root)
+    }
+
+    /// spec_is_black checks index is NULL_INDEX or a black entry.
+    spec fun spec_is_black<V>(tree: RedBlackTree<V>, index: u64): bool {
+        index == NULL_INDEX || tree.entries[index].metadata == RB_BLACK
+    }
+
+    /// spec_child_black_height is the number of black entries on the path from the child on the is_right side
+    /// of the entry at index following the left children, as it was before the subtree at the child on
+    /// the at_right side of at lost one black entry on all its paths.
+    /// The black heights are the current ones if at is NULL_INDEX.
+    /// depth bounds the recursion, which a valid tree never reaches when it starts at the number of entries.
+    spec fun spec_child_black_height<V>(tree: RedBlackTree<V>, index: u64, is_right: bool, at: u64, at_right: bool, depth: num): num {
+        let child = spec_child(tree, index, is_right);
+        let height = if (child == NULL_INDEX || depth <= 0) {
+            0
+        } else {
+            spec_child_black_height(tree, child, false, at, at_right, depth - 1) + (if (tree.entries[child].metadata == RB_BLACK) 1 else 0)
+        };
+        if (index == at && is_right == at_right) height + 1 else height
+    }
+
+    /// spec_is_rb_balanced checks the paths through the left and right subtrees of the entry at index
+    /// have the same number of black entries, counted by spec_child_black_height.
+    spec fun spec_is_rb_balanced<V>(tree: RedBlackTree<V>, index: u64, at: u64, at_right: bool): bool {
+        spec_child_black_height(tree, index, false, at, at_right, len(tree.entries))
+            == spec_child_black_height(tree, index, true, at, at_right, len(tree.entries))
+    }
+
+    /// spec_is_rb_red_ok checks a red entry at index has no red child,
+    /// except the child on the at_right side of at, which the rebalancing is about to fix.
+    spec fun spec_is_rb_red_ok<V>(tree: RedBlackTree<V>, index: u64, at: u64, at_right: bool): bool {
+        let entry = tree.entries[index];
+        entry.metadata == RB_BLACK
+            || (((index == at && !at_right) || spec_is_black(tree, entry.left_child))
+                && ((index == at && at_right) || spec_is_black(tree, entry.right_child)))
+    }
+
+    /// spec_is_valid_entry checks the links of the entry at index, which never point to the excluded entry,
+    /// and the order of its keys against its children.
+    spec fun spec_is_valid_entry<V>(tree: RedBlackTree<V>, index: u64, excluded: u64): bool {
+        let entry = tree.entries[index];
+        spec_is_valid_index(tree, entry.parent)
+            && spec_is_valid_index(tree, entry.left_child)
+            && spec_is_valid_index(tree, entry.right_child)
+            && (excluded == NULL_INDEX || (entry.parent != excluded && entry.left_child != excluded && entry.right_child != excluded))
+            && (entry.parent == NULL_INDEX) == (index == tree.root)
+            && (entry.left_child == NULL_INDEX || (tree.entries[entry.left_child].parent == index && spec_less(tree.entries[entry.left_child], entry)))
+            && (entry.right_child == NULL_INDEX || (tree.entries[entry.right_child].parent == index && spec_less(entry, tree.entries[entry.right_child])))
+            && (entry.metadata == RB_RED || entry.metadata == RB_BLACK)
+            && entry.size == 1
+                + (if (entry.left_child == NULL_INDEX) 0 else tree.entries[entry.left_child].size)
+                + (if (entry.right_child == NULL_INDEX) 0 else tree.entries[entry.right_child].size)
+    }
+
+    /// spec_is_valid_structure checks the entries other than excluded form a binary search tree:
+    /// all of them are reachable from the root, the keys in the left subtree of an entry are smaller than its keys,
+    /// and the keys in the right subtree are bigger. min_index and max_index are the entries with the smallest
+    /// and the biggest keys. excluded is the entry being removed, or NULL_INDEX.
+    spec fun spec_is_valid_structure<V>(tree: RedBlackTree<V>, excluded: u64): bool {
+        let count = len(tree.entries) - (if (excluded == NULL_INDEX) 0 else 1);
+        spec_is_valid_index(tree, tree.root)
+            && spec_is_valid_index(tree, tree.min_index)
+            && spec_is_valid_index(tree, tree.max_index)
+            && (excluded == NULL_INDEX || (tree.root != excluded && tree.min_index != excluded && tree.max_index != excluded))
+            && (tree.root == NULL_INDEX) == (count == 0)
+            && (tree.min_index == NULL_INDEX) == (count == 0)
+            && (tree.max_index == NULL_INDEX) == (count == 0)
+            && (tree.min_index == NULL_INDEX || (forall i in 0..len(tree.entries): i != excluded && i != tree.min_index ==> spec_less(tree.entries[tree.min_index], tree.entries[i])))
+            && (tree.max_index == NULL_INDEX || (forall i in 0..len(tree.entries): i != excluded && i != tree.max_index ==> spec_less(tree.entries[i], tree.entries[tree.max_index])))
+            && (tree.root == NULL_INDEX || tree.entries[tree.root].size == count)
+            && (forall i in 0..len(tree.entries): i != excluded ==> spec_is_valid_entry(tree, i, excluded))
+            && (forall i in 0..len(tree.entries): i != excluded ==> spec_in_subtree(tree, i, tree.root))
+            && (forall i in 0..len(tree.entries), j in 0..len(tree.entries): i != excluded && j != excluded ==>
+                (spec_in_subtree(tree, j, tree.entries[i].left_child) ==> spec_less(tree.entries[j], tree.entries[i]))
+                    && (spec_in_subtree(tree, j, tree.entries[i].right_child) ==> spec_less(tree.entries[i], tree.entries[j])))
+            && (forall i in 0..len(tree.entries), j in 0..len(tree.entries): i != excluded && j != excluded && i != j ==> !spec_same_keys(tree.entries[i], tree.entries[j]))
+    }
+
+    /// spec_is_valid_tree is the invariant of the RedBlackTree.
+    /// It holds between calls to the public functions, but not in the middle of insertion or removal
+    /// (the new entry is pushed before it is linked, and the rotations relink the entries one by one),
+    /// so it is required and ensured by the public functions instead of declared as a struct invariant.
+    spec fun spec_is_valid_tree<V>(tree: RedBlackTree<V>): bool {
+        spec_is_valid_structure(tree, NULL_INDEX)
+            && (tree.root == NULL_INDEX || tree.entries[tree.root].metadata == RB_BLACK)
+            && (forall i in 0..len(tree.entries): spec_is_rb_red_ok(tree, i, NULL_INDEX, false))
+            && (forall i in 0..len(tree.entries): spec_is_rb_balanced(tree, i, NULL_INDEX, false))
+    }
+
+    /// spec_contains checks if the keys are in the RedBlackTree.
+    spec fun spec_contains<V>(tree: RedBlackTree<V>, key0_2: u128, key1_2: u128): bool {
+        exists i in 0..len(tree.entries): tree.entries[i].key0_2 == key0_2 && tree.entries[i].key1_2 == key1_2
+    }
+
+    spec new {
+        aborts_if false;
+        ensures result.root == NULL_INDEX;
+        ensures result.min_index == NULL_INDEX;
+        ensures result.max_index == NULL_INDEX;
+        ensures len(result.entries) == 0;
+    }
+
+    spec find {
+        requires spec_is_valid_tree(tree);
+        ensures result == NULL_INDEX || (result < len(tree.entries) && tree.entries[result].key0_2 == key0_2 && tree.entries[result].key1_2 == key1_2);
+        ensures spec_contains(tree, key0_2, key1_2) ==> result != NULL_INDEX;
+    }
+
+    spec borrow_at_index {
+        aborts_if index >= len(tree.entries);
+        ensures result_1 == tree.entries[index].key0_2;
+        ensures result_2 == tree.entries[index].key1_2;
+        ensures result_3 == tree.entries[index].value;
+    }
+
+    spec borrow_at_index_mut {
+        aborts_if index >= len(tree.entries);
+        ensures result_1 == tree.entries[index].key0_2;
+        ensures result_2 == tree.entries[index].key1_2;
+    }
+
+    spec size {
+        aborts_if false;
+        ensures result == len(tree.entries);
+    }
+
+    spec empty {
+        aborts_if false;
+        ensures result == (len(tree.entries) == 0);
+    }
+
+    spec get_min_index {
+        aborts_if tree.min_index == NULL_INDEX with E_EMPTY_TREE;
+        ensures result == tree.min_index;
+    }
+
+    spec get_max_index {
+        aborts_if tree.max_index == NULL_INDEX with E_EMPTY_TREE;
+        ensures result == tree.max_index;
+    }
+
+    spec schema InsertAbortsIf<V> {
+        tree: RedBlackTree<V>;
+        key0_2: u128;
+        key1_2: u128;
+        aborts_if len(tree.entries) >= NULL_INDEX with E_TREE_TOO_BIG;
+        aborts_if spec_contains(tree, key0_2, key1_2) with E_KEY_ALREADY_EXIST;
+    }
+
+    spec schema InsertEnsures<V> {
+        tree: RedBlackTree<V>;
+        key0_2: u128;
+        key1_2: u128;
+        value: V;
+        ensures spec_is_valid_tree(tree);
+        ensures len(tree.entries) == len(old(tree.entries)) + 1;
+        ensures spec_contains(tree, key0_2, key1_2);
+        // existing elements stay at their indices.
+        ensures forall i in 0..len(old(tree.entries)): tree.entries[i].key0_2 == old(tree.entries[i].key0_2) && tree.entries[i].key1_2 == old(tree.entries[i].key1_2) && tree.entries[i].value == old(tree.entries[i].value);
+    }
+
+    spec insert {
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_tree(tree);
+        include InsertAbortsIf<V>;
+        include InsertEnsures<V>;
+    }
+
+    spec insert_and_get_index {
+        pragma opaque;
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_tree(tree);
+        include InsertAbortsIf<V>;
+        include InsertEnsures<V>;
+        ensures result == len(old(tree.entries));
+        ensures tree.entries[result].key0_2 == key0_2;
+        ensures tree.entries[result].key1_2 == key1_2;
+        ensures tree.entries[result].value == value;
+    }
+
+    spec remove {
+        pragma opaque;
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_tree(tree);
+        aborts_if index >= len(tree.entries);
+        ensures spec_is_valid_tree(tree);
+        ensures len(tree.entries) == len(old(tree.entries)) - 1;
+        ensures result_1 == old(tree.entries[index].key0_2);
+        ensures result_2 == old(tree.entries[index].key1_2);
+        ensures result_3 == old(tree.entries[index].value);
+        ensures !spec_contains(tree, result_1, result_2);
+    }
+
+    spec increase_size_to_root {
+        pragma opaque;
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_index(tree, index);
+        requires forall i in 0..len(tree.entries): spec_is_valid_index(tree, tree.entries[i].parent);
+        ensures len(tree.entries) == len(old(tree.entries));
+        ensures forall i in 0..len(tree.entries): tree.entries[i] == update_field(old(tree.entries[i]), size, old(tree.entries[i]).size
+            + (if (spec_in_subtree(old(tree), index, i)) 1 else 0));
+    }
+
+    spec decrease_size_until {
+        pragma opaque;
+        pragma aborts_if_is_partial;
+        requires spec_is_valid_index(tree, index);
+        requires forall i in 0..len(tree.entries): spec_is_valid_index(tree, tree.entries[i].parent);
+        ensures len(tree.entries) == len(old(tree.entries));
+        ensures forall i in 0..len(tree.entries): tree.entries[i] == update_field(old(tree.entries[i]), size, old(tree.entries[i]).size
+            - (if (spec_in_subtree(old(tree), index, i) && !spec_in_subtree(old(tree), stop_index, i)) 1 else 0));
+    }
+
+    spec destroy_empty {
+        aborts_if len(tree.entries) != 0 with E_TREE_NOT_EMPTY;
+    }
+
+    // keys_for_test splits v into the keys of the tree. The first key is v / 4, and the other keys are v % 4 * 2 + 1,
+    // so the keys are in the same order as v, every 4 elements share the same first key, and the other keys have gaps in between.
+    #[test_only]
+    fun keys_for_test(v: u128): (u128, u128) {
+        (v / 4, v % 4 * 2 + 1)
+    }
+
+    // is_less_for_test checks the keys lhs are less than the keys rhs in the lexicographic order.
+    #[test_only]
+    fun is_less_for_test(lhs_key0_2: u128, lhs_key1_2: u128, rhs_key0_2: u128, rhs_key1_2: u128): bool {
+        ((lhs_key0_2 < rhs_key0_2)) || ((lhs_key0_2 == rhs_key0_2) && (lhs_key1_2 < rhs_key1_2))
+    }
+
+    #[test]
+    fun test_bounds_multi_key() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert the keys of 0, 1, ..., 19 out of order.
+            let v = (idx * 7) % 20;
+            let (key0_2, key1_2) = keys_for_test(v);
+            let index = insert_and_get_index(&mut tree, key0_2, key1_2, v);
+            assert!(find(&tree, key0_2, key1_2) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        // the elements are in the order of v.
+        let v: u128 = 0;
+        let iter = get_min_index(&tree);
+        while (iter != NULL_INDEX) {
+            let (_, _, value) = borrow_at_index(&tree, iter);
+            assert!(*value == v, (v as u64));
+            v = v + 1;
+            iter = next_in_order(&tree, iter);
+        };
+        assert!(v == 20, (v as u64));
+
+        // 8, 9, 10 and 11 have the same first key 2, and the other keys decide the order.
+        let (key0_2, key1_2) = keys_for_test(9);
+        let nine = find(&tree, key0_2, key1_2);
+        let (key0_2, key1_2) = keys_for_test(10);
+        let ten = find(&tree, key0_2, key1_2);
+        let (key0_2, key1_2) = keys_for_test(11);
+        let eleven = find(&tree, key0_2, key1_2);
+        // the keys of 9 and 10 are (2, 3) and (2, 5), so (2, 4) is in between.
+        assert!(lower_bound(&tree, 2, 4) == ten, 0);
+        assert!(upper_bound(&tree, 2, 4) == ten, 1);
+        assert!(floor(&tree, 2, 4) == nine, 2);
+        assert!(ceiling(&tree, 2, 4) == ten, 3);
+        assert!(lower_bound(&tree, 2, 5) == ten, 4);
+        assert!(upper_bound(&tree, 2, 5) == eleven, 5);
+        assert!(floor(&tree, 2, 5) == ten, 6);
+        assert!(ceiling(&tree, 2, 5) == ten, 7);
+
+        // the first keys from 0 to 5 and the other keys from 0 to 8 cover the keys below, equal to, in between, and above the elements.
+        let first: u128 = 0;
+        while (first < 6) {
+            let other: u128 = 0;
+            while (other < 9) {
+                let (q_key0_2, q_key1_2) = (first, other);
+                let expected_lower = NULL_INDEX;
+                let expected_upper = NULL_INDEX;
+                let expected_floor = NULL_INDEX;
+                let iter = get_min_index(&tree);
+                while (iter != NULL_INDEX) {
+                    let (key0_2, key1_2, _) = borrow_at_index(&tree, iter);
+                    if (!is_less_for_test(q_key0_2, q_key1_2, key0_2, key1_2)) {
+                        expected_floor = iter;
+                    };
+                    if (!is_less_for_test(key0_2, key1_2, q_key0_2, q_key1_2) && expected_lower == NULL_INDEX) {
+                        expected_lower = iter;
+                    };
+                    if (is_less_for_test(q_key0_2, q_key1_2, key0_2, key1_2) && expected_upper == NULL_INDEX) {
+                        expected_upper = iter;
+                    };
+                    iter = next_in_order(&tree, iter);
+                };
+
+                let code = ((first * 10 + other) as u64);
+                assert!(lower_bound(&tree, q_key0_2, q_key1_2) == expected_lower, code);
+                assert!(upper_bound(&tree, q_key0_2, q_key1_2) == expected_upper, code);
+                assert!(floor(&tree, q_key0_2, q_key1_2) == expected_floor, code);
+                assert!(ceiling(&tree, q_key0_2, q_key1_2) == expected_lower, code);
+                other = other + 1;
+            };
+            first = first + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test_only]
+    fun count_in_range_for_test<V>(tree: &RedBlackTree<V>, lo: u128, hi: u128): u64 {
+        let (lo_key0_2, lo_key1_2) = keys_for_test(lo);
+        let (hi_key0_2, hi_key1_2) = keys_for_test(hi);
+        count_in_range(tree, lo_key0_2, lo_key1_2, hi_key0_2, hi_key1_2)
+    }
+
+    #[test]
+    fun test_rank_multi_key() {
+        let tree = new<u128>();
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert the keys of 0, 2, 4, ..., 38 out of order.
+            let v = ((idx * 7) % 20) * 2;
+            let (key0_2, key1_2) = keys_for_test(v);
+            insert(&mut tree, key0_2, key1_2, v);
+            idx = idx + 1;
+        };
+
+        check_subtree_size(&tree, tree.root);
+
+        let k = 0;
+        while (k < 20) {
+            let index = select(&tree, k);
+            let (key0_2, key1_2, value) = borrow_at_index(&tree, index);
+            assert!(*value == (k as u128) * 2, k);
+            assert!(rank(&tree, key0_2, key1_2) == k, k);
+            // the last keys are 1 or 5, adding 1 gives the keys between this element and the next one.
+            assert!(rank(&tree, key0_2, key1_2 + 1) == k + 1, k);
+            k = k + 1;
+        };
+
+        // 4, 6, 8 and 10 are in between, and the first keys of the bounds are shared with 2 and 10.
+        assert!(count_in_range_for_test(&tree, 3, 11) == 4, 0);
+        assert!(count_in_range_for_test(&tree, 4, 10) == 4, 1);
+        assert!(count_in_range_for_test(&tree, 11, 3) == 0, 2);
+        assert!(count_in_range_for_test(&tree, 0, 100) == 20, 3);
+
+        while (!empty(&tree)) {
+            let index = select(&tree, size(&tree) / 2);
+            remove(&mut tree, index);
+            check_subtree_size(&tree, tree.root);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test_only]
+    fun check_subtree_size<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        if (index == NULL_INDEX) {
+            return 0
+        };
+        let node = vector::borrow(&tree.entries, index);
+        let expected = check_subtree_size(tree, node.left_child) + check_subtree_size(tree, node.right_child) + 1;
+        assert!(node.size == expected, index);
+        expected
+    }
+}
@@ -0,0 +1,1691 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Tree based on GNU libavl https://adtinfo.org/
+module container::red_black_stable {
+    use std::option::{Self, Option};
+    use std::vector;
+
+    /// Slots stores the elements without moving them: an element keeps its index until it is taken out.
+    /// The slot of a taken element becomes vacant and is reused by the next add.
+    struct Slots<T> has store, copy, drop {
+        items: vector<Option<T>>,
+        // indices of the vacant slots, the last one is reused first.
+        vacant: vector<u64>,
+    }
+
+    fun new_slots<T>(): Slots<T> {
+        Slots<T> {
+            items: vector::empty(),
+            vacant: vector::empty(),
+        }
+    }
+
+    /// length is the number of elements in the slots.
+    fun length<T>(slots: &Slots<T>): u64 {
+        vector::length(&slots.items) - vector::length(&slots.vacant)
+    }
+
+    /// capacity is the number of slots, including the vacant ones. All indices are less than the capacity.
+    fun capacity<T>(slots: &Slots<T>): u64 {
+        vector::length(&slots.items)
+    }
+
+    /// is_occupied checks if there is an element at index.
+    fun is_occupied<T>(slots: &Slots<T>, index: u64): bool {
+        index < vector::length(&slots.items) && option::is_some(vector::borrow(&slots.items, index))
+    }
+
+    fun borrow<T>(slots: &Slots<T>, index: u64): &T {
+        option::borrow(vector::borrow(&slots.items, index))
+    }
+
+    fun borrow_mut<T>(slots: &mut Slots<T>, index: u64): &mut T {
+        option::borrow_mut(vector::borrow_mut(&mut slots.items, index))
+    }
+
+    /// next_slot is the index the next add will put the element at.
+    fun next_slot<T>(slots: &Slots<T>): u64 {
+        if (vector::is_empty(&slots.vacant)) {
+            capacity(slots)
+        } else {
+            *vector::borrow(&slots.vacant, vector::length(&slots.vacant) - 1)
+        }
+    }
+
+    /// add puts the element into the last vacated slot, or a new slot if none is vacant, and returns its index.
+    fun add<T>(slots: &mut Slots<T>, item: T): u64 {
+        if (vector::is_empty(&slots.vacant)) {
+            let index = capacity(slots);
+            vector::push_back(&mut slots.items, option::some(item));
+            index
+        } else {
+            let index = vector::pop_back(&mut slots.vacant);
+            fill(slots, index, item);
+            index
+        }
+    }
+
+    /// take removes the element at index and leaves the slot vacant.
+    fun take<T>(slots: &mut Slots<T>, index: u64): T {
+        let item = option::extract(vector::borrow_mut(&mut slots.items, index));
+        vector::push_back(&mut slots.vacant, index);
+        item
+    }
+
+    /// fill puts the element into the slot at index, which must be vacant and already off the vacant list.
+    fun fill<T>(slots: &mut Slots<T>, index: u64, item: T) {
+        option::fill(vector::borrow_mut(&mut slots.items, index), item);
+    }
+
+    /// relocate moves the element at index to new_index, which must be vacant and already off the vacant list.
+    /// the slot at index is left vacant, but not put on the vacant list.
+    fun relocate<T>(slots: &mut Slots<T>, index: u64, new_index: u64) {
+        let item = option::extract(vector::borrow_mut(&mut slots.items, index));
+        fill(slots, new_index, item);
+    }
+
+    /// pop_vacant_below takes a vacant slot with index less than bound off the vacant list.
+    /// vacant slots at or above bound are dropped from the vacant list on the way.
+    /// aborts if there is no such slot.
+    fun pop_vacant_below<T>(slots: &mut Slots<T>, bound: u64): u64 {
+        loop {
+            let index = vector::pop_back(&mut slots.vacant);
+            if (index < bound) {
+                return index
+            };
+        }
+    }
+
+    /// shrink drops all the vacant slots, which must all be after the elements, so the capacity equals the length.
+    fun shrink<T>(slots: &mut Slots<T>) {
+        let count = length(slots);
+        while (vector::length(&slots.items) > count) {
+            option::destroy_none(vector::pop_back(&mut slots.items));
+        };
+        slots.vacant = vector::empty();
+    }
+
+    /// destroy_slots destroys the slots, aborts if there is any element left.
+    fun destroy_slots<T>(slots: Slots<T>) {
+        let Slots { items, vacant: _ } = slots;
+        while (!vector::is_empty(&items)) {
+            option::destroy_none(vector::pop_back(&mut items));
+        };
+        vector::destroy_empty(items);
+    }
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_KEY_ALREADY_EXIST: u64 = 2;
+    const E_EMPTY_TREE: u64 = 3;
+    const E_INVALID_INDEX: u64 = 4;
+    const E_TREE_TOO_BIG: u64 = 5;
+    const E_TREE_NOT_EMPTY: u64 = 6;
+    const E_PARENT_NULL: u64 = 7;
+    const E_PARENT_INDEX_OUT_OF_RANGE: u64 = 8;
+    const E_RIGHT_ROTATE_LEFT_CHILD_NULL: u64 = 9;
+    const E_LEFT_ROTATE_RIGHT_CHILD_NULL: u64 = 10;
+
+    const E_RB_NOT_RED_NODE: u64 = 15;
+    const E_RB_RED_HAS_RED_PARENT: u64 = 16;
+    const E_RB_RED_HAS_NO_PARENT: u64 = 17;
+    const E_RB_SIBLING_NOT_EXIST: u64 = 18;
+    const E_RB_SIBLING_FAIL_BLACK: u64 = 19;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+
+    const RB_RED: u8 = 128;
+    const RB_BLACK: u8 = 129;
+
+    const METADATA_DEFAULT: u8 = 128;
+
+    /// Entry is the internal RedBlackTree element.
+    struct Entry<V> has store, copy, drop {
+        // key
+        key: u128,
+        // value
+        value: V,
+        // parent
+        parent: u64,
+        // left child
+        left_child: u64,
+        // right child.
+        right_child: u64,
+        // metadata
+        metadata: u8,
+        // number of elements in the subtree rooted at this entry
+        size: u64,
+    }
+
+    fun new_entry<V>(key: u128, value: V): Entry<V> {
+        Entry<V> {
+            key,
+            value,
+            parent: NULL_INDEX,
+            left_child: NULL_INDEX,
+            right_child: NULL_INDEX,
+            metadata: METADATA_DEFAULT,
+            size: 1,
+        }
+    }
+
+    #[test_only]
+    fun new_entry_for_test<V>(key: u128, value: V, parent: u64, left_child: u64, right_child: u64, metadata: u8, size: u64): Entry<V> {
+        Entry {
+            key,
+            value,
+            parent,
+            left_child,
+            right_child,
+            metadata,
+            size,
+        }
+    }
+
+    /// RedBlackTree contains a vector of Entry<V>, which is triple-linked binary search tree.
+    struct RedBlackTree<V> has store, copy, drop {
+        root: u64,
+        entries: Slots<Entry<V>>,
+        min_index: u64,
+        max_index: u64,
+    }
+
+    /// create new tree
+    public fun new<V>(): RedBlackTree<V> {
+        RedBlackTree {
+            root: NULL_INDEX,
+            entries: new_slots(),
+            min_index: NULL_INDEX,
+            max_index: NULL_INDEX,
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// find returns the element index in the RedBlackTree, or none if not found.
+    public fun find<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            if (node.key == key) {
+                return current
+            };
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        NULL_INDEX
+    }
+
+    /// lower_bound returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_smaller = ((node.key < key));
+            if(is_smaller) {
+                current = node.right_child;
+            } else {
+                result = current;
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// upper_bound returns the index of the first element with keys greater than the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                result = current;
+                current = node.left_child;
+            } else {
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// floor returns the index of the last element with keys less than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = NULL_INDEX;
+        let current = tree.root;
+
+        while(current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if(is_bigger) {
+                current = node.left_child;
+            } else {
+                result = current;
+                current = node.right_child;
+            };
+        };
+
+        result
+    }
+
+    /// ceiling returns the index of the first element with keys greater than or equal to the input keys,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        lower_bound(tree, key)
+    }
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V>(tree: &RedBlackTree<V>, index: u64): (u128, &V) {
+        let entry = Self::borrow(&tree.entries, index);
+        (entry.key, &entry.value)
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V>(tree: &mut RedBlackTree<V>, index: u64): (u128, &mut V) {
+        let entry = Self::borrow_mut(&mut tree.entries, index);
+        (entry.key, &mut entry.value)
+    }
+
+    /// size returns the number of elements in the RedBlackTree.
+    public fun size<V>(tree: &RedBlackTree<V>): u64 {
+        Self::length(&tree.entries)
+    }
+
+    /// empty returns true if the RedBlackTree is empty.
+    public fun empty<V>(tree: &RedBlackTree<V>): bool {
+        Self::length(&tree.entries) == 0
+    }
+
+    /// get index of the min of the tree.
+    public fun get_min_index<V>(tree: &RedBlackTree<V>): u64 {
+        let current = tree.min_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the min of the subtree with root at index.
+    public fun get_min_index_from<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        let current = index;
+        let left_child = Self::borrow(&tree.entries, current).left_child;
+
+        while (left_child != NULL_INDEX) {
+            current = left_child;
+            left_child = Self::borrow(&tree.entries, current).left_child;
+        };
+
+        current
+    }
+
+    /// get index of the max of the tree.
+    public fun get_max_index<V>(tree: &RedBlackTree<V>): u64 {
+        let current = tree.max_index;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the max of the subtree with root at index.
+    public fun get_max_index_from<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        let current = index;
+        let right_child = Self::borrow(&tree.entries, current).right_child;
+
+        while (right_child != NULL_INDEX) {
+            current = right_child;
+            right_child = Self::borrow(&tree.entries, current).right_child;
+        };
+
+        current
+    }
+
+    /// find next value in order (the key is increasing)
+    public fun next_in_order<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = Self::borrow(&tree.entries, index);
+        let right_child = node.right_child;
+        let parent = node.parent;
+
+        if (right_child != NULL_INDEX) {
+            // first, check if right child is null.
+            // then go to right child, and check if there is left child.
+            let next = right_child;
+            let next_left = Self::borrow(&tree.entries, next).left_child;
+            while (next_left != NULL_INDEX) {
+                next = next_left;
+                next_left = Self::borrow(&tree.entries, next).left_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no right child, check parent.
+            // if current is the left child of the parent, parent is then next.
+            // if current is the right child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_right_child(tree, current, parent)) {
+                current = parent;
+                parent = Self::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// find next value in reverse order (the key is decreasing)
+    public fun next_in_reverse_order<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        assert!(index != NULL_INDEX, E_INVALID_INDEX);
+        let node = Self::borrow(&tree.entries, index);
+        let left_child = node.left_child;
+        let parent = node.parent;
+        if (left_child != NULL_INDEX) {
+            // first, check if left child is null.
+            // then go to left child, and check if there is right child.
+            let next = left_child;
+            let next_right = Self::borrow(&tree.entries, next).right_child;
+            while (next_right != NULL_INDEX) {
+                next = next_right;
+                next_right = Self::borrow(&tree.entries, next).right_child;
+            };
+
+           next
+        } else if (parent != NULL_INDEX) {
+            // there is no left child, check parent.
+            // if current is the right child of the parent, parent is then next.
+            // if current is the left child of the parent, set current to parent
+            let current = index;
+            while(parent != NULL_INDEX && is_left_child(tree, current, parent)) {
+                current = parent;
+                parent = Self::borrow(&tree.entries, current).parent;
+            };
+
+            parent
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// rank returns the number of elements with keys less than the input keys,
+    /// which is the 0-based position of the keys in order if they are in the tree.
+    public fun rank<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = 0;
+        let current = tree.root;
+
+        while (current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_smaller = ((node.key < key));
+            if (is_smaller) {
+                result = result + subtree_size(tree, node.left_child) + 1;
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// select returns the index of the element at the 0-based position k in order.
+    /// aborts if k is not less than the size of the tree.
+    public fun select<V>(tree: &RedBlackTree<V>, k: u64): u64 {
+        assert!(k < size(tree), E_INVALID_ARGUMENT);
+        let current = tree.root;
+
+        loop {
+            let node = Self::borrow(&tree.entries, current);
+            let left_size = subtree_size(tree, node.left_child);
+            if (k < left_size) {
+                current = node.left_child;
+            } else if (k == left_size) {
+                return current
+            } else {
+                k = k - left_size - 1;
+                current = node.right_child;
+            };
+        }
+    }
+
+    /// count_in_range returns the number of elements with keys between lo and hi, inclusive on both ends.
+    public fun count_in_range<V>(tree: &RedBlackTree<V>, lo_key: u128, hi_key: u128): u64 {
+        let lower = rank(tree, lo_key);
+        let upper = rank_upper(tree, hi_key);
+        if (upper > lower) {
+            upper - lower
+        } else {
+            0
+        }
+    }
+
+    /// rank_upper returns the number of elements with keys less than or equal to the input keys.
+    fun rank_upper<V>(tree: &RedBlackTree<V>, key: u128): u64 {
+        let result = 0;
+        let current = tree.root;
+
+        while (current != NULL_INDEX) {
+            let node = Self::borrow(&tree.entries, current);
+            let is_bigger = ((node.key > key));
+            if (!is_bigger) {
+                result = result + subtree_size(tree, node.left_child) + 1;
+                current = node.right_child;
+            } else {
+                current = node.left_child;
+            };
+        };
+
+        result
+    }
+
+    /// get the number of elements in the subtree with root at index, 0 if index is NULL_INDEX.
+    fun subtree_size<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        if (index == NULL_INDEX) {
+            0
+        } else {
+            Self::borrow(&tree.entries, index).size
+        }
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert puts the value keyed at the input keys into the RedBlackTree.
+    /// aborts if the key is already in the tree.
+    public fun insert<V>(tree: &mut RedBlackTree<V>, key: u128, value: V) {
+        insert_and_get_index(tree, key, value);
+    }
+
+    /// insert_and_get_index puts the value keyed at the input keys into the RedBlackTree, and returns the index of the new element.
+    /// aborts if the key is already in the tree.
+    public fun insert_and_get_index<V>(tree: &mut RedBlackTree<V>, key: u128, value: V): u64 {
+        // the max size of the tree is NULL_INDEX.
+        assert!(size(tree) < NULL_INDEX, E_TREE_TOO_BIG);
+        let node = add(
+            &mut tree.entries,
+            new_entry(key, value)
+        );
+
+        let parent = NULL_INDEX;
+        let insert = tree.root;
+        let is_right_child = false;
+
+        while (insert != NULL_INDEX) {
+            let insert_node = Self::borrow(&tree.entries, insert);
+            assert!((insert_node.key != key), E_KEY_ALREADY_EXIST);
+            parent = insert;
+            is_right_child = ((insert_node.key < key));
+            insert = if (is_right_child) {
+                insert_node.right_child
+            } else {
+                insert_node.left_child
+            };
+        };
+
+        replace_parent(tree, node, parent);
+
+        if (parent != NULL_INDEX) {
+            if (is_right_child) {
+                replace_right_child(tree, parent, node);
+            } else {
+                replace_left_child(tree, parent, node);
+            };
+            let max_node = Self::borrow(&tree.entries, tree.max_index);
+            let is_max_smaller = ((max_node.key < key));
+            if (is_max_smaller) {
+                tree.max_index = node;
+            };
+            let min_node = Self::borrow(&tree.entries, tree.min_index);
+            let is_min_bigger = ((min_node.key > key));
+            if (is_min_bigger) {
+                tree.min_index = node;
+            };
+        } else {
+            tree.root = node;
+            tree.min_index = node;
+            tree.max_index = node;
+        };
+
+        // the new node is added to the subtrees of all its ancestors.
+        increase_size_to_root(tree, parent);
+
+        // updat red black tree metadata
+        while (parent != NULL_INDEX) {
+            let parent_metadata = Self::borrow(&tree.entries, parent).metadata;
+            if (parent_metadata == RB_BLACK) {
+                break
+            };
+
+            parent = rb_update_insert(tree, parent, is_right_child);
+            parent_metadata = Self::borrow(&tree.entries, parent).metadata;
+            if (parent_metadata == RB_BLACK) {
+                break
+            };
+            let new_parent = Self::borrow(&tree.entries, parent).parent;
+            if (new_parent == NULL_INDEX) {
+                break
+            };
+            is_right_child = is_right_child(tree, parent, new_parent);
+            parent = new_parent;
+        };
+
+        if (tree.root != NULL_INDEX) {
+            let root = tree.root;
+            Self::borrow_mut(&mut tree.entries, root).metadata = RB_BLACK;
+        };
+
+        node
+    }
+
+    /// remove deletes and returns the element from the RedBlackTree.
+    public fun remove<V>(tree: &mut RedBlackTree<V>, index: u64): (u128, V) {
+        if (tree.max_index == index) {
+            tree.max_index = next_in_reverse_order(tree, index);
+        };
+        if (tree.min_index == index) {
+            tree.min_index = next_in_order(tree, index);
+        };
+
+        let node = Self::borrow(&tree.entries, index);
+        let parent = node.parent;
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        // index and all its ancestors lose one element.
+        // if index is replaced by another node, the replacement takes over the decreased size of index.
+        decrease_size_until(tree, index, NULL_INDEX);
+        let is_right = if (parent != NULL_INDEX) {
+            is_right_child(tree, index, parent)
+        } else {
+            false
+        };
+
+        let (rebalance_start, is_new_right) =
+        if (right_child == NULL_INDEX) {
+            // right child is null
+            // replace with left child.
+            // No need to swap metadata
+            // - in AVL, left is balanced and new value is also balanced.
+            // - in RB, left must be red and index must be black.
+            //         index
+            //       /       \
+            //     left
+            //  --
+            //        left
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, left_child, NULL_INDEX);
+                tree.root = left_child;
+            } else {
+                replace_child(tree, parent, index, left_child);
+            };
+            (parent, is_right)
+        } else if (left_child == NULL_INDEX){
+            // left child is null.
+            // replace with right child.
+            // No need to swap metadata.
+            // - in AVL, right is balanced and the new value is also balanced.
+            // - in RB, right must be red and index must be black.
+            //         index
+            //       /       \
+            //               right
+            //  --
+            //        right
+            if (parent == NULL_INDEX) {
+                replace_parent(tree, right_child, NULL_INDEX);
+                tree.root = right_child;
+            } else {
+                replace_child(tree, parent, index, right_child);
+            };
+            (parent, is_right)
+        } else {
+            let right_child_s_left = Self::borrow(&tree.entries, right_child).left_child;
+            if (right_child_s_left == NULL_INDEX) {
+                // right child is not null, and right child's left child is null
+                //              index
+                //           /         \
+                //        left         right
+                //                        \
+                //                         a
+                // -------------
+                //               right
+                //            /       \
+                //          left       a
+                replace_left_child(tree, right_child, left_child);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, right_child, NULL_INDEX);
+                    tree.root = right_child;
+                } else {
+                    replace_child(tree, parent, index, right_child);
+                };
+
+                let index_size = Self::borrow(&tree.entries, index).size;
+                Self::borrow_mut(&mut tree.entries, right_child).size = index_size;
+
+                let old_metadata = Self::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = Self::borrow(&tree.entries, right_child).metadata;
+                Self::borrow_mut(&mut tree.entries, right_child).metadata = old_metadata;
+                Self::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (right_child, true)
+            } else {
+                // right child is not null, and right child's left child is not null either
+                //                 index
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    min
+                //                     \
+                //                      a
+                // -------------------------------------------------
+                //                   min
+                //               /       \
+                //             left      right
+                //                       /  \
+                //                      *
+                //                     /
+                //                    a
+                let next_successor = get_min_index_from(tree, right_child_s_left);
+                let next_successor_node = Self::borrow(&tree.entries, next_successor);
+                let successor_parent = next_successor_node.parent;
+                let next_successor_right = next_successor_node.right_child;
+                // subtrees between the successor and index lose the successor.
+                decrease_size_until(tree, successor_parent, index);
+
+                replace_left_child(tree, successor_parent, next_successor_right);
+                replace_left_child(tree, next_successor, left_child);
+                replace_right_child(tree, next_successor, right_child,);
+
+                if (parent == NULL_INDEX) {
+                    replace_parent(tree, next_successor, NULL_INDEX);
+                    tree.root = next_successor;
+                } else {
+                    replace_child(tree, parent, index, next_successor);
+                };
+
+                let index_size = Self::borrow(&tree.entries, index).size;
+                Self::borrow_mut(&mut tree.entries, next_successor).size = index_size;
+
+                let old_metadata = Self::borrow(&tree.entries, index).metadata;
+                let replaced_metadata = Self::borrow(&tree.entries, next_successor).metadata;
+                Self::borrow_mut(&mut tree.entries, next_successor).metadata = old_metadata;
+                Self::borrow_mut(&mut tree.entries, index).metadata = replaced_metadata;
+
+                (successor_parent, false)
+            }
+        };
+
+        let removal_metadata = Self::borrow(&tree.entries, index).metadata;
+        while (rebalance_start != NULL_INDEX) {
+            let (do_continue, new_start) = rb_update_remove(tree, rebalance_start, is_new_right, removal_metadata);
+            if (!do_continue) {
+                break
+            };
+            if (new_start == NULL_INDEX) {
+                break
+            };
+            is_new_right = is_right_child(tree, rebalance_start, new_start);
+            rebalance_start = new_start;
+        };
+
+        if (tree.root != NULL_INDEX) {
+            let root = tree.root;
+            Self::borrow_mut(&mut tree.entries, root).metadata = RB_BLACK;
+        };
+
+        ////////// now clear up, the slot of index is left vacant.
+        let Entry { key,  value, parent: _, left_child: _, right_child: _, metadata: _, size: _ } = take(&mut tree.entries, index);
+
+        if (size(tree) == 0) {
+            tree.root = NULL_INDEX;
+        };
+
+        (key,  value)
+    }
+
+    /// destroys the tree if it's empty.
+    public fun destroy_empty<V>(tree: RedBlackTree<V>) {
+        let RedBlackTree { entries, root: _, min_index: _, max_index: _ } = tree;
+        assert!(Self::length(&entries) == 0, E_TREE_NOT_EMPTY);
+        destroy_slots(entries);
+    }
+
+    /// compact moves the entries into the lowest indices, so no vacant slot is left behind by the removals.
+    /// Indices of the moved entries change, so any index held outside of the tree must be looked up again.
+    public fun compact<V>(tree: &mut RedBlackTree<V>) {
+        let count = size(tree);
+        let index = capacity(&tree.entries);
+        while (index > count) {
+            index = index - 1;
+            if (is_occupied(&tree.entries, index)) {
+                let new_index = pop_vacant_below(&mut tree.entries, count);
+                move_entry(tree, index, new_index);
+            };
+        };
+        shrink(&mut tree.entries);
+    }
+
+    /// move_entry moves the entry at index to the vacant new_index, and updates the links to it.
+    fun move_entry<V>(tree: &mut RedBlackTree<V>, index: u64, new_index: u64) {
+        relocate(&mut tree.entries, index, new_index);
+        if (tree.root == index) {
+            tree.root = new_index;
+        };
+        if (tree.max_index == index) {
+            tree.max_index = new_index;
+        };
+        if (tree.min_index == index) {
+            tree.min_index = new_index;
+        };
+        let node = borrow(&tree.entries, new_index);
+        let parent = node.parent;
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        replace_child(tree, parent, index, new_index);
+        replace_parent(tree, left_child, new_index);
+        replace_parent(tree, right_child, new_index);
+    }
+
+    /// check if index is the right child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_right_child<V>(tree: &RedBlackTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < capacity(&tree.entries), E_PARENT_INDEX_OUT_OF_RANGE);
+        Self::borrow(&tree.entries, parent_index).right_child == index
+    }
+
+    /// check if index is the left child of parent.
+    /// parent cannot be NULL_INDEX.
+    fun is_left_child<V>(tree: &RedBlackTree<V>, index: u64, parent_index: u64): bool {
+        assert!(parent_index != NULL_INDEX, E_PARENT_NULL);
+        assert!(parent_index < capacity(&tree.entries), E_PARENT_INDEX_OUT_OF_RANGE);
+        Self::borrow(&tree.entries, parent_index).left_child == index
+    }
+
+    /// Replace the child of parent if parent_index is not NULL_INDEX.
+    /// also replace parent index of the child.
+    fun replace_child<V>(tree: &mut RedBlackTree<V>, parent_index: u64, original_child: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            if (is_right_child(tree, original_child, parent_index)) {
+                replace_right_child(tree, parent_index, new_child);
+            } else if (is_left_child(tree, original_child, parent_index)) {
+                replace_left_child(tree, parent_index, new_child);
+            }
+        }
+    }
+
+    /// replace left child.
+    /// also replace parent index of the child.
+    fun replace_left_child<V>(tree: &mut RedBlackTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            Self::borrow_mut(&mut tree.entries, parent_index).left_child = new_child;
+            if (new_child != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace right child.
+    /// also replace parent index of the child.
+    fun replace_right_child<V>(tree: &mut RedBlackTree<V>, parent_index: u64, new_child: u64) {
+        if (parent_index != NULL_INDEX) {
+            Self::borrow_mut(&mut tree.entries, parent_index).right_child = new_child;
+                if (new_child != NULL_INDEX) {
+                Self::borrow_mut(&mut tree.entries, new_child).parent = parent_index;
+            };
+        }
+    }
+
+    /// replace parent of index if index is not NULL_INDEX.
+    fun replace_parent<V>(tree: &mut RedBlackTree<V>, index: u64, parent_index: u64) {
+        if (index != NULL_INDEX) {
+            Self::borrow_mut(&mut tree.entries, index).parent = parent_index;
+        }
+    }
+
+    /// increase the subtree size of index and all its ancestors by 1.
+    fun increase_size_to_root<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let current = index;
+        while (current != NULL_INDEX) {
+            let node = Self::borrow_mut(&mut tree.entries, current);
+            node.size = node.size + 1;
+            current = node.parent;
+        };
+    }
+
+    /// decrease the subtree size of index and its ancestors by 1, stopping before stop_index.
+    fun decrease_size_until<V>(tree: &mut RedBlackTree<V>, index: u64, stop_index: u64) {
+        let current = index;
+        while (current != stop_index && current != NULL_INDEX) {
+            let node = Self::borrow_mut(&mut tree.entries, current);
+            node.size = node.size - 1;
+            current = node.parent;
+        };
+    }
+
+    /// recompute the subtree size of index from its children.
+    fun update_subtree_size<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let node = Self::borrow(&tree.entries, index);
+        let left_child = node.left_child;
+        let right_child = node.right_child;
+        let new_size = subtree_size(tree, left_child) + subtree_size(tree, right_child) + 1;
+        Self::borrow_mut(&mut tree.entries, index).size = new_size;
+    }
+
+
+    /// rotate_right (clockwise rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///        x      y
+    /// -----------------------------------------------------
+    ///                  left
+    ///              x          index
+    ///                       y       right
+    fun rotate_right<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let node = Self::borrow(&tree.entries, index);
+        let left = node.left_child;
+        assert!(
+            left != NULL_INDEX,
+            E_RIGHT_ROTATE_LEFT_CHILD_NULL
+        );
+        let y = Self::borrow(&tree.entries, left).right_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_left_child(tree, index, y);
+
+        // update left
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, left);
+        } else {
+            tree.root = left;
+            replace_parent(tree, left, NULL_INDEX);
+        };
+        replace_right_child(tree, left, index);
+
+        // index is now the child of left.
+        update_subtree_size(tree, index);
+        update_subtree_size(tree, left);
+    }
+
+    /// rotate_left (counter-clockwis rotate)
+    /// -----------------------------------------------------
+    ///                 index
+    ///          left            right
+    ///                       x          y
+    /// -----------------------------------------------------
+    ///                  right
+    ///          index             y
+    ///      left        x
+    fun rotate_left<V>(tree: &mut RedBlackTree<V>, index: u64) {
+        let node = Self::borrow(&tree.entries, index);
+        let right = node.right_child;
+        assert!(
+            right != NULL_INDEX,
+            E_INVALID_ARGUMENT,
+        );
+        let x = Self::borrow(&tree.entries, right).left_child;
+
+        let parent = node.parent;
+
+        // update index
+        replace_right_child(tree, index, x);
+
+        // update right
+        if (parent != NULL_INDEX) {
+            replace_child(tree, parent, index, right);
+        } else {
+            tree.root = right;
+            replace_parent(tree, right, NULL_INDEX);
+        };
+        replace_left_child(tree, right, index);
+
+        // index is now the child of right.
+        update_subtree_size(tree, index);
+        update_subtree_size(tree, right);
+    }
+
+    // update red black tree after an insertion of node as red.
+    // - is_right indicates if right child is red, otherwise left child is red.
+    // - index is a red node.
+    // returns
+    // - the parent tree.
+    fun rb_update_insert<V>(tree: &mut RedBlackTree<V>, index: u64, is_right: bool): u64 {
+        let node = Self::borrow(&tree.entries, index);
+        // make sure the index right now is red
+        assert!(
+            node.metadata == RB_RED,
+            E_RB_NOT_RED_NODE,
+        );
+
+        // get the red child.
+        let red_child = if (is_right) {
+            node.right_child
+        } else {
+            node.left_child
+        };
+
+        assert!(
+            Self::borrow(&tree.entries, red_child).metadata == RB_RED,
+            E_RB_NOT_RED_NODE,
+        );
+
+        // get the parent
+        // since index is red, the parent must be black
+        let parent = node.parent;
+        assert!(
+            parent != NULL_INDEX,
+            E_RB_RED_HAS_NO_PARENT,
+        );
+
+        assert!(
+            Self::borrow(&tree.entries, parent).metadata == RB_BLACK,
+            E_RB_RED_HAS_RED_PARENT,
+        );
+
+        let is_index_right = is_right_child(tree, index, parent);
+
+        if (!is_index_right) {
+            // index is the left child of parent
+            //
+            let uncle = Self::borrow(&tree.entries, parent).right_child;
+            if (uncle != NULL_INDEX && Self::borrow(&tree.entries, uncle).metadata == RB_RED) {
+                // case 1, uncle is red
+                // recolor parent, index, and uncle.
+                //
+                //        parent (b)
+                //     /          \
+                //  index (r)     uncle(r)
+                //   /
+                //  rec_child
+                // --------------
+                //        parent (r)
+                //     /          \
+                //  index (b)     uncle(b)
+                //   /
+                //  rec_child (r)
+                Self::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, uncle).metadata = RB_BLACK;
+                parent
+            } else if (!is_right) {
+                // case 2, red_child is left child of index
+                // rotate right at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //       index(r)
+                //       /      \
+                // red_child(r)
+                // ---------------
+                //             index(b)
+                //          /           \
+                //     red_child(r)     parent(r)
+                rotate_right(tree, parent);
+                Self::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                index
+            } else {
+                // case 3, red_child is right child of the index
+                // rotate left at index, the rotate right at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //       index(r)
+                //       /      \
+                //           red_child(r)
+                // ---------------
+                //          red_child(b)
+                //          /           \
+                //     index(r)     parent(r)
+                rotate_left(tree, index);
+                rotate_right(tree, parent);
+                Self::borrow_mut(&mut tree.entries, red_child).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                red_child
+            }
+        } else {
+            let uncle = Self::borrow(&tree.entries, parent).left_child;
+            if (uncle != NULL_INDEX && Self::borrow(&tree.entries, uncle).metadata == RB_RED) {
+                // case 1, uncle is red
+                // recolor parent, index, and uncle.
+                //
+                //        parent (b)
+                //     /          \
+                //  uncle(r)    index (r)
+                //                /
+                //            rec_child
+                // --------------
+                //        parent (r)
+                //     /          \
+                //  uncle(b)     index (b)
+                //                 /
+                //            rec_child (r)
+                Self::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, uncle).metadata = RB_BLACK;
+                parent
+            } else if (is_right) {
+                // case 2, red_child is right child of index
+                // rotate left at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //                    index(r)
+                //                    /      \
+                //                        red_child(r)
+                // ---------------
+                //             index(b)
+                //          /           \
+                //      parent(r)      red_child(r)
+                rotate_left(tree, parent);
+                Self::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                index
+            } else {
+                // case 3, red_child is left child of the index
+                // rotate right at index, the rotate left at parent, recolor parent red, and recolor index black
+                //           parent (b)
+                //         /            \
+                //                   index(r)
+                //       /            /     \
+                //           red_child(r)
+                // ---------------
+                //          red_child(b)
+                //          /           \
+                //     parent(r)       index(r)
+                rotate_right(tree, index);
+                rotate_left(tree, parent);
+                Self::borrow_mut(&mut tree.entries, red_child).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, parent).metadata = RB_RED;
+                red_child
+            }
+        }
+    }
+
+    // update red black tree after a removal of a node.
+    fun rb_update_remove<V>(tree: &mut RedBlackTree<V>, index: u64, is_right: bool, metadata_removed: u8): (bool, u64) {
+        // if the removed node is RED, we are good.
+        if (metadata_removed == RB_RED) {
+            return (false, index)
+        };
+
+        let node = Self::borrow(&tree.entries, index);
+        // get the new child.
+        let child = if (is_right) {
+            node.right_child
+        } else {
+            node.left_child
+        };
+
+        // sibling
+        let w = if (is_right) {
+            node.left_child
+        } else {
+            node.right_child
+        };
+
+        let index_color = node.metadata;
+
+        if (child != NULL_INDEX && Self::borrow(&tree.entries, child).metadata == RB_RED) {
+            Self::borrow_mut(&mut tree.entries, child).metadata = RB_BLACK;
+            return (false, index)
+        };
+
+        // Now child is either black or null.
+        // recall a black node is removed from child side.
+        // so the sibling must has at least one black node.
+        // therefore sibling must exist.
+        // w is sibling
+
+        assert!(
+            w != NULL_INDEX,
+            E_RB_SIBLING_NOT_EXIST,
+        );
+        if (!is_right) {
+            // if sibling (w) is red
+            // rotate left at index.
+            //                index (b)
+            //            /            \
+            // child (null or b)        sibling (r)
+            //                          /      \
+            //                         B(b)     D(b)
+            // ---------------
+            //              sibling (b)
+            //             /           \
+            //          index(r)     D(b)
+            //          /         \
+            //   child(null or b) B(b)
+            let sibling_color = Self::borrow(&tree.entries, w).metadata;
+            if (sibling_color == RB_RED) {
+                assert!(
+                    index_color == RB_BLACK,
+                    E_RB_RED_HAS_RED_PARENT,
+                );
+
+                rotate_left(tree, index);
+                Self::borrow_mut(&mut tree.entries, w).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_RED;
+                index_color = RB_RED;
+
+                w = Self::borrow(&tree.entries, index).right_child;
+                assert!(
+                    Self::borrow(&tree.entries, w).metadata == RB_BLACK,
+                    E_RB_SIBLING_FAIL_BLACK,
+                );
+            };
+
+            // Now both siblings are black
+            let w_node = Self::borrow(&tree.entries, w);
+            let w_left = w_node.left_child;
+            let w_right = w_node.right_child;
+            let w_left_not_red = w_left == NULL_INDEX || Self::borrow(&tree.entries, w_left).metadata == RB_BLACK;
+            let w_right_not_red = w_right == NULL_INDEX || Self::borrow(&tree.entries, w_right).metadata == RB_BLACK;
+            if (w_left_not_red && w_right_not_red) {
+                // case 1, if both of w's child are not red, color it red
+                //            index
+                //           /     \
+                //         child   w (b)
+                Self::borrow_mut(&mut tree.entries, w).metadata = RB_RED;
+                (true, Self::borrow(&tree.entries, index).parent)
+            } else if (!w_right_not_red) {
+                // case 2, w's right child is red, left rotate at index
+                //           index
+                //         /       \
+                //      child     w(b)
+                //                /  \
+                //               E   D(r)
+                // ----------------
+                //           w ()
+                //         /       \
+                //     index(b)   D(b)
+                //      /    \
+                //    child  E
+                rotate_left(tree, index);
+                Self::borrow_mut(&mut tree.entries, w).metadata = index_color;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, w_right).metadata = RB_BLACK;
+                (false, index)
+            } else {
+                // case 3, w's left child is red,
+                // rotate right at w
+                // then treat as case 2, rotate left at index
+                //           index
+                //          /      \
+                //        child       w(b)
+                //                /    \
+                //              wl(r)   D
+                // ---
+                //            index
+                //          /       \
+                //       child     wl(b)
+                //                    \
+                //                   w(r)
+                //                      \
+                //                      D
+                // ---
+                //            wl ()
+                //          /       \
+                //       index (b)  w(b)
+                //      /             \
+                //   child              D
+                rotate_right(tree, w);
+                rotate_left(tree, index);
+                Self::borrow_mut(&mut tree.entries, w_left).metadata = index_color;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                (false, index)
+            }
+        } else {
+            // if sibling (w) is red
+            // rotate right at index.
+            //                index (b)
+            //            /            \
+            //       sibling (r)     child (null or b)
+            //        /      \
+            //      B(b)     D(b)
+            // ---------------
+            //              sibling (b)
+            //             /           \
+            //         B(b)           index(r)
+            //                        /      \
+            //                      D(b)    child(null or b)
+             let sibling_color = Self::borrow(&tree.entries, w).metadata;
+             if (sibling_color == RB_RED) {
+                assert!(
+                    index_color == RB_BLACK,
+                    E_RB_RED_HAS_RED_PARENT,
+                );
+
+                rotate_right(tree, index);
+                Self::borrow_mut(&mut tree.entries, w).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_RED;
+                index_color = RB_RED;
+
+                w = Self::borrow(&tree.entries, index).left_child;
+
+                assert!(
+                    Self::borrow(&tree.entries, w).metadata == RB_BLACK,
+                    E_RB_SIBLING_FAIL_BLACK,
+                );
+             };
+
+            // Now both siblings are black
+            let w_node = Self::borrow(&tree.entries, w);
+            let w_left = w_node.left_child;
+            let w_right = w_node.right_child;
+            let w_left_not_red = w_left == NULL_INDEX || Self::borrow(&tree.entries, w_left).metadata == RB_BLACK;
+            let w_right_not_red = w_right == NULL_INDEX || Self::borrow(&tree.entries, w_right).metadata == RB_BLACK;
+            if (w_left_not_red && w_right_not_red) {
+                // case 1, if both of w's child are not red, color it red
+                //            index
+                //           /     \
+                //        w (b)    child
+                Self::borrow_mut(&mut tree.entries, w).metadata = RB_RED;
+                (true, Self::borrow(&tree.entries, index).parent)
+            } else if (!w_left_not_red) {
+                // case 2, w's left child is red, right rotate at index
+                //           index
+                //         /       \
+                //      w(b)       child
+                //     /  \
+                //   D(r)  E
+                // ----------------
+                //           w ()
+                //         /       \
+                //      D(b)      index(b)
+                //                /   \
+                //               E   child
+                rotate_right(tree, index);
+                Self::borrow_mut(&mut tree.entries, w).metadata = index_color;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                Self::borrow_mut(&mut tree.entries, w_left).metadata = RB_BLACK;
+                (false, index)
+            } else {
+                // case 3, w's right child is red,
+                // rotate left at w
+                // then treat as case 2, rotate right at index
+                //           index
+                //          /      \
+                //       w(b)      child
+                //     /    \
+                //    D     wr(r)
+                // ---
+                //            index
+                //          /       \
+                //        wr(b)     child
+                //       /
+                //     w(r)
+                //    /
+                //   D
+                // ---
+                //            wr ()
+                //          /       \
+                //       w (b)   index(b)
+                //      /             \
+                //    D               child
+                rotate_left(tree, w);
+                rotate_right(tree, index);
+                Self::borrow_mut(&mut tree.entries, w_right).metadata = index_color;
+                Self::borrow_mut(&mut tree.entries, index).metadata = RB_BLACK;
+                (false, index)
+            }
+        }
+    }
+
+    #[test]
+    fun test_bounds() {
+        let tree = new<u128>();
+        assert!(lower_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(upper_bound(&tree, 5) == NULL_INDEX, 0);
+        assert!(floor(&tree, 5) == NULL_INDEX, 0);
+        assert!(ceiling(&tree, 5) == NULL_INDEX, 0);
+
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 3, 8, 13, ..., 98 out of order.
+            let v = ((idx * 7) % 20) * 5 + 3;
+            let index = insert_and_get_index(&mut tree, v, v);
+            assert!(find(&tree, v) == index, (v as u64));
+            idx = idx + 1;
+        };
+
+        let k: u128 = 0;
+        while (k < 110) {
+            let expected_lower = NULL_INDEX;
+            let expected_upper = NULL_INDEX;
+            let expected_floor = NULL_INDEX;
+            let iter = get_min_index(&tree);
+            while (iter != NULL_INDEX) {
+                let (key, _) = borrow_at_index(&tree, iter);
+                if (key <= k) {
+                    expected_floor = iter;
+                };
+                if (key >= k && expected_lower == NULL_INDEX) {
+                    expected_lower = iter;
+                };
+                if (key > k && expected_upper == NULL_INDEX) {
+                    expected_upper = iter;
+                };
+                iter = next_in_order(&tree, iter);
+            };
+
+            assert!(lower_bound(&tree, k) == expected_lower, (k as u64));
+            assert!(upper_bound(&tree, k) == expected_upper, (k as u64));
+            assert!(floor(&tree, k) == expected_floor, (k as u64));
+            assert!(ceiling(&tree, k) == expected_lower, (k as u64));
+            k = k + 1;
+        };
+
+        while (!empty(&tree)) {
+            let index = get_min_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_stable_index() {
+        let tree = new<u128>();
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 0, 1, ..., 19 out of order.
+            let v = (idx * 7) % 20;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let indices = vector::empty<u64>();
+        let idx: u128 = 0;
+        while (idx < 20) {
+            vector::push_back(&mut indices, find(&tree, idx));
+            idx = idx + 1;
+        };
+
+        let removed = vector<u128>[3, 10, 0, 19, 7];
+        let i = 0;
+        while (i < vector::length(&removed)) {
+            let key = *vector::borrow(&removed, i);
+            remove(&mut tree, *vector::borrow(&indices, (key as u64)));
+            i = i + 1;
+        };
+        assert!(size(&tree) == 15, size(&tree));
+
+        // the other elements are still at their indices.
+        let idx: u128 = 0;
+        while (idx < 20) {
+            let expected = if (vector::contains(&removed, &idx)) {
+                NULL_INDEX
+            } else {
+                *vector::borrow(&indices, (idx as u64))
+            };
+            assert!(find(&tree, idx) == expected, (idx as u64));
+            idx = idx + 1;
+        };
+
+        // the last vacated slot is reused first.
+        assert!(insert_and_get_index(&mut tree, 25, 25) == *vector::borrow(&indices, 7), 0);
+
+        compact(&mut tree);
+        assert!(capacity(&tree.entries) == 16, capacity(&tree.entries));
+        let count = 0;
+        let iter = get_min_index(&tree);
+        let last_key: u128 = 0;
+        while (iter != NULL_INDEX) {
+            assert!(iter < 16, iter);
+            let (key, value) = borrow_at_index(&tree, iter);
+            assert!(key == *value, count);
+            assert!(count == 0 || key > last_key, count);
+            assert!(find(&tree, key) == iter, count);
+            last_key = key;
+            count = count + 1;
+            iter = next_in_order(&tree, iter);
+        };
+        assert!(count == 16, count);
+        let (max_key, _) = borrow_at_index(&tree, get_max_index(&tree));
+        assert!(max_key == 25, 0);
+        check_subtree_size(&tree, tree.root);
+
+        while (!empty(&tree)) {
+            let index = get_max_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_compact_drops_vacant_slots() {
+        let tree = new<u128>();
+        // 10 to 16 are at 0 to 6, and 1, 2, 3 are at 7, 8, 9.
+        let keys = vector<u128>[10, 11, 12, 13, 14, 15, 16, 1, 2, 3];
+        let i = 0;
+        while (i < vector::length(&keys)) {
+            let key = *vector::borrow(&keys, i);
+            assert!(insert_and_get_index(&mut tree, key, key) == i, i);
+            i = i + 1;
+        };
+        let key: u128 = 10;
+        while (key < 17) {
+            let index = find(&tree, key);
+            remove(&mut tree, index);
+            key = key + 1;
+        };
+
+        // 2 at 8 is the parent of 3 at 9. Moving 3 first drops the vacant slots 6 to 3 from the vacant list,
+        // and the parent must still be a valid index.
+        assert!(Self::borrow(&tree.entries, 9).parent == 8, 0);
+        compact(&mut tree);
+        assert!(capacity(&tree.entries) == 3, capacity(&tree.entries));
+        let key: u128 = 1;
+        let iter = get_min_index(&tree);
+        while (key < 4) {
+            assert!(iter < 3, iter);
+            assert!(find(&tree, key) == iter, (key as u64));
+            key = key + 1;
+            iter = next_in_order(&tree, iter);
+        };
+        assert!(iter == NULL_INDEX, iter);
+        check_subtree_size(&tree, tree.root);
+
+        while (!empty(&tree)) {
+            let index = get_max_index(&tree);
+            remove(&mut tree, index);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_min_iter_redblack() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 0;
+        let iter = get_min_index(&tree);
+        while (idx < 20) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx, (v as u64));
+            idx = idx + 1;
+            iter = next_in_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let min_index = get_min_index(&tree);
+        remove(&mut tree, min_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let min_index = get_min_index(&tree);
+            let (key, value) = borrow_at_index(&tree, min_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, min_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_max_iter_redblack() {
+        let tree = new<u128>();
+        let idx: u128 = 9;
+        while (idx > 0) {
+            let v = idx * 2;
+            insert(&mut tree, v, v);
+            idx = idx - 1;
+        };
+
+        insert(&mut tree, 0, 0);
+
+        while (idx < 10) {
+            let v = idx * 2 + 1;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        let idx = 0;
+        while (idx < 20) {
+            let v = find(&tree, idx);
+            idx = idx + 1;
+            assert!(v != NULL_INDEX, (idx as u64));
+        };
+
+        let idx: u128 = 20;
+        let iter = get_max_index(&tree);
+        while (idx > 0) {
+            let (_, v) = borrow_at_index(&tree, iter);
+            let v = *v;
+            assert!(v == idx - 1, (v as u64));
+            idx = idx - 1;
+            iter = next_in_reverse_order(&tree, iter);
+        };
+
+        assert!(iter == NULL_INDEX, iter);
+        std::debug::print(&tree.entries);
+        let max_index = get_max_index(&tree);
+        remove(&mut tree, max_index);
+        std::debug::print(&tree.entries);
+        let i = find(&tree, 4);
+        remove(&mut tree, i);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 12);
+        std::debug::print(&tree.entries);
+        remove(&mut tree, 13);
+        while(!empty(&tree)) {
+            std::debug::print(&tree.entries);
+
+            let max_index = get_max_index(&tree);
+            let (key, value) = borrow_at_index(&tree, max_index);
+            let value = *value;
+            assert!(key == value, (key as u64));
+            remove(&mut tree, max_index);
+        };
+
+        std::debug::print(&tree.entries);
+
+        destroy_empty(tree);
+    }
+
+    #[test]
+    fun test_rank() {
+        let tree = new<u128>();
+        let idx: u128 = 0;
+        while (idx < 20) {
+            // insert 0, 2, 4, ..., 38 out of order.
+            let v = ((idx * 7) % 20) * 2;
+            insert(&mut tree, v, v);
+            idx = idx + 1;
+        };
+
+        check_subtree_size(&tree, tree.root);
+
+        let k = 0;
+        while (k < 20) {
+            let index = select(&tree, k);
+            let (key, _) = borrow_at_index(&tree, index);
+            assert!(key == (k as u128) * 2, k);
+            assert!(rank(&tree, key) == k, k);
+            // odd keys are not in the tree.
+            assert!(rank(&tree, key + 1) == k + 1, k);
+            k = k + 1;
+        };
+
+        assert!(count_in_range(&tree, 3, 11) == 4, 0);
+        assert!(count_in_range(&tree, 4, 10) == 4, 1);
+        assert!(count_in_range(&tree, 11, 3) == 0, 2);
+        assert!(count_in_range(&tree, 0, 100) == 20, 3);
+
+        while (!empty(&tree)) {
+            let index = select(&tree, size(&tree) / 2);
+            remove(&mut tree, index);
+            check_subtree_size(&tree, tree.root);
+        };
+
+        destroy_empty(tree);
+    }
+
+    #[test_only]
+    fun check_subtree_size<V>(tree: &RedBlackTree<V>, index: u64): u64 {
+        if (index == NULL_INDEX) {
+            return 0
+        };
+        let node = Self::borrow(&tree.entries, index);
+        let expected = check_subtree_size(tree, node.left_child) + check_subtree_size(tree, node.right_child) + 1;
+        assert!(node.size == expected, index);
+        expected
+    }
+}
@@ -0,0 +1,517 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// Skip List with up to 16 levels.
+module container::skip_list {
+    use std::bcs;
+    use std::hash;
+    use std::vector::{Self, swap, push_back, pop_back};
+
+    const E_INVALID_ARGUMENT: u64 = 1;
+    const E_EMPTY_TREE: u64 = 2;
+    const E_KEY_ALREADY_EXIST: u64 = 4;
+    const E_INDEX_OUT_OF_RANGE: u64 = 5;
+    const E_CANNOT_DESTRORY_NON_EMPTY: u64 = 7;
+    const E_EXCEED_CAPACITY: u64 = 8;
+
+    // NULL_INDEX is 1 << 64 - 1 (all 1s for the 64 bits);
+    const NULL_INDEX: u64 = 18446744073709551615;
+
+    const MAX_CAPACITY: u64 = 18446744073709551614; // NULL_INDEX - 1
+
+    // MAX_LEVEL is the max number of levels of the skip list.
+    const MAX_LEVEL: u64 = 16;
+
+    // check if the index is NULL_INDEX
+    public fun is_null_index(index: u64): bool {
+        index == NULL_INDEX
+    }
+
+    public fun null_index_value(): u64 {
+        NULL_INDEX
+    }
+
+    /// Node is a node in the skip list.
+    struct Node<V> has store, copy, drop {
+        key: u128,
+        value: V,
+        // next[i] is the next node on level i, and the number of levels of the node is the length of next.
+        next: vector<u64>,
+        // previous node on level 0.
+        prev: u64,
+    }
+
+    /// SkipList is a skip list.
+    struct SkipList<V> has store, copy, drop {
+        // head[i] is the first node on level i.
+        head: vector<u64>,
+        // tail is the last node.
+        tail: u64,
+        entries: vector<Node<V>>,
+    }
+
+    public fun new<V>(): SkipList<V> {
+        let head = vector::empty<u64>();
+        let i = 0;
+        while (i < MAX_LEVEL) {
+            vector::push_back(&mut head, NULL_INDEX);
+            i = i + 1;
+        };
+
+        SkipList<V> {
+            head,
+            tail: NULL_INDEX,
+            entries: vector::empty(),
+        }
+    }
+
+    ///////////////
+    // Accessors //
+    ///////////////
+
+    /// find returns the element index in the skip list, or NULL_INDEX if not found.
+    public fun find<V>(list: &SkipList<V>, key: u128): u64 {
+        let index = lower_bound(list, key);
+        if (index != NULL_INDEX && vector::borrow(&list.entries, index).key == key) {
+            index
+        } else {
+            NULL_INDEX
+        }
+    }
+
+    /// lower_bound returns the index of the first element with key greater than or equal to the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun lower_bound<V>(list: &SkipList<V>, key: u128): u64 {
+        let current = NULL_INDEX;
+        let level = MAX_LEVEL;
+        while (level > 0) {
+            level = level - 1;
+            loop {
+                let next = next_at(list, current, level);
+                if (next == NULL_INDEX || vector::borrow(&list.entries, next).key >= key) {
+                    break
+                };
+                current = next;
+            };
+        };
+
+        next_at(list, current, 0)
+    }
+
+    /// upper_bound returns the index of the first element with key greater than the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun upper_bound<V>(list: &SkipList<V>, key: u128): u64 {
+        let index = floor(list, key);
+        next_at(list, index, 0)
+    }
+
+    /// floor returns the index of the last element with key less than or equal to the input key,
+    /// or NULL_INDEX if there is no such element.
+    public fun floor<V>(list: &SkipList<V>, key: u128): u64 {
+        let current = NULL_INDEX;
+        let level = MAX_LEVEL;
+        while (level > 0) {
+            level = level - 1;
+            loop {
+                let next = next_at(list, current, level);
+                if (next == NULL_INDEX || vector::borrow(&list.entries, next).key > key) {
+                    break
+                };
+                current = next;
+            };
+        };
+
+        current
+    }
+
+    /// ceiling returns the index of the first element with key greater than or equal to the input key,
+    /// or NULL_INDEX if there is no such element. Same as lower_bound.
+    public fun ceiling<V>(list: &SkipList<V>, key: u128): u64 {
+        lower_bound(list, key)
+    }
+
+    /// borrow returns a reference to the element with its key at the given index
+    public fun borrow_at_index<V>(list: &SkipList<V>, index: u64): (u128, &V) {
+        let entry = vector::borrow(&list.entries, index);
+        (entry.key, &entry.value)
+    }
+
+    /// borrow_mut returns a mutable reference to the element with its key at the given index
+    public fun borrow_at_index_mut<V>(list: &mut SkipList<V>, index: u64): (u128, &mut V) {
+        let entry = vector::borrow_mut(&mut list.entries, index);
+        (entry.key, &mut entry.value)
+    }
+
+    /// size returns the number of elements in the SkipList.
+    public fun size<V>(list: &SkipList<V>): u64 {
+        vector::length(&list.entries)
+    }
+
+    /// empty returns true if the SkipList is empty.
+    public fun empty<V>(list: &SkipList<V>): bool {
+        vector::length(&list.entries) == 0
+    }
+
+    /// get index of the min of the skip list.
+    public fun get_min_index<V>(list: &SkipList<V>): u64 {
+        let current = *vector::borrow(&list.head, 0);
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// get index of the max of the skip list.
+    public fun get_max_index<V>(list: &SkipList<V>): u64 {
+        let current = list.tail;
+        assert!(current != NULL_INDEX, E_EMPTY_TREE);
+        current
+    }
+
+    /// find next value in order (the key is increasing)
+    public fun next_in_order<V>(list: &SkipList<V>, index: u64): u64 {
+        *vector::borrow(&vector::borrow(&list.entries, index).next, 0)
+    }
+
+    /// find next value in reverse order (the key is decreasing)
+    public fun next_in_reverse_order<V>(list: &SkipList<V>, index: u64): u64 {
+        vector::borrow(&list.entries, index).prev
+    }
+
+    /// level_of_key derives the number of levels for the key from the sha3-256 hash of its bcs bytes.
+    /// Each level is added with probability 1/2, up to MAX_LEVEL.
+    public fun level_of_key(key: u128): u64 {
+        let digest = hash::sha3_256(bcs::to_bytes(&key));
+        let level = 1;
+        while (level < MAX_LEVEL) {
+            let bit = level - 1;
+            let byte = *vector::borrow(&digest, bit / 8);
+            if (((byte >> ((bit % 8) as u8)) & 1) == 0) {
+                break
+            };
+            level = level + 1;
+        };
+
+        level
+    }
+
+    /// next_at returns the next node of the node at index on the level. index can be NULL_INDEX for the head.
+    fun next_at<V>(list: &SkipList<V>, index: u64, level: u64): u64 {
+        if (index == NULL_INDEX) {
+            *vector::borrow(&list.head, level)
+        } else {
+            *vector::borrow(&vector::borrow(&list.entries, index).next, level)
+        }
+    }
+
+    /// set_next_at sets the next node of the node at index on the level. index can be NULL_INDEX for the head.
+    fun set_next_at<V>(list: &mut SkipList<V>, index: u64, level: u64, next: u64) {
+        if (index == NULL_INDEX) {
+            *vector::borrow_mut(&mut list.head, level) = next;
+        } else {
+            *vector::borrow_mut(&mut vector::borrow_mut(&mut list.entries, index).next, level) = next;
+        };
+    }
+
+    /// find_predecessors returns the last node with key less than the input key on each level,
+    /// NULL_INDEX if there is no such node on the level.
+    fun find_predecessors<V>(list: &SkipList<V>, key: u128): vector<u64> {
+        let predecessors = vector::empty<u64>();
+        let current = NULL_INDEX;
+        let level = MAX_LEVEL;
+        while (level > 0) {
+            level = level - 1;
+            loop {
+                let next = next_at(list, current, level);
+                if (next == NULL_INDEX || vector::borrow(&list.entries, next).key >= key) {
+                    break
+                };
+                current = next;
+            };
+            vector::push_back(&mut predecessors, current);
+        };
+        vector::reverse(&mut predecessors);
+
+        predecessors
+    }
+
+    ///////////////
+    // Modifiers //
+    ///////////////
+
+    /// insert puts the value keyed at the input key into the skip list, the number of levels is derived from the key.
+    /// aborts if the key is already in the skip list.
+    public fun insert<V>(list: &mut SkipList<V>, key: u128, value: V) {
+        insert_with_level(list, key, value, level_of_key(key))
+    }
+
+    /// insert_and_get_index puts the value keyed at the input key into the skip list, and returns the index of the new element.
+    /// aborts if the key is already in the skip list.
+    public fun insert_and_get_index<V>(list: &mut SkipList<V>, key: u128, value: V): u64 {
+        insert_with_level_and_get_index(list, key, value, level_of_key(key))
+    }
+
+    /// insert_with_level puts the value keyed at the input key into the skip list with the given number of levels,
+    /// which must be between 1 and MAX_LEVEL.
+    /// aborts if the key is already in the skip list.
+    public fun insert_with_level<V>(list: &mut SkipList<V>, key: u128, value: V, level: u64) {
+        insert_with_level_and_get_index(list, key, value, level);
+    }
+
+    /// insert_with_level_and_get_index is insert_with_level that also returns the index of the new element.
+    public fun insert_with_level_and_get_index<V>(list: &mut SkipList<V>, key: u128, value: V, level: u64): u64 {
+        assert!(level > 0 && level <= MAX_LEVEL, E_INVALID_ARGUMENT);
+
+        let index = vector::length(&list.entries);
+        assert!(
+            index < MAX_CAPACITY,
+            E_EXCEED_CAPACITY
+        );
+
+        let predecessors = find_predecessors(list, key);
+        let prev = *vector::borrow(&predecessors, 0);
+        let next_index = next_at(list, prev, 0);
+        assert!(
+            next_index == NULL_INDEX || vector::borrow(&list.entries, next_index).key != key,
+            E_KEY_ALREADY_EXIST,
+        );
+
+        let next = vector::empty<u64>();
+        let i = 0;
+        while (i < level) {
+            let predecessor = *vector::borrow(&predecessors, i);
+            vector::push_back(&mut next, next_at(list, predecessor, i));
+            set_next_at(list, predecessor, i, index);
+            i = i + 1;
+        };
+
+        if (next_index == NULL_INDEX) {
+            list.tail = index;
+        } else {
+            vector::borrow_mut(&mut list.entries, next_index).prev = index;
+        };
+
+        push_back(&mut list.entries, Node {
+            key,
+            value,
+            next,
+            prev,
+        });
+
+        index
+    }
+
+    /// remove deletes and returns the element from the skip list.
+    /// element is first swapped to the end of the container, then popped out.
+    public fun remove<V>(list: &mut SkipList<V>, index: u64): V {
+        let key = vector::borrow(&list.entries, index).key;
+        let predecessors = find_predecessors(list, key);
+        let node = vector::borrow(&list.entries, index);
+        let next = node.next;
+        let prev = node.prev;
+        let i = 0;
+        let level = vector::length(&next);
+        while (i < level) {
+            set_next_at(list, *vector::borrow(&predecessors, i), i, *vector::borrow(&next, i));
+            i = i + 1;
+        };
+        let next_index = *vector::borrow(&next, 0);
+        if (next_index == NULL_INDEX) {
+            list.tail = prev;
+        } else {
+            vector::borrow_mut(&mut list.entries, next_index).prev = prev;
+        };
+
+        // swap the element to be removed with the last element
+        let last_index = vector::length(&list.entries) - 1;
+        if (index != last_index) {
+            let last = vector::borrow(&list.entries, last_index);
+            let next = last.next;
+            let predecessors = find_predecessors(list, last.key);
+            let i = 0;
+            let level = vector::length(&next);
+            while (i < level) {
+                set_next_at(list, *vector::borrow(&predecessors, i), i, index);
+                i = i + 1;
+            };
+            let next_index = *vector::borrow(&next, 0);
+            if (next_index == NULL_INDEX) {
+                list.tail = index;
+            } else {
+                vector::borrow_mut(&mut list.entries, next_index).prev = index;
+            };
+            swap(&mut list.entries, index, last_index);
+        };
+
+        // pop
+        let Node {
+            key: _,
+            value,
+            next: _,
+            prev: _,
+        } = pop_back(&mut list.entries);
+
+        value
+    }
+
+    /// destroys the skip list if it's empty.
+    public fun destroy_empty<V>(list: SkipList<V>) {
+        assert!(vector::length(&list.entries) == 0, E_CANNOT_DESTRORY_NON_EMPTY);
+
+        let SkipList<V> {
+            head: _,
+            tail: _,
+            entries,
+        } = list;
+
+        vector::destroy_empty(entries);
+    }
+
+    #[test_only]
+    fun check_list<V>(list: &SkipList<V>) {
+        let level = 0;
+        while (level < MAX_LEVEL) {
+            let count = 0;
+            let prev = NULL_INDEX;
+            let current = *vector::borrow(&list.head, level);
+            while (current != NULL_INDEX) {
+                let node = vector::borrow(&list.entries, current);
+                assert!(vector::length(&node.next) > level, current);
+                if (prev != NULL_INDEX) {
+                    assert!(vector::borrow(&list.entries, prev).key < node.key, current);
+                };
+                if (level == 0) {
+                    assert!(node.prev == prev, current);
+                };
+                count = count + 1;
+                prev = current;
+                current = *vector::borrow(&node.next, level);
+            };
+
+            // every node with more than level levels is on the level.
+            let expected = 0;
+            let i = 0;
+            while (i < vector::length(&list.entries)) {
+                if (vector::length(&vector::borrow(&list.entries, i).next) > level) {
+                    expected = expected + 1;
+                };
+                i = i + 1;
+            };
+            assert!(count == expected, level);
+            if (level == 0) {
+                assert!(list.tail == prev, prev);
+            };
+
+            level = level + 1;
+        };
+    }
+
+    #[test]
+    fun test_skip_list() {
+        let list = new<u128>();
+        assert!(find(&list, 5) == NULL_INDEX, 0);
+        assert!(lower_bound(&list, 5) == NULL_INDEX, 0);
+
+        // insert 0 to 199 out of order.
+        let i = 0;
+        while (i < 200) {
+            let key = (((i * 37) % 200) as u128);
+            assert!(insert_and_get_index(&mut list, key, key) == i, i);
+            check_list(&list);
+            i = i + 1;
+        };
+        assert!(size(&list) == 200, size(&list));
+
+        let index = get_min_index(&list);
+        let i = 0;
+        while (index != NULL_INDEX) {
+            let (key, value) = borrow_at_index(&list, index);
+            assert!(key == (i as u128), i);
+            assert!(*value == key, i);
+            assert!(vector::length(&vector::borrow(&list.entries, index).next) == level_of_key(key), i);
+            index = next_in_order(&list, index);
+            i = i + 1;
+        };
+        assert!(i == 200, i);
+
+        let index = get_max_index(&list);
+        while (index != NULL_INDEX) {
+            i = i - 1;
+            let (key, _) = borrow_at_index(&list, index);
+            assert!(key == (i as u128), i);
+            index = next_in_reverse_order(&list, index);
+        };
+        assert!(i == 0, i);
+
+        // remove the even keys.
+        let i = 0;
+        while (i < 200) {
+            let key = (((i * 37) % 200) as u128);
+            if (key % 2 == 0) {
+                let index = find(&list, key);
+                assert!(remove(&mut list, index) == key, i);
+                assert!(find(&list, key) == NULL_INDEX, i);
+                check_list(&list);
+            };
+            i = i + 1;
+        };
+        assert!(size(&list) == 100, size(&list));
+
+        let i = 0;
+        while (i < 200) {
+            let key = (i as u128);
+            let expected_lower = if (i % 2 == 1) {
+                find(&list, key)
+            } else {
+                find(&list, key + 1)
+            };
+            let expected_upper = if (i % 2 == 1) {
+                if (i + 2 < 200) { find(&list, key + 2) } else { NULL_INDEX }
+            } else {
+                find(&list, key + 1)
+            };
+            let expected_floor = if (i % 2 == 1) {
+                find(&list, key)
+            } else if (i > 0) {
+                find(&list, key - 1)
+            } else {
+                NULL_INDEX
+            };
+            assert!(lower_bound(&list, key) == expected_lower, i);
+            assert!(upper_bound(&list, key) == expected_upper, i);
+            assert!(floor(&list, key) == expected_floor, i);
+            assert!(ceiling(&list, key) == expected_lower, i);
+            i = i + 1;
+        };
+
+        while (!empty(&list)) {
+            let index = get_max_index(&list);
+            remove(&mut list, index);
+            check_list(&list);
+        };
+        destroy_empty(list);
+    }
+
+    #[test]
+    fun test_skip_list_with_level() {
+        let list = new<u128>();
+        insert_with_level(&mut list, 5, 5, MAX_LEVEL);
+        insert_with_level(&mut list, 3, 3, 1);
+        insert_with_level(&mut list, 4, 4, 1);
+        check_list(&list);
+        assert!(*vector::borrow(&list.head, MAX_LEVEL - 1) == 0, 0);
+        assert!(*vector::borrow(&list.head, 0) == 1, 1);
+        assert!(list.tail == 0, 2);
+
+        remove(&mut list, 0);
+        check_list(&list);
+        assert!(*vector::borrow(&list.head, MAX_LEVEL - 1) == NULL_INDEX, 3);
+        // 4 is moved from index 2 to 0.
+        assert!(list.tail == 0, 4);
+        assert!(vector::borrow(&list.entries, 0).key == 4, 5);
+
+        while (!empty(&list)) {
+            let index = get_min_index(&list);
+            remove(&mut list, index);
+        };
+
+        destroy_empty(list);
+    }
+}
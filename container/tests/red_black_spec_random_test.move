@@ -0,0 +1,2091 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// 1000 random operations on RedBlackTree of red_black_spec with seed 1.
+// The expected results are computed by the reference model of RedBlackTree in the verifier.
+// The abort code of a failed assert is the number of the operation.
+#[test_only]
+module container::red_black_spec_random_test {
+    use std::vector;
+    use container::red_black_spec::{Self, RedBlackTree};
+
+    /// check the size, min, max, and the indices, keys and values of the tree in order.
+    fun check(tree: &RedBlackTree<u64>, indices: vector<u64>, key0: vector<u128>, key1: vector<u128>, values: vector<u64>, code: u64) {
+        let size = vector::length(&indices);
+        assert!(red_black_spec::size(tree) == size, code);
+        if (size == 0) {
+            return
+        };
+        assert!(red_black_spec::get_min_index(tree) == *vector::borrow(&indices, 0), code);
+        assert!(red_black_spec::get_max_index(tree) == *vector::borrow(&indices, size - 1), code);
+
+        let iter = red_black_spec::get_min_index(tree);
+        let i = 0;
+        while (i < size) {
+            assert!(iter == *vector::borrow(&indices, i), code);
+            let (key0_at, key1_at, value) = red_black_spec::borrow_at_index(tree, iter);
+            assert!(key0_at == *vector::borrow(&key0, i), code);
+            assert!(key1_at == *vector::borrow(&key1, i), code);
+            assert!(*value == *vector::borrow(&values, i), code);
+            iter = red_black_spec::next_in_order(tree, iter);
+            i = i + 1;
+        };
+        assert!(iter == red_black_spec::null_index_value(), code);
+    }
+
+    fun ops_0(tree: &mut RedBlackTree<u64>) {
+        assert!(red_black_spec::insert_and_get_index(tree, 2019727887, 1597969999, 0) == 0, 0);
+        assert!(red_black_spec::find(tree, 2019727887, 1597969999) == 0, 1);
+        assert!(red_black_spec::insert_and_get_index(tree, 2288438073, 2031484958, 2) == 1, 2);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2019727887 && key1 == 1597969999 && value == 0, 3);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2288438073 && key1 == 2031484958 && value == 2, 4);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2607611810, 3125901622, 5) == 0, 5);
+        assert!(red_black_spec::find(tree, 2830508376, 2092150027) == red_black_spec::null_index_value(), 6);
+        assert!(red_black_spec::insert_and_get_index(tree, 2755294859, 2085489512, 7) == 1, 7);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 2755294859 && key1 == 2085489512 && value == 7, 8);
+        };
+        assert!(red_black_spec::find(tree, 2607611810, 3125901622) == 0, 9);
+        assert!(red_black_spec::find(tree, 2607611810, 3125901622) == 0, 10);
+        assert!(red_black_spec::find(tree, 2607611810, 3125901622) == 0, 11);
+        assert!(red_black_spec::find(tree, 3643676663, 2959661531) == red_black_spec::null_index_value(), 12);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2607611810 && key1 == 3125901622 && value == 5, 13);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1304066831, 2644629483, 14) == 0, 14);
+        assert!(red_black_spec::find(tree, 2318109004, 4165004363) == red_black_spec::null_index_value(), 15);
+        assert!(red_black_spec::insert_and_get_index(tree, 2274444279, 4104515131, 16) == 1, 16);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 1304066831 && key1 == 2644629483 && value == 14, 17);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1162003090, 2432798076, 18) == 1, 18);
+        assert!(red_black_spec::insert_and_get_index(tree, 2745574211, 2978395423, 19) == 2, 19);
+        assert!(red_black_spec::insert_and_get_index(tree, 3286907795, 2482585395, 20) == 3, 20);
+        assert!(red_black_spec::insert_and_get_index(tree, 605764324, 424111158, 21) == 4, 21);
+        assert!(red_black_spec::insert_and_get_index(tree, 776971353, 379089909, 22) == 5, 22);
+        assert!(red_black_spec::insert_and_get_index(tree, 2785527369, 1478421305, 23) == 6, 23);
+        assert!(red_black_spec::find(tree, 209282199, 1864800808) == red_black_spec::null_index_value(), 24);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 605764324 && key1 == 424111158 && value == 21, 25);
+        };
+        assert!(red_black_spec::find(tree, 1162003090, 2432798076) == 1, 26);
+        assert!(red_black_spec::insert_and_get_index(tree, 2003272451, 1454009222, 27) == 6, 27);
+        assert!(red_black_spec::find(tree, 1720252605, 1050259624) == red_black_spec::null_index_value(), 28);
+        assert!(red_black_spec::insert_and_get_index(tree, 392828266, 3850586288, 29) == 7, 29);
+        assert!(red_black_spec::find(tree, 2745574211, 2978395423) == 2, 30);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 2745574211 && key1 == 2978395423 && value == 19, 31);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2274444279 && key1 == 4104515131 && value == 16, 32);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 392828266 && key1 == 3850586288 && value == 29, 33);
+        };
+        assert!(red_black_spec::find(tree, 776971353, 379089909) == 2, 34);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 1162003090 && key1 == 2432798076 && value == 18, 35);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 3286907795 && key1 == 2482585395 && value == 20, 36);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2428054785, 1772327236, 37) == 3, 37);
+        assert!(red_black_spec::insert_and_get_index(tree, 4072362889, 1108760575, 38) == 4, 38);
+        assert!(red_black_spec::insert_and_get_index(tree, 1548653033, 2332215575, 39) == 5, 39);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 1548653033 && key1 == 2332215575 && value == 39, 40);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 2428054785 && key1 == 1772327236 && value == 37, 41);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2656056194, 3188988015, 42) == 4, 42);
+        assert!(red_black_spec::find(tree, 4072362889, 1108760575) == 3, 43);
+        assert!(red_black_spec::insert_and_get_index(tree, 272199843, 703344652, 44) == 5, 44);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 776971353 && key1 == 379089909 && value == 22, 45);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 2785527369 && key1 == 1478421305 && value == 23, 46);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1339189757, 2388591733, 47) == 4, 47);
+        assert!(red_black_spec::find(tree, 2003272451, 1454009222) == 0, 48);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2003272451 && key1 == 1454009222 && value == 27, 49);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3444516746, 1729403804, 50) == 4, 50);
+        assert!(red_black_spec::insert_and_get_index(tree, 2211202239, 1516240635, 51) == 5, 51);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 1339189757 && key1 == 2388591733 && value == 47, 52);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3414142915, 1415067332, 53) == 5, 53);
+        assert!(red_black_spec::insert_and_get_index(tree, 3898552919, 317985421, 54) == 6, 54);
+        assert!(red_black_spec::insert_and_get_index(tree, 3131981374, 1244681733, 55) == 7, 55);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 3414142915 && key1 == 1415067332 && value == 53, 56);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 536212066, 2213529286, 57) == 7, 57);
+        assert!(red_black_spec::find(tree, 531430493, 3243509860) == red_black_spec::null_index_value(), 58);
+        assert!(red_black_spec::find(tree, 3131981374, 1244681733) == 5, 59);
+        assert!(red_black_spec::insert_and_get_index(tree, 65117175, 2736238143, 60) == 8, 60);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 65117175 && key1 == 2736238143 && value == 60, 61);
+        };
+        assert!(red_black_spec::find(tree, 2211202239, 1516240635) == 0, 62);
+        assert!(red_black_spec::insert_and_get_index(tree, 764004384, 4123218895, 63) == 8, 63);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 3898552919 && key1 == 317985421 && value == 54, 64);
+        };
+        assert!(red_black_spec::find(tree, 3444516746, 1729403804) == 4, 65);
+        assert!(red_black_spec::insert_and_get_index(tree, 842277839, 3454190185, 66) == 8, 66);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 272199843 && key1 == 703344652 && value == 44, 67);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1263958076, 3415495579, 68) == 8, 68);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 7);
+            assert!(key0 == 536212066 && key1 == 2213529286 && value == 57, 69);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 3444516746 && key1 == 1729403804 && value == 50, 70);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 4072362889 && key1 == 1108760575 && value == 38, 71);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2211202239 && key1 == 1516240635 && value == 51, 72);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 764004384 && key1 == 4123218895 && value == 63, 73);
+        };
+        assert!(red_black_spec::find(tree, 842277839, 3454190185) == 2, 74);
+        assert!(red_black_spec::insert_and_get_index(tree, 214167822, 253830481, 75) == 4, 75);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 842277839 && key1 == 3454190185 && value == 66, 76);
+        };
+        assert!(red_black_spec::find(tree, 3131981374, 1244681733) == 0, 77);
+        assert!(red_black_spec::find(tree, 1263958076, 3415495579) == 3, 78);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 3131981374 && key1 == 1244681733 && value == 55, 79);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 2656056194 && key1 == 3188988015 && value == 42, 80);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1538389371, 196162024, 81) == 2, 81);
+        assert!(red_black_spec::insert_and_get_index(tree, 27543430, 329186506, 82) == 3, 82);
+        assert!(red_black_spec::insert_and_get_index(tree, 2358003348, 2773153607, 83) == 4, 83);
+        assert!(red_black_spec::insert_and_get_index(tree, 1774796720, 2674673580, 84) == 5, 84);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 1263958076 && key1 == 3415495579 && value == 68, 85);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 1538389371 && key1 == 196162024 && value == 81, 86);
+        };
+        assert!(red_black_spec::find(tree, 3010924213, 2400003416) == red_black_spec::null_index_value(), 87);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 214167822 && key1 == 253830481 && value == 75, 88);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 1774796720 && key1 == 2674673580 && value == 84, 89);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2271879343, 1799432493, 90) == 2, 90);
+        assert!(red_black_spec::find(tree, 2271879343, 1799432493) == 2, 91);
+        assert!(red_black_spec::find(tree, 197821092, 769224282) == red_black_spec::null_index_value(), 92);
+        assert!(red_black_spec::insert_and_get_index(tree, 1534797577, 3956923817, 93) == 3, 93);
+        assert!(red_black_spec::find(tree, 1534797577, 3956923817) == 3, 94);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 1534797577 && key1 == 3956923817 && value == 93, 95);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 199651888, 2955392992, 96) == 3, 96);
+        assert!(red_black_spec::insert_and_get_index(tree, 669769103, 1154067264, 97) == 4, 97);
+        assert!(red_black_spec::find(tree, 27543430, 329186506) == 1, 98);
+        assert!(red_black_spec::insert_and_get_index(tree, 3006881455, 2827658879, 99) == 5, 99);
+        check(
+            tree,
+            vector<u64>[1, 3, 4, 2, 0, 5],
+            vector<u128>[27543430, 199651888, 669769103, 2271879343, 2358003348, 3006881455],
+            vector<u128>[329186506, 2955392992, 1154067264, 1799432493, 2773153607, 2827658879],
+            vector<u64>[82, 96, 97, 90, 83, 99],
+            99,
+        );
+        assert!(red_black_spec::insert_and_get_index(tree, 2039858652, 2629615198, 100) == 6, 100);
+        assert!(red_black_spec::insert_and_get_index(tree, 1072422181, 3786930835, 101) == 7, 101);
+        assert!(red_black_spec::insert_and_get_index(tree, 2190022443, 2630800098, 102) == 8, 102);
+        assert!(red_black_spec::insert_and_get_index(tree, 3068085041, 2843150114, 103) == 9, 103);
+        assert!(red_black_spec::find(tree, 4053262644, 2297446151) == red_black_spec::null_index_value(), 104);
+        assert!(red_black_spec::find(tree, 2358003348, 2773153607) == 0, 105);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 2271879343 && key1 == 1799432493 && value == 90, 106);
+        };
+        assert!(red_black_spec::find(tree, 2303065309, 2302292239) == red_black_spec::null_index_value(), 107);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 7);
+            assert!(key0 == 1072422181 && key1 == 3786930835 && value == 101, 108);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3967476602, 3818717915, 109) == 8, 109);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 2039858652 && key1 == 2629615198 && value == 100, 110);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 161141181, 3245741629, 111) == 8, 111);
+        assert!(red_black_spec::insert_and_get_index(tree, 1351479516, 4193869534, 112) == 9, 112);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 1351479516 && key1 == 4193869534 && value == 112, 113);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2179325181, 2289652712, 114) == 9, 114);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 669769103 && key1 == 1154067264 && value == 97, 115);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 161141181 && key1 == 3245741629 && value == 111, 116);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3940215367, 1404315863, 117) == 8, 117);
+        assert!(red_black_spec::insert_and_get_index(tree, 1420331040, 865175050, 118) == 9, 118);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 2358003348 && key1 == 2773153607 && value == 83, 119);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 3068085041 && key1 == 2843150114 && value == 103, 120);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2812233883, 3312167363, 121) == 8, 121);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 3006881455 && key1 == 2827658879 && value == 99, 122);
+        };
+        assert!(red_black_spec::find(tree, 3020944222, 1374226975) == red_black_spec::null_index_value(), 123);
+        assert!(red_black_spec::find(tree, 4205151718, 2530546145) == red_black_spec::null_index_value(), 124);
+        assert!(red_black_spec::insert_and_get_index(tree, 1700103338, 1409503501, 125) == 8, 125);
+        assert!(red_black_spec::insert_and_get_index(tree, 32601544, 2543930449, 126) == 9, 126);
+        assert!(red_black_spec::insert_and_get_index(tree, 4090475822, 848831358, 127) == 10, 127);
+        assert!(red_black_spec::insert_and_get_index(tree, 92173524, 3088016340, 128) == 11, 128);
+        assert!(red_black_spec::insert_and_get_index(tree, 2894505254, 3997401351, 129) == 12, 129);
+        assert!(red_black_spec::find(tree, 92173524, 3088016340) == 11, 130);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 32601544 && key1 == 2543930449 && value == 126, 131);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 676038151, 2694806313, 132) == 12, 132);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 11);
+            assert!(key0 == 92173524 && key1 == 3088016340 && value == 128, 133);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2362660240, 4060153997, 134) == 12, 134);
+        assert!(red_black_spec::find(tree, 1653324231, 2200192249) == red_black_spec::null_index_value(), 135);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 2894505254 && key1 == 3997401351 && value == 129, 136);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2608448991, 3240243296, 137) == 12, 137);
+        assert!(red_black_spec::insert_and_get_index(tree, 754055166, 3362861525, 138) == 13, 138);
+        assert!(red_black_spec::insert_and_get_index(tree, 3389483515, 256881899, 139) == 14, 139);
+        assert!(red_black_spec::find(tree, 754055166, 3362861525) == 13, 140);
+        assert!(red_black_spec::insert_and_get_index(tree, 491596774, 1322880527, 141) == 15, 141);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 7);
+            assert!(key0 == 2190022443 && key1 == 2630800098 && value == 102, 142);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2799893754, 385787091, 143) == 15, 143);
+        assert!(red_black_spec::insert_and_get_index(tree, 1442092286, 1342159487, 144) == 16, 144);
+        assert!(red_black_spec::find(tree, 2799893754, 385787091) == 15, 145);
+        assert!(red_black_spec::insert_and_get_index(tree, 2435427194, 4131393200, 146) == 17, 146);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 3967476602 && key1 == 3818717915 && value == 109, 147);
+        };
+        assert!(red_black_spec::find(tree, 1420331040, 865175050) == 0, 148);
+        assert!(red_black_spec::insert_and_get_index(tree, 3932560179, 1618463952, 149) == 17, 149);
+        assert!(red_black_spec::find(tree, 4090475822, 848831358) == 10, 150);
+        assert!(red_black_spec::insert_and_get_index(tree, 875384926, 3329928128, 151) == 18, 151);
+        assert!(red_black_spec::insert_and_get_index(tree, 2028077412, 2477731491, 152) == 19, 152);
+        assert!(red_black_spec::insert_and_get_index(tree, 619846051, 793492550, 153) == 20, 153);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 2179325181 && key1 == 2289652712 && value == 114, 154);
+        };
+        assert!(red_black_spec::find(tree, 677234698, 2195711406) == red_black_spec::null_index_value(), 155);
+        assert!(red_black_spec::find(tree, 2362660240, 4060153997) == 9, 156);
+        assert!(red_black_spec::find(tree, 27543430, 329186506) == 1, 157);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 18);
+            assert!(key0 == 875384926 && key1 == 3329928128 && value == 151, 158);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 15);
+            assert!(key0 == 2799893754 && key1 == 385787091 && value == 143, 159);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3742021888, 1494306265, 160) == 18, 160);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 14);
+            assert!(key0 == 3389483515 && key1 == 256881899 && value == 139, 161);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 1700103338 && key1 == 1409503501 && value == 125, 162);
+        };
+        assert!(red_black_spec::find(tree, 3026705114, 3181282803) == red_black_spec::null_index_value(), 163);
+        assert!(red_black_spec::insert_and_get_index(tree, 2356351343, 53856967, 164) == 17, 164);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 10);
+            assert!(key0 == 4090475822 && key1 == 848831358 && value == 127, 165);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2382217915, 1571125468, 166) == 17, 166);
+        assert!(red_black_spec::insert_and_get_index(tree, 2699505823, 56330411, 167) == 18, 167);
+        assert!(red_black_spec::insert_and_get_index(tree, 4136937028, 3676381796, 168) == 19, 168);
+        assert!(red_black_spec::find(tree, 2812233883, 3312167363) == 5, 169);
+        assert!(red_black_spec::insert_and_get_index(tree, 3894656532, 4278841148, 170) == 20, 170);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 2362660240 && key1 == 4060153997 && value == 134, 171);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 13);
+            assert!(key0 == 754055166 && key1 == 3362861525 && value == 138, 172);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 10479253, 1529074558, 173) == 19, 173);
+        assert!(red_black_spec::insert_and_get_index(tree, 493005527, 3172904479, 174) == 20, 174);
+        assert!(red_black_spec::insert_and_get_index(tree, 2039975048, 2226950965, 175) == 21, 175);
+        assert!(red_black_spec::insert_and_get_index(tree, 4214703379, 539270571, 176) == 22, 176);
+        assert!(red_black_spec::insert_and_get_index(tree, 3677914954, 706953441, 177) == 23, 177);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 11);
+            assert!(key0 == 676038151 && key1 == 2694806313 && value == 132, 178);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2893666325, 1206922666, 179) == 23, 179);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 4214703379 && key1 == 539270571 && value == 176, 180);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 2893666325 && key1 == 1206922666 && value == 179, 181);
+        };
+        assert!(red_black_spec::find(tree, 493005527, 3172904479) == 20, 182);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 2812233883 && key1 == 3312167363 && value == 121, 183);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2875432046, 2666935000, 184) == 21, 184);
+        assert!(red_black_spec::insert_and_get_index(tree, 4264890413, 591722844, 185) == 22, 185);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 4264890413 && key1 == 591722844 && value == 185, 186);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1695904806, 3842949771, 187) == 22, 187);
+        assert!(red_black_spec::insert_and_get_index(tree, 3559387672, 1744638116, 188) == 23, 188);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 619846051 && key1 == 793492550 && value == 153, 189);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1974084007, 145179341, 190) == 23, 190);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 21);
+            assert!(key0 == 2875432046 && key1 == 2666935000 && value == 184, 191);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 532967774, 3995861003, 192) == 23, 192);
+        assert!(red_black_spec::insert_and_get_index(tree, 3650269388, 1764451723, 193) == 24, 193);
+        assert!(red_black_spec::find(tree, 1100095814, 311845380) == red_black_spec::null_index_value(), 194);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 14);
+            assert!(key0 == 3742021888 && key1 == 1494306265 && value == 160, 195);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 19);
+            assert!(key0 == 10479253 && key1 == 1529074558 && value == 173, 196);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2027256985, 2356940289, 197) == 23, 197);
+        assert!(red_black_spec::find(tree, 2675317851, 387612858) == red_black_spec::null_index_value(), 198);
+    }
+
+    fun ops_1(tree: &mut RedBlackTree<u64>) {
+        assert!(red_black_spec::insert_and_get_index(tree, 1153157092, 383947736, 199) == 24, 199);
+        check(
+            tree,
+            vector<u64>[1, 3, 7, 20, 19, 24, 0, 16, 22, 21, 23, 15, 5, 10, 17, 6, 12, 18, 4, 14, 11, 9, 8, 2, 13],
+            vector<u128>[27543430, 199651888, 491596774, 493005527, 532967774, 1153157092, 1420331040, 1442092286, 1695904806, 1974084007, 2027256985, 2028077412, 2039975048, 2356351343, 2382217915, 2435427194, 2608448991, 2699505823, 3559387672, 3650269388, 3677914954, 3894656532, 3932560179, 3940215367, 4136937028],
+            vector<u128>[329186506, 2955392992, 1322880527, 3172904479, 3995861003, 383947736, 865175050, 1342159487, 3842949771, 145179341, 2356940289, 2477731491, 2226950965, 53856967, 1571125468, 4131393200, 3240243296, 56330411, 1744638116, 1764451723, 706953441, 4278841148, 1618463952, 1404315863, 3676381796],
+            vector<u64>[82, 96, 141, 174, 192, 199, 118, 144, 187, 190, 197, 152, 175, 164, 166, 146, 137, 167, 188, 193, 177, 170, 149, 117, 168],
+            199,
+        );
+        assert!(red_black_spec::insert_and_get_index(tree, 3589878923, 908525619, 200) == 25, 200);
+        assert!(red_black_spec::insert_and_get_index(tree, 1575941262, 3864730842, 201) == 26, 201);
+        assert!(red_black_spec::insert_and_get_index(tree, 3056886665, 739030847, 202) == 27, 202);
+        assert!(red_black_spec::insert_and_get_index(tree, 4194131455, 2539718906, 203) == 28, 203);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 12);
+            assert!(key0 == 2608448991 && key1 == 3240243296 && value == 137, 204);
+        };
+        assert!(red_black_spec::find(tree, 1420331040, 865175050) == 0, 205);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 26);
+            assert!(key0 == 1575941262 && key1 == 3864730842 && value == 201, 206);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 3932560179 && key1 == 1618463952 && value == 149, 207);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2978262258, 2159302662, 208) == 26, 208);
+        assert!(red_black_spec::find(tree, 271365395, 939413394) == red_black_spec::null_index_value(), 209);
+        assert!(red_black_spec::insert_and_get_index(tree, 3245576171, 3029248637, 210) == 27, 210);
+        assert!(red_black_spec::find(tree, 2135900909, 236259649) == red_black_spec::null_index_value(), 211);
+        assert!(red_black_spec::insert_and_get_index(tree, 3709389293, 61520613, 212) == 28, 212);
+        assert!(red_black_spec::insert_and_get_index(tree, 3251340856, 2863979956, 213) == 29, 213);
+        assert!(red_black_spec::insert_and_get_index(tree, 3319156382, 2152931774, 214) == 30, 214);
+        assert!(red_black_spec::insert_and_get_index(tree, 2777307002, 27702635, 215) == 31, 215);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 199651888 && key1 == 2955392992 && value == 96, 216);
+        };
+        assert!(red_black_spec::find(tree, 3589878923, 908525619) == 25, 217);
+        assert!(red_black_spec::find(tree, 27543430, 329186506) == 1, 218);
+        assert!(red_black_spec::find(tree, 3650269388, 1764451723) == 14, 219);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 16);
+            assert!(key0 == 1442092286 && key1 == 1342159487 && value == 144, 220);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 174965596, 3751060627, 221) == 30, 221);
+        assert!(red_black_spec::find(tree, 2039975048, 2226950965) == 5, 222);
+        assert!(red_black_spec::insert_and_get_index(tree, 2844651561, 3606856719, 223) == 31, 223);
+        assert!(red_black_spec::insert_and_get_index(tree, 180789959, 4251450985, 224) == 32, 224);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 2435427194 && key1 == 4131393200 && value == 146, 225);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 3559387672 && key1 == 1744638116 && value == 188, 226);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 28);
+            assert!(key0 == 3709389293 && key1 == 61520613 && value == 212, 227);
+        };
+        assert!(red_black_spec::find(tree, 2027256985, 2356940289) == 23, 228);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 16);
+            assert!(key0 == 3319156382 && key1 == 2152931774 && value == 214, 229);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 955909200, 3419218499, 230) == 29, 230);
+        assert!(red_black_spec::insert_and_get_index(tree, 3581172378, 3183688595, 231) == 30, 231);
+        assert!(red_black_spec::insert_and_get_index(tree, 14231300, 633353270, 232) == 31, 232);
+        assert!(red_black_spec::insert_and_get_index(tree, 3827793082, 1394050111, 233) == 32, 233);
+        assert!(red_black_spec::insert_and_get_index(tree, 3883169314, 3703683436, 234) == 33, 234);
+        assert!(red_black_spec::insert_and_get_index(tree, 3457340693, 377206828, 235) == 34, 235);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 17);
+            assert!(key0 == 2382217915 && key1 == 1571125468 && value == 166, 236);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2677621342, 911427870, 237) == 34, 237);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 3894656532 && key1 == 4278841148 && value == 170, 238);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 3056886665 && key1 == 739030847 && value == 202, 239);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 31);
+            assert!(key0 == 14231300 && key1 == 633353270 && value == 232, 240);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 16);
+            assert!(key0 == 3251340856 && key1 == 2863979956 && value == 213, 241);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 213909719, 3136269216, 242) == 31, 242);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 28);
+            assert!(key0 == 174965596 && key1 == 3751060627 && value == 221, 243);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3969166164, 2532021855, 244) == 31, 244);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 13);
+            assert!(key0 == 4136937028 && key1 == 3676381796 && value == 168, 245);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 591319277, 3992064261, 246) == 31, 246);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 25);
+            assert!(key0 == 3589878923 && key1 == 908525619 && value == 200, 247);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2600325880, 382835013, 248) == 31, 248);
+        assert!(red_black_spec::insert_and_get_index(tree, 1014533875, 4082413749, 249) == 32, 249);
+        assert!(red_black_spec::insert_and_get_index(tree, 806333740, 97599884, 250) == 33, 250);
+        assert!(red_black_spec::find(tree, 1153157092, 383947736) == 24, 251);
+        assert!(red_black_spec::find(tree, 2027256985, 2356940289) == 23, 252);
+        assert!(red_black_spec::find(tree, 2534614360, 1280700505) == red_black_spec::null_index_value(), 253);
+        assert!(red_black_spec::find(tree, 4194131455, 2539718906) == 12, 254);
+        assert!(red_black_spec::insert_and_get_index(tree, 2712589962, 3277789680, 255) == 34, 255);
+        assert!(red_black_spec::find(tree, 2553260871, 2410494122) == red_black_spec::null_index_value(), 256);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 2844651561 && key1 == 3606856719 && value == 223, 257);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3496955378, 827188069, 258) == 34, 258);
+        assert!(red_black_spec::insert_and_get_index(tree, 723669953, 90334679, 259) == 35, 259);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 26);
+            assert!(key0 == 2978262258 && key1 == 2159302662 && value == 208, 260);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 180789959 && key1 == 4251450985 && value == 224, 261);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3876193049, 3995648259, 262) == 34, 262);
+        assert!(red_black_spec::find(tree, 2712589962, 3277789680) == 4, 263);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 3883169314 && key1 == 3703683436 && value == 234, 264);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 2712589962 && key1 == 3277789680 && value == 255, 265);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3545869188, 967553819, 266) == 33, 266);
+        assert!(red_black_spec::insert_and_get_index(tree, 394620342, 2711742261, 267) == 34, 267);
+        assert!(red_black_spec::insert_and_get_index(tree, 1570321898, 825064769, 268) == 35, 268);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 2777307002 && key1 == 27702635 && value == 215, 269);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2195723343, 3946693334, 270) == 35, 270);
+        assert!(red_black_spec::insert_and_get_index(tree, 3221380100, 532807060, 271) == 36, 271);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 3876193049 && key1 == 3995648259 && value == 262, 272);
+        };
+        assert!(red_black_spec::find(tree, 4113468388, 2298328013) == red_black_spec::null_index_value(), 273);
+        assert!(red_black_spec::insert_and_get_index(tree, 1509498098, 3940805301, 274) == 36, 274);
+        assert!(red_black_spec::find(tree, 4169721065, 3617037133) == red_black_spec::null_index_value(), 275);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 31);
+            assert!(key0 == 2600325880 && key1 == 382835013 && value == 248, 276);
+        };
+        assert!(red_black_spec::find(tree, 2349180830, 4030273157) == red_black_spec::null_index_value(), 277);
+        assert!(red_black_spec::find(tree, 723669953, 90334679) == 26, 278);
+        assert!(red_black_spec::insert_and_get_index(tree, 3318692473, 2087597374, 279) == 36, 279);
+        assert!(red_black_spec::find(tree, 806333740, 97599884) == 4, 280);
+        assert!(red_black_spec::insert_and_get_index(tree, 3846587769, 1210349859, 281) == 37, 281);
+        assert!(red_black_spec::insert_and_get_index(tree, 2504027357, 3843383905, 282) == 38, 282);
+        assert!(red_black_spec::insert_and_get_index(tree, 1395114870, 3239316328, 283) == 39, 283);
+        assert!(red_black_spec::insert_and_get_index(tree, 1323851086, 3950865152, 284) == 40, 284);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 23);
+            assert!(key0 == 2027256985 && key1 == 2356940289 && value == 197, 285);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1998903585, 974058772, 286) == 40, 286);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 3496955378 && key1 == 827188069 && value == 258, 287);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 141365392, 606574994, 288) == 40, 288);
+        assert!(red_black_spec::insert_and_get_index(tree, 3702539463, 3754324567, 289) == 41, 289);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 34);
+            assert!(key0 == 394620342 && key1 == 2711742261 && value == 267, 290);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3318989265, 1197905734, 291) == 41, 291);
+        assert!(red_black_spec::insert_and_get_index(tree, 108601099, 138935174, 292) == 42, 292);
+        assert!(red_black_spec::insert_and_get_index(tree, 1329161051, 3074624614, 293) == 43, 293);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 28);
+            assert!(key0 == 213909719 && key1 == 3136269216 && value == 242, 294);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3365190172, 3468101449, 295) == 43, 295);
+        assert!(red_black_spec::insert_and_get_index(tree, 1305716118, 721472415, 296) == 44, 296);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 25);
+            assert!(key0 == 591319277 && key1 == 3992064261 && value == 246, 297);
+        };
+        assert!(red_black_spec::find(tree, 1329161051, 3074624614) == 28, 298);
+        assert!(red_black_spec::insert_and_get_index(tree, 1683075390, 2569726423, 299) == 44, 299);
+        check(
+            tree,
+            vector<u64>[1, 42, 40, 7, 20, 19, 26, 4, 29, 32, 24, 25, 23, 28, 39, 0, 31, 3, 44, 22, 21, 6, 15, 5, 35, 10, 38, 9, 18, 8, 27, 36, 41, 43, 17, 33, 30, 14, 11, 34, 16, 37, 2, 13, 12],
+            vector<u128>[27543430, 108601099, 141365392, 491596774, 493005527, 532967774, 723669953, 806333740, 955909200, 1014533875, 1153157092, 1305716118, 1323851086, 1329161051, 1395114870, 1420331040, 1509498098, 1570321898, 1683075390, 1695904806, 1974084007, 1998903585, 2028077412, 2039975048, 2195723343, 2356351343, 2504027357, 2677621342, 2699505823, 3221380100, 3245576171, 3318692473, 3318989265, 3365190172, 3457340693, 3545869188, 3581172378, 3650269388, 3677914954, 3702539463, 3827793082, 3846587769, 3940215367, 3969166164, 4194131455],
+            vector<u128>[329186506, 138935174, 606574994, 1322880527, 3172904479, 3995861003, 90334679, 97599884, 3419218499, 4082413749, 383947736, 721472415, 3950865152, 3074624614, 3239316328, 865175050, 3940805301, 825064769, 2569726423, 3842949771, 145179341, 974058772, 2477731491, 2226950965, 3946693334, 53856967, 3843383905, 911427870, 56330411, 532807060, 3029248637, 2087597374, 1197905734, 3468101449, 377206828, 967553819, 3183688595, 1764451723, 706953441, 3754324567, 1394050111, 1210349859, 1404315863, 2532021855, 2539718906],
+            vector<u64>[82, 292, 288, 141, 174, 192, 259, 250, 230, 249, 199, 296, 284, 293, 283, 118, 274, 268, 299, 187, 190, 286, 152, 175, 270, 164, 282, 237, 167, 271, 210, 279, 291, 295, 235, 266, 231, 193, 177, 289, 233, 281, 117, 244, 203],
+            299,
+        );
+        assert!(red_black_spec::find(tree, 3581172378, 3183688595) == 30, 300);
+        assert!(red_black_spec::insert_and_get_index(tree, 58237510, 3469772490, 301) == 45, 301);
+        assert!(red_black_spec::insert_and_get_index(tree, 2530049314, 2593508425, 302) == 46, 302);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 26);
+            assert!(key0 == 723669953 && key1 == 90334679 && value == 259, 303);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 30);
+            assert!(key0 == 3581172378 && key1 == 3183688595 && value == 231, 304);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 464210162, 375322906, 305) == 45, 305);
+        assert!(red_black_spec::insert_and_get_index(tree, 1076463817, 3870080487, 306) == 46, 306);
+        assert!(red_black_spec::insert_and_get_index(tree, 2766120488, 2857423560, 307) == 47, 307);
+        assert!(red_black_spec::insert_and_get_index(tree, 519326218, 1783097639, 308) == 48, 308);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 14);
+            assert!(key0 == 3650269388 && key1 == 1764451723 && value == 193, 309);
+        };
+        assert!(red_black_spec::find(tree, 2677621342, 911427870) == 9, 310);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 27543430 && key1 == 329186506 && value == 82, 311);
+        };
+        assert!(red_black_spec::find(tree, 1695904806, 3842949771) == 22, 312);
+        assert!(red_black_spec::find(tree, 2028077412, 2477731491) == 15, 313);
+        assert!(red_black_spec::insert_and_get_index(tree, 4149600896, 2510176509, 314) == 47, 314);
+        assert!(red_black_spec::find(tree, 3676340552, 3926918705) == red_black_spec::null_index_value(), 315);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 1420331040 && key1 == 865175050 && value == 118, 316);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1315228114, 1199323465, 317) == 47, 317);
+        assert!(red_black_spec::find(tree, 955909200, 3419218499) == 29, 318);
+        assert!(red_black_spec::insert_and_get_index(tree, 3038856849, 1640797966, 319) == 48, 319);
+        assert!(red_black_spec::insert_and_get_index(tree, 453693450, 2661030975, 320) == 49, 320);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 27);
+            assert!(key0 == 3245576171 && key1 == 3029248637 && value == 210, 321);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2496929786, 2831061382, 322) == 49, 322);
+        assert!(red_black_spec::insert_and_get_index(tree, 3908888263, 1311293744, 323) == 50, 323);
+        assert!(red_black_spec::insert_and_get_index(tree, 2673024591, 1470911854, 324) == 51, 324);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 24);
+            assert!(key0 == 1153157092 && key1 == 383947736 && value == 199, 325);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2586274241, 3986124895, 326) == 51, 326);
+        assert!(red_black_spec::insert_and_get_index(tree, 3275829960, 4104428067, 327) == 52, 327);
+        assert!(red_black_spec::insert_and_get_index(tree, 999791281, 3157118494, 328) == 53, 328);
+        assert!(red_black_spec::find(tree, 999791281, 3157118494) == 53, 329);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 29);
+            assert!(key0 == 955909200 && key1 == 3419218499 && value == 230, 330);
+        };
+        assert!(red_black_spec::find(tree, 3365190172, 3468101449) == 43, 331);
+        assert!(red_black_spec::insert_and_get_index(tree, 910996955, 1578738510, 332) == 53, 332);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 4);
+            assert!(key0 == 806333740 && key1 == 97599884 && value == 250, 333);
+        };
+        assert!(red_black_spec::find(tree, 3038856849, 1640797966) == 48, 334);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 44);
+            assert!(key0 == 1683075390 && key1 == 2569726423 && value == 299, 335);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2582261211, 4119934707, 336) == 52, 336);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 28);
+            assert!(key0 == 1329161051 && key1 == 3074624614 && value == 293, 337);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1103731523, 3093604366, 338) == 52, 338);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 36);
+            assert!(key0 == 3318692473 && key1 == 2087597374 && value == 279, 339);
+        };
+        assert!(red_black_spec::find(tree, 4245623925, 2655548657) == red_black_spec::null_index_value(), 340);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 2039975048 && key1 == 2226950965 && value == 175, 341);
+        };
+        assert!(red_black_spec::find(tree, 3846587769, 1210349859) == 37, 342);
+        assert!(red_black_spec::insert_and_get_index(tree, 2845816062, 2694158683, 343) == 51, 343);
+        assert!(red_black_spec::insert_and_get_index(tree, 1160976152, 4076044011, 344) == 52, 344);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 45);
+            assert!(key0 == 464210162 && key1 == 375322906 && value == 305, 345);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 750201800, 3055743133, 346) == 52, 346);
+        assert!(red_black_spec::insert_and_get_index(tree, 398585138, 2854753251, 347) == 53, 347);
+        assert!(red_black_spec::insert_and_get_index(tree, 639445197, 1892214798, 348) == 54, 348);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 43);
+            assert!(key0 == 3365190172 && key1 == 3468101449 && value == 295, 349);
+        };
+        assert!(red_black_spec::find(tree, 1570321898, 825064769) == 3, 350);
+        assert!(red_black_spec::insert_and_get_index(tree, 1552257352, 3881647963, 351) == 54, 351);
+        assert!(red_black_spec::insert_and_get_index(tree, 1810118401, 3740505622, 352) == 55, 352);
+        assert!(red_black_spec::insert_and_get_index(tree, 4236550920, 3115333310, 353) == 56, 353);
+        assert!(red_black_spec::find(tree, 3846587769, 1210349859) == 37, 354);
+        assert!(red_black_spec::insert_and_get_index(tree, 24932641, 3336017181, 355) == 57, 355);
+        assert!(red_black_spec::insert_and_get_index(tree, 864273527, 2367084110, 356) == 58, 356);
+        assert!(red_black_spec::insert_and_get_index(tree, 3010032986, 778008855, 357) == 59, 357);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 24);
+            assert!(key0 == 2673024591 && key1 == 1470911854 && value == 324, 358);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3144194646, 3759419319, 359) == 59, 359);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 34);
+            assert!(key0 == 3702539463 && key1 == 3754324567 && value == 289, 360);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1988746362, 1954967203, 361) == 59, 361);
+        assert!(red_black_spec::insert_and_get_index(tree, 3458565727, 1157239761, 362) == 60, 362);
+        assert!(red_black_spec::find(tree, 2677621342, 911427870) == 9, 363);
+        assert!(red_black_spec::insert_and_get_index(tree, 1932530856, 974791328, 364) == 61, 364);
+        assert!(red_black_spec::insert_and_get_index(tree, 1734620989, 2923446853, 365) == 62, 365);
+        assert!(red_black_spec::insert_and_get_index(tree, 3319682789, 3747137179, 366) == 63, 366);
+        assert!(red_black_spec::insert_and_get_index(tree, 2916165250, 4375182, 367) == 64, 367);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 20);
+            assert!(key0 == 493005527 && key1 == 3172904479 && value == 174, 368);
+        };
+        assert!(red_black_spec::find(tree, 3108034151, 2745842370) == red_black_spec::null_index_value(), 369);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 52);
+            assert!(key0 == 750201800 && key1 == 3055743133 && value == 346, 370);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 37);
+            assert!(key0 == 3846587769 && key1 == 1210349859 && value == 281, 371);
+        };
+        assert!(red_black_spec::find(tree, 2195723343, 3946693334) == 35, 372);
+        assert!(red_black_spec::insert_and_get_index(tree, 1774382363, 1107204448, 373) == 62, 373);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 33);
+            assert!(key0 == 3545869188 && key1 == 967553819 && value == 266, 374);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3706837028, 1471669547, 375) == 62, 375);
+        assert!(red_black_spec::insert_and_get_index(tree, 4131803610, 3920949409, 376) == 63, 376);
+        assert!(red_black_spec::insert_and_get_index(tree, 1591926458, 2824388468, 377) == 64, 377);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 28);
+            assert!(key0 == 2582261211 && key1 == 4119934707 && value == 336, 378);
+        };
+        assert!(red_black_spec::find(tree, 662928788, 3529587637) == red_black_spec::null_index_value(), 379);
+        assert!(red_black_spec::find(tree, 2942297064, 2805882285) == red_black_spec::null_index_value(), 380);
+        assert!(red_black_spec::insert_and_get_index(tree, 16474587, 1030867746, 381) == 64, 381);
+        assert!(red_black_spec::insert_and_get_index(tree, 1010049208, 768933090, 382) == 65, 382);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 2845816062 && key1 == 2694158683 && value == 343, 383);
+        };
+        assert!(red_black_spec::find(tree, 1974084007, 145179341) == 21, 384);
+        assert!(red_black_spec::insert_and_get_index(tree, 576740344, 584680637, 385) == 65, 385);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 36);
+            assert!(key0 == 1103731523 && key1 == 3093604366 && value == 338, 386);
+        };
+        assert!(red_black_spec::find(tree, 1774382363, 1107204448) == 33, 387);
+        assert!(red_black_spec::insert_and_get_index(tree, 1474024191, 3404923419, 388) == 65, 388);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 54);
+            assert!(key0 == 1552257352 && key1 == 3881647963 && value == 351, 389);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 887014278, 2586172127, 390) == 65, 390);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 4149600896 && key1 == 2510176509 && value == 314, 391);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2515350031, 1121641143, 392) == 65, 392);
+        assert!(red_black_spec::insert_and_get_index(tree, 4093897383, 1469233550, 393) == 66, 393);
+        assert!(red_black_spec::insert_and_get_index(tree, 1355003799, 3403949306, 394) == 67, 394);
+        assert!(red_black_spec::insert_and_get_index(tree, 2626328652, 3288894247, 395) == 68, 395);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 38);
+            assert!(key0 == 2504027357 && key1 == 3843383905 && value == 282, 396);
+        };
+    }
+
+    fun ops_2(tree: &mut RedBlackTree<u64>) {
+        assert!(red_black_spec::insert_and_get_index(tree, 519193781, 829625319, 397) == 68, 397);
+        assert!(red_black_spec::insert_and_get_index(tree, 3711917204, 478249566, 398) == 69, 398);
+        assert!(red_black_spec::find(tree, 543353816, 2731532612) == red_black_spec::null_index_value(), 399);
+        check(
+            tree,
+            vector<u64>[64, 57, 30, 42, 40, 53, 27, 7, 68, 14, 19, 36, 43, 58, 0, 4, 29, 51, 32, 46, 45, 25, 47, 23, 67, 39, 54, 31, 3, 28, 22, 37, 33, 55, 61, 21, 59, 6, 15, 35, 10, 49, 65, 26, 5, 38, 9, 18, 1, 20, 24, 48, 34, 8, 44, 41, 52, 17, 60, 11, 62, 69, 16, 50, 2, 13, 66, 63, 12, 56],
+            vector<u128>[16474587, 24932641, 58237510, 108601099, 141365392, 398585138, 453693450, 491596774, 519193781, 519326218, 532967774, 576740344, 639445197, 864273527, 887014278, 910996955, 999791281, 1010049208, 1014533875, 1076463817, 1160976152, 1305716118, 1315228114, 1323851086, 1355003799, 1395114870, 1474024191, 1509498098, 1570321898, 1591926458, 1695904806, 1734620989, 1774382363, 1810118401, 1932530856, 1974084007, 1988746362, 1998903585, 2028077412, 2195723343, 2356351343, 2496929786, 2515350031, 2530049314, 2586274241, 2626328652, 2677621342, 2699505823, 2766120488, 2916165250, 3010032986, 3038856849, 3144194646, 3221380100, 3275829960, 3318989265, 3319682789, 3457340693, 3458565727, 3677914954, 3706837028, 3711917204, 3827793082, 3908888263, 3940215367, 3969166164, 4093897383, 4131803610, 4194131455, 4236550920],
+            vector<u128>[1030867746, 3336017181, 3469772490, 138935174, 606574994, 2854753251, 2661030975, 1322880527, 829625319, 1783097639, 3995861003, 584680637, 1892214798, 2367084110, 2586172127, 1578738510, 3157118494, 768933090, 4082413749, 3870080487, 4076044011, 721472415, 1199323465, 3950865152, 3403949306, 3239316328, 3404923419, 3940805301, 825064769, 2824388468, 3842949771, 2923446853, 1107204448, 3740505622, 974791328, 145179341, 1954967203, 974058772, 2477731491, 3946693334, 53856967, 2831061382, 1121641143, 2593508425, 3986124895, 3288894247, 911427870, 56330411, 2857423560, 4375182, 778008855, 1640797966, 3759419319, 532807060, 4104428067, 1197905734, 3747137179, 377206828, 1157239761, 706953441, 1471669547, 478249566, 1394050111, 1311293744, 1404315863, 2532021855, 1469233550, 3920949409, 2539718906, 3115333310],
+            vector<u64>[381, 355, 301, 292, 288, 347, 320, 141, 397, 308, 192, 385, 348, 356, 390, 332, 328, 382, 249, 306, 344, 296, 317, 284, 394, 283, 388, 274, 268, 377, 187, 365, 373, 352, 364, 190, 361, 286, 152, 270, 164, 322, 392, 302, 326, 395, 237, 167, 307, 367, 357, 319, 359, 271, 327, 291, 366, 235, 362, 177, 375, 398, 233, 323, 117, 244, 393, 376, 203, 353],
+            399,
+        );
+        assert!(red_black_spec::insert_and_get_index(tree, 3235751222, 157695217, 400) == 70, 400);
+        assert!(red_black_spec::insert_and_get_index(tree, 3982832851, 2070490924, 401) == 71, 401);
+        assert!(red_black_spec::find(tree, 141365392, 606574994) == 40, 402);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 29);
+            assert!(key0 == 999791281 && key1 == 3157118494 && value == 328, 403);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 597673183, 648949323, 404) == 71, 404);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 66);
+            assert!(key0 == 4093897383 && key1 == 1469233550 && value == 393, 405);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 1010049208 && key1 == 768933090 && value == 382, 406);
+        };
+        assert!(red_black_spec::find(tree, 491596774, 1322880527) == 7, 407);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 43);
+            assert!(key0 == 639445197 && key1 == 1892214798 && value == 348, 408);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1624232403, 1481808047, 409) == 69, 409);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 18);
+            assert!(key0 == 2699505823 && key1 == 56330411 && value == 167, 410);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2492471112, 347545467, 411) == 69, 411);
+        assert!(red_black_spec::insert_and_get_index(tree, 498643366, 1093211729, 412) == 70, 412);
+        assert!(red_black_spec::insert_and_get_index(tree, 1512680405, 1427579219, 413) == 71, 413);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 33);
+            assert!(key0 == 1774382363 && key1 == 1107204448 && value == 373, 414);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 4094832989, 613836765, 415) == 71, 415);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 44);
+            assert!(key0 == 3275829960 && key1 == 4104428067 && value == 327, 416);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2428488595, 698586972, 417) == 71, 417);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 44);
+            assert!(key0 == 4094832989 && key1 == 613836765 && value == 415, 418);
+        };
+        assert!(red_black_spec::find(tree, 1850645203, 3746038785) == red_black_spec::null_index_value(), 419);
+        assert!(red_black_spec::find(tree, 900005647, 1102812153) == red_black_spec::null_index_value(), 420);
+        assert!(red_black_spec::find(tree, 910996955, 1578738510) == 4, 421);
+        assert!(red_black_spec::insert_and_get_index(tree, 4119245339, 2535995678, 422) == 71, 422);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 33);
+            assert!(key0 == 1512680405 && key1 == 1427579219 && value == 413, 423);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2092331143, 3809185847, 424) == 71, 424);
+        assert!(red_black_spec::find(tree, 1607503004, 465263915) == red_black_spec::null_index_value(), 425);
+        assert!(red_black_spec::insert_and_get_index(tree, 34260289, 2844447428, 426) == 72, 426);
+        assert!(red_black_spec::insert_and_get_index(tree, 296612892, 2680106801, 427) == 73, 427);
+        assert!(red_black_spec::insert_and_get_index(tree, 1762573650, 2182001681, 428) == 74, 428);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 53);
+            assert!(key0 == 398585138 && key1 == 2854753251 && value == 347, 429);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1031540325, 1690471635, 430) == 74, 430);
+        assert!(red_black_spec::insert_and_get_index(tree, 1949514269, 2955517683, 431) == 75, 431);
+        assert!(red_black_spec::insert_and_get_index(tree, 29966910, 199597933, 432) == 76, 432);
+        assert!(red_black_spec::insert_and_get_index(tree, 55918171, 932893471, 433) == 77, 433);
+        assert!(red_black_spec::insert_and_get_index(tree, 3101019857, 1327330264, 434) == 78, 434);
+        assert!(red_black_spec::insert_and_get_index(tree, 1680954987, 2116487929, 435) == 79, 435);
+        assert!(red_black_spec::find(tree, 3101019857, 1327330264) == 78, 436);
+        assert!(red_black_spec::insert_and_get_index(tree, 2671849666, 1012222005, 437) == 80, 437);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 65);
+            assert!(key0 == 2515350031 && key1 == 1121641143 && value == 392, 438);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3257169922, 3679515848, 439) == 80, 439);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 43);
+            assert!(key0 == 3711917204 && key1 == 478249566 && value == 398, 440);
+        };
+        assert!(red_black_spec::find(tree, 2428488595, 698586972) == 44, 441);
+        assert!(red_black_spec::insert_and_get_index(tree, 4142577611, 3831793744, 442) == 80, 442);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 16);
+            assert!(key0 == 3827793082 && key1 == 1394050111 && value == 233, 443);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 705614332, 3909895335, 444) == 80, 444);
+        assert!(red_black_spec::insert_and_get_index(tree, 2359244647, 206032040, 445) == 81, 445);
+        assert!(red_black_spec::insert_and_get_index(tree, 1033607799, 1729797569, 446) == 82, 446);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 3221380100 && key1 == 532807060 && value == 271, 447);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2878800478, 161968363, 448) == 82, 448);
+        assert!(red_black_spec::insert_and_get_index(tree, 3480398565, 1547346482, 449) == 83, 449);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 1695904806 && key1 == 3842949771 && value == 187, 450);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 358990972, 3850744039, 451) == 83, 451);
+        assert!(red_black_spec::find(tree, 2671849666, 1012222005) == 65, 452);
+        assert!(red_black_spec::insert_and_get_index(tree, 1294336685, 3498527404, 453) == 84, 453);
+        assert!(red_black_spec::insert_and_get_index(tree, 3836330996, 814727354, 454) == 85, 454);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 66);
+            assert!(key0 == 597673183 && key1 == 648949323 && value == 404, 455);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3702474663, 4118991097, 456) == 85, 456);
+        assert!(red_black_spec::insert_and_get_index(tree, 2400490129, 3298811430, 457) == 86, 457);
+        assert!(red_black_spec::insert_and_get_index(tree, 2510174289, 1207522732, 458) == 87, 458);
+        assert!(red_black_spec::find(tree, 3399508412, 2872586095) == red_black_spec::null_index_value(), 459);
+        assert!(red_black_spec::insert_and_get_index(tree, 662223842, 2722483051, 460) == 88, 460);
+        assert!(red_black_spec::find(tree, 1074508237, 763234913) == red_black_spec::null_index_value(), 461);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 50);
+            assert!(key0 == 3908888263 && key1 == 1311293744 && value == 323, 462);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 4065298861, 31299970, 463) == 88, 463);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 69);
+            assert!(key0 == 2492471112 && key1 == 347545467 && value == 411, 464);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 256287642, 861833899, 465) == 88, 465);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 81);
+            assert!(key0 == 2359244647 && key1 == 206032040 && value == 445, 466);
+        };
+        assert!(red_black_spec::find(tree, 1624232403, 1481808047) == 18, 467);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 57);
+            assert!(key0 == 24932641 && key1 == 3336017181 && value == 355, 468);
+        };
+        assert!(red_black_spec::find(tree, 2784794611, 2068421669) == red_black_spec::null_index_value(), 469);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 57);
+            assert!(key0 == 2510174289 && key1 == 1207522732 && value == 458, 470);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3278660612, 3862935339, 471) == 86, 471);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 52);
+            assert!(key0 == 3319682789 && key1 == 3747137179 && value == 366, 472);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 246753573, 2242141158, 473) == 86, 473);
+        assert!(red_black_spec::find(tree, 1949514269, 2955517683) == 75, 474);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 3235751222 && key1 == 157695217 && value == 400, 475);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 4173601965, 681399419, 476) == 86, 476);
+        assert!(red_black_spec::insert_and_get_index(tree, 3023441248, 2170837473, 477) == 87, 477);
+        assert!(red_black_spec::insert_and_get_index(tree, 353804972, 1145930714, 478) == 88, 478);
+        assert!(red_black_spec::find(tree, 3570063346, 956541244) == red_black_spec::null_index_value(), 479);
+        assert!(red_black_spec::insert_and_get_index(tree, 614731609, 916977886, 480) == 89, 480);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 34);
+            assert!(key0 == 3144194646 && key1 == 3759419319 && value == 359, 481);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 71);
+            assert!(key0 == 2092331143 && key1 == 3809185847 && value == 424, 482);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1297669254, 2211519159, 483) == 88, 483);
+        assert!(red_black_spec::insert_and_get_index(tree, 305921827, 2492119413, 484) == 89, 484);
+        assert!(red_black_spec::find(tree, 1076463817, 3870080487) == 46, 485);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 3480398565 && key1 == 1547346482 && value == 449, 486);
+        };
+        assert!(red_black_spec::find(tree, 1771952355, 1517039702) == red_black_spec::null_index_value(), 487);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 1033607799 && key1 == 1729797569 && value == 446, 488);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1974223204, 920406158, 489) == 88, 489);
+        assert!(red_black_spec::find(tree, 4834220, 2296528620) == red_black_spec::null_index_value(), 490);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 78);
+            assert!(key0 == 3101019857 && key1 == 1327330264 && value == 434, 491);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 2677621342 && key1 == 911427870 && value == 237, 492);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 1);
+            assert!(key0 == 2766120488 && key1 == 2857423560 && value == 307, 493);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 72);
+            assert!(key0 == 34260289 && key1 == 2844447428 && value == 426, 494);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 47);
+            assert!(key0 == 1315228114 && key1 == 1199323465 && value == 317, 495);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 82);
+            assert!(key0 == 2878800478 && key1 == 161968363 && value == 448, 496);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 37);
+            assert!(key0 == 1734620989 && key1 == 2923446853 && value == 365, 497);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2799251236, 2718097326, 498) == 82, 498);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 1297669254 && key1 == 2211519159 && value == 483, 499);
+        };
+        check(
+            tree,
+            vector<u64>[64, 76, 77, 30, 42, 40, 51, 81, 73, 22, 71, 37, 27, 7, 70, 68, 14, 19, 36, 34, 50, 80, 58, 0, 4, 32, 74, 46, 45, 47, 25, 23, 67, 39, 54, 31, 3, 28, 18, 79, 53, 55, 61, 75, 21, 78, 59, 6, 15, 35, 10, 57, 44, 49, 26, 5, 38, 65, 8, 20, 24, 9, 48, 43, 52, 41, 17, 60, 11, 72, 62, 66, 2, 13, 29, 69, 33, 63, 16, 1, 12, 56],
+            vector<u128>[16474587, 29966910, 55918171, 58237510, 108601099, 141365392, 246753573, 256287642, 296612892, 305921827, 353804972, 358990972, 453693450, 491596774, 498643366, 519193781, 519326218, 532967774, 576740344, 614731609, 662223842, 705614332, 864273527, 887014278, 910996955, 1014533875, 1031540325, 1076463817, 1160976152, 1294336685, 1305716118, 1323851086, 1355003799, 1395114870, 1474024191, 1509498098, 1570321898, 1591926458, 1624232403, 1680954987, 1762573650, 1810118401, 1932530856, 1949514269, 1974084007, 1974223204, 1988746362, 1998903585, 2028077412, 2195723343, 2356351343, 2400490129, 2428488595, 2496929786, 2530049314, 2586274241, 2626328652, 2671849666, 2799251236, 2916165250, 3010032986, 3023441248, 3038856849, 3257169922, 3278660612, 3318989265, 3457340693, 3458565727, 3677914954, 3702474663, 3706837028, 3836330996, 3940215367, 3969166164, 3982832851, 4065298861, 4119245339, 4131803610, 4142577611, 4173601965, 4194131455, 4236550920],
+            vector<u128>[1030867746, 199597933, 932893471, 3469772490, 138935174, 606574994, 2242141158, 861833899, 2680106801, 2492119413, 1145930714, 3850744039, 2661030975, 1322880527, 1093211729, 829625319, 1783097639, 3995861003, 584680637, 916977886, 2722483051, 3909895335, 2367084110, 2586172127, 1578738510, 4082413749, 1690471635, 3870080487, 4076044011, 3498527404, 721472415, 3950865152, 3403949306, 3239316328, 3404923419, 3940805301, 825064769, 2824388468, 1481808047, 2116487929, 2182001681, 3740505622, 974791328, 2955517683, 145179341, 920406158, 1954967203, 974058772, 2477731491, 3946693334, 53856967, 3298811430, 698586972, 2831061382, 2593508425, 3986124895, 3288894247, 1012222005, 2718097326, 4375182, 778008855, 2170837473, 1640797966, 3679515848, 3862935339, 1197905734, 377206828, 1157239761, 706953441, 4118991097, 1471669547, 814727354, 1404315863, 2532021855, 2070490924, 31299970, 2535995678, 3920949409, 3831793744, 681399419, 2539718906, 3115333310],
+            vector<u64>[381, 432, 433, 301, 292, 288, 473, 465, 427, 484, 478, 451, 320, 141, 412, 397, 308, 192, 385, 480, 460, 444, 356, 390, 332, 249, 430, 306, 344, 453, 296, 284, 394, 283, 388, 274, 268, 377, 409, 435, 428, 352, 364, 431, 190, 489, 361, 286, 152, 270, 164, 457, 417, 322, 302, 326, 395, 437, 498, 367, 357, 477, 319, 439, 471, 291, 235, 362, 177, 456, 375, 454, 117, 244, 401, 463, 422, 376, 442, 476, 203, 353],
+            499,
+        );
+        assert!(red_black_spec::insert_and_get_index(tree, 4237781332, 3816078340, 500) == 82, 500);
+        assert!(red_black_spec::find(tree, 2195723343, 3946693334) == 35, 501);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 36);
+            assert!(key0 == 576740344 && key1 == 584680637 && value == 385, 502);
+        };
+        assert!(red_black_spec::find(tree, 910996955, 1578738510) == 4, 503);
+        assert!(red_black_spec::find(tree, 1355003799, 3403949306) == 67, 504);
+        assert!(red_black_spec::find(tree, 3023441248, 2170837473) == 9, 505);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 7);
+            assert!(key0 == 491596774 && key1 == 1322880527 && value == 141, 506);
+        };
+        assert!(red_black_spec::find(tree, 1662609513, 3098926923) == red_black_spec::null_index_value(), 507);
+        assert!(red_black_spec::insert_and_get_index(tree, 2010815660, 3565041827, 508) == 81, 508);
+        assert!(red_black_spec::insert_and_get_index(tree, 2498228631, 1888636270, 509) == 82, 509);
+        assert!(red_black_spec::insert_and_get_index(tree, 562634912, 1355877789, 510) == 83, 510);
+        assert!(red_black_spec::insert_and_get_index(tree, 4059942777, 2460250847, 511) == 84, 511);
+        assert!(red_black_spec::find(tree, 4194131455, 2539718906) == 12, 512);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 0);
+            assert!(key0 == 887014278 && key1 == 2586172127 && value == 390, 513);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3308435917, 2389101527, 514) == 84, 514);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 31);
+            assert!(key0 == 1509498098 && key1 == 3940805301 && value == 274, 515);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1215521621, 3728738679, 516) == 84, 516);
+        assert!(red_black_spec::insert_and_get_index(tree, 1940420555, 3363836527, 517) == 85, 517);
+        assert!(red_black_spec::insert_and_get_index(tree, 1019209613, 1420656008, 518) == 86, 518);
+        assert!(red_black_spec::insert_and_get_index(tree, 3037564931, 3684122842, 519) == 87, 519);
+        assert!(red_black_spec::insert_and_get_index(tree, 2566957357, 1233332227, 520) == 88, 520);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 84);
+            assert!(key0 == 1215521621 && key1 == 3728738679 && value == 516, 521);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 10);
+            assert!(key0 == 2356351343 && key1 == 53856967 && value == 164, 522);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 1570321898 && key1 == 825064769 && value == 268, 523);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2191392508, 1080786072, 524) == 86, 524);
+        assert!(red_black_spec::find(tree, 1160976152, 4076044011) == 45, 525);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 39);
+            assert!(key0 == 1395114870 && key1 == 3239316328 && value == 283, 526);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 15);
+            assert!(key0 == 2028077412 && key1 == 2477731491 && value == 152, 527);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 13);
+            assert!(key0 == 3969166164 && key1 == 2532021855 && value == 244, 528);
+        };
+        assert!(red_black_spec::find(tree, 2909950090, 2689717072) == red_black_spec::null_index_value(), 529);
+        assert!(red_black_spec::insert_and_get_index(tree, 3049545504, 2867700499, 530) == 84, 530);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 44);
+            assert!(key0 == 2428488595 && key1 == 698586972 && value == 417, 531);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3608311140, 1777154849, 532) == 84, 532);
+        assert!(red_black_spec::find(tree, 3940215367, 1404315863) == 2, 533);
+        assert!(red_black_spec::insert_and_get_index(tree, 2232800983, 954647573, 534) == 85, 534);
+        assert!(red_black_spec::insert_and_get_index(tree, 2773304619, 744206771, 535) == 86, 535);
+        assert!(red_black_spec::insert_and_get_index(tree, 335186062, 4233289533, 536) == 87, 536);
+        assert!(red_black_spec::find(tree, 296612892, 2680106801) == 73, 537);
+        assert!(red_black_spec::insert_and_get_index(tree, 4158846763, 2762508552, 538) == 88, 538);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 57);
+            assert!(key0 == 2400490129 && key1 == 3298811430 && value == 457, 539);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 12);
+            assert!(key0 == 4194131455 && key1 == 2539718906 && value == 203, 540);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3493280604, 860246842, 541) == 87, 541);
+        assert!(red_black_spec::insert_and_get_index(tree, 988379199, 2478966067, 542) == 88, 542);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 13);
+            assert!(key0 == 2566957357 && key1 == 1233332227 && value == 520, 543);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 64);
+            assert!(key0 == 16474587 && key1 == 1030867746 && value == 381, 544);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2526898564, 1209047605, 545) == 87, 545);
+        assert!(red_black_spec::insert_and_get_index(tree, 2665764580, 2008651843, 546) == 88, 546);
+        assert!(red_black_spec::insert_and_get_index(tree, 2762292049, 2081311082, 547) == 89, 547);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 44);
+            assert!(key0 == 3049545504 && key1 == 2867700499 && value == 530, 548);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 61);
+            assert!(key0 == 1932530856 && key1 == 974791328 && value == 364, 549);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 59);
+            assert!(key0 == 1988746362 && key1 == 1954967203 && value == 361, 550);
+        };
+        assert!(red_black_spec::find(tree, 1974084007, 145179341) == 21, 551);
+        assert!(red_black_spec::find(tree, 1591926458, 2824388468) == 28, 552);
+        assert!(red_black_spec::find(tree, 2232800983, 954647573) == 85, 553);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 70);
+            assert!(key0 == 498643366 && key1 == 1093211729 && value == 412, 554);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 503829409, 833498122, 555) == 86, 555);
+        assert!(red_black_spec::find(tree, 126602285, 2623881581) == red_black_spec::null_index_value(), 556);
+        assert!(red_black_spec::find(tree, 55918171, 932893471) == 77, 557);
+        assert!(red_black_spec::insert_and_get_index(tree, 1917593312, 1214710105, 558) == 87, 558);
+        assert!(red_black_spec::insert_and_get_index(tree, 2536674553, 1307328520, 559) == 88, 559);
+        assert!(red_black_spec::insert_and_get_index(tree, 2474913367, 214199826, 560) == 89, 560);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 45);
+            assert!(key0 == 1160976152 && key1 == 4076044011 && value == 344, 561);
+        };
+        assert!(red_black_spec::find(tree, 660052453, 1354158262) == red_black_spec::null_index_value(), 562);
+        assert!(red_black_spec::find(tree, 4173601965, 681399419) == 1, 563);
+        assert!(red_black_spec::find(tree, 55918171, 932893471) == 77, 564);
+        assert!(red_black_spec::find(tree, 1355003799, 3403949306) == 67, 565);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 83);
+            assert!(key0 == 562634912 && key1 == 1355877789 && value == 510, 566);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1766180786, 3533310028, 567) == 88, 567);
+        assert!(red_black_spec::insert_and_get_index(tree, 3427936494, 2169865563, 568) == 89, 568);
+        assert!(red_black_spec::insert_and_get_index(tree, 2760752762, 1261971612, 569) == 90, 569);
+        assert!(red_black_spec::insert_and_get_index(tree, 217080244, 3937492072, 570) == 91, 570);
+        assert!(red_black_spec::insert_and_get_index(tree, 238411403, 1945637509, 571) == 92, 571);
+        assert!(red_black_spec::find(tree, 1853003734, 516641671) == red_black_spec::null_index_value(), 572);
+        assert!(red_black_spec::insert_and_get_index(tree, 1298117481, 1139826030, 573) == 93, 573);
+        assert!(red_black_spec::insert_and_get_index(tree, 991676491, 2227575589, 574) == 94, 574);
+        assert!(red_black_spec::insert_and_get_index(tree, 1200691904, 2244700131, 575) == 95, 575);
+        assert!(red_black_spec::insert_and_get_index(tree, 533991842, 3283137944, 576) == 96, 576);
+        assert!(red_black_spec::find(tree, 3677914954, 706953441) == 11, 577);
+        assert!(red_black_spec::insert_and_get_index(tree, 1685879753, 3192154737, 578) == 97, 578);
+        assert!(red_black_spec::find(tree, 533832075, 2611720611) == red_black_spec::null_index_value(), 579);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 53);
+            assert!(key0 == 1762573650 && key1 == 2182001681 && value == 428, 580);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 31);
+            assert!(key0 == 3308435917 && key1 == 2389101527 && value == 514, 581);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 2586274241 && key1 == 3986124895 && value == 326, 582);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3248995682, 1320329472, 583) == 95, 583);
+        assert!(red_black_spec::insert_and_get_index(tree, 4230180890, 2949476097, 584) == 96, 584);
+        assert!(red_black_spec::find(tree, 1974223204, 920406158) == 78, 585);
+        assert!(red_black_spec::insert_and_get_index(tree, 3258389408, 1622803221, 586) == 97, 586);
+        assert!(red_black_spec::insert_and_get_index(tree, 607043002, 2037844163, 587) == 98, 587);
+        assert!(red_black_spec::insert_and_get_index(tree, 3765708482, 2590280863, 588) == 99, 588);
+        assert!(red_black_spec::find(tree, 2498228631, 1888636270) == 82, 589);
+        assert!(red_black_spec::insert_and_get_index(tree, 2907281500, 239671848, 590) == 100, 590);
+        assert!(red_black_spec::insert_and_get_index(tree, 775613860, 2644931538, 591) == 101, 591);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 58);
+            assert!(key0 == 864273527 && key1 == 2367084110 && value == 356, 592);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1387178041, 2857709938, 593) == 101, 593);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 85);
+            assert!(key0 == 2232800983 && key1 == 954647573 && value == 534, 594);
+        };
+    }
+
+    fun ops_3(tree: &mut RedBlackTree<u64>) {
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 60);
+            assert!(key0 == 3458565727 && key1 == 1157239761 && value == 362, 595);
+        };
+        assert!(red_black_spec::find(tree, 2526898564, 1209047605) == 59, 596);
+        assert!(red_black_spec::insert_and_get_index(tree, 2020989910, 3102250516, 597) == 100, 597);
+        assert!(red_black_spec::insert_and_get_index(tree, 4115364701, 1950127651, 598) == 101, 598);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 88);
+            assert!(key0 == 1766180786 && key1 == 3533310028 && value == 567, 599);
+        };
+        check(
+            tree,
+            vector<u64>[76, 77, 30, 42, 40, 91, 92, 51, 7, 73, 22, 12, 71, 37, 27, 86, 68, 14, 19, 31, 98, 34, 50, 80, 58, 4, 13, 94, 32, 3, 74, 46, 5, 47, 93, 25, 23, 67, 85, 54, 28, 18, 79, 53, 55, 87, 15, 75, 21, 78, 6, 81, 100, 39, 35, 45, 49, 82, 59, 26, 83, 38, 61, 65, 90, 44, 70, 8, 60, 20, 24, 9, 10, 48, 95, 43, 97, 52, 41, 89, 17, 64, 84, 11, 72, 62, 99, 66, 2, 29, 0, 69, 88, 33, 63, 16, 57, 1, 96, 56, 36],
+            vector<u128>[29966910, 55918171, 58237510, 108601099, 141365392, 217080244, 238411403, 246753573, 256287642, 296612892, 305921827, 335186062, 353804972, 358990972, 453693450, 503829409, 519193781, 519326218, 532967774, 533991842, 607043002, 614731609, 662223842, 705614332, 775613860, 910996955, 988379199, 991676491, 1014533875, 1019209613, 1031540325, 1076463817, 1200691904, 1294336685, 1298117481, 1305716118, 1323851086, 1355003799, 1387178041, 1474024191, 1591926458, 1624232403, 1680954987, 1685879753, 1810118401, 1917593312, 1940420555, 1949514269, 1974084007, 1974223204, 1998903585, 2010815660, 2020989910, 2191392508, 2195723343, 2474913367, 2496929786, 2498228631, 2526898564, 2530049314, 2536674553, 2626328652, 2665764580, 2671849666, 2760752762, 2762292049, 2773304619, 2799251236, 2907281500, 2916165250, 3010032986, 3023441248, 3037564931, 3038856849, 3248995682, 3257169922, 3258389408, 3278660612, 3318989265, 3427936494, 3457340693, 3493280604, 3608311140, 3677914954, 3702474663, 3706837028, 3765708482, 3836330996, 3940215367, 3982832851, 4059942777, 4065298861, 4115364701, 4119245339, 4131803610, 4142577611, 4158846763, 4173601965, 4230180890, 4236550920, 4237781332],
+            vector<u128>[199597933, 932893471, 3469772490, 138935174, 606574994, 3937492072, 1945637509, 2242141158, 861833899, 2680106801, 2492119413, 4233289533, 1145930714, 3850744039, 2661030975, 833498122, 829625319, 1783097639, 3995861003, 3283137944, 2037844163, 916977886, 2722483051, 3909895335, 2644931538, 1578738510, 2478966067, 2227575589, 4082413749, 1420656008, 1690471635, 3870080487, 2244700131, 3498527404, 1139826030, 721472415, 3950865152, 3403949306, 2857709938, 3404923419, 2824388468, 1481808047, 2116487929, 3192154737, 3740505622, 1214710105, 3363836527, 2955517683, 145179341, 920406158, 974058772, 3565041827, 3102250516, 1080786072, 3946693334, 214199826, 2831061382, 1888636270, 1209047605, 2593508425, 1307328520, 3288894247, 2008651843, 1012222005, 1261971612, 2081311082, 744206771, 2718097326, 239671848, 4375182, 778008855, 2170837473, 3684122842, 1640797966, 1320329472, 3679515848, 1622803221, 3862935339, 1197905734, 2169865563, 377206828, 860246842, 1777154849, 706953441, 4118991097, 1471669547, 2590280863, 814727354, 1404315863, 2070490924, 2460250847, 31299970, 1950127651, 2535995678, 3920949409, 3831793744, 2762508552, 681399419, 2949476097, 3115333310, 3816078340],
+            vector<u64>[432, 433, 301, 292, 288, 570, 571, 473, 465, 427, 484, 536, 478, 451, 320, 555, 397, 308, 192, 576, 587, 480, 460, 444, 591, 332, 542, 574, 249, 518, 430, 306, 575, 453, 573, 296, 284, 394, 593, 388, 377, 409, 435, 578, 352, 558, 517, 431, 190, 489, 286, 508, 597, 524, 270, 560, 322, 509, 545, 302, 559, 395, 546, 437, 569, 547, 535, 498, 590, 367, 357, 477, 519, 319, 583, 439, 586, 471, 291, 568, 235, 541, 532, 177, 456, 375, 588, 454, 117, 401, 511, 463, 598, 422, 376, 442, 538, 476, 584, 353, 500],
+            599,
+        );
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 10);
+            assert!(key0 == 3037564931 && key1 == 3684122842 && value == 519, 600);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 99);
+            assert!(key0 == 3765708482 && key1 == 2590280863 && value == 588, 601);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 4034451260, 3611843248, 602) == 99, 602);
+        assert!(red_black_spec::find(tree, 3844359317, 2584646426) == red_black_spec::null_index_value(), 603);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 19);
+            assert!(key0 == 532967774 && key1 == 3995861003 && value == 192, 604);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 38);
+            assert!(key0 == 2626328652 && key1 == 3288894247 && value == 395, 605);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 24);
+            assert!(key0 == 3010032986 && key1 == 778008855 && value == 357, 606);
+        };
+        assert!(red_black_spec::find(tree, 3457340693, 377206828) == 17, 607);
+        assert!(red_black_spec::find(tree, 2677546397, 175818729) == red_black_spec::null_index_value(), 608);
+        assert!(red_black_spec::insert_and_get_index(tree, 1843570114, 4250229751, 609) == 97, 609);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 33);
+            assert!(key0 == 4119245339 && key1 == 2535995678 && value == 422, 610);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3296030356, 2015070422, 611) == 97, 611);
+        assert!(red_black_spec::find(tree, 1624232403, 1481808047) == 18, 612);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 53);
+            assert!(key0 == 1685879753 && key1 == 3192154737 && value == 578, 613);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 59);
+            assert!(key0 == 2526898564 && key1 == 1209047605 && value == 545, 614);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2143441519, 469984730, 615) == 96, 615);
+        assert!(red_black_spec::insert_and_get_index(tree, 3334334705, 832812568, 616) == 97, 616);
+        assert!(red_black_spec::insert_and_get_index(tree, 316394150, 2968432454, 617) == 98, 617);
+        assert!(red_black_spec::insert_and_get_index(tree, 3164169908, 2966757808, 618) == 99, 618);
+        assert!(red_black_spec::insert_and_get_index(tree, 1996815131, 1192772308, 619) == 100, 619);
+        assert!(red_black_spec::find(tree, 256287642, 861833899) == 7, 620);
+        assert!(red_black_spec::find(tree, 2799251236, 2718097326) == 8, 621);
+        assert!(red_black_spec::find(tree, 1940420555, 3363836527) == 15, 622);
+        assert!(red_black_spec::insert_and_get_index(tree, 1833340671, 904725446, 623) == 101, 623);
+        assert!(red_black_spec::insert_and_get_index(tree, 1692624391, 675300612, 624) == 102, 624);
+        assert!(red_black_spec::insert_and_get_index(tree, 1308062854, 2733361088, 625) == 103, 625);
+        assert!(red_black_spec::insert_and_get_index(tree, 2747641874, 505695706, 626) == 104, 626);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 6);
+            assert!(key0 == 1998903585 && key1 == 974058772 && value == 286, 627);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 319450971, 282742674, 628) == 104, 628);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 102);
+            assert!(key0 == 1692624391 && key1 == 675300612 && value == 624, 629);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 334039324, 1107169851, 630) == 104, 630);
+        assert!(red_black_spec::insert_and_get_index(tree, 3435002872, 2871639324, 631) == 105, 631);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 90);
+            assert!(key0 == 2760752762 && key1 == 1261971612 && value == 569, 632);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3590651009, 1478768367, 633) == 105, 633);
+        assert!(red_black_spec::insert_and_get_index(tree, 2594751439, 902468229, 634) == 106, 634);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 56);
+            assert!(key0 == 4236550920 && key1 == 3115333310 && value == 353, 635);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1859564327, 3108911598, 636) == 106, 636);
+        assert!(red_black_spec::find(tree, 141365392, 606574994) == 40, 637);
+        assert!(red_black_spec::find(tree, 305661829, 2934287897) == red_black_spec::null_index_value(), 638);
+        assert!(red_black_spec::insert_and_get_index(tree, 205844106, 2150869414, 639) == 107, 639);
+        assert!(red_black_spec::insert_and_get_index(tree, 1438893163, 2663225304, 640) == 108, 640);
+        assert!(red_black_spec::insert_and_get_index(tree, 2015113421, 102259902, 641) == 109, 641);
+        assert!(red_black_spec::find(tree, 2752469791, 1052581024) == red_black_spec::null_index_value(), 642);
+        assert!(red_black_spec::find(tree, 4237781332, 3816078340) == 36, 643);
+        assert!(red_black_spec::insert_and_get_index(tree, 1625585007, 1533197189, 644) == 110, 644);
+        assert!(red_black_spec::find(tree, 731343998, 1344122749) == red_black_spec::null_index_value(), 645);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 76);
+            assert!(key0 == 29966910 && key1 == 199597933 && value == 432, 646);
+        };
+        assert!(red_black_spec::find(tree, 2529133282, 1718745813) == red_black_spec::null_index_value(), 647);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 47);
+            assert!(key0 == 1294336685 && key1 == 3498527404 && value == 453, 648);
+        };
+        assert!(red_black_spec::find(tree, 2509322071, 2213729134) == red_black_spec::null_index_value(), 649);
+        assert!(red_black_spec::insert_and_get_index(tree, 3739422580, 3613266593, 650) == 109, 650);
+        assert!(red_black_spec::find(tree, 2433410202, 1493673055) == red_black_spec::null_index_value(), 651);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 37);
+            assert!(key0 == 358990972 && key1 == 3850744039 && value == 451, 652);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3180095802, 2686500716, 653) == 109, 653);
+        assert!(red_black_spec::insert_and_get_index(tree, 3241331477, 2398748717, 654) == 110, 654);
+        assert!(red_black_spec::find(tree, 533991842, 3283137944) == 31, 655);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 86);
+            assert!(key0 == 503829409 && key1 == 833498122 && value == 555, 656);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2549196138, 168105887, 657) == 110, 657);
+        assert!(red_black_spec::insert_and_get_index(tree, 2812856818, 1098474667, 658) == 111, 658);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 90);
+            assert!(key0 == 3435002872 && key1 == 2871639324 && value == 631, 659);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2092627465, 833809208, 660) == 111, 660);
+        assert!(red_black_spec::insert_and_get_index(tree, 1408506686, 2342108075, 661) == 112, 661);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 23);
+            assert!(key0 == 1323851086 && key1 == 3950865152 && value == 284, 662);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 68);
+            assert!(key0 == 519193781 && key1 == 829625319 && value == 397, 663);
+        };
+        assert!(red_black_spec::find(tree, 2508528482, 3559284914) == red_black_spec::null_index_value(), 664);
+        assert!(red_black_spec::insert_and_get_index(tree, 2280800472, 221898042, 665) == 111, 665);
+        assert!(red_black_spec::find(tree, 3836330996, 814727354) == 66, 666);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 57);
+            assert!(key0 == 4158846763 && key1 == 2762508552 && value == 538, 667);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1437090954, 2330732027, 668) == 111, 668);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 103);
+            assert!(key0 == 1308062854 && key1 == 2733361088 && value == 625, 669);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 52);
+            assert!(key0 == 3278660612 && key1 == 3862935339 && value == 471, 670);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3009497522, 3603743804, 671) == 110, 671);
+        assert!(red_black_spec::insert_and_get_index(tree, 1160678877, 3351585395, 672) == 111, 672);
+        assert!(red_black_spec::insert_and_get_index(tree, 3740481796, 1331125155, 673) == 112, 673);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 105);
+            assert!(key0 == 3590651009 && key1 == 1478768367 && value == 633, 674);
+        };
+        assert!(red_black_spec::find(tree, 4034451260, 3611843248) == 19, 675);
+        assert!(red_black_spec::insert_and_get_index(tree, 3643913268, 1217123491, 676) == 112, 676);
+        assert!(red_black_spec::find(tree, 3742051260, 941788539) == red_black_spec::null_index_value(), 677);
+        assert!(red_black_spec::insert_and_get_index(tree, 2761008937, 2700230886, 678) == 113, 678);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 63);
+            assert!(key0 == 4131803610 && key1 == 3920949409 && value == 376, 679);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 84);
+            assert!(key0 == 3608311140 && key1 == 1777154849 && value == 532, 680);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 26);
+            assert!(key0 == 2530049314 && key1 == 2593508425 && value == 302, 681);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 19);
+            assert!(key0 == 4034451260 && key1 == 3611843248 && value == 602, 682);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 12);
+            assert!(key0 == 335186062 && key1 == 4233289533 && value == 536, 683);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 36);
+            assert!(key0 == 4237781332 && key1 == 3816078340 && value == 500, 684);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1814929688, 1527247699, 685) == 108, 685);
+        assert!(red_black_spec::insert_and_get_index(tree, 1248395330, 4218173336, 686) == 109, 686);
+        assert!(red_black_spec::insert_and_get_index(tree, 977029689, 1920329146, 687) == 110, 687);
+        assert!(red_black_spec::insert_and_get_index(tree, 4032431796, 1694050263, 688) == 111, 688);
+        assert!(red_black_spec::insert_and_get_index(tree, 4034924286, 1644292067, 689) == 112, 689);
+        assert!(red_black_spec::find(tree, 3241331477, 2398748717) == 86, 690);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 43);
+            assert!(key0 == 3257169922 && key1 == 3679515848 && value == 439, 691);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1703980385, 4211787872, 692) == 112, 692);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 42);
+            assert!(key0 == 108601099 && key1 == 138935174 && value == 292, 693);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 403454114, 564785669, 694) == 112, 694);
+        assert!(red_black_spec::insert_and_get_index(tree, 3573582902, 129614990, 695) == 113, 695);
+        assert!(red_black_spec::insert_and_get_index(tree, 4258651898, 3880855495, 696) == 114, 696);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 97);
+            assert!(key0 == 3334334705 && key1 == 832812568 && value == 616, 697);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 113);
+            assert!(key0 == 3573582902 && key1 == 129614990 && value == 695, 698);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3342422998, 1186024987, 699) == 113, 699);
+        check(
+            tree,
+            vector<u64>[77, 30, 40, 107, 91, 92, 51, 7, 73, 22, 98, 102, 104, 71, 112, 27, 14, 31, 38, 34, 50, 80, 58, 4, 110, 13, 94, 32, 3, 74, 46, 26, 5, 109, 93, 25, 67, 85, 23, 103, 36, 54, 28, 18, 76, 79, 42, 55, 108, 101, 33, 106, 87, 15, 75, 21, 78, 100, 81, 47, 10, 68, 96, 39, 35, 57, 45, 49, 82, 83, 52, 56, 61, 65, 6, 63, 44, 70, 8, 90, 60, 20, 19, 9, 48, 99, 12, 86, 95, 24, 53, 41, 113, 89, 17, 64, 84, 11, 72, 62, 37, 105, 66, 2, 29, 111, 43, 0, 69, 88, 16, 1, 59, 97],
+            vector<u128>[55918171, 58237510, 141365392, 205844106, 217080244, 238411403, 246753573, 256287642, 296612892, 305921827, 316394150, 319450971, 334039324, 353804972, 403454114, 453693450, 519326218, 533991842, 607043002, 614731609, 662223842, 705614332, 775613860, 910996955, 977029689, 988379199, 991676491, 1014533875, 1019209613, 1031540325, 1076463817, 1160678877, 1200691904, 1248395330, 1298117481, 1305716118, 1355003799, 1387178041, 1408506686, 1437090954, 1438893163, 1474024191, 1591926458, 1624232403, 1625585007, 1680954987, 1703980385, 1810118401, 1814929688, 1833340671, 1843570114, 1859564327, 1917593312, 1940420555, 1949514269, 1974084007, 1974223204, 1996815131, 2010815660, 2015113421, 2020989910, 2092627465, 2143441519, 2191392508, 2195723343, 2280800472, 2474913367, 2496929786, 2498228631, 2536674553, 2549196138, 2594751439, 2665764580, 2671849666, 2747641874, 2761008937, 2762292049, 2773304619, 2799251236, 2812856818, 2907281500, 2916165250, 3009497522, 3023441248, 3038856849, 3164169908, 3180095802, 3241331477, 3248995682, 3258389408, 3296030356, 3318989265, 3342422998, 3427936494, 3457340693, 3493280604, 3643913268, 3677914954, 3702474663, 3706837028, 3739422580, 3740481796, 3836330996, 3940215367, 3982832851, 4032431796, 4034924286, 4059942777, 4065298861, 4115364701, 4142577611, 4173601965, 4230180890, 4258651898],
+            vector<u128>[932893471, 3469772490, 606574994, 2150869414, 3937492072, 1945637509, 2242141158, 861833899, 2680106801, 2492119413, 2968432454, 282742674, 1107169851, 1145930714, 564785669, 2661030975, 1783097639, 3283137944, 2037844163, 916977886, 2722483051, 3909895335, 2644931538, 1578738510, 1920329146, 2478966067, 2227575589, 4082413749, 1420656008, 1690471635, 3870080487, 3351585395, 2244700131, 4218173336, 1139826030, 721472415, 3403949306, 2857709938, 2342108075, 2330732027, 2663225304, 3404923419, 2824388468, 1481808047, 1533197189, 2116487929, 4211787872, 3740505622, 1527247699, 904725446, 4250229751, 3108911598, 1214710105, 3363836527, 2955517683, 145179341, 920406158, 1192772308, 3565041827, 102259902, 3102250516, 833809208, 469984730, 1080786072, 3946693334, 221898042, 214199826, 2831061382, 1888636270, 1307328520, 168105887, 902468229, 2008651843, 1012222005, 505695706, 2700230886, 2081311082, 744206771, 2718097326, 1098474667, 239671848, 4375182, 3603743804, 2170837473, 1640797966, 2966757808, 2686500716, 2398748717, 1320329472, 1622803221, 2015070422, 1197905734, 1186024987, 2169865563, 377206828, 860246842, 1217123491, 706953441, 4118991097, 1471669547, 3613266593, 1331125155, 814727354, 1404315863, 2070490924, 1694050263, 1644292067, 2460250847, 31299970, 1950127651, 3831793744, 681399419, 2949476097, 3880855495],
+            vector<u64>[433, 301, 288, 639, 570, 571, 473, 465, 427, 484, 617, 628, 630, 478, 694, 320, 308, 576, 587, 480, 460, 444, 591, 332, 687, 542, 574, 249, 518, 430, 306, 672, 575, 686, 573, 296, 394, 593, 661, 668, 640, 388, 377, 409, 644, 435, 692, 352, 685, 623, 609, 636, 558, 517, 431, 190, 489, 619, 508, 641, 597, 660, 615, 524, 270, 665, 560, 322, 509, 559, 657, 634, 546, 437, 626, 678, 547, 535, 498, 658, 590, 367, 671, 477, 319, 618, 653, 654, 583, 586, 611, 291, 699, 568, 235, 541, 676, 177, 456, 375, 650, 673, 454, 117, 401, 688, 689, 511, 463, 598, 442, 476, 584, 696],
+            699,
+        );
+        assert!(red_black_spec::find(tree, 38277096, 2559474274) == red_black_spec::null_index_value(), 700);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 10);
+            assert!(key0 == 2020989910 && key1 == 3102250516 && value == 597, 701);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3726606076, 1838413002, 702) == 113, 702);
+        assert!(red_black_spec::find(tree, 788328679, 3433580468) == red_black_spec::null_index_value(), 703);
+        assert!(red_black_spec::insert_and_get_index(tree, 3051337108, 1695771620, 704) == 114, 704);
+        assert!(red_black_spec::insert_and_get_index(tree, 1433997490, 90982801, 705) == 115, 705);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 77);
+            assert!(key0 == 55918171 && key1 == 932893471 && value == 433, 706);
+        };
+        assert!(red_black_spec::find(tree, 3700400564, 488479308) == red_black_spec::null_index_value(), 707);
+        assert!(red_black_spec::insert_and_get_index(tree, 733359875, 1377842498, 708) == 115, 708);
+        assert!(red_black_spec::insert_and_get_index(tree, 3957031654, 105641030, 709) == 116, 709);
+        assert!(red_black_spec::find(tree, 726904323, 207973526) == red_black_spec::null_index_value(), 710);
+        assert!(red_black_spec::find(tree, 733359875, 1377842498) == 115, 711);
+        assert!(red_black_spec::insert_and_get_index(tree, 3545608574, 4000436273, 712) == 117, 712);
+        assert!(red_black_spec::find(tree, 3457340693, 377206828) == 17, 713);
+        assert!(red_black_spec::find(tree, 3689073326, 3608177500) == red_black_spec::null_index_value(), 714);
+        assert!(red_black_spec::find(tree, 2010815660, 3565041827) == 81, 715);
+        assert!(red_black_spec::insert_and_get_index(tree, 1905678981, 1002359999, 716) == 118, 716);
+        assert!(red_black_spec::insert_and_get_index(tree, 3615261425, 1318417787, 717) == 119, 717);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 32);
+            assert!(key0 == 1014533875 && key1 == 4082413749 && value == 249, 718);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 293269191, 2356159777, 719) == 119, 719);
+        assert!(red_black_spec::insert_and_get_index(tree, 673767359, 2482698471, 720) == 120, 720);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 46);
+            assert!(key0 == 1076463817 && key1 == 3870080487 && value == 306, 721);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1386195377, 1637553013, 722) == 120, 722);
+        assert!(red_black_spec::find(tree, 3051337108, 1695771620) == 114, 723);
+        assert!(red_black_spec::find(tree, 726760673, 2909844823) == red_black_spec::null_index_value(), 724);
+        assert!(red_black_spec::insert_and_get_index(tree, 482318605, 3820850787, 725) == 121, 725);
+        assert!(red_black_spec::find(tree, 1913921617, 2667043036) == red_black_spec::null_index_value(), 726);
+        assert!(red_black_spec::insert_and_get_index(tree, 3680463813, 3402877974, 727) == 122, 727);
+        assert!(red_black_spec::find(tree, 3836330996, 814727354) == 66, 728);
+        assert!(red_black_spec::insert_and_get_index(tree, 19616911, 4284596764, 729) == 123, 729);
+        assert!(red_black_spec::find(tree, 767594707, 3378101669) == red_black_spec::null_index_value(), 730);
+        assert!(red_black_spec::insert_and_get_index(tree, 3716911153, 3147859783, 731) == 124, 731);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 108);
+            assert!(key0 == 1814929688 && key1 == 1527247699 && value == 685, 732);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 4287896988, 1059340875, 733) == 124, 733);
+        assert!(red_black_spec::find(tree, 2544645847, 4055977045) == red_black_spec::null_index_value(), 734);
+        assert!(red_black_spec::insert_and_get_index(tree, 3470652368, 3303816011, 735) == 125, 735);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 305921827 && key1 == 2492119413 && value == 484, 736);
+        };
+        assert!(red_black_spec::find(tree, 3982832851, 2070490924) == 29, 737);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 8);
+            assert!(key0 == 2799251236 && key1 == 2718097326 && value == 498, 738);
+        };
+        assert!(red_black_spec::find(tree, 1625585007, 1533197189) == 76, 739);
+        assert!(red_black_spec::insert_and_get_index(tree, 2651188402, 1223715818, 740) == 124, 740);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 86);
+            assert!(key0 == 3241331477 && key1 == 2398748717 && value == 654, 741);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 55);
+            assert!(key0 == 1810118401 && key1 == 3740505622 && value == 352, 742);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2923795824, 821994911, 743) == 123, 743);
+        assert!(red_black_spec::insert_and_get_index(tree, 2953580965, 3955132159, 744) == 124, 744);
+        assert!(red_black_spec::find(tree, 991676491, 2227575589) == 94, 745);
+        assert!(red_black_spec::find(tree, 2934501473, 2998057778) == red_black_spec::null_index_value(), 746);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 17);
+            assert!(key0 == 3457340693 && key1 == 377206828 && value == 235, 747);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3724639781, 796003019, 748) == 124, 748);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 246753573 && key1 == 2242141158 && value == 473, 749);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 575918684, 4239214574, 750) == 124, 750);
+        assert!(red_black_spec::find(tree, 1828062131, 543701308) == red_black_spec::null_index_value(), 751);
+        assert!(red_black_spec::find(tree, 4032431796, 1694050263) == 111, 752);
+        assert!(red_black_spec::insert_and_get_index(tree, 4128457590, 3413729376, 753) == 125, 753);
+        assert!(red_black_spec::find(tree, 3706837028, 1471669547) == 62, 754);
+        assert!(red_black_spec::find(tree, 2589275379, 2593447356) == red_black_spec::null_index_value(), 755);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 88);
+            assert!(key0 == 4115364701 && key1 == 1950127651 && value == 598, 756);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 18);
+            assert!(key0 == 1624232403 && key1 == 1481808047 && value == 409, 757);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3215896528, 3731850832, 758) == 124, 758);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 71);
+            assert!(key0 == 353804972 && key1 == 1145930714 && value == 478, 759);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3832424450, 4248413959, 760) == 124, 760);
+        assert!(red_black_spec::find(tree, 3023441248, 2170837473) == 9, 761);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 59);
+            assert!(key0 == 4230180890 && key1 == 2949476097 && value == 584, 762);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 3724639781 && key1 == 796003019 && value == 748, 763);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 49);
+            assert!(key0 == 2496929786 && key1 == 2831061382 && value == 322, 764);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1812861632, 3495503649, 765) == 122, 765);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 5);
+            assert!(key0 == 1200691904 && key1 == 2244700131 && value == 575, 766);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3958029710, 3629596816, 767) == 122, 767);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 54);
+            assert!(key0 == 1474024191 && key1 == 3404923419 && value == 388, 768);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2317956939, 2398065997, 769) == 122, 769);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 34);
+            assert!(key0 == 614731609 && key1 == 916977886 && value == 480, 770);
+        };
+        assert!(red_black_spec::find(tree, 3702474663, 4118991097) == 72, 771);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 96);
+            assert!(key0 == 2143441519 && key1 == 469984730 && value == 615, 772);
+        };
+        assert!(red_black_spec::find(tree, 453693450, 2661030975) == 27, 773);
+        assert!(red_black_spec::find(tree, 2671849666, 1012222005) == 65, 774);
+        assert!(red_black_spec::insert_and_get_index(tree, 2712819153, 2860000942, 775) == 121, 775);
+        assert!(red_black_spec::insert_and_get_index(tree, 2318011241, 974690174, 776) == 122, 776);
+        assert!(red_black_spec::insert_and_get_index(tree, 4104441229, 2101244207, 777) == 123, 777);
+        assert!(red_black_spec::find(tree, 3470652368, 3303816011) == 22, 778);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 118);
+            assert!(key0 == 1905678981 && key1 == 1002359999 && value == 716, 779);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1886749641, 4259025631, 780) == 123, 780);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 42);
+            assert!(key0 == 1703980385 && key1 == 4211787872 && value == 692, 781);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 821743677, 2501251229, 782) == 123, 782);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 102);
+            assert!(key0 == 319450971 && key1 == 282742674 && value == 628, 783);
+        };
+        assert!(red_black_spec::find(tree, 1682118265, 2433746065) == red_black_spec::null_index_value(), 784);
+        assert!(red_black_spec::insert_and_get_index(tree, 1053245831, 761446132, 785) == 123, 785);
+        assert!(red_black_spec::insert_and_get_index(tree, 1295957935, 3027937565, 786) == 124, 786);
+        assert!(red_black_spec::insert_and_get_index(tree, 1477468202, 2270675062, 787) == 125, 787);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 27);
+            assert!(key0 == 453693450 && key1 == 2661030975 && value == 320, 788);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 87);
+            assert!(key0 == 1917593312 && key1 == 1214710105 && value == 558, 789);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 459291366, 3585369399, 790) == 124, 790);
+        assert!(red_black_spec::insert_and_get_index(tree, 1704779303, 683376306, 791) == 125, 791);
+        assert!(red_black_spec::find(tree, 1485935178, 3674936505) == red_black_spec::null_index_value(), 792);
+    }
+
+    fun ops_4(tree: &mut RedBlackTree<u64>) {
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 12);
+            assert!(key0 == 3180095802 && key1 == 2686500716 && value == 653, 793);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1292148412, 2167077531, 794) == 125, 794);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 22);
+            assert!(key0 == 3470652368 && key1 == 3303816011 && value == 735, 795);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2348973037, 2469803465, 796) == 125, 796);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 59);
+            assert!(key0 == 3832424450 && key1 == 4248413959 && value == 760, 797);
+        };
+        assert!(red_black_spec::find(tree, 2411157103, 899101124) == red_black_spec::null_index_value(), 798);
+        assert!(red_black_spec::insert_and_get_index(tree, 3401514881, 3646370565, 799) == 125, 799);
+        check(
+            tree,
+            vector<u64>[55, 30, 40, 107, 91, 92, 7, 119, 73, 98, 104, 112, 124, 96, 14, 31, 18, 38, 50, 46, 80, 115, 58, 102, 4, 110, 13, 94, 3, 74, 123, 26, 109, 22, 87, 93, 25, 67, 120, 85, 23, 77, 103, 36, 27, 28, 76, 79, 12, 5, 101, 33, 106, 42, 15, 75, 21, 78, 100, 81, 47, 68, 39, 35, 57, 34, 122, 59, 45, 82, 83, 52, 56, 86, 61, 65, 121, 6, 63, 44, 70, 90, 60, 20, 51, 17, 19, 9, 48, 114, 99, 71, 95, 24, 53, 41, 10, 125, 89, 64, 117, 32, 84, 11, 49, 72, 62, 108, 113, 37, 105, 66, 2, 116, 54, 29, 111, 43, 0, 69, 118, 88, 16, 1, 97, 8],
+            vector<u128>[19616911, 58237510, 141365392, 205844106, 217080244, 238411403, 256287642, 293269191, 296612892, 316394150, 334039324, 403454114, 459291366, 482318605, 519326218, 533991842, 575918684, 607043002, 662223842, 673767359, 705614332, 733359875, 775613860, 821743677, 910996955, 977029689, 988379199, 991676491, 1019209613, 1031540325, 1053245831, 1160678877, 1248395330, 1292148412, 1295957935, 1298117481, 1305716118, 1355003799, 1386195377, 1387178041, 1408506686, 1433997490, 1437090954, 1438893163, 1477468202, 1591926458, 1625585007, 1680954987, 1704779303, 1812861632, 1833340671, 1843570114, 1859564327, 1886749641, 1940420555, 1949514269, 1974084007, 1974223204, 1996815131, 2010815660, 2015113421, 2092627465, 2191392508, 2195723343, 2280800472, 2317956939, 2318011241, 2348973037, 2474913367, 2498228631, 2536674553, 2549196138, 2594751439, 2651188402, 2665764580, 2671849666, 2712819153, 2747641874, 2761008937, 2762292049, 2773304619, 2812856818, 2907281500, 2916165250, 2923795824, 2953580965, 3009497522, 3023441248, 3038856849, 3051337108, 3164169908, 3215896528, 3248995682, 3258389408, 3296030356, 3318989265, 3342422998, 3401514881, 3427936494, 3493280604, 3545608574, 3615261425, 3643913268, 3677914954, 3680463813, 3702474663, 3706837028, 3716911153, 3726606076, 3739422580, 3740481796, 3836330996, 3940215367, 3957031654, 3958029710, 3982832851, 4032431796, 4034924286, 4059942777, 4065298861, 4104441229, 4128457590, 4142577611, 4173601965, 4258651898, 4287896988],
+            vector<u128>[4284596764, 3469772490, 606574994, 2150869414, 3937492072, 1945637509, 861833899, 2356159777, 2680106801, 2968432454, 1107169851, 564785669, 3585369399, 3820850787, 1783097639, 3283137944, 4239214574, 2037844163, 2722483051, 2482698471, 3909895335, 1377842498, 2644931538, 2501251229, 1578738510, 1920329146, 2478966067, 2227575589, 1420656008, 1690471635, 761446132, 3351585395, 4218173336, 2167077531, 3027937565, 1139826030, 721472415, 3403949306, 1637553013, 2857709938, 2342108075, 90982801, 2330732027, 2663225304, 2270675062, 2824388468, 1533197189, 2116487929, 683376306, 3495503649, 904725446, 4250229751, 3108911598, 4259025631, 3363836527, 2955517683, 145179341, 920406158, 1192772308, 3565041827, 102259902, 833809208, 1080786072, 3946693334, 221898042, 2398065997, 974690174, 2469803465, 214199826, 1888636270, 1307328520, 168105887, 902468229, 1223715818, 2008651843, 1012222005, 2860000942, 505695706, 2700230886, 2081311082, 744206771, 1098474667, 239671848, 4375182, 821994911, 3955132159, 3603743804, 2170837473, 1640797966, 1695771620, 2966757808, 3731850832, 1320329472, 1622803221, 2015070422, 1197905734, 1186024987, 3646370565, 2169865563, 860246842, 4000436273, 1318417787, 1217123491, 706953441, 3402877974, 4118991097, 1471669547, 3147859783, 1838413002, 3613266593, 1331125155, 814727354, 1404315863, 105641030, 3629596816, 2070490924, 1694050263, 1644292067, 2460250847, 31299970, 2101244207, 3413729376, 3831793744, 681399419, 3880855495, 1059340875],
+            vector<u64>[729, 301, 288, 639, 570, 571, 465, 719, 427, 617, 630, 694, 790, 725, 308, 576, 750, 587, 460, 720, 444, 708, 591, 782, 332, 687, 542, 574, 518, 430, 785, 672, 686, 794, 786, 573, 296, 394, 722, 593, 661, 705, 668, 640, 787, 377, 644, 435, 791, 765, 623, 609, 636, 780, 517, 431, 190, 489, 619, 508, 641, 660, 524, 270, 665, 769, 776, 796, 560, 509, 559, 657, 634, 740, 546, 437, 775, 626, 678, 547, 535, 658, 590, 367, 743, 744, 671, 477, 319, 704, 618, 758, 583, 586, 611, 291, 699, 799, 568, 541, 712, 717, 676, 177, 727, 456, 375, 731, 702, 650, 673, 454, 117, 709, 767, 401, 688, 689, 511, 463, 777, 753, 442, 476, 696, 733],
+            799,
+        );
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 114);
+            assert!(key0 == 3051337108 && key1 == 1695771620 && value == 704, 800);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 933769992, 1074434548, 801) == 125, 801);
+        assert!(red_black_spec::find(tree, 3038856849, 1640797966) == 48, 802);
+        assert!(red_black_spec::insert_and_get_index(tree, 3654371984, 3568677588, 803) == 126, 803);
+        assert!(red_black_spec::insert_and_get_index(tree, 3976051474, 297583285, 804) == 127, 804);
+        assert!(red_black_spec::find(tree, 205844106, 2150869414) == 107, 805);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 108);
+            assert!(key0 == 3716911153 && key1 == 3147859783 && value == 731, 806);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 126);
+            assert!(key0 == 3654371984 && key1 == 3568677588 && value == 803, 807);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 109);
+            assert!(key0 == 1248395330 && key1 == 4218173336 && value == 686, 808);
+        };
+        assert!(red_black_spec::find(tree, 3311890324, 4208962172) == red_black_spec::null_index_value(), 809);
+        assert!(red_black_spec::find(tree, 2923795824, 821994911) == 51, 810);
+        assert!(red_black_spec::find(tree, 3957031654, 105641030) == 116, 811);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 56);
+            assert!(key0 == 2594751439 && key1 == 902468229 && value == 634, 812);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 9);
+            assert!(key0 == 3023441248 && key1 == 2170837473 && value == 477, 813);
+        };
+        assert!(red_black_spec::find(tree, 4059942777, 2460250847) == 0, 814);
+        assert!(red_black_spec::insert_and_get_index(tree, 1013207353, 1583270081, 815) == 123, 815);
+        assert!(red_black_spec::insert_and_get_index(tree, 523023790, 2342848850, 816) == 124, 816);
+        assert!(red_black_spec::insert_and_get_index(tree, 689132252, 729680389, 817) == 125, 817);
+        assert!(red_black_spec::find(tree, 2353119461, 339963221) == red_black_spec::null_index_value(), 818);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 91);
+            assert!(key0 == 217080244 && key1 == 3937492072 && value == 570, 819);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1932382675, 3823781801, 820) == 125, 820);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 115);
+            assert!(key0 == 733359875 && key1 == 1377842498 && value == 708, 821);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1108373637, 1326113566, 822) == 125, 822);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 26);
+            assert!(key0 == 1160678877 && key1 == 3351585395 && value == 672, 823);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 20);
+            assert!(key0 == 2916165250 && key1 == 4375182 && value == 367, 824);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2659135985, 2237144669, 825) == 124, 825);
+        assert!(red_black_spec::find(tree, 1843570114, 4250229751) == 33, 826);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 81);
+            assert!(key0 == 2010815660 && key1 == 3565041827 && value == 508, 827);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 28);
+            assert!(key0 == 1591926458 && key1 == 2824388468 && value == 377, 828);
+        };
+        assert!(red_black_spec::find(tree, 2498228631, 1888636270) == 82, 829);
+        assert!(red_black_spec::insert_and_get_index(tree, 515868279, 533646702, 830) == 123, 830);
+        assert!(red_black_spec::insert_and_get_index(tree, 747813787, 838514199, 831) == 124, 831);
+        assert!(red_black_spec::insert_and_get_index(tree, 2888209375, 3563678726, 832) == 125, 832);
+        assert!(red_black_spec::insert_and_get_index(tree, 3538880804, 204635312, 833) == 126, 833);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 87);
+            assert!(key0 == 1295957935 && key1 == 3027937565 && value == 786, 834);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 818105109, 1620057641, 835) == 126, 835);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 86);
+            assert!(key0 == 2651188402 && key1 == 1223715818 && value == 740, 836);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 81);
+            assert!(key0 == 2659135985 && key1 == 2237144669 && value == 825, 837);
+        };
+        assert!(red_black_spec::find(tree, 3215896528, 3731850832) == 71, 838);
+        assert!(red_black_spec::find(tree, 3406878758, 3258325414) == red_black_spec::null_index_value(), 839);
+        assert!(red_black_spec::find(tree, 3541725237, 1259193341) == red_black_spec::null_index_value(), 840);
+        assert!(red_black_spec::find(tree, 4173601965, 681399419) == 1, 841);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 24);
+            assert!(key0 == 3258389408 && key1 == 1622803221 && value == 586, 842);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3710701967, 581802903, 843) == 124, 843);
+        assert!(red_black_spec::find(tree, 1996815131, 1192772308) == 100, 844);
+        assert!(red_black_spec::insert_and_get_index(tree, 3691577264, 3658521523, 845) == 125, 845);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 42);
+            assert!(key0 == 1886749641 && key1 == 4259025631 && value == 780, 846);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3226028093, 4221046026, 847) == 125, 847);
+        assert!(red_black_spec::insert_and_get_index(tree, 1038755905, 3252436752, 848) == 126, 848);
+        assert!(red_black_spec::find(tree, 2481264614, 2132355243) == red_black_spec::null_index_value(), 849);
+        assert!(red_black_spec::find(tree, 4032431796, 1694050263) == 111, 850);
+        assert!(red_black_spec::insert_and_get_index(tree, 3474554884, 1646663338, 851) == 127, 851);
+        assert!(red_black_spec::find(tree, 1680954987, 2116487929) == 79, 852);
+        assert!(red_black_spec::insert_and_get_index(tree, 2326399608, 1823178716, 853) == 128, 853);
+        assert!(red_black_spec::insert_and_get_index(tree, 1754459221, 3156030722, 854) == 129, 854);
+        assert!(red_black_spec::find(tree, 3164169908, 2966757808) == 99, 855);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 129);
+            assert!(key0 == 1754459221 && key1 == 3156030722 && value == 854, 856);
+        };
+        assert!(red_black_spec::find(tree, 2888209375, 3563678726) == 81, 857);
+        assert!(red_black_spec::insert_and_get_index(tree, 633339176, 3889006055, 858) == 129, 858);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 69);
+            assert!(key0 == 4065298861 && key1 == 31299970 && value == 463, 859);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1037607231, 2589252170, 860) == 129, 860);
+        assert!(red_black_spec::find(tree, 4173601965, 681399419) == 1, 861);
+        assert!(red_black_spec::find(tree, 334039324, 1107169851) == 104, 862);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 7);
+            assert!(key0 == 256287642 && key1 == 861833899 && value == 465, 863);
+        };
+        assert!(red_black_spec::find(tree, 1013207353, 1583270081) == 28, 864);
+        assert!(red_black_spec::insert_and_get_index(tree, 1617987325, 2563846194, 865) == 129, 865);
+        assert!(red_black_spec::find(tree, 3296030356, 2015070422) == 53, 866);
+        assert!(red_black_spec::insert_and_get_index(tree, 2981976279, 444108019, 867) == 130, 867);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 65);
+            assert!(key0 == 2671849666 && key1 == 1012222005 && value == 437, 868);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2675903200, 3364158180, 869) == 130, 869);
+        assert!(red_black_spec::insert_and_get_index(tree, 3781296856, 2604470939, 870) == 131, 870);
+        assert!(red_black_spec::find(tree, 750290346, 3109659740) == red_black_spec::null_index_value(), 871);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 77);
+            assert!(key0 == 1433997490 && key1 == 90982801 && value == 705, 872);
+        };
+        assert!(red_black_spec::find(tree, 420173855, 3987020002) == red_black_spec::null_index_value(), 873);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 25);
+            assert!(key0 == 1305716118 && key1 == 721472415 && value == 296, 874);
+        };
+        assert!(red_black_spec::find(tree, 3178604436, 2647434364) == red_black_spec::null_index_value(), 875);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 115);
+            assert!(key0 == 1932382675 && key1 == 3823781801 && value == 820, 876);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3178970913, 70403289, 877) == 129, 877);
+        assert!(red_black_spec::insert_and_get_index(tree, 73507733, 3278334683, 878) == 130, 878);
+        assert!(red_black_spec::insert_and_get_index(tree, 4159306942, 1567690252, 879) == 131, 879);
+        assert!(red_black_spec::insert_and_get_index(tree, 1608820516, 2249283165, 880) == 132, 880);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 62);
+            assert!(key0 == 3706837028 && key1 == 1471669547 && value == 375, 881);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 119);
+            assert!(key0 == 293269191 && key1 == 2356159777 && value == 719, 882);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 30);
+            assert!(key0 == 58237510 && key1 == 3469772490 && value == 301, 883);
+        };
+        assert!(red_black_spec::find(tree, 334039324, 1107169851) == 104, 884);
+        assert!(red_black_spec::insert_and_get_index(tree, 3700410494, 3743921027, 885) == 130, 885);
+        assert!(red_black_spec::insert_and_get_index(tree, 3957860748, 3799150201, 886) == 131, 886);
+        assert!(red_black_spec::find(tree, 41883229, 1342120790) == red_black_spec::null_index_value(), 887);
+        assert!(red_black_spec::insert_and_get_index(tree, 4274529572, 1712601072, 888) == 132, 888);
+        assert!(red_black_spec::insert_and_get_index(tree, 525575444, 2839672205, 889) == 133, 889);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 47);
+            assert!(key0 == 2015113421 && key1 == 102259902 && value == 641, 890);
+        };
+        assert!(red_black_spec::find(tree, 3957031654, 105641030) == 116, 891);
+        assert!(red_black_spec::insert_and_get_index(tree, 3522074858, 2076582191, 892) == 133, 892);
+        assert!(red_black_spec::find(tree, 713355237, 269661230) == red_black_spec::null_index_value(), 893);
+        assert!(red_black_spec::insert_and_get_index(tree, 4095290174, 1045658598, 894) == 134, 894);
+        assert!(red_black_spec::insert_and_get_index(tree, 653033120, 3593781800, 895) == 135, 895);
+        assert!(red_black_spec::insert_and_get_index(tree, 187374370, 143813603, 896) == 136, 896);
+        assert!(red_black_spec::insert_and_get_index(tree, 1380543672, 2632028241, 897) == 137, 897);
+        assert!(red_black_spec::insert_and_get_index(tree, 2981534957, 4132876090, 898) == 138, 898);
+        assert!(red_black_spec::insert_and_get_index(tree, 923322239, 2134756660, 899) == 139, 899);
+        check(
+            tree,
+            vector<u64>[55, 30, 40, 136, 107, 92, 73, 98, 104, 112, 56, 96, 123, 14, 20, 47, 31, 18, 38, 69, 135, 50, 46, 91, 80, 24, 58, 86, 102, 4, 139, 109, 110, 13, 94, 28, 3, 74, 7, 126, 9, 26, 22, 93, 67, 137, 120, 85, 23, 103, 36, 27, 62, 115, 76, 79, 12, 5, 101, 33, 106, 15, 75, 21, 78, 100, 68, 39, 35, 57, 34, 122, 128, 59, 45, 82, 83, 52, 61, 25, 121, 6, 63, 44, 70, 90, 81, 60, 51, 17, 138, 65, 19, 48, 99, 129, 71, 125, 95, 53, 41, 10, 114, 89, 127, 64, 133, 87, 117, 32, 84, 11, 49, 42, 130, 72, 124, 113, 37, 105, 77, 66, 2, 116, 131, 54, 108, 29, 111, 43, 0, 134, 118, 88, 16, 119, 1, 97, 132, 8],
+            vector<u128>[19616911, 73507733, 141365392, 187374370, 205844106, 238411403, 296612892, 316394150, 334039324, 403454114, 459291366, 482318605, 515868279, 519326218, 523023790, 525575444, 533991842, 575918684, 607043002, 633339176, 653033120, 662223842, 673767359, 689132252, 705614332, 747813787, 775613860, 818105109, 821743677, 910996955, 923322239, 933769992, 977029689, 988379199, 991676491, 1013207353, 1019209613, 1031540325, 1037607231, 1038755905, 1053245831, 1108373637, 1292148412, 1298117481, 1355003799, 1380543672, 1386195377, 1387178041, 1408506686, 1437090954, 1438893163, 1477468202, 1608820516, 1617987325, 1625585007, 1680954987, 1704779303, 1812861632, 1833340671, 1843570114, 1859564327, 1940420555, 1949514269, 1974084007, 1974223204, 1996815131, 2092627465, 2191392508, 2195723343, 2280800472, 2317956939, 2318011241, 2326399608, 2348973037, 2474913367, 2498228631, 2536674553, 2549196138, 2665764580, 2675903200, 2712819153, 2747641874, 2761008937, 2762292049, 2773304619, 2812856818, 2888209375, 2907281500, 2923795824, 2953580965, 2981534957, 2981976279, 3009497522, 3038856849, 3164169908, 3178970913, 3215896528, 3226028093, 3248995682, 3296030356, 3318989265, 3342422998, 3401514881, 3427936494, 3474554884, 3493280604, 3522074858, 3538880804, 3545608574, 3615261425, 3643913268, 3677914954, 3680463813, 3691577264, 3700410494, 3702474663, 3710701967, 3726606076, 3739422580, 3740481796, 3781296856, 3836330996, 3940215367, 3957031654, 3957860748, 3958029710, 3976051474, 3982832851, 4032431796, 4034924286, 4059942777, 4095290174, 4104441229, 4128457590, 4142577611, 4159306942, 4173601965, 4258651898, 4274529572, 4287896988],
+            vector<u128>[4284596764, 3278334683, 606574994, 143813603, 2150869414, 1945637509, 2680106801, 2968432454, 1107169851, 564785669, 3585369399, 3820850787, 533646702, 1783097639, 2342848850, 2839672205, 3283137944, 4239214574, 2037844163, 3889006055, 3593781800, 2722483051, 2482698471, 729680389, 3909895335, 838514199, 2644931538, 1620057641, 2501251229, 1578738510, 2134756660, 1074434548, 1920329146, 2478966067, 2227575589, 1583270081, 1420656008, 1690471635, 2589252170, 3252436752, 761446132, 1326113566, 2167077531, 1139826030, 3403949306, 2632028241, 1637553013, 2857709938, 2342108075, 2330732027, 2663225304, 2270675062, 2249283165, 2563846194, 1533197189, 2116487929, 683376306, 3495503649, 904725446, 4250229751, 3108911598, 3363836527, 2955517683, 145179341, 920406158, 1192772308, 833809208, 1080786072, 3946693334, 221898042, 2398065997, 974690174, 1823178716, 2469803465, 214199826, 1888636270, 1307328520, 168105887, 2008651843, 3364158180, 2860000942, 505695706, 2700230886, 2081311082, 744206771, 1098474667, 3563678726, 239671848, 821994911, 3955132159, 4132876090, 444108019, 3603743804, 1640797966, 2966757808, 70403289, 3731850832, 4221046026, 1320329472, 2015070422, 1197905734, 1186024987, 3646370565, 2169865563, 1646663338, 860246842, 2076582191, 204635312, 4000436273, 1318417787, 1217123491, 706953441, 3402877974, 3658521523, 3743921027, 4118991097, 581802903, 1838413002, 3613266593, 1331125155, 2604470939, 814727354, 1404315863, 105641030, 3799150201, 3629596816, 297583285, 2070490924, 1694050263, 1644292067, 2460250847, 1045658598, 2101244207, 3413729376, 3831793744, 1567690252, 681399419, 3880855495, 1712601072, 1059340875],
+            vector<u64>[729, 878, 288, 896, 639, 571, 427, 617, 630, 694, 790, 725, 830, 308, 816, 889, 576, 750, 587, 858, 895, 460, 720, 817, 444, 831, 591, 835, 782, 332, 899, 801, 687, 542, 574, 815, 518, 430, 860, 848, 785, 822, 794, 573, 394, 897, 722, 593, 661, 668, 640, 787, 880, 865, 644, 435, 791, 765, 623, 609, 636, 517, 431, 190, 489, 619, 660, 524, 270, 665, 769, 776, 853, 796, 560, 509, 559, 657, 546, 869, 775, 626, 678, 547, 535, 658, 832, 590, 743, 744, 898, 867, 671, 319, 618, 877, 758, 847, 583, 611, 291, 699, 799, 568, 851, 541, 892, 833, 712, 717, 676, 177, 727, 845, 885, 456, 843, 702, 650, 673, 870, 454, 117, 709, 886, 767, 804, 401, 688, 689, 511, 894, 777, 753, 442, 879, 476, 696, 888, 733],
+            899,
+        );
+        assert!(red_black_spec::insert_and_get_index(tree, 1384081158, 3097437829, 900) == 140, 900);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 54);
+            assert!(key0 == 3958029710 && key1 == 3629596816 && value == 767, 901);
+        };
+        assert!(red_black_spec::find(tree, 4258651898, 3880855495) == 97, 902);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 2);
+            assert!(key0 == 3940215367 && key1 == 1404315863 && value == 117, 903);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3050178815, 304300837, 904) == 139, 904);
+        assert!(red_black_spec::find(tree, 316394150, 2968432454) == 98, 905);
+        assert!(red_black_spec::find(tree, 2268774112, 3344501316) == red_black_spec::null_index_value(), 906);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 79);
+            assert!(key0 == 1680954987 && key1 == 2116487929 && value == 435, 907);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1251722367, 1238238216, 908) == 139, 908);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 101);
+            assert!(key0 == 1833340671 && key1 == 904725446 && value == 623, 909);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 137);
+            assert!(key0 == 1380543672 && key1 == 2632028241 && value == 897, 910);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 109);
+            assert!(key0 == 933769992 && key1 == 1074434548 && value == 801, 911);
+        };
+        assert!(red_black_spec::find(tree, 1625585007, 1533197189) == 76, 912);
+        assert!(red_black_spec::find(tree, 3050178815, 304300837) == 79, 913);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 79);
+            assert!(key0 == 3050178815 && key1 == 304300837 && value == 904, 914);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 67);
+            assert!(key0 == 1355003799 && key1 == 3403949306 && value == 394, 915);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 435459792, 1050282329, 916) == 135, 916);
+        assert!(red_black_spec::find(tree, 1038755905, 3252436752) == 126, 917);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 86);
+            assert!(key0 == 818105109 && key1 == 1620057641 && value == 835, 918);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3426149504, 983954815, 919) == 135, 919);
+        assert!(red_black_spec::find(tree, 3702474663, 4118991097) == 72, 920);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 55);
+            assert!(key0 == 19616911 && key1 == 4284596764 && value == 729, 921);
+        };
+        assert!(red_black_spec::find(tree, 1704779303, 683376306) == 12, 922);
+        assert!(red_black_spec::insert_and_get_index(tree, 451597607, 2975269931, 923) == 135, 923);
+        assert!(red_black_spec::insert_and_get_index(tree, 2762338007, 1569523087, 924) == 136, 924);
+        assert!(red_black_spec::insert_and_get_index(tree, 509494953, 3379395903, 925) == 137, 925);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 55);
+            assert!(key0 == 3426149504 && key1 == 983954815 && value == 919, 926);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1969171681, 3253542924, 927) == 137, 927);
+        assert!(red_black_spec::insert_and_get_index(tree, 1056607747, 2134955596, 928) == 138, 928);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 3);
+            assert!(key0 == 1019209613 && key1 == 1420656008 && value == 518, 929);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 4246565072, 1931120184, 930) == 138, 930);
+        assert!(red_black_spec::insert_and_get_index(tree, 4257700449, 1267986010, 931) == 139, 931);
+        assert!(red_black_spec::insert_and_get_index(tree, 1625034989, 389636468, 932) == 140, 932);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 89);
+            assert!(key0 == 3427936494 && key1 == 2169865563 && value == 568, 933);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 16);
+            assert!(key0 == 4142577611 && key1 == 3831793744 && value == 442, 934);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3515962021, 2845762699, 935) == 139, 935);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 30);
+            assert!(key0 == 73507733 && key1 == 3278334683 && value == 878, 936);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3119164103, 3297373564, 937) == 139, 937);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 15);
+            assert!(key0 == 1940420555 && key1 == 3363836527 && value == 517, 938);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 43);
+            assert!(key0 == 4034924286 && key1 == 1644292067 && value == 689, 939);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 73);
+            assert!(key0 == 296612892 && key1 == 2680106801 && value == 427, 940);
+        };
+        assert!(red_black_spec::find(tree, 662223842, 2722483051) == 50, 941);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 102);
+            assert!(key0 == 821743677 && key1 == 2501251229 && value == 782, 942);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 58);
+            assert!(key0 == 775613860 && key1 == 2644931538 && value == 591, 943);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 66);
+            assert!(key0 == 3836330996 && key1 == 814727354 && value == 454, 944);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 920089223, 1315114740, 945) == 134, 945);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 113);
+            assert!(key0 == 3726606076 && key1 == 1838413002 && value == 702, 946);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2635546568, 1032940250, 947) == 134, 947);
+        assert!(red_black_spec::insert_and_get_index(tree, 2857821231, 1321679421, 948) == 135, 948);
+        assert!(red_black_spec::insert_and_get_index(tree, 16409702, 1839289047, 949) == 136, 949);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 136);
+            assert!(key0 == 16409702 && key1 == 1839289047 && value == 949, 950);
+        };
+        assert!(red_black_spec::find(tree, 2280800472, 221898042) == 57, 951);
+        assert!(red_black_spec::find(tree, 3033787278, 2479617288) == red_black_spec::null_index_value(), 952);
+        assert!(red_black_spec::insert_and_get_index(tree, 3773561672, 1418997081, 953) == 136, 953);
+        assert!(red_black_spec::find(tree, 334039324, 1107169851) == 104, 954);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 110);
+            assert!(key0 == 977029689 && key1 == 1920329146 && value == 687, 955);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 21);
+            assert!(key0 == 1974084007 && key1 == 145179341 && value == 190, 956);
+        };
+        assert!(red_black_spec::find(tree, 648616989, 1884455728) == red_black_spec::null_index_value(), 957);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 52);
+            assert!(key0 == 2549196138 && key1 == 168105887 && value == 657, 958);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 74);
+            assert!(key0 == 1031540325 && key1 == 1690471635 && value == 430, 959);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1188400554, 22229276, 960) == 133, 960);
+        assert!(red_black_spec::insert_and_get_index(tree, 278798856, 2121732195, 961) == 134, 961);
+        assert!(red_black_spec::insert_and_get_index(tree, 1743749648, 851280322, 962) == 135, 962);
+        assert!(red_black_spec::find(tree, 3970217301, 1850714787) == red_black_spec::null_index_value(), 963);
+        assert!(red_black_spec::find(tree, 4128457590, 3413729376) == 88, 964);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 93);
+            assert!(key0 == 1298117481 && key1 == 1139826030 && value == 573, 965);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 2537505969, 34624170, 966) == 135, 966);
+        assert!(red_black_spec::insert_and_get_index(tree, 2487700732, 2955793216, 967) == 136, 967);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 2923795824 && key1 == 821994911 && value == 743, 968);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 51);
+            assert!(key0 == 2487700732 && key1 == 2955793216 && value == 967, 969);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 60);
+            assert!(key0 == 2907281500 && key1 == 239671848 && value == 590, 970);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1607404897, 3643995141, 971) == 134, 971);
+        assert!(red_black_spec::insert_and_get_index(tree, 3169365540, 1585471018, 972) == 135, 972);
+        assert!(red_black_spec::find(tree, 598190523, 2510888263) == red_black_spec::null_index_value(), 973);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 114);
+            assert!(key0 == 3401514881 && key1 == 3646370565 && value == 799, 974);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 130);
+            assert!(key0 == 3700410494 && key1 == 3743921027 && value == 885, 975);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1732416884, 4068289703, 976) == 134, 976);
+        assert!(red_black_spec::insert_and_get_index(tree, 2087204102, 1036915344, 977) == 135, 977);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 107);
+            assert!(key0 == 205844106 && key1 == 2150869414 && value == 639, 978);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 3446151498, 3275897573, 979) == 135, 979);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 133);
+            assert!(key0 == 1188400554 && key1 == 22229276 && value == 960, 980);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1956449827, 1244199064, 981) == 135, 981);
+        assert!(red_black_spec::find(tree, 2888209375, 3563678726) == 81, 982);
+        assert!(red_black_spec::find(tree, 2812856818, 1098474667) == 90, 983);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 27);
+            assert!(key0 == 1477468202 && key1 == 2270675062 && value == 787, 984);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 97);
+            assert!(key0 == 4258651898 && key1 == 3880855495 && value == 696, 985);
+        };
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 109);
+            assert!(key0 == 2981534957 && key1 == 4132876090 && value == 898, 986);
+        };
+        assert!(red_black_spec::find(tree, 3318989265, 1197905734) == 41, 987);
+        assert!(red_black_spec::insert_and_get_index(tree, 3342908407, 901108206, 988) == 133, 988);
+        assert!(red_black_spec::find(tree, 2536894725, 1049594900) == red_black_spec::null_index_value(), 989);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 124);
+            assert!(key0 == 3710701967 && key1 == 581802903 && value == 843, 990);
+        };
+    }
+
+    fun ops_5(tree: &mut RedBlackTree<u64>) {
+        assert!(red_black_spec::insert_and_get_index(tree, 735681631, 4250322599, 991) == 133, 991);
+        assert!(red_black_spec::find(tree, 2648991117, 2400402612) == red_black_spec::null_index_value(), 992);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 79);
+            assert!(key0 == 187374370 && key1 == 143813603 && value == 896, 993);
+        };
+        assert!(red_black_spec::insert_and_get_index(tree, 1136383835, 1032155310, 994) == 133, 994);
+        {
+            let (key0, key1, value) = red_black_spec::remove(tree, 98);
+            assert!(key0 == 316394150 && key1 == 2968432454 && value == 617, 995);
+        };
+        assert!(red_black_spec::find(tree, 2534620458, 1426144576) == red_black_spec::null_index_value(), 996);
+        assert!(red_black_spec::find(tree, 2489951642, 1966679235) == red_black_spec::null_index_value(), 997);
+        assert!(red_black_spec::find(tree, 249232725, 3675227525) == red_black_spec::null_index_value(), 998);
+        assert!(red_black_spec::insert_and_get_index(tree, 4235080813, 1944396120, 999) == 133, 999);
+        check(
+            tree,
+            vector<u64>[40, 92, 60, 104, 112, 86, 58, 56, 96, 55, 123, 14, 20, 47, 31, 18, 38, 69, 67, 50, 46, 91, 80, 79, 24, 4, 113, 2, 13, 94, 28, 7, 126, 9, 3, 26, 98, 101, 22, 54, 120, 85, 23, 103, 36, 130, 62, 115, 89, 76, 12, 97, 93, 5, 33, 106, 75, 27, 73, 78, 100, 107, 68, 39, 35, 57, 34, 122, 128, 59, 45, 82, 83, 51, 52, 61, 25, 121, 6, 63, 44, 102, 70, 90, 21, 81, 17, 65, 19, 48, 15, 99, 114, 129, 71, 125, 95, 53, 41, 10, 124, 109, 127, 64, 30, 74, 87, 117, 32, 84, 11, 49, 42, 72, 37, 105, 110, 77, 116, 131, 108, 29, 111, 0, 66, 118, 88, 119, 1, 133, 43, 16, 132, 8],
+            vector<u128>[141365392, 238411403, 278798856, 334039324, 403454114, 435459792, 451597607, 459291366, 482318605, 509494953, 515868279, 519326218, 523023790, 525575444, 533991842, 575918684, 607043002, 633339176, 653033120, 662223842, 673767359, 689132252, 705614332, 735681631, 747813787, 910996955, 920089223, 923322239, 988379199, 991676491, 1013207353, 1037607231, 1038755905, 1053245831, 1056607747, 1108373637, 1136383835, 1251722367, 1292148412, 1384081158, 1386195377, 1387178041, 1408506686, 1437090954, 1438893163, 1607404897, 1608820516, 1617987325, 1625034989, 1625585007, 1704779303, 1732416884, 1743749648, 1812861632, 1843570114, 1859564327, 1949514269, 1956449827, 1969171681, 1974223204, 1996815131, 2087204102, 2092627465, 2191392508, 2195723343, 2280800472, 2317956939, 2318011241, 2326399608, 2348973037, 2474913367, 2498228631, 2536674553, 2537505969, 2635546568, 2665764580, 2675903200, 2712819153, 2747641874, 2761008937, 2762292049, 2762338007, 2773304619, 2812856818, 2857821231, 2888209375, 2953580965, 2981976279, 3009497522, 3038856849, 3119164103, 3164169908, 3169365540, 3178970913, 3215896528, 3226028093, 3248995682, 3296030356, 3318989265, 3342422998, 3342908407, 3446151498, 3474554884, 3493280604, 3515962021, 3522074858, 3538880804, 3545608574, 3615261425, 3643913268, 3677914954, 3680463813, 3691577264, 3702474663, 3739422580, 3740481796, 3773561672, 3781296856, 3957031654, 3957860748, 3976051474, 3982832851, 4032431796, 4059942777, 4095290174, 4104441229, 4128457590, 4159306942, 4173601965, 4235080813, 4246565072, 4257700449, 4274529572, 4287896988],
+            vector<u128>[606574994, 1945637509, 2121732195, 1107169851, 564785669, 1050282329, 2975269931, 3585369399, 3820850787, 3379395903, 533646702, 1783097639, 2342848850, 2839672205, 3283137944, 4239214574, 2037844163, 3889006055, 3593781800, 2722483051, 2482698471, 729680389, 3909895335, 4250322599, 838514199, 1578738510, 1315114740, 2134756660, 2478966067, 2227575589, 1583270081, 2589252170, 3252436752, 761446132, 2134955596, 1326113566, 1032155310, 1238238216, 2167077531, 3097437829, 1637553013, 2857709938, 2342108075, 2330732027, 2663225304, 3643995141, 2249283165, 2563846194, 389636468, 1533197189, 683376306, 4068289703, 851280322, 3495503649, 4250229751, 3108911598, 2955517683, 1244199064, 3253542924, 920406158, 1192772308, 1036915344, 833809208, 1080786072, 3946693334, 221898042, 2398065997, 974690174, 1823178716, 2469803465, 214199826, 1888636270, 1307328520, 34624170, 1032940250, 2008651843, 3364158180, 2860000942, 505695706, 2700230886, 2081311082, 1569523087, 744206771, 1098474667, 1321679421, 3563678726, 3955132159, 444108019, 3603743804, 1640797966, 3297373564, 2966757808, 1585471018, 70403289, 3731850832, 4221046026, 1320329472, 2015070422, 1197905734, 1186024987, 901108206, 3275897573, 1646663338, 860246842, 2845762699, 2076582191, 204635312, 4000436273, 1318417787, 1217123491, 706953441, 3402877974, 3658521523, 4118991097, 3613266593, 1331125155, 1418997081, 2604470939, 105641030, 3799150201, 297583285, 2070490924, 1694050263, 2460250847, 1045658598, 2101244207, 3413729376, 1567690252, 681399419, 1944396120, 1931120184, 1267986010, 1712601072, 1059340875],
+            vector<u64>[288, 571, 961, 630, 694, 916, 923, 790, 725, 925, 830, 308, 816, 889, 576, 750, 587, 858, 895, 460, 720, 817, 444, 991, 831, 332, 945, 899, 542, 574, 815, 860, 848, 785, 928, 822, 994, 908, 794, 900, 722, 593, 661, 668, 640, 971, 880, 865, 932, 644, 791, 976, 962, 765, 609, 636, 431, 981, 927, 489, 619, 977, 660, 524, 270, 665, 769, 776, 853, 796, 560, 509, 559, 966, 947, 546, 869, 775, 626, 678, 547, 924, 535, 658, 948, 832, 744, 867, 671, 319, 937, 618, 972, 877, 758, 847, 583, 611, 291, 699, 988, 979, 851, 541, 935, 892, 833, 712, 717, 676, 177, 727, 845, 456, 650, 673, 953, 870, 709, 886, 804, 401, 688, 511, 894, 777, 753, 879, 476, 999, 930, 931, 888, 733],
+            999,
+        );
+    }
+
+    #[test]
+    fun test_random_ops() {
+        let tree = red_black_spec::new<u64>();
+        ops_0(&mut tree);
+        ops_1(&mut tree);
+        ops_2(&mut tree);
+        ops_3(&mut tree);
+        ops_4(&mut tree);
+        ops_5(&mut tree);
+    }
+}
@@ -2,7 +2,4 @@ package container
 
 // Run go generate to generate the files.
 
-//go:generate go run .. red-black --key-width 256
-//go:generate go run .. avl --key-width 256
-//go:generate go run .. bst --key-width 256
-//go:generate go run .. critbit --key-width 256
+//go:generate go run .. build -f containers.toml
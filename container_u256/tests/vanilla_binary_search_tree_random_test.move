@@ -0,0 +1,2080 @@
+// Code generated from github.com/fardream/gen-move-container
+// Caution when editing manually.
+// 1000 random operations on BinarySearchTree of vanilla_binary_search_tree with seed 1.
+// The expected results are computed by the reference model of BinarySearchTree in the verifier.
+// The abort code of a failed assert is the number of the operation.
+#[test_only]
+module container::vanilla_binary_search_tree_random_test {
+    use std::vector;
+    use container::vanilla_binary_search_tree::{Self, BinarySearchTree};
+
+    /// check the size, min, max, and the indices, keys and values of the tree in order.
+    fun check(tree: &BinarySearchTree<u64>, indices: vector<u64>, key0: vector<u256>, values: vector<u64>, code: u64) {
+        let size = vector::length(&indices);
+        assert!(vanilla_binary_search_tree::size(tree) == size, code);
+        if (size == 0) {
+            return
+        };
+        assert!(vanilla_binary_search_tree::get_min_index(tree) == *vector::borrow(&indices, 0), code);
+        assert!(vanilla_binary_search_tree::get_max_index(tree) == *vector::borrow(&indices, size - 1), code);
+
+        let iter = vanilla_binary_search_tree::get_min_index(tree);
+        let i = 0;
+        while (i < size) {
+            assert!(iter == *vector::borrow(&indices, i), code);
+            let (key0_at, value) = vanilla_binary_search_tree::borrow_at_index(tree, iter);
+            assert!(key0_at == *vector::borrow(&key0, i), code);
+            assert!(*value == *vector::borrow(&values, i), code);
+            iter = vanilla_binary_search_tree::next_in_order(tree, iter);
+            i = i + 1;
+        };
+        assert!(iter == vanilla_binary_search_tree::null_index_value(), code);
+    }
+
+    fun ops_0(tree: &mut BinarySearchTree<u64>) {
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8674665223082153551, 0) == 0, 0);
+        assert!(vanilla_binary_search_tree::find(tree, 8674665223082153551) == 0, 1);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9828766684487745566, 2) == 1, 2);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 8674665223082153551 && value == 0, 3);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 9828766684487745566 && value == 2, 4);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11199607447739267382, 5) == 0, 5);
+        assert!(vanilla_binary_search_tree::find(tree, 12156940908066221323) == vanilla_binary_search_tree::null_index_value(), 6);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11833901312327420776, 7) == 1, 7);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 11833901312327420776 && value == 7, 8);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 11199607447739267382) == 0, 9);
+        assert!(vanilla_binary_search_tree::find(tree, 11199607447739267382) == 0, 10);
+        assert!(vanilla_binary_search_tree::find(tree, 11199607447739267382) == 0, 11);
+        assert!(vanilla_binary_search_tree::find(tree, 15649472107743074779) == vanilla_binary_search_tree::null_index_value(), 12);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 11199607447739267382 && value == 5, 13);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5600924393587988459, 14) == 0, 14);
+        assert!(vanilla_binary_search_tree::find(tree, 9956202364908137547) == vanilla_binary_search_tree::null_index_value(), 15);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9768663798983814715, 16) == 1, 16);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 5600924393587988459 && value == 14, 17);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4990765271833742716, 18) == 1, 18);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11792151447964398879, 19) == 2, 19);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14117161486975057715, 20) == 3, 20);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2601737961087659062, 21) == 4, 21);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3337066551442961397, 22) == 5, 22);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11963748953446345529, 23) == 6, 23);
+        assert!(vanilla_binary_search_tree::find(tree, 898860202204764712) == vanilla_binary_search_tree::null_index_value(), 24);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 2601737961087659062 && value == 21, 25);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 4990765271833742716) == 1, 26);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8603989663476771718, 27) == 6, 27);
+        assert!(vanilla_binary_search_tree::find(tree, 7388428680384065704) == vanilla_binary_search_tree::null_index_value(), 28);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1687184559264975024, 29) == 7, 29);
+        assert!(vanilla_binary_search_tree::find(tree, 11792151447964398879) == 2, 30);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 11792151447964398879 && value == 19, 31);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 9768663798983814715 && value == 16, 32);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 1687184559264975024 && value == 29, 33);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 3337066551442961397) == 2, 34);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 4990765271833742716 && value == 18, 35);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 14117161486975057715 && value == 20, 36);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10428415896243638596, 37) == 3, 37);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17490665426807838719, 38) == 4, 38);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6651414131918424343, 39) == 5, 39);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 6651414131918424343 && value == 39, 40);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 10428415896243638596 && value == 37, 41);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11407674492757219439, 42) == 4, 42);
+        assert!(vanilla_binary_search_tree::find(tree, 17490665426807838719) == 3, 43);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1169089424364679180, 44) == 5, 44);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 3337066551442961397 && value == 22, 45);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 11963748953446345529 && value == 23, 46);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5751776211841778805, 47) == 4, 47);
+        assert!(vanilla_binary_search_tree::find(tree, 8603989663476771718) == 0, 48);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 8603989663476771718 && value == 27, 49);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14794086776323742620, 50) == 4, 50);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9497041302863216379, 51) == 5, 51);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 5751776211841778805 && value == 47, 52);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14663632165210175172, 53) == 5, 53);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16744157289148322445, 54) == 6, 54);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13451757574255826437, 55) == 7, 55);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 14663632165210175172 && value == 53, 56);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2303013289404122822, 57) == 7, 57);
+        assert!(vanilla_binary_search_tree::find(tree, 2282476590775666788) == vanilla_binary_search_tree::null_index_value(), 58);
+        assert!(vanilla_binary_search_tree::find(tree, 13451757574255826437) == 5, 59);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 279676139769146943, 60) == 8, 60);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 279676139769146943 && value == 60, 61);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 9497041302863216379) == 0, 62);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3281373847403844559, 63) == 8, 63);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 16744157289148322445 && value == 54, 64);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 14794086776323742620) == 4, 65);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3617555776104743529, 66) == 8, 66);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 1169089424364679180 && value == 44, 67);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5428658603350578075, 68) == 8, 68);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 7);
+            assert!(key0 == 2303013289404122822 && value == 57, 69);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 14794086776323742620 && value == 50, 70);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 17490665426807838719 && value == 38, 71);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 9497041302863216379 && value == 51, 72);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 3281373847403844559 && value == 63, 73);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 3617555776104743529) == 2, 74);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 919843791599379793, 75) == 4, 75);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 3617555776104743529 && value == 66, 76);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 13451757574255826437) == 0, 77);
+        assert!(vanilla_binary_search_tree::find(tree, 5428658603350578075) == 3, 78);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 13451757574255826437 && value == 55, 79);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 11407674492757219439 && value == 42, 80);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6607332037155172840, 81) == 2, 81);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 118298131398851786, 82) == 3, 82);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10127547266291660615, 83) == 4, 83);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7622693872122742700, 84) == 5, 84);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 5428658603350578075 && value == 68, 85);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 6607332037155172840 && value == 81, 86);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 12931821027969541464) == vanilla_binary_search_tree::null_index_value(), 87);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 919843791599379793 && value == 75, 88);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 7622693872122742700 && value == 84, 89);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9757647480442399021, 90) == 2, 90);
+        assert!(vanilla_binary_search_tree::find(tree, 9757647480442399021) == 2, 91);
+        assert!(vanilla_binary_search_tree::find(tree, 849635121368231514) == vanilla_binary_search_tree::null_index_value(), 92);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6591905403151965609, 93) == 3, 93);
+        assert!(vanilla_binary_search_tree::find(tree, 6591905403151965609) == 3, 94);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 6591905403151965609 && value == 93, 95);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 857498332500047840, 96) == 3, 96);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2876636394410322752, 97) == 4, 97);
+        assert!(vanilla_binary_search_tree::find(tree, 118298131398851786) == 1, 98);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12914457515001554559, 99) == 5, 99);
+        check(
+            tree,
+            vector<u64>[1, 3, 4, 2, 0, 5],
+            vector<u256>[118298131398851786, 857498332500047840, 2876636394410322752, 9757647480442399021, 10127547266291660615, 12914457515001554559],
+            vector<u64>[82, 96, 97, 90, 83, 99],
+            99,
+        );
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8761126201432260190, 100) == 6, 100);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4606018198686923411, 101) == 7, 101);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9406074772821824226, 102) == 8, 102);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13177324915284969250, 103) == 9, 103);
+        assert!(vanilla_binary_search_tree::find(tree, 17408630500375936775) == vanilla_binary_search_tree::null_index_value(), 104);
+        assert!(vanilla_binary_search_tree::find(tree, 10127547266291660615) == 0, 105);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 9757647480442399021 && value == 90, 106);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 9891590185009426703) == vanilla_binary_search_tree::null_index_value(), 107);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 7);
+            assert!(key0 == 4606018198686923411 && value == 101, 108);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17040182257053926107, 109) == 8, 109);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 8761126201432260190 && value == 100, 110);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 692096105679558205, 111) == 8, 111);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5804560326627778270, 112) == 9, 112);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 5804560326627778270 && value == 112, 113);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9360130382033933288, 114) == 9, 114);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 2876636394410322752 && value == 97, 115);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 692096105679558205 && value == 111, 116);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16923096141865953495, 117) == 8, 117);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6100275367158842890, 118) == 9, 118);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 10127547266291660615 && value == 83, 119);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 13177324915284969250 && value == 103, 120);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12078452559500257731, 121) == 8, 121);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 12914457515001554559 && value == 99, 122);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 12974856637904390687) == vanilla_binary_search_tree::null_index_value(), 123);
+        assert!(vanilla_binary_search_tree::find(tree, 18060989106058760673) == vanilla_binary_search_tree::null_index_value(), 124);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7301888237939937549, 125) == 8, 125);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 140022567823035473, 126) == 9, 126);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17568459881417548670, 127) == 10, 127);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 395882274225087444, 128) == 11, 128);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12431805408027574535, 129) == 12, 129);
+        assert!(vanilla_binary_search_tree::find(tree, 395882274225087444) == 11, 130);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 140022567823035473 && value == 126, 131);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2903561752088116009, 132) == 12, 132);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 11);
+            assert!(key0 == 395882274225087444 && value == 128, 133);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10147548466419665037, 134) == 12, 134);
+        assert!(vanilla_binary_search_tree::find(tree, 7100973504029541625) == vanilla_binary_search_tree::null_index_value(), 135);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 12431805408027574535 && value == 129, 136);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11203203112869441632, 137) == 12, 137);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3238642280712712661, 138) == 13, 138);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14557720847513007339, 139) == 14, 139);
+        assert!(vanilla_binary_search_tree::find(tree, 3238642280712712661) == 13, 140);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2111392068471983631, 141) == 15, 141);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 7);
+            assert!(key0 == 9406074772821824226 && value == 102, 142);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12025452106090456275, 143) == 15, 143);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6193739207526038143, 144) == 16, 144);
+        assert!(vanilla_binary_search_tree::find(tree, 12025452106090456275) == 15, 145);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10460080154150440624, 146) == 17, 146);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 17040182257053926107 && value == 109, 147);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 6100275367158842890) == 0, 148);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16890217359975369936, 149) == 17, 149);
+        assert!(vanilla_binary_search_tree::find(tree, 17568459881417548670) == 10, 150);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3759749631911308224, 151) == 18, 151);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8710526160774049443, 152) == 19, 152);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2662218518393240646, 153) == 20, 153);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 9360130382033933288 && value == 114, 154);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2908700881822148014) == vanilla_binary_search_tree::null_index_value(), 155);
+        assert!(vanilla_binary_search_tree::find(tree, 10147548466419665037) == 9, 156);
+        assert!(vanilla_binary_search_tree::find(tree, 118298131398851786) == 1, 157);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 18);
+            assert!(key0 == 3759749631911308224 && value == 151, 158);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 15);
+            assert!(key0 == 12025452106090456275 && value == 143, 159);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16071861631370481113, 160) == 18, 160);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 14);
+            assert!(key0 == 14557720847513007339 && value == 139, 161);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 7301888237939937549 && value == 125, 162);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 12999599482447234547) == vanilla_binary_search_tree::null_index_value(), 163);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10120451956124535495, 164) == 17, 164);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 10);
+            assert!(key0 == 17568459881417548670 && value == 127, 165);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10231548038441433308, 166) == 17, 166);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11594289225202895019, 167) == 18, 167);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17768009244547818084, 168) == 19, 168);
+        assert!(vanilla_binary_search_tree::find(tree, 12078452559500257731) == 5, 169);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16727422438371618620, 170) == 20, 170);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 10147548466419665037 && value == 134, 171);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 13);
+            assert!(key0 == 3238642280712712661 && value == 138, 172);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 45008050450584446, 173) == 19, 173);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2117442618385149471, 174) == 20, 174);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8761626118042981173, 175) == 21, 175);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18102013175684963755, 176) == 22, 176);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15796524445606297825, 177) == 23, 177);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 11);
+            assert!(key0 == 2903561752088116009 && value == 132, 178);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12428202232618429866, 179) == 23, 179);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 18102013175684963755 && value == 176, 180);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 12428202232618429866 && value == 179, 181);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2117442618385149471) == 20, 182);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 12078452559500257731 && value == 121, 183);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12349886602107302616, 184) == 21, 184);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18317564845450656092, 185) == 22, 185);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 18317564845450656092 && value == 185, 186);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7283855682742174347, 187) == 22, 187);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15287453646770213028, 188) == 23, 188);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 2662218518393240646 && value == 153, 189);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8478626249766814413, 190) == 23, 190);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 21);
+            assert!(key0 == 12349886602107302616 && value == 184, 191);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2289079163147780107, 192) == 23, 192);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15677787644814386571, 193) == 24, 193);
+        assert!(vanilla_binary_search_tree::find(tree, 4724875543908344324) == vanilla_binary_search_tree::null_index_value(), 194);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 14);
+            assert!(key0 == 16071861631370481113 && value == 160, 195);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 19);
+            assert!(key0 == 45008050450584446 && value == 173, 196);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8707002453519502849, 197) == 23, 197);
+        assert!(vanilla_binary_search_tree::find(tree, 11490402676837613754) == vanilla_binary_search_tree::null_index_value(), 198);
+    }
+
+    fun ops_1(tree: &mut BinarySearchTree<u64>) {
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4952771997674410968, 199) == 24, 199);
+        check(
+            tree,
+            vector<u64>[1, 3, 7, 20, 19, 24, 0, 16, 22, 21, 23, 15, 5, 10, 17, 6, 12, 18, 4, 14, 11, 9, 8, 2, 13],
+            vector<u256>[118298131398851786, 857498332500047840, 2111392068471983631, 2117442618385149471, 2289079163147780107, 4952771997674410968, 6100275367158842890, 6193739207526038143, 7283855682742174347, 8478626249766814413, 8707002453519502849, 8710526160774049443, 8761626118042981173, 10120451956124535495, 10231548038441433308, 10460080154150440624, 11203203112869441632, 11594289225202895019, 15287453646770213028, 15677787644814386571, 15796524445606297825, 16727422438371618620, 16890217359975369936, 16923096141865953495, 17768009244547818084],
+            vector<u64>[82, 96, 141, 174, 192, 199, 118, 144, 187, 190, 197, 152, 175, 164, 166, 146, 137, 167, 188, 193, 177, 170, 149, 117, 168],
+            199,
+        );
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15418412571793227827, 200) == 25, 200);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6768616184571698394, 201) == 26, 201);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13129228254492538687, 202) == 27, 202);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18013657436889614586, 203) == 28, 203);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 12);
+            assert!(key0 == 11203203112869441632 && value == 137, 204);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 6100275367158842890) == 0, 205);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 26);
+            assert!(key0 == 6768616184571698394 && value == 201, 206);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 16890217359975369936 && value == 149, 207);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12791538999180417030, 208) == 26, 208);
+        assert!(vanilla_binary_search_tree::find(tree, 1165505497730535314) == vanilla_binary_search_tree::null_index_value(), 209);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13939643514151152253, 210) == 27, 210);
+        assert!(vanilla_binary_search_tree::find(tree, 9173624551887931713) == vanilla_binary_search_tree::null_index_value(), 211);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15931705701629082341, 212) == 28, 212);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13964402647532625332, 213) == 29, 213);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14255668113152614846, 214) == 30, 214);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11928442744569509227, 215) == 31, 215);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 857498332500047840 && value == 96, 216);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 15418412571793227827) == 25, 217);
+        assert!(vanilla_binary_search_tree::find(tree, 118298131398851786) == 1, 218);
+        assert!(vanilla_binary_search_tree::find(tree, 15677787644814386571) == 14, 219);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 16);
+            assert!(key0 == 6193739207526038143 && value == 144, 220);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 751471516496209043, 221) == 30, 221);
+        assert!(vanilla_binary_search_tree::find(tree, 8761626118042981173) == 5, 222);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12217685426617205775, 223) == 31, 223);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 776486965601631849, 224) == 32, 224);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 10460080154150440624 && value == 146, 225);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 15287453646770213028 && value == 188, 226);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 28);
+            assert!(key0 == 15931705701629082341 && value == 212, 227);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 8707002453519502849) == 23, 228);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 16);
+            assert!(key0 == 14255668113152614846 && value == 214, 229);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4105598755364741699, 230) == 29, 230);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15381018248032238483, 231) == 30, 231);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 61122968712918070, 232) == 31, 232);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16440246104439096383, 233) == 32, 233);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16678085212164438380, 234) == 33, 234);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14849165207942182956, 235) == 34, 235);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 17);
+            assert!(key0 == 10231548038441433308 && value == 166, 236);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11500296095873059102, 237) == 34, 237);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 16727422438371618620 && value == 170, 238);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 13129228254492538687 && value == 202, 239);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 31);
+            assert!(key0 == 61122968712918070 && value == 232, 240);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 16);
+            assert!(key0 == 13964402647532625332 && value == 213, 241);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 918735250537819040, 242) == 31, 242);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 28);
+            assert!(key0 == 751471516496209043 && value == 221, 243);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17047438869301794399, 244) == 31, 244);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 13);
+            assert!(key0 == 17768009244547818084 && value == 168, 245);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2539696960201429253, 246) == 31, 246);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 25);
+            assert!(key0 == 15418412571793227827 && value == 200, 247);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11168314613925255493, 248) == 31, 248);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4357389817891565749, 249) == 32, 249);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3463177043058966924, 250) == 33, 250);
+        assert!(vanilla_binary_search_tree::find(tree, 4952771997674410968) == 24, 251);
+        assert!(vanilla_binary_search_tree::find(tree, 8707002453519502849) == 23, 252);
+        assert!(vanilla_binary_search_tree::find(tree, 10886085785452671065) == vanilla_binary_search_tree::null_index_value(), 253);
+        assert!(vanilla_binary_search_tree::find(tree, 18013657436889614586) == 12, 254);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11650485177525672432, 255) == 34, 255);
+        assert!(vanilla_binary_search_tree::find(tree, 10966171941511968938) == vanilla_binary_search_tree::null_index_value(), 256);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 12217685426617205775 && value == 223, 257);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15019308984908505957, 258) == 34, 258);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3108138781323191767, 259) == 35, 259);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 26);
+            assert!(key0 == 12791538999180417030 && value == 208, 260);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 776486965601631849 && value == 224, 261);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16648122382433173763, 262) == 34, 262);
+        assert!(vanilla_binary_search_tree::find(tree, 11650485177525672432) == 4, 263);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 16678085212164438380 && value == 234, 264);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 11650485177525672432 && value == 255, 265);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15229392199321629467, 266) == 33, 266);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1694881465938077493, 267) == 34, 267);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6744481196927712577, 268) == 35, 268);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 11928442744569509227 && value == 215, 269);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9430559953195483862, 270) == 35, 270);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13835722178018016660, 271) == 36, 271);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 16648122382433173763 && value == 262, 272);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 17667212201888166861) == vanilla_binary_search_tree::null_index_value(), 273);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6483244968225008309, 274) == 36, 274);
+        assert!(vanilla_binary_search_tree::find(tree, 17908815611234327373) == vanilla_binary_search_tree::null_index_value(), 275);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 31);
+            assert!(key0 == 11168314613925255493 && value == 248, 276);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10089654841270408837) == vanilla_binary_search_tree::null_index_value(), 277);
+        assert!(vanilla_binary_search_tree::find(tree, 3108138781323191767) == 26, 278);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14253675639103960382, 279) == 36, 279);
+        assert!(vanilla_binary_search_tree::find(tree, 3463177043058966924) == 4, 280);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16520968670258952483, 281) == 37, 281);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10754715610447700577, 282) == 38, 282);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5991972744052607848, 283) == 39, 283);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5685897123094948608, 284) == 40, 284);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 23);
+            assert!(key0 == 8707002453519502849 && value == 197, 285);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8585225526406214932, 286) == 40, 286);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 15019308984908505957 && value == 258, 287);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 607159736032795026, 288) == 40, 288);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15902285909488726615, 289) == 41, 289);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 34);
+            assert!(key0 == 1694881465938077493 && value == 267, 290);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14254950350147983174, 291) == 41, 291);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 466438168653593478, 292) == 42, 292);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5708703248236612710, 293) == 43, 293);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 28);
+            assert!(key0 == 918735250537819040 && value == 242, 294);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14453381737028716361, 295) == 43, 295);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5608008025391549343, 296) == 44, 296);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 25);
+            assert!(key0 == 2539696960201429253 && value == 246, 297);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 5708703248236612710) == 28, 298);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7228753759322171863, 299) == 44, 299);
+        check(
+            tree,
+            vector<u64>[1, 42, 40, 7, 20, 19, 26, 4, 29, 32, 24, 25, 23, 28, 39, 0, 31, 3, 44, 22, 21, 6, 15, 5, 35, 10, 38, 9, 18, 8, 27, 36, 41, 43, 17, 33, 30, 14, 11, 34, 16, 37, 2, 13, 12],
+            vector<u256>[118298131398851786, 466438168653593478, 607159736032795026, 2111392068471983631, 2117442618385149471, 2289079163147780107, 3108138781323191767, 3463177043058966924, 4105598755364741699, 4357389817891565749, 4952771997674410968, 5608008025391549343, 5685897123094948608, 5708703248236612710, 5991972744052607848, 6100275367158842890, 6483244968225008309, 6744481196927712577, 7228753759322171863, 7283855682742174347, 8478626249766814413, 8585225526406214932, 8710526160774049443, 8761626118042981173, 9430559953195483862, 10120451956124535495, 10754715610447700577, 11500296095873059102, 11594289225202895019, 13835722178018016660, 13939643514151152253, 14253675639103960382, 14254950350147983174, 14453381737028716361, 14849165207942182956, 15229392199321629467, 15381018248032238483, 15677787644814386571, 15796524445606297825, 15902285909488726615, 16440246104439096383, 16520968670258952483, 16923096141865953495, 17047438869301794399, 18013657436889614586],
+            vector<u64>[82, 292, 288, 141, 174, 192, 259, 250, 230, 249, 199, 296, 284, 293, 283, 118, 274, 268, 299, 187, 190, 286, 152, 175, 270, 164, 282, 237, 167, 271, 210, 279, 291, 295, 235, 266, 231, 193, 177, 289, 233, 281, 117, 244, 203],
+            299,
+        );
+        assert!(vanilla_binary_search_tree::find(tree, 15381018248032238483) == 30, 300);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 250128204320245450, 301) == 45, 301);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10866479063490743369, 302) == 46, 302);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 26);
+            assert!(key0 == 3108138781323191767 && value == 259, 303);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 30);
+            assert!(key0 == 15381018248032238483 && value == 231, 304);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1993767464636184858, 305) == 45, 305);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4623376893212409319, 306) == 46, 306);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11880397035612984008, 307) == 47, 307);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2230489124048464167, 308) == 48, 308);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 14);
+            assert!(key0 == 15677787644814386571 && value == 193, 309);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 11500296095873059102) == 9, 310);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 118298131398851786 && value == 82, 311);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7283855682742174347) == 22, 312);
+        assert!(vanilla_binary_search_tree::find(tree, 8710526160774049443) == 15, 313);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17822400142282473725, 314) == 47, 314);
+        assert!(vanilla_binary_search_tree::find(tree, 15789762443725506097) == vanilla_binary_search_tree::null_index_value(), 315);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 6100275367158842890 && value == 118, 316);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5648861737609083209, 317) == 47, 317);
+        assert!(vanilla_binary_search_tree::find(tree, 4105598755364741699) == 29, 318);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13051790785321408270, 319) == 48, 319);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1948598532820442175, 320) == 49, 320);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 27);
+            assert!(key0 == 13939643514151152253 && value == 210, 321);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10724231774109340038, 322) == 49, 322);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16788547254614540592, 323) == 50, 323);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11480553201219687790, 324) == 51, 324);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 24);
+            assert!(key0 == 4952771997674410968 && value == 199, 325);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11107963287568347231, 326) == 51, 326);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14069582549561416227, 327) == 52, 327);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4294070857878064670, 328) == 53, 328);
+        assert!(vanilla_binary_search_tree::find(tree, 4294070857878064670) == 53, 329);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 29);
+            assert!(key0 == 4105598755364741699 && value == 230, 330);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 14453381737028716361) == 43, 331);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3912702130059322190, 332) == 53, 332);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 4);
+            assert!(key0 == 3463177043058966924 && value == 250, 333);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 13051790785321408270) == 48, 334);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 44);
+            assert!(key0 == 7228753759322171863 && value == 299, 335);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11090727455094290163, 336) == 52, 336);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 28);
+            assert!(key0 == 5708703248236612710 && value == 293, 337);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4740490797942876174, 338) == 52, 338);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 36);
+            assert!(key0 == 14253675639103960382 && value == 279, 339);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 18234815911645705457) == vanilla_binary_search_tree::null_index_value(), 340);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 8761626118042981173 && value == 175, 341);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 16520968670258952483) == 37, 342);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12222686919415667035, 343) == 51, 343);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4986354608351969003, 344) == 52, 344);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 45);
+            assert!(key0 == 1993767464636184858 && value == 305, 345);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3222092199456075933, 346) == 52, 346);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1711910135236400099, 347) == 53, 347);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2746396210591492110, 348) == 54, 348);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 43);
+            assert!(key0 == 14453381737028716361 && value == 295, 349);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 6744481196927712577) == 3, 350);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6666894565697208155, 351) == 54, 351);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7774399337923319318, 352) == 55, 352);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18195847652354045630, 353) == 56, 353);
+        assert!(vanilla_binary_search_tree::find(tree, 16520968670258952483) == 37, 354);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 107084881033925917, 355) == 57, 355);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3712026535630657102, 356) == 58, 356);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12927993235529234711, 357) == 59, 357);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 24);
+            assert!(key0 == 11480553201219687790 && value == 324, 358);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13504213180587716535, 359) == 59, 359);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 34);
+            assert!(key0 == 15902285909488726615 && value == 289, 360);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8541600586783944355, 361) == 59, 361);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14854426689688703953, 362) == 60, 362);
+        assert!(vanilla_binary_search_tree::find(tree, 11500296095873059102) == 9, 363);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8300156826005676704, 364) == 61, 364);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7450140421633622597, 365) == 62, 365);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14257929015596205723, 366) == 63, 366);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12524834378486039182, 367) == 64, 367);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 20);
+            assert!(key0 == 2117442618385149471 && value == 174, 368);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 13348905036141968066) == vanilla_binary_search_tree::null_index_value(), 369);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 52);
+            assert!(key0 == 3222092199456075933 && value == 346, 370);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 37);
+            assert!(key0 == 16520968670258952483 && value == 281, 371);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 9430559953195483862) == 35, 372);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7620914220791404896, 373) == 62, 373);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 33);
+            assert!(key0 == 15229392199321629467 && value == 266, 374);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15920743808333505835, 375) == 62, 375);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17745961382365687969, 376) == 63, 376);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6837272077571506036, 377) == 64, 377);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 28);
+            assert!(key0 == 11090727455094290163 && value == 336, 378);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2847257467566504885) == vanilla_binary_search_tree::null_index_value(), 379);
+        assert!(vanilla_binary_search_tree::find(tree, 12637069667802701229) == vanilla_binary_search_tree::null_index_value(), 380);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 70757813410974498, 381) == 64, 381);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4338128316479634658, 382) == 65, 382);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 12222686919415667035 && value == 343, 383);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 8478626249766814413) == 21, 384);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2477080916348470461, 385) == 65, 385);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 36);
+            assert!(key0 == 4740490797942876174 && value == 338, 386);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7620914220791404896) == 33, 387);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6330885697262780955, 388) == 65, 388);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 54);
+            assert!(key0 == 6666894565697208155 && value == 351, 389);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3809697317681224415, 390) == 65, 390);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 17822400142282473725 && value == 314, 391);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10803346122259227319, 392) == 65, 392);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17583155374634219918, 393) == 66, 393);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5819697006064706810, 394) == 67, 394);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11279995672176659239, 395) == 68, 395);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 38);
+            assert!(key0 == 10754715610447700577 && value == 282, 396);
+        };
+    }
+
+    fun ops_2(tree: &mut BinarySearchTree<u64>) {
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2229920310511211495, 397) == 68, 397);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15942562997118009950, 398) == 69, 398);
+        assert!(vanilla_binary_search_tree::find(tree, 2333686872608334148) == vanilla_binary_search_tree::null_index_value(), 399);
+        check(
+            tree,
+            vector<u64>[64, 57, 30, 42, 40, 53, 27, 7, 68, 14, 19, 36, 43, 58, 0, 4, 29, 51, 32, 46, 45, 25, 47, 23, 67, 39, 54, 31, 3, 28, 22, 37, 33, 55, 61, 21, 59, 6, 15, 35, 10, 49, 65, 26, 5, 38, 9, 18, 1, 20, 24, 48, 34, 8, 44, 41, 52, 17, 60, 11, 62, 69, 16, 50, 2, 13, 66, 63, 12, 56],
+            vector<u256>[70757813410974498, 107084881033925917, 250128204320245450, 466438168653593478, 607159736032795026, 1711910135236400099, 1948598532820442175, 2111392068471983631, 2229920310511211495, 2230489124048464167, 2289079163147780107, 2477080916348470461, 2746396210591492110, 3712026535630657102, 3809697317681224415, 3912702130059322190, 4294070857878064670, 4338128316479634658, 4357389817891565749, 4623376893212409319, 4986354608351969003, 5608008025391549343, 5648861737609083209, 5685897123094948608, 5819697006064706810, 5991972744052607848, 6330885697262780955, 6483244968225008309, 6744481196927712577, 6837272077571506036, 7283855682742174347, 7450140421633622597, 7620914220791404896, 7774399337923319318, 8300156826005676704, 8478626249766814413, 8541600586783944355, 8585225526406214932, 8710526160774049443, 9430559953195483862, 10120451956124535495, 10724231774109340038, 10803346122259227319, 10866479063490743369, 11107963287568347231, 11279995672176659239, 11500296095873059102, 11594289225202895019, 11880397035612984008, 12524834378486039182, 12927993235529234711, 13051790785321408270, 13504213180587716535, 13835722178018016660, 14069582549561416227, 14254950350147983174, 14257929015596205723, 14849165207942182956, 14854426689688703953, 15796524445606297825, 15920743808333505835, 15942562997118009950, 16440246104439096383, 16788547254614540592, 16923096141865953495, 17047438869301794399, 17583155374634219918, 17745961382365687969, 18013657436889614586, 18195847652354045630],
+            vector<u64>[381, 355, 301, 292, 288, 347, 320, 141, 397, 308, 192, 385, 348, 356, 390, 332, 328, 382, 249, 306, 344, 296, 317, 284, 394, 283, 388, 274, 268, 377, 187, 365, 373, 352, 364, 190, 361, 286, 152, 270, 164, 322, 392, 302, 326, 395, 237, 167, 307, 367, 357, 319, 359, 271, 327, 291, 366, 235, 362, 177, 375, 398, 233, 323, 117, 244, 393, 376, 203, 353],
+            399,
+        );
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13897445676639730929, 400) == 70, 400);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17106136842549931820, 401) == 71, 401);
+        assert!(vanilla_binary_search_tree::find(tree, 607159736032795026) == 40, 402);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 29);
+            assert!(key0 == 4294070857878064670 && value == 328, 403);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2566986775330172491, 404) == 71, 404);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 66);
+            assert!(key0 == 17583155374634219918 && value == 393, 405);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 4338128316479634658 && value == 382, 406);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2111392068471983631) == 7, 407);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 43);
+            assert!(key0 == 2746396210591492110 && value == 348, 408);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6976025053470300335, 409) == 69, 409);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 18);
+            assert!(key0 == 11594289225202895019 && value == 167, 410);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10705081912612298619, 411) == 69, 411);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2141656950430570065, 412) == 70, 412);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6496912870202614099, 413) == 71, 413);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 33);
+            assert!(key0 == 7620914220791404896 && value == 373, 414);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17587173770950764509, 415) == 71, 415);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 44);
+            assert!(key0 == 14069582549561416227 && value == 327, 416);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10430279094932576092, 417) == 71, 417);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 44);
+            assert!(key0 == 17587173770950764509 && value == 415, 418);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7948460627130319873) == vanilla_binary_search_tree::null_index_value(), 419);
+        assert!(vanilla_binary_search_tree::find(tree, 3865494821183132665) == vanilla_binary_search_tree::null_index_value(), 420);
+        assert!(vanilla_binary_search_tree::find(tree, 3912702130059322190) == 4, 421);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17692024017741429022, 422) == 71, 422);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 33);
+            assert!(key0 == 6496912870202614099 && value == 413, 423);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8986493835396485175, 424) == 71, 424);
+        assert!(vanilla_binary_search_tree::find(tree, 6904172830867021099) == vanilla_binary_search_tree::null_index_value(), 425);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 147146823650955972, 426) == 72, 426);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1273942673392086833, 427) == 73, 427);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7570196185723352081, 428) == 74, 428);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 53);
+            assert!(key0 == 1711910135236400099 && value == 347, 429);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4430431962070682835, 430) == 74, 430);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8373100031395864307, 431) == 75, 431);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 128706898611773293, 432) == 76, 432);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 240166716630029087, 433) == 77, 433);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13318778871388926936, 434) == 78, 434);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7219646697329593081, 435) == 79, 435);
+        assert!(vanilla_binary_search_tree::find(tree, 13318778871388926936) == 78, 436);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11475506936310745141, 437) == 80, 437);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 65);
+            assert!(key0 == 10803346122259227319 && value == 392, 438);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13989438296184386760, 439) == 80, 439);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 43);
+            assert!(key0 == 15942562997118009950 && value == 398, 440);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10430279094932576092) == 44, 441);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17792235364218603600, 442) == 80, 442);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 16);
+            assert!(key0 == 16440246104439096383 && value == 233, 443);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3030590483438781607, 444) == 80, 444);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10132878602334096552, 445) == 81, 445);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4439311695325339073, 446) == 82, 446);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 13835722178018016660 && value == 271, 447);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12364353904881135851, 448) == 82, 448);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14948198015267676722, 449) == 83, 449);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 7283855682742174347 && value == 187, 450);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1541854488149995751, 451) == 83, 451);
+        assert!(vanilla_binary_search_tree::find(tree, 11475506936310745141) == 65, 452);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5559133735586581164, 453) == 84, 453);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16476916165265834170, 454) == 85, 454);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 66);
+            assert!(key0 == 2566986775330172491 && value == 404, 455);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15902007595972612345, 456) == 85, 456);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10310026601724632614, 457) == 86, 457);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10781116479722575276, 458) == 87, 458);
+        assert!(vanilla_binary_search_tree::find(tree, 14600777454889480047) == vanilla_binary_search_tree::null_index_value(), 459);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2844229746743954283, 460) == 88, 460);
+        assert!(vanilla_binary_search_tree::find(tree, 4614977737960852065) == vanilla_binary_search_tree::null_index_value(), 461);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 50);
+            assert!(key0 == 16788547254614540592 && value == 323, 462);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17460325656492349826, 463) == 88, 463);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 69);
+            assert!(key0 == 10705081912612298619 && value == 411, 464);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1100747041620789931, 465) == 88, 465);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 81);
+            assert!(key0 == 10132878602334096552 && value == 445, 466);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 6976025053470300335) == 18, 467);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 57);
+            assert!(key0 == 107084881033925917 && value == 355, 468);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 11960601782390463525) == vanilla_binary_search_tree::null_index_value(), 469);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 57);
+            assert!(key0 == 10781116479722575276 && value == 458, 470);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14081740107086280491, 471) == 86, 471);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 52);
+            assert!(key0 == 14257929015596205723 && value == 366, 472);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1059798528448289766, 473) == 86, 473);
+        assert!(vanilla_binary_search_tree::find(tree, 8373100031395864307) == 75, 474);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 13897445676639730929 && value == 400, 475);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17925483946877736059, 476) == 86, 476);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12985581283708262881, 477) == 87, 477);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1519580785048126426, 478) == 88, 478);
+        assert!(vanilla_binary_search_tree::find(tree, 15333305316674873660) == vanilla_binary_search_tree::null_index_value(), 479);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2640252157389437150, 480) == 89, 480);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 34);
+            assert!(key0 == 13504213180587716535 && value == 359, 481);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 71);
+            assert!(key0 == 8986493835396485175 && value == 424, 482);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5573447009166236343, 483) == 88, 483);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1313924244589689205, 484) == 89, 484);
+        assert!(vanilla_binary_search_tree::find(tree, 4623376893212409319) == 46, 485);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 14948198015267676722 && value == 449, 486);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7610477416312221782) == vanilla_binary_search_tree::null_index_value(), 487);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 4439311695325339073 && value == 446, 488);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8479224097104742542, 489) == 88, 489);
+        assert!(vanilla_binary_search_tree::find(tree, 20762819098197740) == vanilla_binary_search_tree::null_index_value(), 490);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 78);
+            assert!(key0 == 13318778871388926936 && value == 434, 491);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 11500296095873059102 && value == 237, 492);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 1);
+            assert!(key0 == 11880397035612984008 && value == 307, 493);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 72);
+            assert!(key0 == 147146823650955972 && value == 426, 494);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 47);
+            assert!(key0 == 5648861737609083209 && value == 317, 495);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 82);
+            assert!(key0 == 12364353904881135851 && value == 448, 496);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 37);
+            assert!(key0 == 7450140421633622597 && value == 365, 497);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12022692514625675182, 498) == 82, 498);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 5573447009166236343 && value == 483, 499);
+        };
+        check(
+            tree,
+            vector<u64>[64, 76, 77, 30, 42, 40, 51, 81, 73, 22, 71, 37, 27, 7, 70, 68, 14, 19, 36, 34, 50, 80, 58, 0, 4, 32, 74, 46, 45, 47, 25, 23, 67, 39, 54, 31, 3, 28, 18, 79, 53, 55, 61, 75, 21, 78, 59, 6, 15, 35, 10, 57, 44, 49, 26, 5, 38, 65, 8, 20, 24, 9, 48, 43, 52, 41, 17, 60, 11, 72, 62, 66, 2, 13, 29, 69, 33, 63, 16, 1, 12, 56],
+            vector<u256>[70757813410974498, 128706898611773293, 240166716630029087, 250128204320245450, 466438168653593478, 607159736032795026, 1059798528448289766, 1100747041620789931, 1273942673392086833, 1313924244589689205, 1519580785048126426, 1541854488149995751, 1948598532820442175, 2111392068471983631, 2141656950430570065, 2229920310511211495, 2230489124048464167, 2289079163147780107, 2477080916348470461, 2640252157389437150, 2844229746743954283, 3030590483438781607, 3712026535630657102, 3809697317681224415, 3912702130059322190, 4357389817891565749, 4430431962070682835, 4623376893212409319, 4986354608351969003, 5559133735586581164, 5608008025391549343, 5685897123094948608, 5819697006064706810, 5991972744052607848, 6330885697262780955, 6483244968225008309, 6744481196927712577, 6837272077571506036, 6976025053470300335, 7219646697329593081, 7570196185723352081, 7774399337923319318, 8300156826005676704, 8373100031395864307, 8478626249766814413, 8479224097104742542, 8541600586783944355, 8585225526406214932, 8710526160774049443, 9430559953195483862, 10120451956124535495, 10310026601724632614, 10430279094932576092, 10724231774109340038, 10866479063490743369, 11107963287568347231, 11279995672176659239, 11475506936310745141, 12022692514625675182, 12524834378486039182, 12927993235529234711, 12985581283708262881, 13051790785321408270, 13989438296184386760, 14081740107086280491, 14254950350147983174, 14849165207942182956, 14854426689688703953, 15796524445606297825, 15902007595972612345, 15920743808333505835, 16476916165265834170, 16923096141865953495, 17047438869301794399, 17106136842549931820, 17460325656492349826, 17692024017741429022, 17745961382365687969, 17792235364218603600, 17925483946877736059, 18013657436889614586, 18195847652354045630],
+            vector<u64>[381, 432, 433, 301, 292, 288, 473, 465, 427, 484, 478, 451, 320, 141, 412, 397, 308, 192, 385, 480, 460, 444, 356, 390, 332, 249, 430, 306, 344, 453, 296, 284, 394, 283, 388, 274, 268, 377, 409, 435, 428, 352, 364, 431, 190, 489, 361, 286, 152, 270, 164, 457, 417, 322, 302, 326, 395, 437, 498, 367, 357, 477, 319, 439, 471, 291, 235, 362, 177, 456, 375, 454, 117, 244, 401, 463, 422, 376, 442, 476, 203, 353],
+            499,
+        );
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18201132232355396612, 500) == 82, 500);
+        assert!(vanilla_binary_search_tree::find(tree, 9430559953195483862) == 35, 501);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 36);
+            assert!(key0 == 2477080916348470461 && value == 385, 502);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 3912702130059322190) == 4, 503);
+        assert!(vanilla_binary_search_tree::find(tree, 5819697006064706810) == 67, 504);
+        assert!(vanilla_binary_search_tree::find(tree, 12985581283708262881) == 9, 505);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 7);
+            assert!(key0 == 2111392068471983631 && value == 141, 506);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7140853487452413771) == vanilla_binary_search_tree::null_index_value(), 507);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8636387501549697187, 508) == 81, 508);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10729810269964488046, 509) == 82, 509);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2416498547983715741, 510) == 83, 510);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17437321453306671839, 511) == 84, 511);
+        assert!(vanilla_binary_search_tree::find(tree, 18013657436889614586) == 12, 512);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 0);
+            assert!(key0 == 3809697317681224415 && value == 390, 513);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14209624066815871959, 514) == 84, 514);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 31);
+            assert!(key0 == 6483244968225008309 && value == 274, 515);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5220625613504645495, 516) == 84, 516);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8334042827575005807, 517) == 85, 517);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4377471957024472456, 518) == 86, 518);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13046242041805619418, 519) == 87, 519);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11024997899774928899, 520) == 88, 520);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 84);
+            assert!(key0 == 5220625613504645495 && value == 516, 521);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 10);
+            assert!(key0 == 10120451956124535495 && value == 164, 522);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 6744481196927712577 && value == 268, 523);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9411959155640204440, 524) == 86, 524);
+        assert!(vanilla_binary_search_tree::find(tree, 4986354608351969003) == 45, 525);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 39);
+            assert!(key0 == 5991972744052607848 && value == 283, 526);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 15);
+            assert!(key0 == 8710526160774049443 && value == 152, 527);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 13);
+            assert!(key0 == 17047438869301794399 && value == 244, 528);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 12498140472231973712) == vanilla_binary_search_tree::null_index_value(), 529);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13097698210211537683, 530) == 84, 530);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 44);
+            assert!(key0 == 10430279094932576092 && value == 417, 531);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15497578341869632289, 532) == 84, 532);
+        assert!(vanilla_binary_search_tree::find(tree, 16923096141865953495) == 2, 533);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9589807201416299541, 534) == 85, 534);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11911252641194946995, 535) == 86, 535);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1439613178598317885, 536) == 87, 536);
+        assert!(vanilla_binary_search_tree::find(tree, 1273942673392086833) == 73, 537);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17862110838922971400, 538) == 88, 538);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 57);
+            assert!(key0 == 10310026601724632614 && value == 457, 539);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 12);
+            assert!(key0 == 18013657436889614586 && value == 203, 540);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15003525950791373626, 541) == 87, 541);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4245056338230641971, 542) == 88, 542);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 13);
+            assert!(key0 == 11024997899774928899 && value == 520, 543);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 64);
+            assert!(key0 == 70757813410974498 && value == 381, 544);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10852946693898410549, 545) == 87, 545);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11449371691943827523, 546) == 88, 546);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11863954014537140586, 547) == 89, 547);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 44);
+            assert!(key0 == 13097698210211537683 && value == 530, 548);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 61);
+            assert!(key0 == 8300156826005676704 && value == 364, 549);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 59);
+            assert!(key0 == 8541600586783944355 && value == 361, 550);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 8478626249766814413) == 21, 551);
+        assert!(vanilla_binary_search_tree::find(tree, 6837272077571506036) == 28, 552);
+        assert!(vanilla_binary_search_tree::find(tree, 9589807201416299541) == 85, 553);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 70);
+            assert!(key0 == 2141656950430570065 && value == 412, 554);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2163930835251506186, 555) == 86, 555);
+        assert!(vanilla_binary_search_tree::find(tree, 543752676297752941) == vanilla_binary_search_tree::null_index_value(), 556);
+        assert!(vanilla_binary_search_tree::find(tree, 240166716630029087) == 77, 557);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8236000563283034457, 558) == 87, 558);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10894934247037747208, 559) == 88, 559);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10629671971912445458, 560) == 89, 560);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 45);
+            assert!(key0 == 4986354608351969003 && value == 344, 561);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2834903700633735350) == vanilla_binary_search_tree::null_index_value(), 562);
+        assert!(vanilla_binary_search_tree::find(tree, 17925483946877736059) == 1, 563);
+        assert!(vanilla_binary_search_tree::find(tree, 240166716630029087) == 77, 564);
+        assert!(vanilla_binary_search_tree::find(tree, 5819697006064706810) == 67, 565);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 83);
+            assert!(key0 == 2416498547983715741 && value == 510, 566);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7585688718226884684, 567) == 88, 567);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14722875136664765787, 568) == 89, 568);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11857342826393643164, 569) == 90, 569);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 932352552525192296, 570) == 91, 570);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1023969180824113797, 571) == 92, 571);
+        assert!(vanilla_binary_search_tree::find(tree, 7958590437412524935) == vanilla_binary_search_tree::null_index_value(), 572);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5575372128400727406, 573) == 93, 573);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4259218099284613925, 574) == 94, 574);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5156932462496671715, 575) == 95, 575);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2293477501003937176, 576) == 96, 576);
+        assert!(vanilla_binary_search_tree::find(tree, 15796524445606297825) == 11, 577);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7240798407315712625, 578) == 97, 578);
+        assert!(vanilla_binary_search_tree::find(tree, 2292791306292539811) == vanilla_binary_search_tree::null_index_value(), 579);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 53);
+            assert!(key0 == 7570196185723352081 && value == 428, 580);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 31);
+            assert!(key0 == 14209624066815871959 && value == 514, 581);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 11107963287568347231 && value == 326, 582);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13954330200355545344, 583) == 95, 583);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18168488581663649537, 584) == 96, 584);
+        assert!(vanilla_binary_search_tree::find(tree, 8479224097104742542) == 78, 585);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13994675946615603989, 586) == 97, 586);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2607229842893506755, 587) == 98, 587);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16173594779050085535, 588) == 99, 588);
+        assert!(vanilla_binary_search_tree::find(tree, 10729810269964488046) == 82, 589);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12486678963005495848, 590) == 100, 590);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3331236165669254098, 591) == 101, 591);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 58);
+            assert!(key0 == 3712026535630657102 && value == 356, 592);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5957884322682057074, 593) == 101, 593);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 85);
+            assert!(key0 == 9589807201416299541 && value == 534, 594);
+        };
+    }
+
+    fun ops_3(tree: &mut BinarySearchTree<u64>) {
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 60);
+            assert!(key0 == 14854426689688703953 && value == 362, 595);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10852946693898410549) == 59, 596);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8680085572098233876, 597) == 100, 597);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17675356803857946147, 598) == 101, 598);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 88);
+            assert!(key0 == 7585688718226884684 && value == 567, 599);
+        };
+        check(
+            tree,
+            vector<u64>[76, 77, 30, 42, 40, 91, 92, 51, 7, 73, 22, 12, 71, 37, 27, 86, 68, 14, 19, 31, 98, 34, 50, 80, 58, 4, 13, 94, 32, 3, 74, 46, 5, 47, 93, 25, 23, 67, 85, 54, 28, 18, 79, 53, 55, 87, 15, 75, 21, 78, 6, 81, 100, 39, 35, 45, 49, 82, 59, 26, 83, 38, 61, 65, 90, 44, 70, 8, 60, 20, 24, 9, 10, 48, 95, 43, 97, 52, 41, 89, 17, 64, 84, 11, 72, 62, 99, 66, 2, 29, 0, 69, 88, 33, 63, 16, 57, 1, 96, 56, 36],
+            vector<u256>[128706898611773293, 240166716630029087, 250128204320245450, 466438168653593478, 607159736032795026, 932352552525192296, 1023969180824113797, 1059798528448289766, 1100747041620789931, 1273942673392086833, 1313924244589689205, 1439613178598317885, 1519580785048126426, 1541854488149995751, 1948598532820442175, 2163930835251506186, 2229920310511211495, 2230489124048464167, 2289079163147780107, 2293477501003937176, 2607229842893506755, 2640252157389437150, 2844229746743954283, 3030590483438781607, 3331236165669254098, 3912702130059322190, 4245056338230641971, 4259218099284613925, 4357389817891565749, 4377471957024472456, 4430431962070682835, 4623376893212409319, 5156932462496671715, 5559133735586581164, 5575372128400727406, 5608008025391549343, 5685897123094948608, 5819697006064706810, 5957884322682057074, 6330885697262780955, 6837272077571506036, 6976025053470300335, 7219646697329593081, 7240798407315712625, 7774399337923319318, 8236000563283034457, 8334042827575005807, 8373100031395864307, 8478626249766814413, 8479224097104742542, 8585225526406214932, 8636387501549697187, 8680085572098233876, 9411959155640204440, 9430559953195483862, 10629671971912445458, 10724231774109340038, 10729810269964488046, 10852946693898410549, 10866479063490743369, 10894934247037747208, 11279995672176659239, 11449371691943827523, 11475506936310745141, 11857342826393643164, 11863954014537140586, 11911252641194946995, 12022692514625675182, 12486678963005495848, 12524834378486039182, 12927993235529234711, 12985581283708262881, 13046242041805619418, 13051790785321408270, 13954330200355545344, 13989438296184386760, 13994675946615603989, 14081740107086280491, 14254950350147983174, 14722875136664765787, 14849165207942182956, 15003525950791373626, 15497578341869632289, 15796524445606297825, 15902007595972612345, 15920743808333505835, 16173594779050085535, 16476916165265834170, 16923096141865953495, 17106136842549931820, 17437321453306671839, 17460325656492349826, 17675356803857946147, 17692024017741429022, 17745961382365687969, 17792235364218603600, 17862110838922971400, 17925483946877736059, 18168488581663649537, 18195847652354045630, 18201132232355396612],
+            vector<u64>[432, 433, 301, 292, 288, 570, 571, 473, 465, 427, 484, 536, 478, 451, 320, 555, 397, 308, 192, 576, 587, 480, 460, 444, 591, 332, 542, 574, 249, 518, 430, 306, 575, 453, 573, 296, 284, 394, 593, 388, 377, 409, 435, 578, 352, 558, 517, 431, 190, 489, 286, 508, 597, 524, 270, 560, 322, 509, 545, 302, 559, 395, 546, 437, 569, 547, 535, 498, 590, 367, 357, 477, 519, 319, 583, 439, 586, 471, 291, 568, 235, 541, 532, 177, 456, 375, 588, 454, 117, 401, 511, 463, 598, 422, 376, 442, 538, 476, 584, 353, 500],
+            599,
+        );
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 10);
+            assert!(key0 == 13046242041805619418 && value == 519, 600);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 99);
+            assert!(key0 == 16173594779050085535 && value == 588, 601);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17327836222617836208, 602) == 99, 602);
+        assert!(vanilla_binary_search_tree::find(tree, 16511397543172543258) == vanilla_binary_search_tree::null_index_value(), 603);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 19);
+            assert!(key0 == 2289079163147780107 && value == 192, 604);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 38);
+            assert!(key0 == 11279995672176659239 && value == 395, 605);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 24);
+            assert!(key0 == 12927993235529234711 && value == 357, 606);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 14849165207942182956) == 17, 607);
+        assert!(vanilla_binary_search_tree::find(tree, 11499974208813451241) == vanilla_binary_search_tree::null_index_value(), 608);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7918073351763221495, 609) == 97, 609);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 33);
+            assert!(key0 == 17692024017741429022 && value == 422, 610);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14156342587658307798, 611) == 97, 611);
+        assert!(vanilla_binary_search_tree::find(tree, 6976025053470300335) == 18, 612);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 53);
+            assert!(key0 == 7240798407315712625 && value == 578, 613);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 59);
+            assert!(key0 == 10852946693898410549 && value == 545, 614);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9206011225463547354, 615) == 96, 615);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14320858512725620248, 616) == 97, 616);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1358902529864150854, 617) == 98, 617);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13590006276814086576, 618) == 99, 618);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8576255684995728084, 619) == 100, 619);
+        assert!(vanilla_binary_search_tree::find(tree, 1100747041620789931) == 7, 620);
+        assert!(vanilla_binary_search_tree::find(tree, 12022692514625675182) == 8, 621);
+        assert!(vanilla_binary_search_tree::find(tree, 8334042827575005807) == 15, 622);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7874138225276421062, 623) == 101, 623);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7269766404432217348, 624) == 102, 624);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5618087181775783872, 625) == 103, 625);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11801031990455848410, 626) == 104, 626);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 6);
+            assert!(key0 == 8585225526406214932 && value == 286, 627);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1372031473403187090, 628) == 104, 628);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 102);
+            assert!(key0 == 7269766404432217348 && value == 624, 629);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1434687973265117755, 630) == 104, 630);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14753224999777713436, 631) == 105, 631);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 90);
+            assert!(key0 == 11857342826393643164 && value == 569, 632);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15421728656483170031, 633) == 105, 633);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11144372572656407173, 634) == 106, 634);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 56);
+            assert!(key0 == 18195847652354045630 && value == 353, 635);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7986767972382161390, 636) == 106, 636);
+        assert!(vanilla_binary_search_tree::find(tree, 607159736032795026) == 40, 637);
+        assert!(vanilla_binary_search_tree::find(tree, 1312807562124832281) == vanilla_binary_search_tree::null_index_value(), 638);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 884093705495226790, 639) == 107, 639);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6179999080186222552, 640) == 108, 640);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8654846241027939518, 641) == 109, 641);
+        assert!(vanilla_binary_search_tree::find(tree, 11821767736625536160) == vanilla_binary_search_tree::null_index_value(), 642);
+        assert!(vanilla_binary_search_tree::find(tree, 18201132232355396612) == 36, 643);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6981834443466128261, 644) == 110, 644);
+        assert!(vanilla_binary_search_tree::find(tree, 3141098554880012157) == vanilla_binary_search_tree::null_index_value(), 645);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 76);
+            assert!(key0 == 128706898611773293 && value == 432, 646);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10862544735133891285) == vanilla_binary_search_tree::null_index_value(), 647);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 47);
+            assert!(key0 == 5559133735586581164 && value == 453, 648);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10777456232289719150) == vanilla_binary_search_tree::null_index_value(), 649);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16060697690637210273, 650) == 109, 650);
+        assert!(vanilla_binary_search_tree::find(tree, 10451417236836426847) == vanilla_binary_search_tree::null_index_value(), 651);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 37);
+            assert!(key0 == 1541854488149995751 && value == 451, 652);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13658407470423392108, 653) == 109, 653);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13921412691609124909, 654) == 110, 654);
+        assert!(vanilla_binary_search_tree::find(tree, 2293477501003937176) == 31, 655);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 86);
+            assert!(key0 == 2163930835251506186 && value == 555, 656);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10948714043967608735, 657) == 110, 657);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12081128042739098795, 658) == 111, 658);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 90);
+            assert!(key0 == 14753224999777713436 && value == 631, 659);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8987766525720193848, 660) == 111, 660);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6049490154909449131, 661) == 112, 661);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 23);
+            assert!(key0 == 5685897123094948608 && value == 284, 662);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 68);
+            assert!(key0 == 2229920310511211495 && value == 397, 663);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10774047794833809586) == vanilla_binary_search_tree::null_index_value(), 664);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9795963436163261754, 665) == 111, 665);
+        assert!(vanilla_binary_search_tree::find(tree, 16476916165265834170) == 66, 666);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 57);
+            assert!(key0 == 17862110838922971400 && value == 538, 667);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6172258651138172411, 668) == 111, 668);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 103);
+            assert!(key0 == 5618087181775783872 && value == 625, 669);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 52);
+            assert!(key0 == 14081740107086280491 && value == 471, 670);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12925693437986784316, 671) == 110, 671);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4985077821224591987, 672) == 111, 672);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16065246986434468771, 673) == 112, 673);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 105);
+            assert!(key0 == 15421728656483170031 && value == 633, 674);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 17327836222617836208) == 19, 675);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15650488316737606819, 676) == 112, 676);
+        assert!(vanilla_binary_search_tree::find(tree, 16071987782597381499) == vanilla_binary_search_tree::null_index_value(), 677);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11858443091078955238, 678) == 113, 678);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 63);
+            assert!(key0 == 17745961382365687969 && value == 376, 679);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 84);
+            assert!(key0 == 15497578341869632289 && value == 532, 680);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 26);
+            assert!(key0 == 10866479063490743369 && value == 302, 681);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 19);
+            assert!(key0 == 17327836222617836208 && value == 602, 682);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 12);
+            assert!(key0 == 1439613178598317885 && value == 536, 683);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 36);
+            assert!(key0 == 18201132232355396612 && value == 500, 684);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7795063656026731347, 685) == 108, 685);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5361817119047301016, 686) == 109, 686);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4196310563396380090, 687) == 110, 687);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17319162688864593879, 688) == 111, 688);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17329867851850442723, 689) == 112, 689);
+        assert!(vanilla_binary_search_tree::find(tree, 13921412691609124909) == 86, 690);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 43);
+            assert!(key0 == 13989438296184386760 && value == 439, 691);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7318540030812276832, 692) == 112, 692);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 42);
+            assert!(key0 == 466438168653593478 && value == 292, 693);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1732822225631441413, 694) == 112, 694);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15348421693764387982, 695) == 113, 695);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18290770630839183303, 696) == 114, 696);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 97);
+            assert!(key0 == 14320858512725620248 && value == 616, 697);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 113);
+            assert!(key0 == 15348421693764387982 && value == 695, 698);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14355597466994298395, 699) == 113, 699);
+        check(
+            tree,
+            vector<u64>[77, 30, 40, 107, 91, 92, 51, 7, 73, 22, 98, 102, 104, 71, 112, 27, 14, 31, 38, 34, 50, 80, 58, 4, 110, 13, 94, 32, 3, 74, 46, 26, 5, 109, 93, 25, 67, 85, 23, 103, 36, 54, 28, 18, 76, 79, 42, 55, 108, 101, 33, 106, 87, 15, 75, 21, 78, 100, 81, 47, 10, 68, 96, 39, 35, 57, 45, 49, 82, 83, 52, 56, 61, 65, 6, 63, 44, 70, 8, 90, 60, 20, 19, 9, 48, 99, 12, 86, 95, 24, 53, 41, 113, 89, 17, 64, 84, 11, 72, 62, 37, 105, 66, 2, 29, 111, 43, 0, 69, 88, 16, 1, 59, 97],
+            vector<u256>[240166716630029087, 250128204320245450, 607159736032795026, 884093705495226790, 932352552525192296, 1023969180824113797, 1059798528448289766, 1100747041620789931, 1273942673392086833, 1313924244589689205, 1358902529864150854, 1372031473403187090, 1434687973265117755, 1519580785048126426, 1732822225631441413, 1948598532820442175, 2230489124048464167, 2293477501003937176, 2607229842893506755, 2640252157389437150, 2844229746743954283, 3030590483438781607, 3331236165669254098, 3912702130059322190, 4196310563396380090, 4245056338230641971, 4259218099284613925, 4357389817891565749, 4377471957024472456, 4430431962070682835, 4623376893212409319, 4985077821224591987, 5156932462496671715, 5361817119047301016, 5575372128400727406, 5608008025391549343, 5819697006064706810, 5957884322682057074, 6049490154909449131, 6172258651138172411, 6179999080186222552, 6330885697262780955, 6837272077571506036, 6976025053470300335, 6981834443466128261, 7219646697329593081, 7318540030812276832, 7774399337923319318, 7795063656026731347, 7874138225276421062, 7918073351763221495, 7986767972382161390, 8236000563283034457, 8334042827575005807, 8373100031395864307, 8478626249766814413, 8479224097104742542, 8576255684995728084, 8636387501549697187, 8654846241027939518, 8680085572098233876, 8987766525720193848, 9206011225463547354, 9411959155640204440, 9430559953195483862, 9795963436163261754, 10629671971912445458, 10724231774109340038, 10729810269964488046, 10894934247037747208, 10948714043967608735, 11144372572656407173, 11449371691943827523, 11475506936310745141, 11801031990455848410, 11858443091078955238, 11863954014537140586, 11911252641194946995, 12022692514625675182, 12081128042739098795, 12486678963005495848, 12524834378486039182, 12925693437986784316, 12985581283708262881, 13051790785321408270, 13590006276814086576, 13658407470423392108, 13921412691609124909, 13954330200355545344, 13994675946615603989, 14156342587658307798, 14254950350147983174, 14355597466994298395, 14722875136664765787, 14849165207942182956, 15003525950791373626, 15650488316737606819, 15796524445606297825, 15902007595972612345, 15920743808333505835, 16060697690637210273, 16065246986434468771, 16476916165265834170, 16923096141865953495, 17106136842549931820, 17319162688864593879, 17329867851850442723, 17437321453306671839, 17460325656492349826, 17675356803857946147, 17792235364218603600, 17925483946877736059, 18168488581663649537, 18290770630839183303],
+            vector<u64>[433, 301, 288, 639, 570, 571, 473, 465, 427, 484, 617, 628, 630, 478, 694, 320, 308, 576, 587, 480, 460, 444, 591, 332, 687, 542, 574, 249, 518, 430, 306, 672, 575, 686, 573, 296, 394, 593, 661, 668, 640, 388, 377, 409, 644, 435, 692, 352, 685, 623, 609, 636, 558, 517, 431, 190, 489, 619, 508, 641, 597, 660, 615, 524, 270, 665, 560, 322, 509, 559, 657, 634, 546, 437, 626, 678, 547, 535, 498, 658, 590, 367, 671, 477, 319, 618, 653, 654, 583, 586, 611, 291, 699, 568, 235, 541, 676, 177, 456, 375, 650, 673, 454, 117, 401, 688, 689, 511, 463, 598, 442, 476, 584, 696],
+            699,
+        );
+        assert!(vanilla_binary_search_tree::find(tree, 164398878065326690) == vanilla_binary_search_tree::null_index_value(), 700);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 10);
+            assert!(key0 == 8680085572098233876 && value == 597, 701);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16005651223333303498, 702) == 113, 702);
+        assert!(vanilla_binary_search_tree::find(tree, 3385845898237462452) == vanilla_binary_search_tree::null_index_value(), 703);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13105393089626991588, 704) == 114, 704);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6158972322187069841, 705) == 115, 705);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 77);
+            assert!(key0 == 240166716630029087 && value == 433, 706);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 15893099404968434252) == vanilla_binary_search_tree::null_index_value(), 707);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3149756680701490498, 708) == 115, 708);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16995321543272428614, 709) == 116, 709);
+        assert!(vanilla_binary_search_tree::find(tree, 3122030294813994134) == vanilla_binary_search_tree::null_index_value(), 710);
+        assert!(vanilla_binary_search_tree::find(tree, 3149756680701490498) == 115, 711);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15228272873747632177, 712) == 117, 712);
+        assert!(vanilla_binary_search_tree::find(tree, 14849165207942182956) == 17, 713);
+        assert!(vanilla_binary_search_tree::find(tree, 15844449291324123996) == vanilla_binary_search_tree::null_index_value(), 714);
+        assert!(vanilla_binary_search_tree::find(tree, 8636387501549697187) == 81, 715);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8184828901071965375, 716) == 118, 716);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15527429588183774587, 717) == 119, 717);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 32);
+            assert!(key0 == 4357389817891565749 && value == 249, 718);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1259581586625537313, 719) == 119, 719);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2893808774499989735, 720) == 120, 720);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 46);
+            assert!(key0 == 4623376893212409319 && value == 306, 721);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5953663811718943605, 722) == 120, 722);
+        assert!(vanilla_binary_search_tree::find(tree, 13105393089626991588) == 114, 723);
+        assert!(vanilla_binary_search_tree::find(tree, 3121413325463795031) == vanilla_binary_search_tree::null_index_value(), 724);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2071542638548192867, 725) == 121, 725);
+        assert!(vanilla_binary_search_tree::find(tree, 8220230754789480668) == vanilla_binary_search_tree::null_index_value(), 726);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15807471714349337622, 727) == 122, 727);
+        assert!(vanilla_binary_search_tree::find(tree, 16476916165265834170) == 66, 728);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 84253995478139420, 729) == 123, 729);
+        assert!(vanilla_binary_search_tree::find(tree, 3296794166525803941) == vanilla_binary_search_tree::null_index_value(), 730);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15964011847420512071, 731) == 124, 731);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 108);
+            assert!(key0 == 7795063656026731347 && value == 685, 732);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18416377333136245323, 733) == 124, 733);
+        assert!(vanilla_binary_search_tree::find(tree, 10929170696823196757) == vanilla_binary_search_tree::null_index_value(), 734);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14906338419648772939, 735) == 125, 735);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 1313924244589689205 && value == 484, 736);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 17106136842549931820) == 29, 737);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 8);
+            assert!(key0 == 12022692514625675182 && value == 498, 738);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 6981834443466128261) == 76, 739);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11386767483348216810, 740) == 124, 740);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 86);
+            assert!(key0 == 13921412691609124909 && value == 654, 741);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 55);
+            assert!(key0 == 7774399337923319318 && value == 352, 742);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12557607445083366815, 743) == 123, 743);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12685533654718252799, 744) == 124, 744);
+        assert!(vanilla_binary_search_tree::find(tree, 4259218099284613925) == 94, 745);
+        assert!(vanilla_binary_search_tree::find(tree, 12603587859596884786) == vanilla_binary_search_tree::null_index_value(), 746);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 17);
+            assert!(key0 == 14849165207942182956 && value == 235, 747);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15997206049571605195, 748) == 124, 748);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 1059798528448289766 && value == 473, 749);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2473551917174573038, 750) == 124, 750);
+        assert!(vanilla_binary_search_tree::find(tree, 7851467068244769084) == vanilla_binary_search_tree::null_index_value(), 751);
+        assert!(vanilla_binary_search_tree::find(tree, 17319162688864593879) == 111, 752);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17731590335386706016, 753) == 125, 753);
+        assert!(vanilla_binary_search_tree::find(tree, 15920743808333505835) == 62, 754);
+        assert!(vanilla_binary_search_tree::find(tree, 11120853075736452540) == vanilla_binary_search_tree::null_index_value(), 755);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 88);
+            assert!(key0 == 17675356803857946147 && value == 598, 756);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 18);
+            assert!(key0 == 6976025053470300335 && value == 409, 757);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13812170418811799120, 758) == 124, 758);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 71);
+            assert!(key0 == 1519580785048126426 && value == 478, 759);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16460137681389201159, 760) == 124, 760);
+        assert!(vanilla_binary_search_tree::find(tree, 12985581283708262881) == 9, 761);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 59);
+            assert!(key0 == 18168488581663649537 && value == 584, 762);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 15997206049571605195 && value == 748, 763);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 49);
+            assert!(key0 == 10724231774109340038 && value == 322, 764);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7786181425108690721, 765) == 122, 765);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 5);
+            assert!(key0 == 5156932462496671715 && value == 575, 766);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16999608164675960976, 767) == 122, 767);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 54);
+            assert!(key0 == 6330885697262780955 && value == 388, 768);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9955549248939332941, 769) == 122, 769);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 34);
+            assert!(key0 == 2640252157389437150 && value == 480, 770);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 15902007595972612345) == 72, 771);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 96);
+            assert!(key0 == 9206011225463547354 && value == 615, 772);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 1948598532820442175) == 27, 773);
+        assert!(vanilla_binary_search_tree::find(tree, 11475506936310745141) == 65, 774);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11651469544957421230, 775) == 121, 775);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9955782472830064510, 776) == 122, 776);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17628440849010290991, 777) == 123, 777);
+        assert!(vanilla_binary_search_tree::find(tree, 14906338419648772939) == 22, 778);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 118);
+            assert!(key0 == 8184828901071965375 && value == 716, 779);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8103528008093766367, 780) == 123, 780);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 42);
+            assert!(key0 == 7318540030812276832 && value == 692, 781);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3529362220911038621, 782) == 123, 782);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 102);
+            assert!(key0 == 1372031473403187090 && value == 628, 783);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7224642938613007505) == vanilla_binary_search_tree::null_index_value(), 784);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4523656399554789108, 785) == 123, 785);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5566096950844631325, 786) == 124, 786);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6345677610740596854, 787) == 125, 787);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 27);
+            assert!(key0 == 1948598532820442175 && value == 320, 788);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 87);
+            assert!(key0 == 8236000563283034457 && value == 558, 789);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1972641399890535735, 790) == 124, 790);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7321971353966050994, 791) == 125, 791);
+        assert!(vanilla_binary_search_tree::find(tree, 6382042997160875193) == vanilla_binary_search_tree::null_index_value(), 792);
+    }
+
+    fun ops_4(tree: &mut BinarySearchTree<u64>) {
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 12);
+            assert!(key0 == 13658407470423392108 && value == 653, 793);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5549735173285411483, 794) == 125, 794);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 22);
+            assert!(key0 == 14906338419648772939 && value == 735, 795);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10088762375570601417, 796) == 125, 796);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 59);
+            assert!(key0 == 16460137681389201159 && value == 760, 797);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10355840903802204612) == vanilla_binary_search_tree::null_index_value(), 798);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14609395174398702341, 799) == 125, 799);
+        check(
+            tree,
+            vector<u64>[55, 30, 40, 107, 91, 92, 7, 119, 73, 98, 104, 112, 124, 96, 14, 31, 18, 38, 50, 46, 80, 115, 58, 102, 4, 110, 13, 94, 3, 74, 123, 26, 109, 22, 87, 93, 25, 67, 120, 85, 23, 77, 103, 36, 27, 28, 76, 79, 12, 5, 101, 33, 106, 42, 15, 75, 21, 78, 100, 81, 47, 68, 39, 35, 57, 34, 122, 59, 45, 82, 83, 52, 56, 86, 61, 65, 121, 6, 63, 44, 70, 90, 60, 20, 51, 17, 19, 9, 48, 114, 99, 71, 95, 24, 53, 41, 10, 125, 89, 64, 117, 32, 84, 11, 49, 72, 62, 108, 113, 37, 105, 66, 2, 116, 54, 29, 111, 43, 0, 69, 118, 88, 16, 1, 97, 8],
+            vector<u256>[84253995478139420, 250128204320245450, 607159736032795026, 884093705495226790, 932352552525192296, 1023969180824113797, 1100747041620789931, 1259581586625537313, 1273942673392086833, 1358902529864150854, 1434687973265117755, 1732822225631441413, 1972641399890535735, 2071542638548192867, 2230489124048464167, 2293477501003937176, 2473551917174573038, 2607229842893506755, 2844229746743954283, 2893808774499989735, 3030590483438781607, 3149756680701490498, 3331236165669254098, 3529362220911038621, 3912702130059322190, 4196310563396380090, 4245056338230641971, 4259218099284613925, 4377471957024472456, 4430431962070682835, 4523656399554789108, 4985077821224591987, 5361817119047301016, 5549735173285411483, 5566096950844631325, 5575372128400727406, 5608008025391549343, 5819697006064706810, 5953663811718943605, 5957884322682057074, 6049490154909449131, 6158972322187069841, 6172258651138172411, 6179999080186222552, 6345677610740596854, 6837272077571506036, 6981834443466128261, 7219646697329593081, 7321971353966050994, 7786181425108690721, 7874138225276421062, 7918073351763221495, 7986767972382161390, 8103528008093766367, 8334042827575005807, 8373100031395864307, 8478626249766814413, 8479224097104742542, 8576255684995728084, 8636387501549697187, 8654846241027939518, 8987766525720193848, 9411959155640204440, 9430559953195483862, 9795963436163261754, 9955549248939332941, 9955782472830064510, 10088762375570601417, 10629671971912445458, 10729810269964488046, 10894934247037747208, 10948714043967608735, 11144372572656407173, 11386767483348216810, 11449371691943827523, 11475506936310745141, 11651469544957421230, 11801031990455848410, 11858443091078955238, 11863954014537140586, 11911252641194946995, 12081128042739098795, 12486678963005495848, 12524834378486039182, 12557607445083366815, 12685533654718252799, 12925693437986784316, 12985581283708262881, 13051790785321408270, 13105393089626991588, 13590006276814086576, 13812170418811799120, 13954330200355545344, 13994675946615603989, 14156342587658307798, 14254950350147983174, 14355597466994298395, 14609395174398702341, 14722875136664765787, 15003525950791373626, 15228272873747632177, 15527429588183774587, 15650488316737606819, 15796524445606297825, 15807471714349337622, 15902007595972612345, 15920743808333505835, 15964011847420512071, 16005651223333303498, 16060697690637210273, 16065246986434468771, 16476916165265834170, 16923096141865953495, 16995321543272428614, 16999608164675960976, 17106136842549931820, 17319162688864593879, 17329867851850442723, 17437321453306671839, 17460325656492349826, 17628440849010290991, 17731590335386706016, 17792235364218603600, 17925483946877736059, 18290770630839183303, 18416377333136245323],
+            vector<u64>[729, 301, 288, 639, 570, 571, 465, 719, 427, 617, 630, 694, 790, 725, 308, 576, 750, 587, 460, 720, 444, 708, 591, 782, 332, 687, 542, 574, 518, 430, 785, 672, 686, 794, 786, 573, 296, 394, 722, 593, 661, 705, 668, 640, 787, 377, 644, 435, 791, 765, 623, 609, 636, 780, 517, 431, 190, 489, 619, 508, 641, 660, 524, 270, 665, 769, 776, 796, 560, 509, 559, 657, 634, 740, 546, 437, 775, 626, 678, 547, 535, 658, 590, 367, 743, 744, 671, 477, 319, 704, 618, 758, 583, 586, 611, 291, 699, 799, 568, 541, 712, 717, 676, 177, 727, 456, 375, 731, 702, 650, 673, 454, 117, 709, 767, 401, 688, 689, 511, 463, 777, 753, 442, 476, 696, 733],
+            799,
+        );
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 114);
+            assert!(key0 == 13105393089626991588 && value == 704, 800);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4010511578700616180, 801) == 125, 801);
+        assert!(vanilla_binary_search_tree::find(tree, 13051790785321408270) == 48, 802);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15695408162267312852, 803) == 126, 803);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17077011048340177589, 804) == 127, 804);
+        assert!(vanilla_binary_search_tree::find(tree, 884093705495226790) == 107, 805);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 108);
+            assert!(key0 == 15964011847420512071 && value == 731, 806);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 126);
+            assert!(key0 == 15695408162267312852 && value == 803, 807);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 109);
+            assert!(key0 == 5361817119047301016 && value == 686, 808);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 14224460633727806076) == vanilla_binary_search_tree::null_index_value(), 809);
+        assert!(vanilla_binary_search_tree::find(tree, 12557607445083366815) == 51, 810);
+        assert!(vanilla_binary_search_tree::find(tree, 16995321543272428614) == 116, 811);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 56);
+            assert!(key0 == 11144372572656407173 && value == 634, 812);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 9);
+            assert!(key0 == 12985581283708262881 && value == 477, 813);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 17437321453306671839) == 0, 814);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4351692446784997569, 815) == 123, 815);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2246370075422820690, 816) == 124, 816);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2959800485688510981, 817) == 125, 817);
+        assert!(vanilla_binary_search_tree::find(tree, 10106571128916110677) == vanilla_binary_search_tree::null_index_value(), 818);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 91);
+            assert!(key0 == 932352552525192296 && value == 570, 819);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8299520396305778601, 820) == 125, 820);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 115);
+            assert!(key0 == 3149756680701490498 && value == 708, 821);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4760428523989689118, 822) == 125, 822);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 26);
+            assert!(key0 == 4985077821224591987 && value == 672, 823);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 20);
+            assert!(key0 == 12524834378486039182 && value == 367, 824);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11420902093428891229, 825) == 124, 825);
+        assert!(vanilla_binary_search_tree::find(tree, 7918073351763221495) == 33, 826);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 81);
+            assert!(key0 == 8636387501549697187 && value == 508, 827);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 28);
+            assert!(key0 == 6837272077571506036 && value == 377, 828);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10729810269964488046) == 82, 829);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2215637387882450286, 830) == 123, 830);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3211835759501424151, 831) == 124, 831);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12404764813189278726, 832) == 125, 832);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15199377317826821296, 833) == 126, 833);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 87);
+            assert!(key0 == 5566096950844631325 && value == 786, 834);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3513734689465572905, 835) == 126, 835);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 86);
+            assert!(key0 == 11386767483348216810 && value == 740, 836);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 81);
+            assert!(key0 == 11420902093428891229 && value == 825, 837);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 13812170418811799120) == 71, 838);
+        assert!(vanilla_binary_search_tree::find(tree, 14632432850305423782) == vanilla_binary_search_tree::null_index_value(), 839);
+        assert!(vanilla_binary_search_tree::find(tree, 15211594065592042493) == vanilla_binary_search_tree::null_index_value(), 840);
+        assert!(vanilla_binary_search_tree::find(tree, 17925483946877736059) == 1, 841);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 24);
+            assert!(key0 == 13994675946615603989 && value == 586, 842);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15937343594049674135, 843) == 124, 843);
+        assert!(vanilla_binary_search_tree::find(tree, 8576255684995728084) == 100, 844);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15855203623195679667, 845) == 125, 845);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 42);
+            assert!(key0 == 8103528008093766367 && value == 780, 846);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13855685159633292554, 847) == 125, 847);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4461422643754319632, 848) == 126, 848);
+        assert!(vanilla_binary_search_tree::find(tree, 10656950371984418987) == vanilla_binary_search_tree::null_index_value(), 849);
+        assert!(vanilla_binary_search_tree::find(tree, 17319162688864593879) == 111, 850);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14923099596583737002, 851) == 127, 851);
+        assert!(vanilla_binary_search_tree::find(tree, 7219646697329593081) == 79, 852);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 9991810235610398684, 853) == 128, 853);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7535344979516667138, 854) == 129, 854);
+        assert!(vanilla_binary_search_tree::find(tree, 13590006276814086576) == 99, 855);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 129);
+            assert!(key0 == 7535344979516667138 && value == 854, 856);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 12404764813189278726) == 81, 857);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2720171052084594151, 858) == 129, 858);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 69);
+            assert!(key0 == 17460325656492349826 && value == 463, 859);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4456489125827369546, 860) == 129, 860);
+        assert!(vanilla_binary_search_tree::find(tree, 17925483946877736059) == 1, 861);
+        assert!(vanilla_binary_search_tree::find(tree, 1434687973265117755) == 104, 862);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 7);
+            assert!(key0 == 1100747041620789931 && value == 465, 863);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 4351692446784997569) == 28, 864);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6949202648781369394, 865) == 129, 865);
+        assert!(vanilla_binary_search_tree::find(tree, 14156342587658307798) == 53, 866);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12807490596196879603, 867) == 130, 867);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 65);
+            assert!(key0 == 11475506936310745141 && value == 437, 868);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11492916734625905380, 869) == 130, 869);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16240546335592092315, 870) == 131, 870);
+        assert!(vanilla_binary_search_tree::find(tree, 3222472501684184156) == vanilla_binary_search_tree::null_index_value(), 871);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 77);
+            assert!(key0 == 6158972322187069841 && value == 705, 872);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 1804632969846266082) == vanilla_binary_search_tree::null_index_value(), 873);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 25);
+            assert!(key0 == 5608008025391549343 && value == 296, 874);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 13652002102187959420) == vanilla_binary_search_tree::null_index_value(), 875);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 115);
+            assert!(key0 == 8299520396305778601 && value == 820, 876);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13653576106340664537, 877) == 129, 877);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 315713312516434651, 878) == 130, 878);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17864087291483459084, 879) == 131, 879);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6909831503603127901, 880) == 132, 880);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 62);
+            assert!(key0 == 15920743808333505835 && value == 375, 881);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 119);
+            assert!(key0 == 1259581586625537313 && value == 719, 882);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 30);
+            assert!(key0 == 250128204320245450 && value == 301, 883);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 1434687973265117755) == 104, 884);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15893142057249125251, 885) == 130, 885);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16998882478581247609, 886) == 131, 886);
+        assert!(vanilla_binary_search_tree::find(tree, 179887100147999574) == vanilla_binary_search_tree::null_index_value(), 887);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18358964719237478384, 888) == 132, 888);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2257329346400351629, 889) == 133, 889);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 47);
+            assert!(key0 == 8654846241027939518 && value == 641, 890);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 16995321543272428614) == 116, 891);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15127196331250426159, 892) == 133, 892);
+        assert!(vanilla_binary_search_tree::find(tree, 3063837413614990382) == vanilla_binary_search_tree::null_index_value(), 893);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 17589137366005808102, 894) == 134, 894);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2804755897198625320, 895) == 135, 895);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 804766791402417123, 896) == 136, 896);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5929389924571779153, 897) == 137, 897);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12805595136328642362, 898) == 138, 898);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3965638822309252404, 899) == 139, 899);
+        check(
+            tree,
+            vector<u64>[55, 30, 40, 136, 107, 92, 73, 98, 104, 112, 56, 96, 123, 14, 20, 47, 31, 18, 38, 69, 135, 50, 46, 91, 80, 24, 58, 86, 102, 4, 139, 109, 110, 13, 94, 28, 3, 74, 7, 126, 9, 26, 22, 93, 67, 137, 120, 85, 23, 103, 36, 27, 62, 115, 76, 79, 12, 5, 101, 33, 106, 15, 75, 21, 78, 100, 68, 39, 35, 57, 34, 122, 128, 59, 45, 82, 83, 52, 61, 25, 121, 6, 63, 44, 70, 90, 81, 60, 51, 17, 138, 65, 19, 48, 99, 129, 71, 125, 95, 53, 41, 10, 114, 89, 127, 64, 133, 87, 117, 32, 84, 11, 49, 42, 130, 72, 124, 113, 37, 105, 77, 66, 2, 116, 131, 54, 108, 29, 111, 43, 0, 134, 118, 88, 16, 119, 1, 97, 132, 8],
+            vector<u256>[84253995478139420, 315713312516434651, 607159736032795026, 804766791402417123, 884093705495226790, 1023969180824113797, 1273942673392086833, 1358902529864150854, 1434687973265117755, 1732822225631441413, 1972641399890535735, 2071542638548192867, 2215637387882450286, 2230489124048464167, 2246370075422820690, 2257329346400351629, 2293477501003937176, 2473551917174573038, 2607229842893506755, 2720171052084594151, 2804755897198625320, 2844229746743954283, 2893808774499989735, 2959800485688510981, 3030590483438781607, 3211835759501424151, 3331236165669254098, 3513734689465572905, 3529362220911038621, 3912702130059322190, 3965638822309252404, 4010511578700616180, 4196310563396380090, 4245056338230641971, 4259218099284613925, 4351692446784997569, 4377471957024472456, 4430431962070682835, 4456489125827369546, 4461422643754319632, 4523656399554789108, 4760428523989689118, 5549735173285411483, 5575372128400727406, 5819697006064706810, 5929389924571779153, 5953663811718943605, 5957884322682057074, 6049490154909449131, 6172258651138172411, 6179999080186222552, 6345677610740596854, 6909831503603127901, 6949202648781369394, 6981834443466128261, 7219646697329593081, 7321971353966050994, 7786181425108690721, 7874138225276421062, 7918073351763221495, 7986767972382161390, 8334042827575005807, 8373100031395864307, 8478626249766814413, 8479224097104742542, 8576255684995728084, 8987766525720193848, 9411959155640204440, 9430559953195483862, 9795963436163261754, 9955549248939332941, 9955782472830064510, 9991810235610398684, 10088762375570601417, 10629671971912445458, 10729810269964488046, 10894934247037747208, 10948714043967608735, 11449371691943827523, 11492916734625905380, 11651469544957421230, 11801031990455848410, 11858443091078955238, 11863954014537140586, 11911252641194946995, 12081128042739098795, 12404764813189278726, 12486678963005495848, 12557607445083366815, 12685533654718252799, 12805595136328642362, 12807490596196879603, 12925693437986784316, 13051790785321408270, 13590006276814086576, 13653576106340664537, 13812170418811799120, 13855685159633292554, 13954330200355545344, 14156342587658307798, 14254950350147983174, 14355597466994298395, 14609395174398702341, 14722875136664765787, 14923099596583737002, 15003525950791373626, 15127196331250426159, 15199377317826821296, 15228272873747632177, 15527429588183774587, 15650488316737606819, 15796524445606297825, 15807471714349337622, 15855203623195679667, 15893142057249125251, 15902007595972612345, 15937343594049674135, 16005651223333303498, 16060697690637210273, 16065246986434468771, 16240546335592092315, 16476916165265834170, 16923096141865953495, 16995321543272428614, 16998882478581247609, 16999608164675960976, 17077011048340177589, 17106136842549931820, 17319162688864593879, 17329867851850442723, 17437321453306671839, 17589137366005808102, 17628440849010290991, 17731590335386706016, 17792235364218603600, 17864087291483459084, 17925483946877736059, 18290770630839183303, 18358964719237478384, 18416377333136245323],
+            vector<u64>[729, 878, 288, 896, 639, 571, 427, 617, 630, 694, 790, 725, 830, 308, 816, 889, 576, 750, 587, 858, 895, 460, 720, 817, 444, 831, 591, 835, 782, 332, 899, 801, 687, 542, 574, 815, 518, 430, 860, 848, 785, 822, 794, 573, 394, 897, 722, 593, 661, 668, 640, 787, 880, 865, 644, 435, 791, 765, 623, 609, 636, 517, 431, 190, 489, 619, 660, 524, 270, 665, 769, 776, 853, 796, 560, 509, 559, 657, 546, 869, 775, 626, 678, 547, 535, 658, 832, 590, 743, 744, 898, 867, 671, 319, 618, 877, 758, 847, 583, 611, 291, 699, 799, 568, 851, 541, 892, 833, 712, 717, 676, 177, 727, 845, 885, 456, 843, 702, 650, 673, 870, 454, 117, 709, 886, 767, 804, 401, 688, 689, 511, 894, 777, 753, 442, 879, 476, 696, 888, 733],
+            899,
+        );
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5944583311717246597, 900) == 140, 900);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 54);
+            assert!(key0 == 16999608164675960976 && value == 767, 901);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 18290770630839183303) == 97, 902);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 2);
+            assert!(key0 == 16923096141865953495 && value == 117, 903);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13100418257681335077, 904) == 139, 904);
+        assert!(vanilla_binary_search_tree::find(tree, 1358902529864150854) == 98, 905);
+        assert!(vanilla_binary_search_tree::find(tree, 9744310616395942468) == vanilla_binary_search_tree::null_index_value(), 906);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 79);
+            assert!(key0 == 7219646697329593081 && value == 435, 907);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5376106631174947848, 908) == 139, 908);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 101);
+            assert!(key0 == 7874138225276421062 && value == 623, 909);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 137);
+            assert!(key0 == 5929389924571779153 && value == 897, 910);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 109);
+            assert!(key0 == 4010511578700616180 && value == 801, 911);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 6981834443466128261) == 76, 912);
+        assert!(vanilla_binary_search_tree::find(tree, 13100418257681335077) == 79, 913);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 79);
+            assert!(key0 == 13100418257681335077 && value == 904, 914);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 67);
+            assert!(key0 == 5819697006064706810 && value == 394, 915);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1870285566413244761, 916) == 135, 916);
+        assert!(vanilla_binary_search_tree::find(tree, 4461422643754319632) == 126, 917);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 86);
+            assert!(key0 == 3513734689465572905 && value == 835, 918);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14715200071870575999, 919) == 135, 919);
+        assert!(vanilla_binary_search_tree::find(tree, 15902007595972612345) == 72, 920);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 55);
+            assert!(key0 == 84253995478139420 && value == 729, 921);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 7321971353966050994) == 12, 922);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1939596955992130603, 923) == 135, 923);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11864151402132342159, 924) == 136, 924);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 2188264163991452991, 925) == 137, 925);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 55);
+            assert!(key0 == 14715200071870575999 && value == 919, 926);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8457527973357887500, 927) == 137, 927);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4538095720200197708, 928) == 138, 928);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 3);
+            assert!(key0 == 4377471957024472456 && value == 518, 929);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18238858106507005496, 930) == 138, 930);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18286684185887501914, 931) == 139, 931);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6979472133000356212, 932) == 140, 932);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 89);
+            assert!(key0 == 14722875136664765787 && value == 568, 933);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 16);
+            assert!(key0 == 17792235364218603600 && value == 442, 934);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 15100941897018827915, 935) == 139, 935);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 30);
+            assert!(key0 == 315713312516434651 && value == 878, 936);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13396707816539549052, 937) == 139, 937);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 15);
+            assert!(key0 == 8334042827575005807 && value == 517, 938);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 43);
+            assert!(key0 == 17329867851850442723 && value == 689, 939);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 73);
+            assert!(key0 == 1273942673392086833 && value == 427, 940);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2844229746743954283) == 50, 941);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 102);
+            assert!(key0 == 3529362220911038621 && value == 782, 942);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 58);
+            assert!(key0 == 3331236165669254098 && value == 591, 943);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 66);
+            assert!(key0 == 16476916165265834170 && value == 454, 944);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3951753123502165748, 945) == 134, 945);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 113);
+            assert!(key0 == 16005651223333303498 && value == 702, 946);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 11319586317677980378, 947) == 134, 947);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 12274248726281140797, 948) == 135, 948);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 70479135266394839, 949) == 136, 949);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 136);
+            assert!(key0 == 70479135266394839 && value == 949, 950);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 9795963436163261754) == 57, 951);
+        assert!(vanilla_binary_search_tree::find(tree, 13030017144510477576) == vanilla_binary_search_tree::null_index_value(), 952);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 16207323972098075993, 953) == 136, 953);
+        assert!(vanilla_binary_search_tree::find(tree, 1434687973265117755) == 104, 954);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 110);
+            assert!(key0 == 4196310563396380090 && value == 687, 955);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 21);
+            assert!(key0 == 8478626249766814413 && value == 190, 956);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 2785788757269447472) == vanilla_binary_search_tree::null_index_value(), 957);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 52);
+            assert!(key0 == 10948714043967608735 && value == 657, 958);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 74);
+            assert!(key0 == 4430431962070682835 && value == 430, 959);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 5104141514000511260, 960) == 133, 960);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 1197431970803945571, 961) == 134, 961);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7489347711422792130, 962) == 135, 962);
+        assert!(vanilla_binary_search_tree::find(tree, 17051953467659102883) == vanilla_binary_search_tree::null_index_value(), 963);
+        assert!(vanilla_binary_search_tree::find(tree, 17731590335386706016) == 88, 964);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 93);
+            assert!(key0 == 5575372128400727406 && value == 573, 965);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10898505150294413994, 966) == 135, 966);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 10684593289131053888, 967) == 136, 967);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 12557607445083366815 && value == 743, 968);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 51);
+            assert!(key0 == 10684593289131053888 && value == 967, 969);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 60);
+            assert!(key0 == 12486678963005495848 && value == 590, 970);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 6903751467689243653, 971) == 134, 971);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 13612321344954850858, 972) == 135, 972);
+        assert!(vanilla_binary_search_tree::find(tree, 2569208735573024071) == vanilla_binary_search_tree::null_index_value(), 973);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 114);
+            assert!(key0 == 14609395174398702341 && value == 799, 974);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 130);
+            assert!(key0 == 15893142057249125251 && value == 885, 975);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 7440673863886515367, 976) == 134, 976);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8964473359203963536, 977) == 135, 977);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 107);
+            assert!(key0 == 884093705495226790 && value == 639, 978);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14801107984247306981, 979) == 135, 979);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 133);
+            assert!(key0 == 5104141514000511260 && value == 960, 980);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 8402888024474056856, 981) == 135, 981);
+        assert!(vanilla_binary_search_tree::find(tree, 12404764813189278726) == 81, 982);
+        assert!(vanilla_binary_search_tree::find(tree, 12081128042739098795) == 90, 983);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 27);
+            assert!(key0 == 6345677610740596854 && value == 787, 984);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 97);
+            assert!(key0 == 18290770630839183303 && value == 696, 985);
+        };
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 109);
+            assert!(key0 == 12805595136328642362 && value == 898, 986);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 14254950350147983174) == 41, 987);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 14357682282489565678, 988) == 133, 988);
+        assert!(vanilla_binary_search_tree::find(tree, 10895879878319508500) == vanilla_binary_search_tree::null_index_value(), 989);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 124);
+            assert!(key0 == 15937343594049674135 && value == 843, 990);
+        };
+    }
+
+    fun ops_5(tree: &mut BinarySearchTree<u64>) {
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 3159728549663262375, 991) == 133, 991);
+        assert!(vanilla_binary_search_tree::find(tree, 11377330217309912244) == vanilla_binary_search_tree::null_index_value(), 992);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 79);
+            assert!(key0 == 804766791402417123 && value == 896, 993);
+        };
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 4880731408060215470, 994) == 133, 994);
+        {
+            let (key0, value) = vanilla_binary_search_tree::remove(tree, 98);
+            assert!(key0 == 1358902529864150854 && value == 617, 995);
+        };
+        assert!(vanilla_binary_search_tree::find(tree, 10886111976308686144) == vanilla_binary_search_tree::null_index_value(), 996);
+        assert!(vanilla_binary_search_tree::find(tree, 10694260872978179267) == vanilla_binary_search_tree::null_index_value(), 997);
+        assert!(vanilla_binary_search_tree::find(tree, 1070446406643189125) == vanilla_binary_search_tree::null_index_value(), 998);
+        assert!(vanilla_binary_search_tree::insert_and_get_index(tree, 18189533589696487768, 999) == 133, 999);
+        check(
+            tree,
+            vector<u64>[40, 92, 60, 104, 112, 86, 58, 56, 96, 55, 123, 14, 20, 47, 31, 18, 38, 69, 67, 50, 46, 91, 80, 79, 24, 4, 113, 2, 13, 94, 28, 7, 126, 9, 3, 26, 98, 101, 22, 54, 120, 85, 23, 103, 36, 130, 62, 115, 89, 76, 12, 97, 93, 5, 33, 106, 75, 27, 73, 78, 100, 107, 68, 39, 35, 57, 34, 122, 128, 59, 45, 82, 83, 51, 52, 61, 25, 121, 6, 63, 44, 102, 70, 90, 21, 81, 17, 65, 19, 48, 15, 99, 114, 129, 71, 125, 95, 53, 41, 10, 124, 109, 127, 64, 30, 74, 87, 117, 32, 84, 11, 49, 42, 72, 37, 105, 110, 77, 116, 131, 108, 29, 111, 0, 66, 118, 88, 119, 1, 133, 43, 16, 132, 8],
+            vector<u256>[607159736032795026, 1023969180824113797, 1197431970803945571, 1434687973265117755, 1732822225631441413, 1870285566413244761, 1939596955992130603, 1972641399890535735, 2071542638548192867, 2188264163991452991, 2215637387882450286, 2230489124048464167, 2246370075422820690, 2257329346400351629, 2293477501003937176, 2473551917174573038, 2607229842893506755, 2720171052084594151, 2804755897198625320, 2844229746743954283, 2893808774499989735, 2959800485688510981, 3030590483438781607, 3159728549663262375, 3211835759501424151, 3912702130059322190, 3951753123502165748, 3965638822309252404, 4245056338230641971, 4259218099284613925, 4351692446784997569, 4456489125827369546, 4461422643754319632, 4523656399554789108, 4538095720200197708, 4760428523989689118, 4880731408060215470, 5376106631174947848, 5549735173285411483, 5944583311717246597, 5953663811718943605, 5957884322682057074, 6049490154909449131, 6172258651138172411, 6179999080186222552, 6903751467689243653, 6909831503603127901, 6949202648781369394, 6979472133000356212, 6981834443466128261, 7321971353966050994, 7440673863886515367, 7489347711422792130, 7786181425108690721, 7918073351763221495, 7986767972382161390, 8373100031395864307, 8402888024474056856, 8457527973357887500, 8479224097104742542, 8576255684995728084, 8964473359203963536, 8987766525720193848, 9411959155640204440, 9430559953195483862, 9795963436163261754, 9955549248939332941, 9955782472830064510, 9991810235610398684, 10088762375570601417, 10629671971912445458, 10729810269964488046, 10894934247037747208, 10898505150294413994, 11319586317677980378, 11449371691943827523, 11492916734625905380, 11651469544957421230, 11801031990455848410, 11858443091078955238, 11863954014537140586, 11864151402132342159, 11911252641194946995, 12081128042739098795, 12274248726281140797, 12404764813189278726, 12685533654718252799, 12807490596196879603, 12925693437986784316, 13051790785321408270, 13396707816539549052, 13590006276814086576, 13612321344954850858, 13653576106340664537, 13812170418811799120, 13855685159633292554, 13954330200355545344, 14156342587658307798, 14254950350147983174, 14355597466994298395, 14357682282489565678, 14801107984247306981, 14923099596583737002, 15003525950791373626, 15100941897018827915, 15127196331250426159, 15199377317826821296, 15228272873747632177, 15527429588183774587, 15650488316737606819, 15796524445606297825, 15807471714349337622, 15855203623195679667, 15902007595972612345, 16060697690637210273, 16065246986434468771, 16207323972098075993, 16240546335592092315, 16995321543272428614, 16998882478581247609, 17077011048340177589, 17106136842549931820, 17319162688864593879, 17437321453306671839, 17589137366005808102, 17628440849010290991, 17731590335386706016, 17864087291483459084, 17925483946877736059, 18189533589696487768, 18238858106507005496, 18286684185887501914, 18358964719237478384, 18416377333136245323],
+            vector<u64>[288, 571, 961, 630, 694, 916, 923, 790, 725, 925, 830, 308, 816, 889, 576, 750, 587, 858, 895, 460, 720, 817, 444, 991, 831, 332, 945, 899, 542, 574, 815, 860, 848, 785, 928, 822, 994, 908, 794, 900, 722, 593, 661, 668, 640, 971, 880, 865, 932, 644, 791, 976, 962, 765, 609, 636, 431, 981, 927, 489, 619, 977, 660, 524, 270, 665, 769, 776, 853, 796, 560, 509, 559, 966, 947, 546, 869, 775, 626, 678, 547, 924, 535, 658, 948, 832, 744, 867, 671, 319, 937, 618, 972, 877, 758, 847, 583, 611, 291, 699, 988, 979, 851, 541, 935, 892, 833, 712, 717, 676, 177, 727, 845, 456, 650, 673, 953, 870, 709, 886, 804, 401, 688, 511, 894, 777, 753, 879, 476, 999, 930, 931, 888, 733],
+            999,
+        );
+    }
+
+    #[test]
+    fun test_random_ops() {
+        let tree = vanilla_binary_search_tree::new<u64>();
+        ops_0(&mut tree);
+        ops_1(&mut tree);
+        ops_2(&mut tree);
+        ops_3(&mut tree);
+        ops_4(&mut tree);
+        ops_5(&mut tree);
+    }
+}
@@ -0,0 +1,93 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Backend is the storage the container keeps its elements in.
+//
+// Backend implements the Value interface of pflag, so it can be used as a command line flag directly.
+type Backend string
+
+const (
+	// VectorBackend stores the elements in a move vector.
+	VectorBackend Backend = "vector"
+	// AptosTableBackend stores the elements in aptos_std::table_with_length.
+	AptosTableBackend Backend = "aptos-table"
+	// AptosSmartTableBackend stores the elements in aptos_std::smart_table, which buckets many elements in one table item.
+	AptosSmartTableBackend Backend = "aptos-smart-table"
+	// AptosSmartVectorBackend stores the elements in aptos_std::smart_vector, which buckets the elements by their indices.
+	// aptos_std::big_vector cannot be used directly, because it can only be created by its friends.
+	AptosSmartVectorBackend Backend = "aptos-smart-vector"
+	// SuiTableBackend stores the elements in sui::table.
+	SuiTableBackend Backend = "sui-table"
+	// SuiObjectTableBackend stores the elements in sui::object_table, each wrapped into an object.
+	// Inserting needs a TxContext to create the object.
+	SuiObjectTableBackend Backend = "sui-object-table"
+	// SuiDynamicFieldBackend stores the elements as sui::dynamic_field of an object owned by the container.
+	SuiDynamicFieldBackend Backend = "sui-dynamic-field"
+)
+
+// Backends are all the supported backends.
+var Backends = []Backend{
+	VectorBackend,
+	AptosTableBackend,
+	AptosSmartTableBackend,
+	AptosSmartVectorBackend,
+	SuiTableBackend,
+	SuiObjectTableBackend,
+	SuiDynamicFieldBackend,
+}
+
+// ParseBackend parses the name of a backend. Empty name is the vector backend.
+func ParseBackend(name string) (Backend, error) {
+	if name == "" {
+		return VectorBackend, nil
+	}
+	for _, b := range Backends {
+		if string(b) == name {
+			return b, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown backend %q, must be one of %s", name, BackendNames())
+}
+
+// BackendNames lists the names of all the backends.
+func BackendNames() string {
+	names := make([]string, 0, len(Backends))
+	for _, b := range Backends {
+		names = append(names, string(b))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (b Backend) String() string {
+	return string(b)
+}
+
+func (b *Backend) Set(name string) error {
+	v, err := ParseBackend(name)
+	if err != nil {
+		return err
+	}
+	*b = v
+
+	return nil
+}
+
+func (b *Backend) Type() string {
+	return "backend"
+}
+
+// IsSui checks if the backend is for sui, whose storage needs a TxContext to create.
+func (b Backend) IsSui() bool {
+	switch b {
+	case SuiTableBackend, SuiObjectTableBackend, SuiDynamicFieldBackend:
+		return true
+	default:
+		return false
+	}
+}
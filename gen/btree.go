@@ -0,0 +1,68 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+//go:embed btree.move.template
+var bTreeTemplate string
+
+var bTreeTmpl = parseContainer("btree.move.template", bTreeTemplate)
+
+type BTreeData struct {
+	*Shared
+
+	KeyIntWidth int
+	// Order is the max number of children of a node, each node holds up to Order - 1 keys.
+	Order int
+}
+
+// NewBTreeData creates the default settings for a B+ tree.
+func NewBTreeData() *BTreeData {
+	return &BTreeData{
+		Shared:      NewShared("btree", "btree"),
+		KeyIntWidth: 128,
+		Order:       16,
+	}
+}
+
+// GenerateBTree renders a B+ tree with the settings in data.
+func GenerateBTree(data BTreeData) ([]byte, error) {
+	return data.Generate()
+}
+
+func (btree *BTreeData) KeyType() string {
+	return fmt.Sprintf("u%d", btree.KeyIntWidth)
+}
+
+// MaxKeys is the max number of keys in a node.
+func (btree *BTreeData) MaxKeys() int {
+	return btree.Order - 1
+}
+
+// MinKeys is the min number of keys in a node other than the root.
+func (btree *BTreeData) MinKeys() int {
+	return (btree.Order - 1) / 2
+}
+
+// Generate renders the B+ tree.
+func (btree *BTreeData) Generate() ([]byte, error) {
+	if err := btree.Shared.check(); err != nil {
+		return nil, err
+	}
+	if err := btree.Shared.checkNoStableIndex("b tree"); err != nil {
+		return nil, err
+	}
+	if err := btree.Shared.checkNotBackend("b tree", SuiObjectTableBackend); err != nil {
+		return nil, err
+	}
+	if err := checkKeyIntWidth(btree.KeyIntWidth); err != nil {
+		return nil, err
+	}
+	if btree.Order < 3 {
+		return nil, fmt.Errorf("order of b tree must be at least 3: %d", btree.Order)
+	}
+
+	return execute(bTreeTmpl, btree)
+}
@@ -0,0 +1,46 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// StaleError is returned by Check when the output file differs from the generated code.
+type StaleError struct {
+	FileName string
+	// Diff is the unified diff from the output file to the generated code.
+	Diff string
+}
+
+func (e *StaleError) Error() string {
+	return fmt.Sprintf("%s is not up to date with the generated code", e.FileName)
+}
+
+// Check generates the code and compares it with the content of the output file, which is not modified.
+// A *StaleError is returned if they are different or the file doesn't exist.
+func Check(g Generator) error {
+	code, err := g.Generate()
+	if err != nil {
+		return err
+	}
+
+	fileName := g.OutputFile()
+	existing, err := os.ReadFile(fileName)
+	oldName := fileName
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		existing = nil
+		oldName = "/dev/null"
+	case err != nil:
+		return fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+
+	diff := UnifiedDiff(oldName, fileName+" (generated)", existing, code)
+	if diff == "" {
+		return nil
+	}
+
+	return &StaleError{FileName: fileName, Diff: diff}
+}
@@ -0,0 +1,72 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+)
+
+//go:embed critbit.move.template
+var critbitTreeTemplate string
+
+var critbitTreeTmpl = parseContainer("critbit.move.template", critbitTreeTemplate)
+
+type UnrolledLeadingZero struct {
+	Width uint
+	Ones  string
+}
+
+// value 1 in big.Int
+var one = big.NewInt(1)
+
+// UnrollLeadingZero creates an unrolled
+func UnrollLeadingZero(n uint, w uint) UnrolledLeadingZero {
+	return UnrolledLeadingZero{
+		Width: n,
+		Ones:  big.NewInt(0).Lsh(big.NewInt(0).Sub(big.NewInt(0).Lsh(one, n), one), w-n).String(),
+	}
+}
+
+type CritbitTreeData struct {
+	*Shared
+
+	KeyIntWidth int
+}
+
+// NewCritbitTreeData creates the default settings for a critbit tree.
+func NewCritbitTreeData() *CritbitTreeData {
+	return &CritbitTreeData{
+		Shared:      NewShared("critbit", "critbit"),
+		KeyIntWidth: 128,
+	}
+}
+
+// GenerateCritbit renders a critbit tree with the settings in data.
+func GenerateCritbit(data CritbitTreeData) ([]byte, error) {
+	return data.Generate()
+}
+
+func (critbit *CritbitTreeData) KeyType() string {
+	return fmt.Sprintf("u%d", critbit.KeyIntWidth)
+}
+
+func (critbit *CritbitTreeData) UnrolledLeadingZeros() []UnrolledLeadingZero {
+	result := make([]UnrolledLeadingZero, 0)
+	w := uint(critbit.KeyIntWidth)
+	for n := w >> 1; n > 0; n = n >> 1 {
+		result = append(result, UnrollLeadingZero(n, w))
+	}
+	return result
+}
+
+// Generate renders the critbit tree.
+func (critbit *CritbitTreeData) Generate() ([]byte, error) {
+	if err := critbit.Shared.check(); err != nil {
+		return nil, err
+	}
+	if err := checkKeyIntWidth(critbit.KeyIntWidth); err != nil {
+		return nil, err
+	}
+
+	return execute(critbitTreeTmpl, critbit)
+}
@@ -0,0 +1,46 @@
+package gen
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	a := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n")
+	b := []byte("1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13\n14\n16\n17\n")
+
+	expected := `--- a
++++ b
+@@ -2,7 +2,7 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+@@ -12,5 +12,5 @@
+ 12
+ 13
+ 14
+-15
+ 16
++17
+`
+
+	if diff := UnifiedDiff("a", "b", a, b); diff != expected {
+		t.Errorf("expecting:\n%s\ngot:\n%s", expected, diff)
+	}
+
+	if diff := UnifiedDiff("a", "b", a, a); diff != "" {
+		t.Errorf("expecting no diff, got:\n%s", diff)
+	}
+
+	expected = `--- a
++++ b
+@@ -0,0 +1,2 @@
++x
++y
+`
+	if diff := UnifiedDiff("a", "b", nil, []byte("x\ny\n")); diff != expected {
+		t.Errorf("expecting:\n%s\ngot:\n%s", expected, diff)
+	}
+}
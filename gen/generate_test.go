@@ -0,0 +1,470 @@
+package gen_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fardream/gen-move-container/gen"
+)
+
+func TestGenerateRedBlack(t *testing.T) {
+	data := gen.NewRedBlackData()
+	data.KeyCount = 2
+	data.ModulePostfix = "two"
+	data.WithSize = true
+
+	code, err := gen.GenerateRedBlack(*data)
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if !bytes.Contains(code, []byte("module container::red_black_two {")) {
+		t.Errorf("module name is not set:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("struct RedBlackTree<V>")) {
+		t.Errorf("red black tree is not generated:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("key1_2: u128,")) {
+		t.Errorf("keys are not generated:\n%s", code)
+	}
+	// the hand written tests only take one key.
+	if bytes.Contains(code, []byte("fun test_redblack()")) || bytes.Contains(code, []byte("fun test_bounds()")) {
+		t.Errorf("single key tests are generated for two keys:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("assert!(rank(&tree, key0_2, key1_2 + 1) == k + 1, k);")) {
+		t.Errorf("rank test for two keys is not generated:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("assert!(floor(&tree, 2, 4) == nine, 2);")) {
+		t.Errorf("bounds test for two keys is not generated:\n%s", code)
+	}
+
+	if data.ModuleName != "red_black" || !data.IsRb || data.Keys != nil {
+		t.Errorf("data is modified: %#v", data)
+	}
+
+	again, err := data.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(again, []byte("module container::red_black_two {")) {
+		t.Errorf("module name is not set:\n%s", again)
+	}
+}
+
+func TestGenerateStableIndex(t *testing.T) {
+	list := gen.NewLinkedListData()
+	list.StableIndex = true
+
+	code, err := list.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if !bytes.Contains(code, []byte("entries: Slots<Node<V>>,")) {
+		t.Errorf("slots are not used:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("public fun compact<V>(list: &mut LinkedList<V>)")) {
+		t.Errorf("compact is not generated:\n%s", code)
+	}
+	if bytes.Contains(code, []byte("swap(")) {
+		t.Errorf("elements are still swapped on removal:\n%s", code)
+	}
+
+	// the capacity of a table cannot be derived from the vacant slots during compact.
+	tree := gen.NewAvlData()
+	tree.StableIndex = true
+	tree.Backend = gen.AptosTableBackend
+	code, err = tree.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(code, []byte("slots.capacity = index + 1;")) {
+		t.Errorf("capacity is not stored in the slots:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("fun test_compact_drops_vacant_slots()")) {
+		t.Errorf("compact test is not generated:\n%s", code)
+	}
+}
+
+func TestGenerateSuiBackend(t *testing.T) {
+	critbit := gen.NewCritbitTreeData()
+	critbit.Backend = gen.SuiObjectTableBackend
+
+	code, err := critbit.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if !bytes.Contains(code, []byte("public fun new<V: store>(ctx: &mut TxContext): CritbitTree<V> {")) {
+		t.Errorf("new doesn't take TxContext:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("key: u128, value: V, ctx: &mut TxContext): u64 {")) {
+		t.Errorf("insert doesn't take TxContext:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("entries: ObjectTable<u64, Item<DataNode<V>>>,")) {
+		t.Errorf("object table is not used:\n%s", code)
+	}
+	for _, expected := range []string{
+		"    #[test_only]\n    use sui::tx_context;\n",
+		"let tree = new<u128>(&mut ctx);",
+		"let index = insert_and_get_index(&mut tree, v, v, &mut ctx);",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q in the tests:\n%s", expected, code)
+		}
+	}
+
+	list := gen.NewLinkedListData()
+	list.Backend = gen.SuiDynamicFieldBackend
+
+	code, err = list.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(code, []byte("entries: Fields<Node<V>>,")) {
+		t.Errorf("dynamic fields are not used:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("public fun insert<V: store>(list: &mut LinkedList<V>, value: V) {")) {
+		t.Errorf("insert should not take TxContext:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("insert(&mut l, (i as u128) * 2 + 1);")) {
+		t.Errorf("insert in the tests should not take TxContext:\n%s", code)
+	}
+
+	heap := gen.NewHeapData()
+	heap.Backend = gen.SuiTableBackend
+
+	code, err = heap.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(code, []byte("            &mut ctx,\n        );")) {
+		t.Errorf("from_vectors in the tests doesn't take TxContext:\n%s", code)
+	}
+}
+
+func TestGenerateAptosTableTests(t *testing.T) {
+	critbit := gen.NewCritbitTreeData()
+	critbit.Backend = gen.AptosTableBackend
+
+	code, err := critbit.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if !bytes.Contains(code, []byte("fun test_bounds_critbit()")) {
+		t.Errorf("tests are not generated:\n%s", code)
+	}
+	if bytes.Contains(code, []byte("fun test_critbit()")) {
+		t.Errorf("tests comparing the vector layout are generated:\n%s", code)
+	}
+}
+
+func TestGenerateAptosSmartVector(t *testing.T) {
+	tree := gen.NewAvlData()
+	tree.Backend = gen.AptosSmartVectorBackend
+	tree.BucketSize = 64
+
+	code, err := tree.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if !bytes.Contains(code, []byte("const BUCKET_SIZE: u64 = 64;")) {
+		t.Errorf("bucket size is not set:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("entries: smart_vector::empty_with_config(0, BUCKET_SIZE),")) {
+		t.Errorf("smart vector is not used:\n%s", code)
+	}
+	if !bytes.Contains(code, []byte("smart_vector::borrow(&tree.entries, ")) {
+		t.Errorf("smart vector is not used:\n%s", code)
+	}
+
+	tree.BucketSize = 0
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for bucket size 0")
+	}
+
+	tree.BucketSize = 64
+	tree.StableIndex = true
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for stable index on smart vector")
+	}
+}
+
+func TestGenerateWithSpec(t *testing.T) {
+	tree := gen.NewAvlData()
+	tree.KeyCount = 2
+	tree.WithSpec = true
+
+	code, err := tree.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	for _, expected := range []string{
+		"spec fun spec_is_valid_tree<V>(tree: AvlTree<V>): bool {",
+		"aborts_if spec_contains(tree, key0_2, key1_2) with E_KEY_ALREADY_EXIST;",
+		"ensures spec_contains(tree, key0_2, key1_2) ==> result != NULL_INDEX;",
+		"invariant forall i in 0..len(tree.entries): (tree.entries[i].key0_2 == key0_2 && tree.entries[i].key1_2 == key1_2) ==> spec_in_subtree(tree, i, current);",
+		"ensures result_3 == old(tree.entries[index].value);",
+		"entry.metadata >= AVL_LEFT_HIGH && entry.metadata <= AVL_RIGHT_HIGH",
+		"(spec_in_subtree(tree, j, tree.entries[i].left_child) ==> spec_less(tree.entries[j], tree.entries[i]))",
+		"(forall i in 0..len(tree.entries): spec_is_avl_balanced(tree, i, NULL_INDEX, false, false))",
+		"invariant forall i in 0..len(tree.entries): spec_is_avl_balanced(tree, i, parent, is_right_child, true);",
+		"invariant forall i in 0..len(tree.entries): i != index ==> spec_is_avl_balanced(tree, i, rebalance_start, is_new_right, false);",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q:\n%s", expected, code)
+		}
+	}
+	if bytes.Contains(code, []byte("pragma verify = false;")) {
+		t.Errorf("verification is turned off:\n%s", code)
+	}
+
+	rb := gen.NewRedBlackData()
+	rb.WithSpec = true
+	code, err = rb.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	for _, expected := range []string{
+		"(forall i in 0..len(tree.entries): spec_is_rb_balanced(tree, i, NULL_INDEX, false))",
+		"invariant forall i in 0..len(tree.entries): spec_is_rb_red_ok(tree, i, parent, is_right_child);",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q:\n%s", expected, code)
+		}
+	}
+
+	// without spec, the loops have no invariants.
+	rb.WithSpec = false
+	code, err = rb.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(code, []byte("        while (parent != NULL_INDEX) {\n")) || bytes.Contains(code, []byte("invariant")) {
+		t.Errorf("loop invariants are generated without spec:\n%s", code)
+	}
+
+	tree.Backend = gen.AptosTableBackend
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for spec on aptos table backend")
+	}
+}
+
+func TestGenerateBTree(t *testing.T) {
+	btree := gen.NewBTreeData()
+	btree.Order = 5
+	btree.KeyIntWidth = 64
+	btree.Backend = gen.AptosTableBackend
+
+	code, err := btree.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	for _, expected := range []string{
+		"module container::btree {",
+		"const MAX_KEYS: u64 = 4;",
+		"const MIN_KEYS: u64 = 2;",
+		"public fun remove<V>(tree: &mut BTree<V>, index: u64): (u64, V) {",
+		"        (key, value)\n    }",
+		"let tree = new<u64>();",
+		"fun test_btree()",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q:\n%s", expected, code)
+		}
+	}
+
+	btree.Backend = gen.SuiTableBackend
+	code, err = btree.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(code, []byte("let tree = new<u64>(&mut ctx);")) {
+		t.Errorf("new in the tests doesn't take TxContext:\n%s", code)
+	}
+
+	btree.Backend = gen.SuiObjectTableBackend
+	if _, err := btree.Generate(); err == nil {
+		t.Errorf("expecting error for object table backend on btree")
+	}
+}
+
+func TestGenerateSkipList(t *testing.T) {
+	skipList := gen.NewSkipListData()
+	skipList.MaxLevel = 8
+
+	code, err := skipList.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	for _, expected := range []string{
+		"module container::skip_list {",
+		"const MAX_LEVEL: u64 = 8;",
+		"struct SkipList<V> has store, copy, drop {",
+		"fun test_skip_list()",
+		"fun test_skip_list_with_level()",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q:\n%s", expected, code)
+		}
+	}
+
+	skipList.MaxLevel = 65
+	if _, err := skipList.Generate(); err == nil {
+		t.Errorf("expecting error for max level 65")
+	}
+
+	skipList.MaxLevel = 8
+	skipList.Backend = gen.SuiObjectTableBackend
+	if _, err := skipList.Generate(); err == nil {
+		t.Errorf("expecting error for object table backend on skip list")
+	}
+}
+
+func TestGenerateHeap(t *testing.T) {
+	heap := gen.NewHeapData()
+
+	code, err := heap.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Contains(code, []byte("if (!(key < vector::borrow(&heap.nodes, parent).key)) {")) {
+		t.Errorf("min heap is not generated:\n%s", code)
+	}
+
+	heap.MaxHeap = true
+	code, err = heap.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	for _, expected := range []string{
+		"module container::heap {",
+		"if (!(key > vector::borrow(&heap.nodes, parent).key)) {",
+		"fun test_heap()",
+		"fun test_heap_from_vectors()",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q:\n%s", expected, code)
+		}
+	}
+	if bytes.Contains(code, []byte(".key < ")) {
+		t.Errorf("min heap comparison is generated for max heap:\n%s", code)
+	}
+
+	for _, backend := range []gen.Backend{gen.SuiObjectTableBackend, gen.SuiDynamicFieldBackend} {
+		heap.Backend = backend
+		if _, err := heap.Generate(); err == nil {
+			t.Errorf("expecting error for %s backend on heap", backend)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tree := gen.NewVanillaBinarySearchTreeData()
+	tree.KeyIntWidth = 100
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for key width 100")
+	}
+
+	tree = gen.NewVanillaBinarySearchTreeData()
+	tree.KeyCount = 0
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for key count 0")
+	}
+
+	btree := gen.NewBTreeData()
+	btree.Order = 2
+	if _, err := btree.Generate(); err == nil {
+		t.Errorf("expecting error for order 2")
+	}
+
+	skipList := gen.NewSkipListData()
+	skipList.MaxLevel = 0
+	if _, err := skipList.Generate(); err == nil {
+		t.Errorf("expecting error for max level 0")
+	}
+
+	btree = gen.NewBTreeData()
+	btree.StableIndex = true
+	if _, err := btree.Generate(); err == nil {
+		t.Errorf("expecting error for stable index on btree")
+	}
+
+	heap := gen.NewHeapData()
+	heap.Backend = gen.SuiDynamicFieldBackend
+	if _, err := heap.Generate(); err == nil {
+		t.Errorf("expecting error for dynamic field backend on heap")
+	}
+
+	btree = gen.NewBTreeData()
+	btree.Backend = gen.SuiObjectTableBackend
+	if _, err := btree.Generate(); err == nil {
+		t.Errorf("expecting error for object table backend on btree")
+	}
+
+	tree = gen.NewVanillaBinarySearchTreeData()
+	tree.Backend = gen.SuiDynamicFieldBackend
+	tree.StableIndex = true
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for stable index on dynamic field backend")
+	}
+
+	tree = gen.NewVanillaBinarySearchTreeData()
+	tree.Backend = "sui-bag"
+	if _, err := tree.Generate(); err == nil {
+		t.Errorf("expecting error for unknown backend")
+	}
+
+	if _, err := gen.GenerateLinkedList(gen.LinkedListData{}); err == nil {
+		t.Errorf("expecting error for missing shared settings")
+	}
+}
+
+func TestGenerateRandomOps(t *testing.T) {
+	data := gen.NewRandomOpsData()
+	data.Kind = "avl"
+	data.KeyCount = 2
+	data.KeyIntWidth = 8
+	data.Ops = 1000
+	data.Checkpoint = 100
+
+	code, err := data.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if data.OutputFile() != "tests/avl_random_test.move" {
+		t.Errorf("unexpected output file: %s", data.OutputFile())
+	}
+	for _, expected := range []string{
+		"module container::avl_random_test {",
+		"use container::avl::{Self, AvlTree};",
+		"fun check(tree: &AvlTree<u64>, indices: vector<u64>, key0: vector<u8>, key1: vector<u8>, values: vector<u64>, code: u64) {",
+		"        ops_4(&mut tree);\n",
+	} {
+		if !bytes.Contains(code, []byte(expected)) {
+			t.Errorf("missing %q in:\n%s", expected, code)
+		}
+	}
+	if n := bytes.Count(code, []byte("        check(\n")); n != 10 {
+		t.Errorf("expecting 10 checkpoints, got %d", n)
+	}
+
+	again, err := data.Generate()
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+	if !bytes.Equal(code, again) {
+		t.Errorf("the same seed should generate the same test")
+	}
+
+	data.Kind = "critbit"
+	if _, err := data.Generate(); err == nil {
+		t.Errorf("critbit with 2 keys should fail")
+	}
+}
@@ -0,0 +1,41 @@
+package gen_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/fardream/gen-move-container/gen"
+)
+
+// TestGeneratedUpToDate checks the generated code in the repo is up to date with the templates.
+func TestGeneratedUpToDate(t *testing.T) {
+	manifests, err := filepath.Glob("../*/containers.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(manifests) == 0 {
+		t.Fatal("cannot find any manifest")
+	}
+
+	for _, manifestFile := range manifests {
+		manifest, err := gen.LoadManifest(manifestFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generators, err := manifest.Generators(filepath.Dir(manifestFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, g := range generators {
+			err := gen.Check(g)
+			var staleErr *gen.StaleError
+			switch {
+			case errors.As(err, &staleErr):
+				t.Errorf("%v, run go generate ./...\n%s", err, staleErr.Diff)
+			case err != nil:
+				t.Errorf("failed to check %s: %v", g.OutputFile(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+//go:embed heap.move.template
+var heapTemplate string
+
+var heapTmpl = parseContainer("heap.move.template", heapTemplate)
+
+type HeapData struct {
+	*Shared
+
+	KeyIntWidth int
+	// MaxHeap puts the element with the largest key at the top instead of the smallest.
+	MaxHeap bool
+}
+
+// NewHeapData creates the default settings for a heap.
+func NewHeapData() *HeapData {
+	return &HeapData{
+		Shared:      NewShared("heap", "heap"),
+		KeyIntWidth: 128,
+	}
+}
+
+// GenerateHeap renders a heap with the settings in data.
+func GenerateHeap(data HeapData) ([]byte, error) {
+	return data.Generate()
+}
+
+func (heap *HeapData) KeyType() string {
+	return fmt.Sprintf("u%d", heap.KeyIntWidth)
+}
+
+// Cmp is the operator comparing two keys, which is true if the first key should be closer to the top.
+func (heap *HeapData) Cmp() string {
+	if heap.MaxHeap {
+		return ">"
+	}
+	return "<"
+}
+
+// Generate renders the heap.
+func (heap *HeapData) Generate() ([]byte, error) {
+	if err := heap.Shared.check(); err != nil {
+		return nil, err
+	}
+	if err := heap.Shared.checkNoStableIndex("heap, whose handles are already stable"); err != nil {
+		return nil, err
+	}
+	if err := heap.Shared.checkNotBackend("heap", SuiObjectTableBackend, SuiDynamicFieldBackend); err != nil {
+		return nil, err
+	}
+	if err := checkKeyIntWidth(heap.KeyIntWidth); err != nil {
+		return nil, err
+	}
+
+	return execute(heapTmpl, heap)
+}
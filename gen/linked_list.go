@@ -0,0 +1,35 @@
+package gen
+
+import (
+	_ "embed"
+)
+
+//go:embed linked_list.move.template
+var linkedListTemplate string
+
+var linkedListTmpl = parseContainer("linked_list.move.template", linkedListTemplate)
+
+type LinkedListData struct {
+	*Shared
+}
+
+// NewLinkedListData creates the default settings for a linked list.
+func NewLinkedListData() *LinkedListData {
+	return &LinkedListData{
+		Shared: NewShared("linked_list", "linked_list"),
+	}
+}
+
+// GenerateLinkedList renders a linked list with the settings in data.
+func GenerateLinkedList(data LinkedListData) ([]byte, error) {
+	return data.Generate()
+}
+
+// Generate renders the linked list.
+func (linkedListData *LinkedListData) Generate() ([]byte, error) {
+	if err := linkedListData.Shared.check(); err != nil {
+		return nil, err
+	}
+
+	return execute(linkedListTmpl, linkedListData)
+}
@@ -0,0 +1,275 @@
+package gen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Manifest lists all the containers to generate in one run.
+//
+//	address = "container"
+//
+//	[[container]]
+//	kind = "red-black"
+//	key-width = 256
+//
+//	[[container]]
+//	kind = "critbit"
+//	module = "critbit_u64"
+//	key-width = 64
+//	backend = "aptos-table"
+//	output = "sources/critbit_u64.move"
+//
+//	[[random-test]]
+//	kind = "red-black"
+//	ops = 1000
+type Manifest struct {
+	// Address is the default (named) address for containers that don't specify one.
+	Address     string               `toml:"address"`
+	Containers  []ManifestContainer  `toml:"container"`
+	RandomTests []ManifestRandomTest `toml:"random-test"`
+}
+
+// ManifestContainer describes one container in the manifest.
+// Zero values take the defaults of the corresponding command.
+type ManifestContainer struct {
+	// Kind is the name of the command: red-black, avl, bst, critbit, btree, skip-list, heap, or linked-list.
+	Kind          string `toml:"kind"`
+	Module        string `toml:"module"`
+	ModulePostfix string `toml:"module-postfix"`
+	Address       string `toml:"address"`
+	KeyWidth      int    `toml:"key-width"`
+	KeyCount      int    `toml:"key-count"`
+	Order         int    `toml:"order"`
+	MaxLevel      int    `toml:"max-level"`
+	// Backend is one of vector (default), aptos-table, aptos-smart-table, aptos-smart-vector,
+	// sui-table, sui-object-table or sui-dynamic-field.
+	Backend string `toml:"backend"`
+	// BucketSize is only supported by aptos-smart-vector backend.
+	BucketSize int    `toml:"bucket-size"`
+	Output     string `toml:"output"`
+	NoTest     bool   `toml:"no-test"`
+	NoAssert   bool   `toml:"no-assert"`
+	WithSize   bool   `toml:"with-size"`
+	WithSpec   bool   `toml:"with-spec"`
+	MaxHeap    bool   `toml:"max-heap"`
+	// StableIndex is supported by trees, critbit, and linked-list.
+	StableIndex bool `toml:"stable-index"`
+}
+
+// ManifestRandomTest describes one test module of random operations in the manifest, see gen-tests command.
+// Zero values take the defaults of the command.
+type ManifestRandomTest struct {
+	// Kind is the container under test: red-black, avl, bst or critbit.
+	Kind            string `toml:"kind"`
+	Module          string `toml:"module"`
+	ContainerModule string `toml:"container-module"`
+	Address         string `toml:"address"`
+	KeyWidth        int    `toml:"key-width"`
+	KeyCount        int    `toml:"key-count"`
+	Seed            int64  `toml:"seed"`
+	Ops             int    `toml:"ops"`
+	Checkpoint      int    `toml:"checkpoint"`
+	Output          string `toml:"output"`
+}
+
+// LoadManifest reads the manifest from a toml file.
+func LoadManifest(fileName string) (*Manifest, error) {
+	manifest := &Manifest{}
+	meta, err := toml.DecodeFile(fileName, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", fileName, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown keys in manifest %s: %v", fileName, undecoded)
+	}
+
+	return manifest, nil
+}
+
+// Generators converts the manifest into generators.
+// Relative output paths are resolved against baseDir.
+func (manifest *Manifest) Generators(baseDir string) ([]Generator, error) {
+	var result []Generator
+	for i := range manifest.Containers {
+		g, err := manifest.Containers[i].Generator(manifest.Address, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("container #%d (%s): %w", i, manifest.Containers[i].Kind, err)
+		}
+		result = append(result, g)
+	}
+	for i := range manifest.RandomTests {
+		g, err := manifest.RandomTests[i].Generator(manifest.Address, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("random test #%d (%s): %w", i, manifest.RandomTests[i].Kind, err)
+		}
+		result = append(result, g)
+	}
+
+	return result, nil
+}
+
+// Generator creates the generator with the default settings of the container kind, overridden by the settings in the manifest.
+func (c *ManifestContainer) Generator(defaultAddress string, baseDir string) (Generator, error) {
+	var shared *Shared
+	var keyWidth *int
+	var generator Generator
+	var specTree *SpecTreeData
+	var btree *BTreeData
+	var skipList *SkipListData
+	var heap *HeapData
+
+	switch c.Kind {
+	case "red-black":
+		specTree = NewRedBlackData()
+	case "avl":
+		specTree = NewAvlData()
+	case "bst":
+		specTree = NewVanillaBinarySearchTreeData()
+	case "critbit":
+		critbit := NewCritbitTreeData()
+		shared, keyWidth, generator = critbit.Shared, &critbit.KeyIntWidth, critbit
+	case "btree":
+		btree = NewBTreeData()
+		shared, keyWidth, generator = btree.Shared, &btree.KeyIntWidth, btree
+	case "skip-list":
+		skipList = NewSkipListData()
+		shared, keyWidth, generator = skipList.Shared, &skipList.KeyIntWidth, skipList
+	case "heap":
+		heap = NewHeapData()
+		shared, keyWidth, generator = heap.Shared, &heap.KeyIntWidth, heap
+	case "linked-list":
+		linkedList := NewLinkedListData()
+		shared, generator = linkedList.Shared, linkedList
+	default:
+		return nil, fmt.Errorf("unknown container kind: %q", c.Kind)
+	}
+
+	if specTree != nil {
+		shared, keyWidth, generator = specTree.Shared, &specTree.KeyIntWidth, specTree
+		if c.KeyCount != 0 {
+			specTree.KeyCount = c.KeyCount
+		}
+		specTree.ModulePostfix = c.ModulePostfix
+		specTree.NoAssert = c.NoAssert
+		specTree.WithSize = c.WithSize
+		specTree.WithSpec = c.WithSpec
+	} else if c.KeyCount != 0 || c.ModulePostfix != "" || c.NoAssert || c.WithSize || c.WithSpec {
+		return nil, fmt.Errorf("key-count, module-postfix, no-assert, with-size and with-spec are only supported by trees")
+	}
+
+	if c.Order != 0 {
+		if btree == nil {
+			return nil, fmt.Errorf("order is only supported by btree")
+		}
+		btree.Order = c.Order
+	}
+
+	if c.MaxLevel != 0 {
+		if skipList == nil {
+			return nil, fmt.Errorf("max-level is only supported by skip-list")
+		}
+		skipList.MaxLevel = c.MaxLevel
+	}
+
+	if c.MaxHeap {
+		if heap == nil {
+			return nil, fmt.Errorf("max-heap is only supported by heap")
+		}
+		heap.MaxHeap = true
+	}
+
+	if c.StableIndex && (btree != nil || skipList != nil || heap != nil) {
+		return nil, fmt.Errorf("stable-index is only supported by trees, critbit and linked-list")
+	}
+
+	if c.KeyWidth != 0 {
+		if keyWidth == nil {
+			return nil, fmt.Errorf("key-width is not supported")
+		}
+		*keyWidth = c.KeyWidth
+	}
+
+	switch {
+	case c.Address != "":
+		shared.Address = c.Address
+	case defaultAddress != "":
+		shared.Address = defaultAddress
+	}
+
+	if c.Module != "" {
+		shared.ModuleName = c.Module
+	}
+
+	backend, err := ParseBackend(c.Backend)
+	if err != nil {
+		return nil, err
+	}
+	shared.Backend = backend
+
+	if c.BucketSize != 0 {
+		if backend != AptosSmartVectorBackend {
+			return nil, fmt.Errorf("bucket-size is only supported by aptos-smart-vector backend")
+		}
+		shared.BucketSize = c.BucketSize
+	}
+
+	if c.Output != "" {
+		shared.OutputFileName = c.Output
+	}
+	if !filepath.IsAbs(shared.OutputFileName) {
+		shared.OutputFileName = filepath.Join(baseDir, shared.OutputFileName)
+	}
+
+	shared.NoTest = c.NoTest
+	shared.StableIndex = c.StableIndex
+
+	return generator, nil
+}
+
+// Generator creates the generator of the random operation test with the default settings, overridden by the settings in the manifest.
+func (c *ManifestRandomTest) Generator(defaultAddress string, baseDir string) (*RandomOpsData, error) {
+	data := NewRandomOpsData()
+
+	switch c.Kind {
+	case "":
+	case "red-black", "avl", "bst", "critbit":
+		data.Kind = c.Kind
+	default:
+		return nil, fmt.Errorf("unknown container kind: %q", c.Kind)
+	}
+
+	switch {
+	case c.Address != "":
+		data.Address = c.Address
+	case defaultAddress != "":
+		data.Address = defaultAddress
+	}
+
+	data.ModuleName = c.Module
+	data.ContainerModule = c.ContainerModule
+	if c.KeyWidth != 0 {
+		data.KeyIntWidth = c.KeyWidth
+	}
+	if c.KeyCount != 0 {
+		data.KeyCount = c.KeyCount
+	}
+	if c.Seed != 0 {
+		data.Seed = c.Seed
+	}
+	if c.Ops != 0 {
+		data.Ops = c.Ops
+	}
+	if c.Checkpoint != 0 {
+		data.Checkpoint = c.Checkpoint
+	}
+
+	data.OutputFileName = c.Output
+	if output := data.OutputFile(); !filepath.IsAbs(output) {
+		data.OutputFileName = filepath.Join(baseDir, output)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+const testManifest = `
+address = "my_address"
+
+[[container]]
+kind = "avl"
+key-width = 64
+key-count = 2
+backend = "aptos-table"
+
+[[container]]
+kind = "linked-list"
+address = "other"
+module = "my_list"
+output = "/tmp/list.move"
+
+[[random-test]]
+kind = "avl"
+key-count = 2
+ops = 100
+`
+
+func TestManifestGenerators(t *testing.T) {
+	manifest := &Manifest{}
+	if _, err := toml.Decode(testManifest, manifest); err != nil {
+		t.Fatalf("failed to decode manifest: %v", err)
+	}
+
+	generators, err := manifest.Generators("base")
+	if err != nil {
+		t.Fatalf("failed to create generators: %v", err)
+	}
+
+	if len(generators) != 3 {
+		t.Fatalf("expecting 3 generators, got %d", len(generators))
+	}
+
+	avl, ok := generators[0].(*SpecTreeData)
+	if !ok {
+		t.Fatalf("expecting *SpecTreeData, got %T", generators[0])
+	}
+	if !avl.IsAvl || avl.KeyIntWidth != 64 || avl.KeyCount != 2 || avl.Backend != AptosTableBackend || avl.Address != "my_address" || avl.ModuleName != "avl" {
+		t.Errorf("unexpected avl settings: %#v %#v", avl, avl.Shared)
+	}
+	if avl.OutputFileName != filepath.Join("base", "sources", "avl.move") {
+		t.Errorf("unexpected output: %s", avl.OutputFileName)
+	}
+
+	list, ok := generators[1].(*LinkedListData)
+	if !ok {
+		t.Fatalf("expecting *LinkedListData, got %T", generators[1])
+	}
+	if list.Address != "other" || list.ModuleName != "my_list" || list.OutputFileName != "/tmp/list.move" {
+		t.Errorf("unexpected linked list settings: %#v", list.Shared)
+	}
+
+	randomTest, ok := generators[2].(*RandomOpsData)
+	if !ok {
+		t.Fatalf("expecting *RandomOpsData, got %T", generators[2])
+	}
+	if randomTest.Kind != "avl" || randomTest.KeyCount != 2 || randomTest.Ops != 100 || randomTest.Checkpoint != 500 || randomTest.Address != "my_address" {
+		t.Errorf("unexpected random test settings: %#v", randomTest)
+	}
+	if randomTest.OutputFile() != filepath.Join("base", "tests", "avl_random_test.move") {
+		t.Errorf("unexpected output: %s", randomTest.OutputFile())
+	}
+
+	bad := &Manifest{Containers: []ManifestContainer{{Kind: "linked-list", KeyWidth: 64}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for key-width on linked-list")
+	}
+
+	bad = &Manifest{Containers: []ManifestContainer{{Kind: "critbit", Order: 8}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for order on critbit")
+	}
+
+	bad = &Manifest{Containers: []ManifestContainer{{Kind: "skip-list", StableIndex: true}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for stable-index on skip-list")
+	}
+
+	bad = &Manifest{Containers: []ManifestContainer{{Kind: "avl", Backend: "sui-bag"}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for unknown backend")
+	}
+
+	bad = &Manifest{Containers: []ManifestContainer{{Kind: "avl", Backend: "aptos-table", BucketSize: 64}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for bucket-size on aptos-table")
+	}
+
+	bad = &Manifest{Containers: []ManifestContainer{{Kind: "priority-queue"}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for unknown kind")
+	}
+
+	bad = &Manifest{RandomTests: []ManifestRandomTest{{Kind: "btree"}}}
+	if _, err := bad.Generators(""); err == nil {
+		t.Errorf("expecting error for random test of btree")
+	}
+}
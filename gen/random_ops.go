@@ -0,0 +1,370 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"text/template"
+
+	"github.com/fardream/gen-move-container/verifier"
+)
+
+//go:embed random_ops.move.template
+var randomOpsTemplate string
+
+var randomOpsTmpl = template.Must(template.New("random_ops.move.template").Parse(randomOpsTemplate))
+
+// randomOpsPerFunction is the number of operations in each generated function, which keeps the functions within the size limit of move.
+const randomOpsPerFunction = 200
+
+// RandomOpsData generates a move test module performing random operations on a tree or critbit tree.
+// The same operations are run on the reference model in the verifier, which gives the expected result of each operation,
+// and the expected size, min, max, and the ordered indices, keys and values at the checkpoints.
+type RandomOpsData struct {
+	Address string
+	// ModuleName is the name of the test module, default to the container module with postfix _random_test.
+	ModuleName string
+	// Kind is the container under test: red-black, avl, bst or critbit.
+	Kind string
+	// ContainerModule is the module of the container under test, which must use the vector backend without stable index.
+	// Default to the module name of the command of the kind.
+	ContainerModule string
+	KeyIntWidth     int
+	// KeyCount is the number of keys of the tree, critbit only supports 1.
+	KeyCount int
+	Seed     int64
+	Ops      int
+	// Checkpoint is the number of operations between the checks of the whole container.
+	Checkpoint int
+	// OutputFileName defaults to tests/<module name>.move.
+	OutputFileName string
+}
+
+// NewRandomOpsData creates the default settings to test the red black tree.
+func NewRandomOpsData() *RandomOpsData {
+	return &RandomOpsData{
+		Address:     "container",
+		Kind:        "red-black",
+		KeyIntWidth: 128,
+		KeyCount:    1,
+		Seed:        1,
+		Ops:         5000,
+		Checkpoint:  500,
+	}
+}
+
+// RandomOp is one operation in the generated test.
+type RandomOp struct {
+	// Code is the abort code of the asserts of the operation, which is the number of the operation.
+	Code int
+	// Insert, Remove, Find or Check.
+	Kind string
+	// Keys are the keys inserted, removed or found.
+	Keys  []string
+	Value uint64
+	// Index is the index returned by insert and find, or removed.
+	// Find expects NotFound instead if the key is not in the container.
+	Index    uint64
+	NotFound bool
+
+	// Indices, KeyColumns and Values are the ordered content of the container at the checkpoint.
+	Indices    []uint64
+	KeyColumns [][]string
+	Values     []uint64
+}
+
+// randomOpsRenderData is passed to the template.
+type randomOpsRenderData struct {
+	*RandomOpsData
+
+	TypeName  string
+	KeyType   string
+	KeyNames  []string
+	Functions [][]*RandomOp
+}
+
+// containerModule is the module of the container under test.
+func (data *RandomOpsData) containerModule() string {
+	if data.ContainerModule != "" {
+		return data.ContainerModule
+	}
+	switch data.Kind {
+	case "red-black":
+		return NewRedBlackData().ModuleName
+	case "avl":
+		return NewAvlData().ModuleName
+	case "bst":
+		return NewVanillaBinarySearchTreeData().ModuleName
+	case "critbit":
+		return NewCritbitTreeData().ModuleName
+	default:
+		return ""
+	}
+}
+
+// moduleName is the name of the test module.
+func (data *RandomOpsData) moduleName() string {
+	if data.ModuleName != "" {
+		return data.ModuleName
+	}
+
+	return data.containerModule() + "_random_test"
+}
+
+// OutputFile is the file the generated code should be written to.
+func (data *RandomOpsData) OutputFile() string {
+	if data.OutputFileName != "" {
+		return data.OutputFileName
+	}
+
+	return fmt.Sprintf("tests/%s.move", data.moduleName())
+}
+
+// randomOpsModel abstracts the tree and the critbit tree models.
+type randomOpsModel interface {
+	insert(key uint64, value uint64) (uint64, error)
+	remove(index uint64) (key uint64, value uint64, err error)
+	find(key uint64) (uint64, bool)
+	size() uint64
+	key(index uint64) uint64
+	// inOrder returns the indices in the order of the keys.
+	inOrder() []uint64
+	value(index uint64) uint64
+}
+
+type treeOpsModel struct {
+	*verifier.TreeModel
+}
+
+func (m treeOpsModel) insert(key uint64, value uint64) (uint64, error) {
+	return m.Insert(verifier.NewU256(key), verifier.NewU256(value))
+}
+
+func (m treeOpsModel) remove(index uint64) (uint64, uint64, error) {
+	entry, err := m.Remove(index)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return entry.Key.Uint64(), entry.Value.Uint64(), nil
+}
+
+func (m treeOpsModel) find(key uint64) (uint64, bool) {
+	index := m.Find(verifier.NewU256(key))
+	return index, index != verifier.NULL_INDEX
+}
+
+func (m treeOpsModel) size() uint64              { return m.Size() }
+func (m treeOpsModel) key(index uint64) uint64   { return m.Entries[index].Key.Uint64() }
+func (m treeOpsModel) value(index uint64) uint64 { return m.Entries[index].Value.Uint64() }
+
+func (m treeOpsModel) inOrder() []uint64 {
+	var r []uint64
+	for i := m.MinIndex; i != verifier.NULL_INDEX; i = m.NextInOrder(i) {
+		r = append(r, i)
+	}
+
+	return r
+}
+
+type critbitOpsModel struct {
+	*verifier.CritbitTree
+}
+
+func (m critbitOpsModel) insert(key uint64, value uint64) (uint64, error) {
+	return m.Insert(verifier.NewU256(key), verifier.NewU256(value))
+}
+
+func (m critbitOpsModel) remove(index uint64) (uint64, uint64, error) {
+	entry, err := m.Remove(index)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return entry.Key.Uint64(), entry.Value.Uint64(), nil
+}
+
+func (m critbitOpsModel) find(key uint64) (uint64, bool) {
+	index := m.Find(verifier.NewU256(key))
+	return index, index != verifier.CRITBIT_NULL_INDEX
+}
+
+func (m critbitOpsModel) size() uint64              { return m.Size() }
+func (m critbitOpsModel) key(index uint64) uint64   { return m.Entries[index].Key.Uint64() }
+func (m critbitOpsModel) value(index uint64) uint64 { return m.Entries[index].Value.Uint64() }
+
+func (m critbitOpsModel) inOrder() []uint64 {
+	var r []uint64
+	for i := m.MinIndex; i != verifier.CRITBIT_NULL_INDEX; i = m.NextInOrder(i) {
+		r = append(r, i)
+	}
+
+	return r
+}
+
+// keyBits is the number of bits of each key.
+// The keys are combined into one uint64 for the model, the first key in the highest bits,
+// so the order of the combined key is the lexicographic order of the keys.
+func (data *RandomOpsData) keyBits() int {
+	bits := 64 / data.KeyCount
+	if bits > data.KeyIntWidth {
+		bits = data.KeyIntWidth
+	}
+
+	return bits
+}
+
+func (data *RandomOpsData) splitKey(key uint64) []string {
+	bits := data.keyBits()
+	r := make([]string, data.KeyCount)
+	for i := data.KeyCount - 1; i >= 0; i-- {
+		r[i] = strconv.FormatUint(key&(1<<bits-1), 10)
+		key >>= bits
+	}
+
+	return r
+}
+
+func (data *RandomOpsData) newModel() (randomOpsModel, string, error) {
+	switch data.Kind {
+	case "red-black":
+		return treeOpsModel{verifier.NewTreeModel(verifier.TreeType_RedBlack)}, "RedBlackTree", nil
+	case "avl":
+		return treeOpsModel{verifier.NewTreeModel(verifier.TreeType_Avl)}, "AvlTree", nil
+	case "bst":
+		return treeOpsModel{verifier.NewTreeModel(verifier.TreeType_Vanilla)}, "BinarySearchTree", nil
+	case "critbit":
+		if data.KeyCount != 1 {
+			return nil, "", fmt.Errorf("critbit only supports 1 key: %d", data.KeyCount)
+		}
+		return critbitOpsModel{verifier.NewCritbitTree()}, "CritbitTree", nil
+	default:
+		return nil, "", fmt.Errorf("random operations are only supported by red-black, avl, bst and critbit: %q", data.Kind)
+	}
+}
+
+// RandomOps runs the random operations on the reference model, and returns the operations with their expected results.
+func (data *RandomOpsData) RandomOps() ([]*RandomOp, error) {
+	if err := checkKeyIntWidth(data.KeyIntWidth); err != nil {
+		return nil, err
+	}
+	if data.KeyCount < 1 || data.KeyCount > 64 {
+		return nil, fmt.Errorf("key count must be between 1 and 64: %d", data.KeyCount)
+	}
+	if data.Ops < 1 {
+		return nil, fmt.Errorf("number of operations must be positive: %d", data.Ops)
+	}
+	if data.Checkpoint < 1 {
+		return nil, fmt.Errorf("checkpoint interval must be positive: %d", data.Checkpoint)
+	}
+
+	model, _, err := data.newModel()
+	if err != nil {
+		return nil, err
+	}
+
+	r := rand.New(rand.NewSource(data.Seed))
+	totalBits := data.keyBits() * data.KeyCount
+	randomKey := func() uint64 {
+		if totalBits == 64 {
+			return r.Uint64()
+		}
+		return r.Uint64() & (1<<totalBits - 1)
+	}
+	// keep the container at most half full, so new keys are easy to find.
+	maxSize := uint64(1) << 62
+	if totalBits < 63 {
+		maxSize = uint64(1) << (totalBits - 1)
+	}
+
+	var ops []*RandomOp
+	for i := 0; i < data.Ops; i++ {
+		op := &RandomOp{Code: i}
+		size := model.size()
+		switch dice := r.Intn(20); {
+		case size > 0 && (dice < 6 || size >= maxSize):
+			op.Kind = "Remove"
+			op.Index = uint64(r.Intn(int(size)))
+			key, value, err := model.remove(op.Index)
+			if err != nil {
+				return nil, fmt.Errorf("operation #%d: %w", i, err)
+			}
+			op.Keys, op.Value = data.splitKey(key), value
+		case size > 0 && dice < 11:
+			op.Kind = "Find"
+			key := randomKey()
+			if dice < 9 {
+				key = model.key(uint64(r.Intn(int(size))))
+			}
+			op.Keys = data.splitKey(key)
+			index, found := model.find(key)
+			op.Index, op.NotFound = index, !found
+		default:
+			op.Kind = "Insert"
+			key := randomKey()
+			for _, found := model.find(key); found; _, found = model.find(key) {
+				key = randomKey()
+			}
+			op.Keys, op.Value = data.splitKey(key), uint64(i)
+			index, err := model.insert(key, op.Value)
+			if err != nil {
+				return nil, fmt.Errorf("operation #%d: %w", i, err)
+			}
+			op.Index = index
+		}
+		ops = append(ops, op)
+
+		if (i+1)%data.Checkpoint == 0 || i == data.Ops-1 {
+			check := &RandomOp{Code: i, Kind: "Check", KeyColumns: make([][]string, data.KeyCount)}
+			for _, index := range model.inOrder() {
+				check.Indices = append(check.Indices, index)
+				check.Values = append(check.Values, model.value(index))
+				for j, key := range data.splitKey(model.key(index)) {
+					check.KeyColumns[j] = append(check.KeyColumns[j], key)
+				}
+			}
+			ops = append(ops, check)
+		}
+	}
+
+	return ops, nil
+}
+
+// Generate renders the test module.
+func (data *RandomOpsData) Generate() ([]byte, error) {
+	if data.Address == "" {
+		return nil, fmt.Errorf("missing address")
+	}
+
+	ops, err := data.RandomOps()
+	if err != nil {
+		return nil, err
+	}
+	_, typeName, err := data.newModel()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := *data
+	settings.ContainerModule = data.containerModule()
+	settings.ModuleName = data.moduleName()
+	rendered := &randomOpsRenderData{
+		RandomOpsData: &settings,
+		TypeName:      typeName,
+		KeyType:       fmt.Sprintf("u%d", data.KeyIntWidth),
+	}
+	for i := 0; i < data.KeyCount; i++ {
+		rendered.KeyNames = append(rendered.KeyNames, fmt.Sprintf("key%d", i))
+	}
+	for len(ops) > 0 {
+		n := randomOpsPerFunction
+		if n > len(ops) {
+			n = len(ops)
+		}
+		rendered.Functions = append(rendered.Functions, ops[:n])
+		ops = ops[n:]
+	}
+
+	return execute(randomOpsTmpl, rendered)
+}
@@ -0,0 +1,291 @@
+// Package gen generates container types for move.
+//
+// Each container is described by its data (SpecTreeData, CritbitTreeData, LinkedListData),
+// which renders the move source code with Generate.
+package gen
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+type Shared struct {
+	Address        string
+	ModuleName     string
+	Backend        Backend
+	OutputFileName string
+	NoTest         bool
+	// StableIndex keeps the index of an element unchanged until it is removed,
+	// by leaving the removed slots vacant instead of moving the last element in.
+	StableIndex bool
+	// BucketSize is the number of elements in each bucket of aptos smart vector.
+	BucketSize int
+}
+
+// DefaultBucketSize is the default number of elements in each bucket of aptos smart vector.
+const DefaultBucketSize = 16
+
+func NewShared(moduleName, outputFileName string) *Shared {
+	return &Shared{
+		Address:        "container",
+		ModuleName:     moduleName,
+		Backend:        VectorBackend,
+		OutputFileName: fmt.Sprintf("sources/%s.move", outputFileName),
+		BucketSize:     DefaultBucketSize,
+	}
+}
+
+func (shared *Shared) DoTest() bool {
+	return !shared.NoTest
+}
+
+// VectorLayout checks if the elements are stored in a plain vector,
+// which the tests can compare with the expected layout of the container.
+func (shared *Shared) VectorLayout() bool {
+	return !shared.StableIndex && !shared.UseTable()
+}
+
+// UseTable checks if the elements are stored in a table like storage instead of a vector.
+// Such storage cannot be copied or dropped.
+func (shared *Shared) UseTable() bool {
+	return shared.Backend != "" && shared.Backend != VectorBackend
+}
+
+func (shared *Shared) UnderlyingModule() string {
+	if shared.StableIndex {
+		// the slots are defined in the generated module itself.
+		return "Self"
+	}
+	switch shared.Backend {
+	case AptosTableBackend, AptosSmartTableBackend, SuiTableBackend:
+		return "table"
+	case AptosSmartVectorBackend:
+		return "smart_vector"
+	case SuiObjectTableBackend, SuiDynamicFieldBackend:
+		// the shims over the storage are defined in the generated module itself.
+		return "Self"
+	default:
+		return "vector"
+	}
+}
+
+// StorageType is the type of the storage for elements of type elem.
+func (shared *Shared) StorageType(elem string) string {
+	switch {
+	case shared.StableIndex:
+		return fmt.Sprintf("Slots<%s>", elem)
+	case shared.Backend == SuiObjectTableBackend:
+		return fmt.Sprintf("ObjectTable<u64, Item<%s>>", elem)
+	case shared.Backend == SuiDynamicFieldBackend:
+		return fmt.Sprintf("Fields<%s>", elem)
+	case shared.Backend == AptosSmartVectorBackend:
+		return fmt.Sprintf("SmartVector<%s>", elem)
+	case shared.UseTable():
+		return fmt.Sprintf("Table<u64, %s>", elem)
+	default:
+		return fmt.Sprintf("vector<%s>", elem)
+	}
+}
+
+// NewStorage is the expression creating an empty storage.
+func (shared *Shared) NewStorage() string {
+	ctx := ""
+	if shared.Backend.IsSui() {
+		ctx = "ctx"
+	}
+	switch {
+	case shared.StableIndex:
+		return fmt.Sprintf("new_slots(%s)", ctx)
+	case shared.Backend == SuiObjectTableBackend:
+		return "object_table::new(ctx)"
+	case shared.Backend == SuiDynamicFieldBackend:
+		return "new_fields(ctx)"
+	case shared.Backend == AptosSmartVectorBackend:
+		return "smart_vector::empty_with_config(0, BUCKET_SIZE)"
+	case shared.UseTable():
+		return fmt.Sprintf("table::new(%s)", ctx)
+	default:
+		return "vector::empty()"
+	}
+}
+
+// DestroyStorage is the function destroying an empty storage.
+func (shared *Shared) DestroyStorage() string {
+	switch {
+	case shared.StableIndex:
+		return "destroy_slots"
+	case shared.Backend == SuiObjectTableBackend:
+		return "object_table::destroy_empty"
+	case shared.Backend == SuiDynamicFieldBackend:
+		return "destroy_fields"
+	default:
+		return shared.UnderlyingModule() + "::destroy_empty"
+	}
+}
+
+// NewParams are the parameters of new, sui needs the TxContext to create the storage.
+func (shared *Shared) NewParams() string {
+	if shared.Backend.IsSui() {
+		return "ctx: &mut TxContext"
+	}
+
+	return ""
+}
+
+// InsertParams are the extra parameters of insert, sui object table needs the TxContext to create the object of the element.
+func (shared *Shared) InsertParams() string {
+	if shared.Backend == SuiObjectTableBackend {
+		return ", ctx: &mut TxContext"
+	}
+
+	return ""
+}
+
+// InsertArgs passes InsertParams on.
+func (shared *Shared) InsertArgs() string {
+	if shared.Backend == SuiObjectTableBackend {
+		return ", ctx"
+	}
+
+	return ""
+}
+
+// TestNewArgs are the arguments of new in the tests, which pass the TxContext created by tx_context::dummy on sui.
+func (shared *Shared) TestNewArgs() string {
+	if shared.Backend.IsSui() {
+		return "&mut ctx"
+	}
+
+	return ""
+}
+
+// TestInsertArgs are the extra arguments of insert in the tests, see InsertParams.
+func (shared *Shared) TestInsertArgs() string {
+	if shared.Backend == SuiObjectTableBackend {
+		return ", &mut ctx"
+	}
+
+	return ""
+}
+
+// ValueBound is the constraint on the type of the values, sui storage and aptos smart vector can only hold values with store.
+func (shared *Shared) ValueBound() string {
+	if shared.Backend.IsSui() || shared.Backend == AptosSmartVectorBackend {
+		return ": store"
+	}
+
+	return ""
+}
+
+// OutputFile is the file the generated code should be written to.
+func (shared *Shared) OutputFile() string {
+	return shared.OutputFileName
+}
+
+// Generator renders the move code for a container.
+type Generator interface {
+	// Generate returns the rendered move source code.
+	Generate() ([]byte, error)
+	// OutputFile is the file the generated code should be written to.
+	OutputFile() string
+}
+
+// WriteFile generates the code and writes it to the output file of the generator.
+func WriteFile(g Generator) error {
+	code, err := g.Generate()
+	if err != nil {
+		return err
+	}
+
+	// the default output of the tests is in the tests directory, which may not exist yet.
+	if err := os.MkdirAll(filepath.Dir(g.OutputFile()), 0o777); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", g.OutputFile(), err)
+	}
+	if err := os.WriteFile(g.OutputFile(), code, 0o666); err != nil {
+		return fmt.Errorf("failed to write %s: %w", g.OutputFile(), err)
+	}
+
+	return nil
+}
+
+//go:embed slots.move.template
+var slotsTemplate string
+
+//go:embed storage.move.template
+var storageTemplate string
+
+// parseContainer parses the template of a container, together with the storage shims of the backends
+// and the slots storage used by StableIndex.
+func parseContainer(name string, text string) *template.Template {
+	tmpl := template.Must(template.New(name).Parse(text))
+	template.Must(tmpl.Parse(storageTemplate))
+	return template.Must(tmpl.Parse(slotsTemplate))
+}
+
+func execute(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", tmpl.Name(), err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func checkKeyIntWidth(width int) error {
+	switch width {
+	case 8, 16, 32, 64, 128, 256:
+		return nil
+	default:
+		return fmt.Errorf("key width must be one of 8, 16, 32, 64, 128, or 256: %d", width)
+	}
+}
+
+func (shared *Shared) check() error {
+	if shared == nil {
+		return fmt.Errorf("missing shared settings")
+	}
+	if shared.Address == "" {
+		return fmt.Errorf("missing address")
+	}
+	if shared.ModuleName == "" {
+		return fmt.Errorf("missing module name")
+	}
+	if _, err := ParseBackend(string(shared.Backend)); err != nil {
+		return err
+	}
+	switch shared.Backend {
+	case SuiObjectTableBackend, SuiDynamicFieldBackend, AptosSmartVectorBackend:
+		if shared.StableIndex {
+			return fmt.Errorf("stable index is not supported by backend %s", shared.Backend)
+		}
+	}
+	if shared.Backend == AptosSmartVectorBackend && shared.BucketSize < 1 {
+		return fmt.Errorf("bucket size of smart vector must be positive: %d", shared.BucketSize)
+	}
+
+	return nil
+}
+
+// checkNoStableIndex returns an error if stable index is requested for a container that doesn't support it.
+func (shared *Shared) checkNoStableIndex(kind string) error {
+	if shared.StableIndex {
+		return fmt.Errorf("stable index is not supported by %s", kind)
+	}
+
+	return nil
+}
+
+// checkNotBackend returns an error if the container doesn't support the backend.
+func (shared *Shared) checkNotBackend(kind string, unsupported ...Backend) error {
+	for _, b := range unsupported {
+		if shared.Backend == b {
+			return fmt.Errorf("backend %s is not supported by %s", b, kind)
+		}
+	}
+
+	return nil
+}
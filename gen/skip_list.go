@@ -0,0 +1,58 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+//go:embed skip_list.move.template
+var skipListTemplate string
+
+var skipListTmpl = parseContainer("skip_list.move.template", skipListTemplate)
+
+type SkipListData struct {
+	*Shared
+
+	KeyIntWidth int
+	// MaxLevel is the max number of levels of the skip list.
+	MaxLevel int
+}
+
+// NewSkipListData creates the default settings for a skip list.
+func NewSkipListData() *SkipListData {
+	return &SkipListData{
+		Shared:      NewShared("skip_list", "skip_list"),
+		KeyIntWidth: 128,
+		MaxLevel:    16,
+	}
+}
+
+// GenerateSkipList renders a skip list with the settings in data.
+func GenerateSkipList(data SkipListData) ([]byte, error) {
+	return data.Generate()
+}
+
+func (skipList *SkipListData) KeyType() string {
+	return fmt.Sprintf("u%d", skipList.KeyIntWidth)
+}
+
+// Generate renders the skip list.
+func (skipList *SkipListData) Generate() ([]byte, error) {
+	if err := skipList.Shared.check(); err != nil {
+		return nil, err
+	}
+	if err := skipList.Shared.checkNoStableIndex("skip list"); err != nil {
+		return nil, err
+	}
+	if err := skipList.Shared.checkNotBackend("skip list", SuiObjectTableBackend); err != nil {
+		return nil, err
+	}
+	if err := checkKeyIntWidth(skipList.KeyIntWidth); err != nil {
+		return nil, err
+	}
+	if skipList.MaxLevel < 1 || skipList.MaxLevel > 64 {
+		return nil, fmt.Errorf("max level of skip list must be between 1 and 64: %d", skipList.MaxLevel)
+	}
+
+	return execute(skipListTmpl, skipList)
+}
@@ -0,0 +1,176 @@
+package gen
+
+import (
+	_ "embed"
+	"fmt"
+	"text/template"
+)
+
+//go:embed spec.move.template
+var specTreeTemplate string
+
+//go:embed prover.move.template
+var proverTemplate string
+
+var specTreeTmpl = template.Must(parseContainer("spec.move.template", specTreeTemplate).Parse(proverTemplate))
+
+type Key struct {
+	KeyName string
+	More    bool
+	// Position is the 1-based position of the key in the keys, and in the results of remove and borrow_at_index.
+	Position     int
+	EqualsBefore []*Key
+}
+
+type SpecTreeData struct {
+	*Shared
+	IsAvl         bool
+	IsRb          bool
+	NoAssert      bool
+	KeyCount      int
+	ModulePostfix string
+	KeyIntWidth   int
+	// WithSize stores the size of the subtree in each entry for rank and select.
+	WithSize bool
+	// WithSpec generates the move prover specifications. Only vector backend is supported.
+	WithSpec bool
+
+	Keys []Key
+}
+
+// NewRedBlackData creates the default settings for a red black tree.
+func NewRedBlackData() *SpecTreeData {
+	return &SpecTreeData{
+		Shared:      NewShared("red_black", "red-black"),
+		IsRb:        true,
+		IsAvl:       false,
+		KeyCount:    1,
+		KeyIntWidth: 128,
+	}
+}
+
+// NewAvlData creates the default settings for an avl tree.
+func NewAvlData() *SpecTreeData {
+	return &SpecTreeData{
+		Shared:      NewShared("avl", "avl"),
+		IsRb:        false,
+		IsAvl:       true,
+		KeyCount:    1,
+		KeyIntWidth: 128,
+	}
+}
+
+// NewVanillaBinarySearchTreeData creates the default settings for a vanilla binary search tree.
+func NewVanillaBinarySearchTreeData() *SpecTreeData {
+	return &SpecTreeData{
+		Shared:      NewShared("vanilla_binary_search_tree", "bst"),
+		IsRb:        false,
+		IsAvl:       false,
+		KeyCount:    1,
+		KeyIntWidth: 128,
+	}
+}
+
+// GenerateRedBlack renders a red black tree with the settings in data.
+func GenerateRedBlack(data SpecTreeData) ([]byte, error) {
+	data.IsRb, data.IsAvl = true, false
+	return data.Generate()
+}
+
+// GenerateAvl renders an avl tree with the settings in data.
+func GenerateAvl(data SpecTreeData) ([]byte, error) {
+	data.IsRb, data.IsAvl = false, true
+	return data.Generate()
+}
+
+// GenerateVanillaBinarySearchTree renders a vanilla binary search tree with the settings in data.
+func GenerateVanillaBinarySearchTree(data SpecTreeData) ([]byte, error) {
+	data.IsRb, data.IsAvl = false, false
+	return data.Generate()
+}
+
+func (data *SpecTreeData) NeedMetadata() bool {
+	return data.IsAvl || data.IsRb
+}
+
+func (data *SpecTreeData) DoAssert() bool {
+	return !data.NoAssert
+}
+
+// SingleKey checks the tree has only one key. The hand written tests insert and look up single keys,
+// so they are only generated for such trees.
+func (data *SpecTreeData) SingleKey() bool {
+	return len(data.Keys) == 1
+}
+
+// ValuePosition is the 1-based position of the value in the results of remove and borrow_at_index, which comes after the keys.
+func (data *SpecTreeData) ValuePosition() int {
+	return len(data.Keys) + 1
+}
+
+func (data *SpecTreeData) TreeType() string {
+	switch {
+	case data.IsRb:
+		return "RedBlackTree"
+	case data.IsAvl:
+		return "AvlTree"
+	default:
+		return "BinarySearchTree"
+	}
+}
+
+func (data *SpecTreeData) KeyType() string {
+	return fmt.Sprintf("u%d", data.KeyIntWidth)
+}
+
+// Generate renders the tree. data is not modified.
+func (data *SpecTreeData) Generate() ([]byte, error) {
+	if err := data.Shared.check(); err != nil {
+		return nil, err
+	}
+	if err := checkKeyIntWidth(data.KeyIntWidth); err != nil {
+		return nil, err
+	}
+	if data.IsAvl && data.IsRb {
+		return nil, fmt.Errorf("tree cannot be both avl and red black")
+	}
+	if data.WithSpec && (data.UseTable() || data.StableIndex) {
+		return nil, fmt.Errorf("specifications are only supported with vector backend and without stable index")
+	}
+
+	keyCount := data.KeyCount
+
+	if keyCount < 1 {
+		return nil, fmt.Errorf("less than 1 key is requested: %d", keyCount)
+	}
+
+	rendered := *data
+	shared := *data.Shared
+	rendered.Shared = &shared
+	rendered.Keys = nil
+
+	if keyCount == 1 {
+		rendered.Keys = []Key{{KeyName: "key", More: false, Position: 1}}
+	} else {
+		for i := 0; i < keyCount; i++ {
+			key := Key{
+				KeyName:  fmt.Sprintf("key%d_%d", i, keyCount),
+				More:     i != keyCount-1,
+				Position: i + 1,
+			}
+			for j := 0; j < i; j++ {
+				key.EqualsBefore = append(key.EqualsBefore, &Key{
+					KeyName: fmt.Sprintf("key%d_%d", j, keyCount),
+					More:    j != i-1,
+				})
+			}
+			rendered.Keys = append(rendered.Keys, key)
+		}
+	}
+
+	if data.ModulePostfix != "" {
+		shared.ModuleName = fmt.Sprintf("%s_%s", data.ModuleName, data.ModulePostfix)
+	}
+
+	return execute(specTreeTmpl, &rendered)
+}
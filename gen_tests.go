@@ -0,0 +1,41 @@
+package main
+
+import (
+	"github.com/fardream/gen-move-container/gen"
+	"github.com/spf13/cobra"
+)
+
+func GetGenTestsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen-tests",
+		Short: "generate move test of random operations on a tree",
+		Long: `Generate a #[test] module performing random inserts, removes and finds on a generated tree or critbit tree.
+
+The same operations are run on the reference model in the verifier, and the test asserts
+the result of each operation, and the size, min, max and the ordered keys at the checkpoints.
+The container must be generated with the vector backend and without stable index,
+since the test also checks the index of each element.
+
+Multiple keys are drawn from the lower 64/key-count bits of each key.
+`,
+		Args: cobra.NoArgs,
+	}
+
+	data := gen.NewRandomOpsData()
+
+	cmd.Flags().StringVarP(&data.Address, "address", "p", data.Address, "(named) address of the test module and the container.")
+	cmd.Flags().StringVarP(&data.ModuleName, "module", "m", data.ModuleName, "module name of the test. (default to the container module with postfix _random_test)")
+	cmd.Flags().StringVar(&data.Kind, "kind", data.Kind, "container under test: red-black, avl, bst, or critbit.")
+	cmd.Flags().StringVar(&data.ContainerModule, "container-module", data.ContainerModule, "module name of the container under test. (default to the module name of the command of the kind)")
+	cmd.Flags().IntVar(&data.KeyIntWidth, "key-width", data.KeyIntWidth, "int width for keys of the container")
+	cmd.Flags().IntVar(&data.KeyCount, "key-count", data.KeyCount, "number of keys of the container")
+	cmd.Flags().Int64Var(&data.Seed, "seed", data.Seed, "seed of the random operations")
+	cmd.Flags().IntVar(&data.Ops, "ops", data.Ops, "number of operations")
+	cmd.Flags().IntVar(&data.Checkpoint, "checkpoint", data.Checkpoint, "number of operations between the checks of the whole container")
+	cmd.Flags().StringVarP(&data.OutputFileName, "output", "o", data.OutputFileName, "output file (default to tests/<module>.move)")
+	cmd.MarkFlagFilename("output")
+
+	setGeneratorRun(cmd, data)
+
+	return cmd
+}
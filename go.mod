@@ -3,6 +3,7 @@ module github.com/fardream/gen-move-container
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.2.1
 	github.com/google/go-cmp v0.5.9
 	github.com/spf13/cobra v1.6.1
 )
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/fardream/gen-move-container/gen"
+	"github.com/spf13/cobra"
+)
+
+func GetHeapCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "heap",
+		Short: "generate binary heap",
+		Long: `generate binary heap (priority queue).
+
+The heap is a min heap by default, use --max-heap for a max heap.
+Elements are referenced by handles returned from push, which stay valid until the element is removed.`,
+	}
+
+	heap := gen.NewHeapData()
+
+	setSharedCmd(cmd, heap.Shared)
+	cmd.Flags().IntVar(&heap.KeyIntWidth, "key-width", heap.KeyIntWidth, "int width for keys")
+	cmd.Flags().BoolVar(&heap.MaxHeap, "max-heap", heap.MaxHeap, "generate max heap instead of min heap")
+
+	setGeneratorRun(cmd, heap)
+
+	return cmd
+}
@@ -1,53 +1,22 @@
 package main
 
 import (
-	"bytes"
-	_ "embed"
-	"os"
-	"text/template"
-
+	"github.com/fardream/gen-move-container/gen"
 	"github.com/spf13/cobra"
 )
 
-//go:embed linked_list.move.template
-var linkdListTemplate string
-
-type LinkedListData struct {
-	*Shared
-}
-
 func GetLinkedListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "linked-list",
 		Short: "generate linked list",
 	}
 
-	linkedList := &LinkedListData{
-		Shared: NewShared("linked_list", "linked_list"),
-	}
+	linkedList := gen.NewLinkedListData()
 
-	linkedList.SetCmd(cmd)
+	setSharedCmd(cmd, linkedList.Shared)
+	cmd.Flags().BoolVar(&linkedList.StableIndex, "stable-index", linkedList.StableIndex, "keep the index of an element unchanged until it is removed, by reusing vacated slots instead of moving the last element in. compact reclaims the vacated slots.")
 
-	cmd.Run = linkedList.Run
+	setGeneratorRun(cmd, linkedList)
 
 	return cmd
 }
-
-func (linkedListData *LinkedListData) Run(_ *cobra.Command, _ []string) {
-	tmpl, err := template.New("temp").Parse(linkdListTemplate)
-	if err != nil {
-		panic(err)
-	}
-
-	var buf bytes.Buffer
-
-	err = tmpl.Execute(&buf, linkedListData)
-	if err != nil {
-		panic(err)
-	}
-
-	err = os.WriteFile(linkedListData.OutputFileName, buf.Bytes(), 0o666)
-	if err != nil {
-		panic(err)
-	}
-}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 
+	"github.com/fardream/gen-move-container/gen"
 	"github.com/spf13/cobra"
 )
 
@@ -17,46 +20,94 @@ based on http://github.com/agl/critbit
 - critbit tree
 
 also provided:
+- b+ tree
+- skip list
+- binary heap
 - double linked list
 `
 
-type Shared struct {
-	Address        string
-	ModuleName     string
-	UseAptosTable  bool
-	OutputFileName string
-	NoTest         bool
-}
-
-func NewShared(moduleName, outputFileName string) *Shared {
-	return &Shared{
-		Address:        "container",
-		ModuleName:     moduleName,
-		UseAptosTable:  false,
-		OutputFileName: fmt.Sprintf("sources/%s.move", outputFileName),
-	}
-}
-
-func (shared *Shared) SetCmd(cmd *cobra.Command) {
+func setSharedCmd(cmd *cobra.Command, shared *gen.Shared) {
 	cmd.Args = cobra.NoArgs
 
 	cmd.Flags().StringVarP(&shared.Address, "address", "p", shared.Address, "(named) address of the generated codes.")
 	cmd.Flags().StringVarP(&shared.ModuleName, "module", "m", shared.ModuleName, "module name for the generated codes.")
 	cmd.Flags().BoolVar(&shared.NoTest, "no-test", shared.NoTest, "turn off test")
-	cmd.Flags().BoolVar(&shared.UseAptosTable, "use-aptos-table", shared.UseAptosTable, "use aptos table instead of vector.")
+	cmd.Flags().Var(&shared.Backend, "backend", fmt.Sprintf("storage of the elements, one of %s.", gen.BackendNames()))
+	cmd.Flags().IntVar(&shared.BucketSize, "bucket-size", shared.BucketSize, "number of elements in each bucket of aptos smart vector.")
+	cmd.Flags().Var(aptosTableFlag{backend: &shared.Backend}, "use-aptos-table", "use aptos table instead of vector.")
+	cmd.Flags().Lookup("use-aptos-table").NoOptDefVal = "true"
+	cmd.Flags().MarkDeprecated("use-aptos-table", "use --backend aptos-table instead.")
 
 	cmd.Flags().StringVarP(&shared.OutputFileName, "output", "o", shared.OutputFileName, "output file")
 	cmd.MarkFlagFilename("output")
 }
 
-func (shared *Shared) DoTest() bool {
-	return !shared.NoTest && !shared.UseAptosTable
+// aptosTableFlag is the boolean flag --use-aptos-table from before --backend, it sets the backend to aptos table.
+type aptosTableFlag struct {
+	backend *gen.Backend
+}
+
+func (f aptosTableFlag) String() string {
+	return strconv.FormatBool(f.backend != nil && *f.backend == gen.AptosTableBackend)
 }
 
-func (shared *Shared) UnderlyingModule() string {
-	if shared.UseAptosTable {
-		return "table"
-	} else {
-		return "vector"
+func (f aptosTableFlag) Set(s string) error {
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	switch {
+	case v:
+		*f.backend = gen.AptosTableBackend
+	case *f.backend == gen.AptosTableBackend:
+		*f.backend = gen.VectorBackend
 	}
+
+	return nil
+}
+
+func (f aptosTableFlag) Type() string {
+	return "bool"
+}
+
+// setGeneratorRun sets the command to write the generated code to the output file,
+// or to check the output file is up to date with --check.
+func setGeneratorRun(cmd *cobra.Command, g gen.Generator) {
+	check := false
+	cmd.Flags().BoolVar(&check, "check", check, "instead of writing the output file, check it is up to date and print the diff if not.")
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		return runGenerators(cmd, check, g)
+	}
+}
+
+// runGenerators writes the generated code of all generators, or checks all of them and prints the diffs of stale files.
+func runGenerators(cmd *cobra.Command, check bool, generators ...gen.Generator) error {
+	if !check {
+		for _, g := range generators {
+			if err := gen.WriteFile(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	stale := 0
+	for _, g := range generators {
+		err := gen.Check(g)
+		var staleErr *gen.StaleError
+		switch {
+		case errors.As(err, &staleErr):
+			stale++
+			fmt.Fprint(cmd.OutOrStdout(), staleErr.Diff)
+		case err != nil:
+			return err
+		}
+	}
+
+	if stale > 0 {
+		return fmt.Errorf("%d of %d generated files are not up to date", stale, len(generators))
+	}
+
+	return nil
 }
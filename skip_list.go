@@ -0,0 +1,27 @@
+package main
+
+import (
+	"github.com/fardream/gen-move-container/gen"
+	"github.com/spf13/cobra"
+)
+
+func GetSkipListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "skip-list",
+		Short: "generate skip list",
+		Long: `generate skip list.
+
+The number of levels of each element is derived from the sha3-256 hash of the key,
+or can be supplied by the caller with insert_with_level.`,
+	}
+
+	skipList := gen.NewSkipListData()
+
+	setSharedCmd(cmd, skipList.Shared)
+	cmd.Flags().IntVar(&skipList.KeyIntWidth, "key-width", skipList.KeyIntWidth, "int width for keys")
+	cmd.Flags().IntVar(&skipList.MaxLevel, "max-level", skipList.MaxLevel, "max number of levels")
+
+	setGeneratorRun(cmd, skipList)
+
+	return cmd
+}
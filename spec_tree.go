@@ -1,114 +1,22 @@
 package main
 
 import (
-	"bytes"
-	_ "embed"
-	"fmt"
-	"os"
-	"text/template"
-
+	"github.com/fardream/gen-move-container/gen"
 	"github.com/spf13/cobra"
 )
 
-//go:embed spec.move.template
-var specTreeTemplate string
-
-type Key struct {
-	KeyName      string
-	More         bool
-	EqualsBefore []*Key
-}
-
-type SpecTreeData struct {
-	*Shared
-	IsAvl         bool
-	IsRb          bool
-	NoAssert      bool
-	KeyCount      int
-	ModulePostfix string
-	KeyIntWidth   int
-
-	Keys []Key
-}
-
-func (data *SpecTreeData) SetSpecTreeData(cmd *cobra.Command) {
-	data.SetCmd(cmd)
+func setSpecTreeCmd(cmd *cobra.Command, data *gen.SpecTreeData) {
+	setSharedCmd(cmd, data.Shared)
 
 	cmd.Flags().StringVar(&data.ModulePostfix, "module-postfilx", data.ModulePostfix, "post fix for module name")
 	cmd.Flags().IntVar(&data.KeyCount, "key-count", data.KeyCount, "number of keys for the tree")
 	cmd.Flags().BoolVar(&data.NoAssert, "no-ssert", data.NoAssert, "turn off assert")
 	cmd.Flags().IntVar(&data.KeyIntWidth, "key-width", data.KeyIntWidth, "int width for keys")
+	cmd.Flags().BoolVar(&data.WithSize, "with-size", data.WithSize, "maintain subtree sizes for rank, select and count_in_range")
+	cmd.Flags().BoolVar(&data.WithSpec, "with-spec", data.WithSpec, "generate move prover specifications: the tree invariant, and the pre/post conditions and aborts_if of insert, remove, find and accessors. vector backend only.")
+	cmd.Flags().BoolVar(&data.StableIndex, "stable-index", data.StableIndex, "keep the index of an element unchanged until it is removed, by reusing vacated slots instead of moving the last element in. compact reclaims the vacated slots.")
 
-	cmd.Run = data.Run
-}
-
-func (data *SpecTreeData) NeedMetadata() bool {
-	return data.IsAvl || data.IsRb
-}
-
-func (data *SpecTreeData) DoAssert() bool {
-	return !data.NoAssert
-}
-
-func (data *SpecTreeData) TreeType() string {
-	switch {
-	case data.IsRb:
-		return "RedBlackTree"
-	case data.IsAvl:
-		return "AvlTree"
-	default:
-		return "BinarySearchTree"
-	}
-}
-
-func (data *SpecTreeData) KeyType() string {
-	return fmt.Sprintf("u%d", data.KeyIntWidth)
-}
-
-func (data *SpecTreeData) Run(cmd *cobra.Command, _ []string) {
-	keyCount := data.KeyCount
-
-	if keyCount < 1 {
-		panic(fmt.Errorf("less than 1 key is requested: %d", keyCount))
-	}
-
-	if keyCount == 1 {
-		data.Keys = []Key{{KeyName: "key", More: false}}
-	} else {
-		for i := 0; i < keyCount; i++ {
-			key := Key{
-				KeyName: fmt.Sprintf("key%d_%d", i, keyCount),
-				More:    i != keyCount-1,
-			}
-			for j := 0; j < i; j++ {
-				key.EqualsBefore = append(key.EqualsBefore, &Key{
-					KeyName: fmt.Sprintf("key%d_%d", j, keyCount),
-					More:    j != i-1,
-				})
-			}
-			data.Keys = append(data.Keys, key)
-		}
-	}
-
-	if data.ModulePostfix != "" {
-		data.ModuleName = fmt.Sprintf("%s_%s", data.ModuleName, data.ModulePostfix)
-	}
-
-	tmpl, err := template.New("temp").Parse(specTreeTemplate)
-	if err != nil {
-		panic(err)
-	}
-
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, data)
-
-	if err != nil {
-		panic(err)
-	}
-
-	if err := os.WriteFile(data.OutputFileName, buf.Bytes(), 0o666); err != nil {
-		panic(err)
-	}
+	setGeneratorRun(cmd, data)
 }
 
 func GetRedBlackCmd() *cobra.Command {
@@ -118,15 +26,8 @@ func GetRedBlackCmd() *cobra.Command {
 		Long: `Generate red black tree based on GNU libavl https://adtinfo.org/
 `,
 	}
-	shared := SpecTreeData{
-		Shared:      NewShared("red_black", "red-black"),
-		IsRb:        true,
-		IsAvl:       false,
-		KeyCount:    1,
-		KeyIntWidth: 128,
-	}
 
-	shared.SetSpecTreeData(cmd)
+	setSpecTreeCmd(cmd, gen.NewRedBlackData())
 
 	return cmd
 }
@@ -138,15 +39,8 @@ func GetAvlCmd() *cobra.Command {
 		Long: `Generate avl tree based on GNU libavl https://adtinfo.org/
 `,
 	}
-	shared := SpecTreeData{
-		Shared:      NewShared("avl", "avl"),
-		IsRb:        false,
-		IsAvl:       true,
-		KeyCount:    1,
-		KeyIntWidth: 128,
-	}
 
-	shared.SetSpecTreeData(cmd)
+	setSpecTreeCmd(cmd, gen.NewAvlData())
 
 	return cmd
 }
@@ -158,15 +52,8 @@ func GetVanillaBinarySearchTreeCmd() *cobra.Command {
 		Long: `Generate vanilla binary search tree based on GNU libavl https://adtinfo.org/
 `,
 	}
-	shared := SpecTreeData{
-		Shared:      NewShared("vanilla_binary_search_tree", "bst"),
-		IsRb:        false,
-		IsAvl:       false,
-		KeyCount:    1,
-		KeyIntWidth: 128,
-	}
 
-	shared.SetSpecTreeData(cmd)
+	setSpecTreeCmd(cmd, gen.NewVanillaBinarySearchTreeData())
 
 	return cmd
 }
@@ -0,0 +1,550 @@
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// AptosResource is a resource returned by the /accounts/{address}/resource/{type} api of the aptos node.
+// The fields of the move struct are in Data, where u64, u128 and u256 are json strings, and u8 is a json number.
+type AptosResource struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// ParseAptosResource parses the json of the resource.
+func ParseAptosResource(data []byte) (*AptosResource, error) {
+	r := &AptosResource{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse aptos resource: %w", err)
+	}
+	if len(r.Data) == 0 {
+		return nil, fmt.Errorf("aptos resource %s has no data", r.Type)
+	}
+
+	return r, nil
+}
+
+// Field returns the field at the dot separated path in the data, such as "book.bids", or the data itself if the path is empty.
+func (r *AptosResource) Field(path string) (json.RawMessage, error) {
+	data := r.Data
+	if path == "" {
+		return data, nil
+	}
+
+	for _, name := range strings.Split(path, ".") {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("cannot find %s of %s in a non-struct: %w", name, path, err)
+		}
+		field, ok := fields[name]
+		if !ok {
+			return nil, fmt.Errorf("cannot find %s of %s in %s", name, path, r.Type)
+		}
+		data = field
+	}
+
+	return data, nil
+}
+
+// StructName is the name of the struct of the resource without the address, module and type arguments,
+// such as RedBlackTree for 0x1::red_black::RedBlackTree<u64>.
+func (r *AptosResource) StructName() string {
+	name, _, _ := strings.Cut(r.Type, "<")
+	if i := strings.LastIndex(name, "::"); i >= 0 {
+		name = name[i+2:]
+	}
+
+	return strings.TrimSpace(name)
+}
+
+// AptosTableItems are the items of the tables of the containers on the aptos-table or aptos-smart-vector backend,
+// keyed by the handle of the table, then the index. Each item is the response of the /tables/{handle}/item api
+// for the u64 index, which is the json of the value.
+type AptosTableItems map[string]map[uint64]json.RawMessage
+
+// Add adds the item at the index of the table.
+func (items AptosTableItems) Add(handle string, index uint64, item json.RawMessage) {
+	handle = strings.ToLower(handle)
+	if items[handle] == nil {
+		items[handle] = make(map[uint64]json.RawMessage)
+	}
+	items[handle][index] = item
+}
+
+// LoadAptosTableItems loads the table items saved in the directory, where the item at the index of the table
+// is the file <handle>/<index>.json. Other files are ignored.
+func LoadAptosTableItems(dir string) (AptosTableItems, error) {
+	tables, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(AptosTableItems)
+	for _, table := range tables {
+		if !table.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, table.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			index, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), ".json"), 10, 64)
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") || err != nil {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(dir, table.Name(), file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			items.Add(table.Name(), index, data)
+		}
+	}
+
+	return items, nil
+}
+
+// elements reads the elements of a vector, a table_with_length keyed from 0 to length - 1,
+// a big_vector, whose buckets are vectors in a table_with_length,
+// or a smart_vector, whose elements are in the inline vector followed by the optional big_vector.
+func (items AptosTableItems) elements(data json.RawMessage, name string) ([]json.RawMessage, error) {
+	var r []json.RawMessage
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		return r, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	switch {
+	case fields["inner"] != nil && fields["length"] != nil:
+		return items.tableElements(fields, name)
+	case fields["buckets"] != nil && fields["end_index"] != nil:
+		var buckets map[string]json.RawMessage
+		if err := json.Unmarshal(fields["buckets"], &buckets); err != nil {
+			return nil, fmt.Errorf("failed to parse buckets of %s: %w", name, err)
+		}
+		bucketItems, err := items.tableElements(buckets, "buckets of "+name)
+		if err != nil {
+			return nil, err
+		}
+		for i, item := range bucketItems {
+			var bucket []json.RawMessage
+			if err := json.Unmarshal(item, &bucket); err != nil {
+				return nil, fmt.Errorf("failed to parse bucket %d of %s: %w", i, name, err)
+			}
+			r = append(r, bucket...)
+		}
+		endIndex, err := aptosIndex(fields, "end_index")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		if uint64(len(r)) != endIndex {
+			return nil, fmt.Errorf("%s has %d elements in the buckets, expecting %d", name, len(r), endIndex)
+		}
+
+		return r, nil
+	case fields["inline_vec"] != nil && fields["big_vec"] != nil:
+		r, err := items.elements(fields["inline_vec"], "inline vector of "+name)
+		if err != nil {
+			return nil, err
+		}
+		// the big vector is an option, which is a vector of zero or one element in json.
+		var bigVec struct {
+			Vec []json.RawMessage `json:"vec"`
+		}
+		if err := json.Unmarshal(fields["big_vec"], &bigVec); err != nil || len(bigVec.Vec) > 1 {
+			return nil, fmt.Errorf("failed to parse big vector of %s: %s", name, fields["big_vec"])
+		}
+		for _, v := range bigVec.Vec {
+			bigElements, err := items.elements(v, "big vector of "+name)
+			if err != nil {
+				return nil, err
+			}
+			r = append(r, bigElements...)
+		}
+
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage of %s, only vector, table_with_length, big_vector and smart_vector are supported: %s", name, data)
+	}
+}
+
+// tableElements reads the items of the table_with_length from 0 to length - 1.
+func (items AptosTableItems) tableElements(fields map[string]json.RawMessage, name string) ([]json.RawMessage, error) {
+	var inner struct {
+		Handle string `json:"handle"`
+	}
+	if err := json.Unmarshal(fields["inner"], &inner); err != nil || inner.Handle == "" {
+		return nil, fmt.Errorf("cannot find the table handle of %s", name)
+	}
+	length, err := aptosIndex(fields, "length")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	table := items[strings.ToLower(inner.Handle)]
+	r := make([]json.RawMessage, 0, length)
+	for i := uint64(0); i < length; i++ {
+		item, ok := table[i]
+		if !ok {
+			return nil, fmt.Errorf("missing item %d of %d in table %s of %s", i, length, inner.Handle, name)
+		}
+		r = append(r, item)
+	}
+
+	return r, nil
+}
+
+// aptosStruct parses the fields of a struct.
+func aptosStruct(data json.RawMessage, kind string) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", kind, err)
+	}
+
+	return fields, nil
+}
+
+// aptosU256 parses the unsigned int field, which is a json string or number.
+func aptosU256(fields map[string]json.RawMessage, name string) (U256, error) {
+	data, ok := fields[name]
+	if !ok {
+		return U256{}, fmt.Errorf("missing field %s", name)
+	}
+
+	var r U256
+	if err := json.Unmarshal(data, &r); err != nil {
+		return U256{}, fmt.Errorf("failed to parse %s %s: %w", name, data, err)
+	}
+
+	return r, nil
+}
+
+// aptosIndex parses the u64 field.
+func aptosIndex(fields map[string]json.RawMessage, name string) (uint64, error) {
+	r, err := aptosU256(fields, name)
+	if err != nil {
+		return 0, err
+	}
+	if !r.IsUint64() {
+		return 0, fmt.Errorf("%s %s is out of the range of u64", name, r)
+	}
+
+	return r.Uint64(), nil
+}
+
+// aptosValue parses the value, which is left as 0 if it is not an unsigned int.
+func aptosValue(fields map[string]json.RawMessage, name string) U256 {
+	r, err := aptosU256(fields, name)
+	if err != nil {
+		return U256{}
+	}
+
+	return r
+}
+
+var aptosKeyMatch = regexp.MustCompile(`^key\d+_(\d+)$`)
+
+// aptosKeys parses key, or key0_n to key{n-1}_n of the entries with more than one key.
+func aptosKeys(fields map[string]json.RawMessage) ([]U256, error) {
+	if _, ok := fields["key"]; ok {
+		key, err := aptosU256(fields, "key")
+		if err != nil {
+			return nil, err
+		}
+
+		return []U256{key}, nil
+	}
+
+	keyCount := 0
+	for name := range fields {
+		if matched := aptosKeyMatch.FindStringSubmatch(name); len(matched) == 2 {
+			keyCount, _ = strconv.Atoi(matched[1])
+			break
+		}
+	}
+	if keyCount == 0 {
+		return nil, fmt.Errorf("missing field key")
+	}
+
+	r := make([]U256, 0, keyCount)
+	for i := 0; i < keyCount; i++ {
+		key, err := aptosU256(fields, fmt.Sprintf("key%d_%d", i, keyCount))
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, key)
+	}
+
+	return r, nil
+}
+
+// parseAptosEntry parses the entry of the tree. The metadata is 0 if the entry has none, same as ParseEntryWithLayout,
+// and the size is parsed if the entry has it.
+func parseAptosEntry(data json.RawMessage) (*Entry, error) {
+	fields, err := aptosStruct(data, "entry")
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := aptosKeys(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse entry %s: %w", data, err)
+	}
+
+	r := &Entry{Key: keys[0], Value: aptosValue(fields, "value")}
+	if len(keys) > 1 {
+		r.MoreKeys = keys[1:]
+	}
+	for _, field := range []struct {
+		name  string
+		value *uint64
+	}{
+		{name: "parent", value: &r.Parent},
+		{name: "left_child", value: &r.LeftChild},
+		{name: "right_child", value: &r.RightChild},
+	} {
+		if *field.value, err = aptosIndex(fields, field.name); err != nil {
+			return nil, fmt.Errorf("failed to parse entry %s: %w", data, err)
+		}
+	}
+
+	if _, ok := fields["metadata"]; ok {
+		metadata, err := aptosU256(fields, "metadata")
+		if err != nil || !metadata.IsUint64() || metadata.Uint64() > 255 {
+			return nil, fmt.Errorf("failed to parse metadata of entry %s", data)
+		}
+		r.Metadata = uint8(metadata.Uint64())
+	}
+
+	if _, ok := fields["size"]; ok {
+		size, err := aptosU256(fields, "size")
+		if err != nil || !size.IsUint64() {
+			return nil, fmt.Errorf("failed to parse size of entry %s", data)
+		}
+		r.Size = size.Uint64()
+		r.HasSize = true
+	}
+
+	return r, nil
+}
+
+// aptosTreeFields parses the root, min_index and max_index of the tree.
+func aptosTreeFields(fields map[string]json.RawMessage) (*TreeFields, error) {
+	r := &TreeFields{}
+	for _, field := range []struct {
+		name  string
+		value *uint64
+	}{
+		{name: "root", value: &r.Root},
+		{name: "min_index", value: &r.MinIndex},
+		{name: "max_index", value: &r.MaxIndex},
+	} {
+		v, err := aptosIndex(fields, field.name)
+		if err != nil {
+			return nil, err
+		}
+		*field.value = v
+	}
+
+	return r, nil
+}
+
+// LoadAptosTreeDump loads the red black tree, avl tree or binary search tree from the json of the move struct,
+// with the entries in the items if they are stored in a table.
+func LoadAptosTreeDump(data json.RawMessage, items AptosTableItems) (*TreeDump, error) {
+	fields, err := aptosStruct(data, "tree")
+	if err != nil {
+		return nil, err
+	}
+
+	treeFields, err := aptosTreeFields(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tree: %w", err)
+	}
+
+	if _, ok := fields["entries"]; !ok {
+		return nil, fmt.Errorf("failed to parse tree: missing field entries")
+	}
+	entries, err := items.elements(fields["entries"], "entries")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &TreeDump{Fields: treeFields}
+	for _, data := range entries {
+		entry, err := parseAptosEntry(data)
+		if err != nil {
+			return nil, err
+		}
+		r.Entries = append(r.Entries, *entry)
+	}
+
+	return r, nil
+}
+
+// aptosTreeTypes are the tree types of the struct names.
+var aptosTreeTypes = map[string]TreeType{
+	"RedBlackTree":     TreeType_RedBlack,
+	"AvlTree":          TreeType_Avl,
+	"BinarySearchTree": TreeType_Vanilla,
+}
+
+// LoadAptosTree loads the tree at the dot separated path of the resource, or the resource itself if the path is empty.
+// The type of the tree is taken from the struct name of the resource if it is the tree,
+// otherwise it is detected from the metadata, see TreeDump.DetectTreeType.
+func LoadAptosTree(resource *AptosResource, path string, items AptosTableItems) (*Tree, error) {
+	data, err := resource.Field(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dump, err := LoadAptosTreeDump(data, items)
+	if err != nil {
+		return nil, err
+	}
+
+	treeType, ok := aptosTreeTypes[resource.StructName()]
+	if path != "" || !ok {
+		if treeType, err = dump.DetectTreeType(); err != nil {
+			return nil, err
+		}
+	}
+
+	return dump.NewTree(treeType), nil
+}
+
+// LoadAptosCritbitTree loads the critbit tree at the dot separated path of the resource, or the resource itself if the path is empty.
+func LoadAptosCritbitTree(resource *AptosResource, path string, items AptosTableItems) (*CritbitTree, error) {
+	data, err := resource.Field(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := aptosStruct(data, "critbit tree")
+	if err != nil {
+		return nil, err
+	}
+
+	treeFields, err := aptosTreeFields(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse critbit tree: %w", err)
+	}
+	r := &CritbitTree{Root: treeFields.Root, MinIndex: treeFields.MinIndex, MaxIndex: treeFields.MaxIndex}
+
+	for _, name := range []string{"tree", "entries"} {
+		if _, ok := fields[name]; !ok {
+			return nil, fmt.Errorf("failed to parse critbit tree: missing field %s", name)
+		}
+	}
+
+	nodes, err := items.elements(fields["tree"], "tree")
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range nodes {
+		node, err := aptosStruct(data, "tree node")
+		if err != nil {
+			return nil, err
+		}
+		mask, err := aptosU256(node, "mask")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tree node %s: %w", data, err)
+		}
+		links := make([]uint64, 0, 3)
+		for _, name := range []string{"parent", "left_child", "right_child"} {
+			link, err := aptosIndex(node, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse tree node %s: %w", data, err)
+			}
+			links = append(links, link)
+		}
+		r.Tree = append(r.Tree, CritbitTreeNode{Mask: mask, Parent: links[0], LeftChild: links[1], RightChild: links[2]})
+	}
+
+	entries, err := items.elements(fields["entries"], "entries")
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range entries {
+		entry, err := aptosStruct(data, "data node")
+		if err != nil {
+			return nil, err
+		}
+		key, err := aptosU256(entry, "key")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse data node %s: %w", data, err)
+		}
+		parent, err := aptosIndex(entry, "parent")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse data node %s: %w", data, err)
+		}
+		r.Entries = append(r.Entries, CritbitDataNode{Key: key, Parent: parent, Value: aptosValue(entry, "value")})
+	}
+
+	return r, nil
+}
+
+// LoadAptosLinkedList loads the linked list at the dot separated path of the resource, or the resource itself if the path is empty.
+// Values that are not u64 are left as 0.
+func LoadAptosLinkedList(resource *AptosResource, path string, items AptosTableItems) (*LinkedList, error) {
+	data, err := resource.Field(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := aptosStruct(data, "linked list")
+	if err != nil {
+		return nil, err
+	}
+
+	r := &LinkedList{Fields: &LinkedListFields{}}
+	if r.Fields.Head, err = aptosIndex(fields, "head"); err != nil {
+		return nil, fmt.Errorf("failed to parse linked list: %w", err)
+	}
+	if r.Fields.Tail, err = aptosIndex(fields, "tail"); err != nil {
+		return nil, fmt.Errorf("failed to parse linked list: %w", err)
+	}
+
+	if _, ok := fields["entries"]; !ok {
+		return nil, fmt.Errorf("failed to parse linked list: missing field entries")
+	}
+	nodes, err := items.elements(fields["entries"], "entries")
+	if err != nil {
+		return nil, err
+	}
+	for _, data := range nodes {
+		node, err := aptosStruct(data, "node")
+		if err != nil {
+			return nil, err
+		}
+		prev, err := aptosIndex(node, "prev")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse node %s: %w", data, err)
+		}
+		next, err := aptosIndex(node, "next")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse node %s: %w", data, err)
+		}
+		value := aptosValue(node, "value")
+		if !value.IsUint64() {
+			value = U256{}
+		}
+		r.Entries = append(r.Entries, LinkedListNode{Value: value.Uint64(), Prev: prev, Next: next})
+	}
+
+	return r, nil
+}
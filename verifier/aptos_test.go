@@ -0,0 +1,116 @@
+package verifier_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+	"github.com/google/go-cmp/cmp"
+)
+
+func loadAptosFixture(t *testing.T, name string) (*verifier.AptosResource, verifier.AptosTableItems) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "aptos", name))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	resource, err := verifier.ParseAptosResource(data)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", name, err)
+	}
+	items, err := verifier.LoadAptosTableItems(filepath.Join("testdata", "aptos", "items"))
+	if err != nil {
+		t.Fatalf("failed to load table items: %v", err)
+	}
+
+	return resource, items
+}
+
+func TestLoadAptosTree(t *testing.T) {
+	resource, items := loadAptosFixture(t, "red_black.json")
+	tree, err := verifier.LoadAptosTree(resource, "", items)
+	if err != nil {
+		t.Fatalf("failed to load tree: %v", err)
+	}
+
+	expected := verifier.NewTreeWithFields(
+		[]verifier.Entry{entry(2, 20, null, 1, 2, 129), entry(1, 10, 0, null, null, 128), entry(3, 30, 0, null, null, 128)},
+		verifier.TreeFields{Root: 0, MinIndex: 1, MaxIndex: 2},
+		verifier.TreeType_RedBlack,
+	)
+	if diff := cmp.Diff(expected, tree); diff != "" {
+		t.Errorf("tree differs (-expected +got):\n%s", diff)
+	}
+	if violations := tree.VerifyAll(); len(violations) > 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+
+	// the entries of the table are missing.
+	if _, err := verifier.LoadAptosTree(resource, "", verifier.AptosTableItems{}); err == nil {
+		t.Errorf("expecting error for missing table items")
+	}
+
+	// the avl tree with two keys and struct values is in a vector nested in the resource, and its keys are out of order.
+	resource, items = loadAptosFixture(t, "market.json")
+	tree, err = verifier.LoadAptosTree(resource, "book.bids", items)
+	if err != nil {
+		t.Fatalf("failed to load tree: %v", err)
+	}
+	if tree.Type != verifier.TreeType_Avl {
+		t.Errorf("expecting avl tree, got %s", tree.Type)
+	}
+	root := entry(20, 0, null, 1, null, 127)
+	root.MoreKeys = []verifier.U256{u(1)}
+	if diff := cmp.Diff(root, tree.Entries[0].Entry); diff != "" {
+		t.Errorf("root differs (-expected +got):\n%s", diff)
+	}
+	violations := tree.VerifyAll()
+	if len(violations) != 1 || violations[0].Kind != verifier.ViolationKind_OutOfOrder {
+		t.Errorf("expecting one out of order violation, got: %v", violations)
+	}
+
+	if _, err := verifier.LoadAptosTree(resource, "book.asks", items); err == nil {
+		t.Errorf("expecting error for missing field")
+	}
+}
+
+func TestLoadAptosCritbitTree(t *testing.T) {
+	resource, items := loadAptosFixture(t, "critbit.json")
+	tree, err := verifier.LoadAptosCritbitTree(resource, "", items)
+	if err != nil {
+		t.Fatalf("failed to load critbit tree: %v", err)
+	}
+
+	expected, err := verifier.ParseCritbitTree(strings.Split(critbitTestData, "\n")[0])
+	if err != nil {
+		t.Fatalf("failed to parse critbit tree: %v", err)
+	}
+	if diff := cmp.Diff(expected, tree); diff != "" {
+		t.Errorf("critbit tree differs (-expected +got):\n%s", diff)
+	}
+	if violations := tree.VerifyAll(); len(violations) > 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
+
+func TestLoadAptosLinkedList(t *testing.T) {
+	resource, items := loadAptosFixture(t, "linked_list.json")
+	list, err := verifier.LoadAptosLinkedList(resource, "", items)
+	if err != nil {
+		t.Fatalf("failed to load linked list: %v", err)
+	}
+
+	expected := &verifier.LinkedList{
+		Entries: []verifier.LinkedListNode{{Value: 7, Prev: 2, Next: 1}, {Value: 8, Prev: 0, Next: null}, {Value: 6, Prev: null, Next: 0}},
+		Fields:  &verifier.LinkedListFields{Head: 2, Tail: 1},
+	}
+	if diff := cmp.Diff(expected, list); diff != "" {
+		t.Errorf("linked list differs (-expected +got):\n%s", diff)
+	}
+	if violations := list.VerifyAll(); len(violations) > 0 {
+		t.Errorf("unexpected violations: %v", violations)
+	}
+}
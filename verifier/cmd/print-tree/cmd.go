@@ -1,46 +1,401 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/fardream/gen-move-container/verifier"
+	"github.com/spf13/cobra"
 )
 
-func RunAMoveTest(isAvl bool) {
-	arg := "_redblack"
-	if isAvl {
-		arg = "_avl"
+const longDescription = `print and verify the containers printed by move test
+
+The containers are read from the [debug] lines of the move test output, which is read from the file,
+or stdin if the file is - or missing. With --run, the move cli is run instead, for example
+
+    print-tree --run --move-cli "aptos move" --filter test_max_iter_avl
+
+The type of each container is detected from the printed line unless --type is set:
+critbit trees and linked lists are told from their layouts, and binary search trees from their metadata.
+Red and black are also the balanced and right high factors of avl trees, so a tree with only such metadata
+needs --type unless it is valid as both red black and avl tree.
+
+Trees generated with more than one key, with size, or with values other than unsigned ints
+need --key-count, --with-size or --opaque-value to parse their entries.
+
+With --aptos, the input is the json of a resource from the /accounts/{address}/resource/{type} api of the aptos node,
+which is a tree, critbit tree or linked list, or contains one at --aptos-field, such as book.bids.
+Containers on the aptos-table and aptos-smart-vector backends need the table items in --aptos-items,
+where the item at the index of the table is saved as <handle>/<index>.json.
+
+The command exits with non-zero status if any container is invalid.
+`
+
+// kinds are the values of --type.
+var kinds = map[string]struct {
+	treeType verifier.TreeType
+	isTree   bool
+}{
+	"auto":        {},
+	"red-black":   {treeType: verifier.TreeType_RedBlack, isTree: true},
+	"avl":         {treeType: verifier.TreeType_Avl, isTree: true},
+	"bst":         {treeType: verifier.TreeType_Vanilla, isTree: true},
+	"critbit":     {},
+	"linked-list": {},
+}
+
+// container is a tree, critbit tree or linked list parsed from the output.
+type container interface {
+	VerifyAll() verifier.Violations
+	Print(out io.Writer)
+}
+
+func main() {
+	kind := "auto"
+	format := "ascii"
+	run := false
+	moveCli := "move"
+	filter := ""
+	var moveArgs []string
+	layout := verifier.DefaultEntryLayout()
+	opaqueValue := false
+	aptos := false
+	aptosField := ""
+	aptosItems := ""
+
+	cmd := &cobra.Command{
+		Use:   "print-tree [file]",
+		Short: "print and verify the containers printed by move test",
+		Long:  longDescription,
+		Args:  cobra.MaximumNArgs(1),
+
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&kind, "type", kind, "type of the containers: auto, red-black, avl, bst, critbit, or linked-list.")
+	cmd.Flags().StringVar(&format, "format", format, "output format: ascii, dot, or json. dot and json are only supported for red-black, avl and bst.")
+	cmd.Flags().BoolVar(&run, "run", run, "run move test instead of reading the output from the file or stdin.")
+	cmd.Flags().StringVar(&moveCli, "move-cli", moveCli, `move cli to run the tests, such as "move", "aptos move" or "sui move".`)
+	cmd.Flags().StringVar(&filter, "filter", filter, "filter of the tests to run.")
+	cmd.Flags().StringArrayVar(&moveArgs, "move-arg", moveArgs, "extra argument to move test, can be repeated.")
+	cmd.Flags().IntVar(&layout.KeyCount, "key-count", layout.KeyCount, "number of keys of the tree entries.")
+	cmd.Flags().BoolVar(&layout.WithSize, "with-size", layout.WithSize, "tree entries have the size of the subtree, which is verified.")
+	cmd.Flags().BoolVar(&opaqueValue, "opaque-value", opaqueValue, "values of the tree entries are not unsigned ints, such as structs or vectors, and are skipped.")
+
+	cmd.Flags().BoolVar(&aptos, "aptos", aptos, "read the json of an aptos resource instead of the move test output.")
+	cmd.Flags().StringVar(&aptosField, "aptos-field", aptosField, "dot separated path of the container in the aptos resource, empty if the resource is the container.")
+	cmd.Flags().StringVar(&aptosItems, "aptos-items", aptosItems, "directory of the table items of the aptos resource.")
+	cmd.MarkFlagDirname("aptos-items")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if _, ok := kinds[kind]; !ok {
+			return fmt.Errorf("unknown type %q", kind)
+		}
+		if opaqueValue {
+			layout.ValueShape = verifier.ValueShape_Opaque
+		}
+		switch format {
+		case "ascii":
+		case "dot", "json":
+			if kind == "critbit" || kind == "linked-list" {
+				return fmt.Errorf("%s output is not supported for %s", format, kind)
+			}
+		default:
+			return fmt.Errorf("unknown format %q", format)
+		}
+
+		var text string
+		var err error
+		switch {
+		case run && len(args) > 0:
+			return fmt.Errorf("cannot read from %s with --run", args[0])
+		case run:
+			text, err = runMoveTest(moveCli, filter, moveArgs)
+		case len(args) == 0 || args[0] == "-":
+			text, err = readAll(os.Stdin)
+		default:
+			text, err = readFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+
+		var containers []container
+		if aptos {
+			c, err := loadAptosContainer(text, kind, aptosField, aptosItems)
+			if err != nil {
+				return err
+			}
+			containers = append(containers, c)
+		} else {
+			containers, err = parseContainers(text, kind, layout)
+			if err != nil {
+				return err
+			}
+		}
+		if format != "ascii" {
+			// critbit trees and linked lists found by auto are skipped, since they cannot be written in dot or json.
+			containers = onlyTrees(containers)
+		}
+		if len(containers) == 0 {
+			return fmt.Errorf("cannot find any %s container in the output", kind)
+		}
+
+		return printContainers(cmd.OutOrStdout(), cmd.ErrOrStderr(), containers, format)
+	}
+
+	if err := cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// runMoveTest runs the tests, and returns the output even if some tests fail.
+func runMoveTest(moveCli string, filter string, moveArgs []string) (string, error) {
+	cli := strings.Fields(moveCli)
+	if len(cli) == 0 {
+		return "", fmt.Errorf("missing move cli")
 	}
 
-	cmd := exec.Command("move", "test", "--filter", arg)
+	args := append(cli, "test")
+	if filter != "" {
+		args = append(args, "--filter", filter)
+	}
+	args = append(args, moveArgs...)
+
+	data, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+	var exitError *exec.ExitError
+	if err != nil && !errors.As(err, &exitError) {
+		return "", fmt.Errorf("failed to run %s: %w", strings.Join(args, " "), err)
+	}
 
-	data, err := cmd.CombinedOutput()
+	return string(data), nil
+}
 
-	if _, isExitError := err.(*exec.ExitError); err != nil && !isExitError {
-		panic(err)
+func readAll(in io.Reader) (string, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read: %w", err)
 	}
 
-	vv, err := verifier.ParseMoveTestOut(string(data))
+	return string(data), nil
+}
+
+func readFile(name string) (string, error) {
+	data, err := os.ReadFile(name)
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
-	for _, v := range vv {
-		treeType := verifier.TreeType_RedBlack
-		if isAvl {
-			treeType = verifier.TreeType_Avl
+	return string(data), nil
+}
+
+// parseContainers parses the containers of the kind line by line, and skips the lines that are not such containers.
+// For auto, critbit trees are tried first, then binary search trees, and linked lists last,
+// since the nodes of a binary search tree also start with the value, prev and next of a linked list node.
+// An error is returned if the type of a tree cannot be detected.
+func parseContainers(text string, kind string, layout verifier.EntryLayout) ([]container, error) {
+	var r []container
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		c, err := parseContainer(line, kind, layout)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
 		}
-		tree := verifier.NewTree(v, treeType)
+		if c != nil {
+			r = append(r, c)
+		}
+	}
 
-		tree.VerifyAll()
-		fmt.Printf("--------------------- %d ----------------------\n", len(tree.Entries))
-		tree.Print(os.Stdout)
+	return r, nil
+}
+
+// parseContainer parses the container of the kind from the line, and returns nil if the line is not such a container.
+func parseContainer(line string, kind string, layout verifier.EntryLayout) (container, error) {
+	if kind == "auto" || kind == "critbit" {
+		if tree, err := verifier.ParseCritbitTree(line); err == nil {
+			return tree, nil
+		}
+	}
+
+	if kind == "auto" || kinds[kind].isTree {
+		if dumps, err := verifier.ParseMoveTestDumpsWithLayout(line, layout); err == nil && len(dumps) == 1 {
+			treeType := kinds[kind].treeType
+			if kind == "auto" {
+				if treeType, err = dumps[0].DetectTreeType(); err != nil {
+					return nil, withTypeHint(err)
+				}
+			}
+			return dumps[0].NewTree(treeType), nil
+		}
+	}
+
+	if kind == "auto" || kind == "linked-list" {
+		if list, err := verifier.ParseLinkedList(line); err == nil {
+			return list, nil
+		}
 	}
+
+	return nil, nil
 }
 
-func main() {
-	RunAMoveTest(true)
-	RunAMoveTest(false)
+// withTypeHint asks for --type if the type of the tree is ambiguous.
+func withTypeHint(err error) error {
+	if errors.Is(err, verifier.ErrAmbiguousTreeType) {
+		return fmt.Errorf("%w, pass --type", err)
+	}
+
+	return err
+}
+
+// loadAptosContainer loads the container from the json of the aptos resource.
+// For auto, the type is taken from the struct name of the resource if it is the container, otherwise from its fields.
+func loadAptosContainer(text string, kind string, path string, itemsDir string) (container, error) {
+	resource, err := verifier.ParseAptosResource([]byte(text))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(verifier.AptosTableItems)
+	if itemsDir != "" {
+		if items, err = verifier.LoadAptosTableItems(itemsDir); err != nil {
+			return nil, err
+		}
+	}
+
+	if kind == "auto" {
+		kind, err = detectAptosKind(resource, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch kind {
+	case "critbit":
+		return verifier.LoadAptosCritbitTree(resource, path, items)
+	case "linked-list":
+		return verifier.LoadAptosLinkedList(resource, path, items)
+	case "auto":
+		tree, err := verifier.LoadAptosTree(resource, path, items)
+		if err != nil {
+			return nil, withTypeHint(err)
+		}
+
+		return tree, nil
+	default:
+		tree, err := verifier.LoadAptosTree(resource, path, items)
+		if err != nil {
+			return nil, err
+		}
+		tree.Type = kinds[kind].treeType
+
+		return tree, nil
+	}
+}
+
+// detectAptosKind detects the kind of the container in the resource, and returns auto for trees,
+// whose type is detected by verifier.LoadAptosTree.
+func detectAptosKind(resource *verifier.AptosResource, path string) (string, error) {
+	if path == "" {
+		switch resource.StructName() {
+		case "CritbitTree":
+			return "critbit", nil
+		case "LinkedList":
+			return "linked-list", nil
+		}
+	}
+
+	data, err := resource.Field(path)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("container in %s is not a struct: %w", resource.Type, err)
+	}
+
+	switch {
+	case fields["tree"] != nil:
+		return "critbit", nil
+	case fields["head"] != nil:
+		return "linked-list", nil
+	default:
+		return "auto", nil
+	}
+}
+
+func onlyTrees(containers []container) []container {
+	var r []container
+	for _, c := range containers {
+		if _, isTree := c.(*verifier.Tree); isTree {
+			r = append(r, c)
+		}
+	}
+
+	return r
+}
+
+// printContainers prints the containers in the format, and the violations to errOut.
+// An error is returned if any container is invalid.
+func printContainers(out io.Writer, errOut io.Writer, containers []container, format string) error {
+	invalid := 0
+	var trees []*verifier.TreeJSON
+	for i, c := range containers {
+		violations := c.VerifyAll()
+		if len(violations) > 0 {
+			invalid++
+			fmt.Fprintf(errOut, "container #%d has %d violations:\n%s\n", i, len(violations), violations.Error())
+		}
+
+		tree, isTree := c.(*verifier.Tree)
+		if format != "ascii" && !isTree {
+			return fmt.Errorf("%s output is not supported for container #%d, which is %T", format, i, c)
+		}
+
+		var err error
+		switch format {
+		case "dot":
+			err = tree.WriteDOT(out)
+		case "json":
+			trees = append(trees, tree.ToJSON())
+		default:
+			_, err = fmt.Fprintf(out, "--------------------- #%d %s ----------------------\n", i, describe(c))
+			c.Print(out)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(trees); err != nil {
+			return err
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d containers are invalid", invalid, len(containers))
+	}
+
+	return nil
+}
+
+// describe names the type and size of the container for the ascii output.
+func describe(c container) string {
+	switch c := c.(type) {
+	case *verifier.Tree:
+		return fmt.Sprintf("%s tree of %d entries", c.Type, len(c.Entries))
+	case *verifier.CritbitTree:
+		return fmt.Sprintf("critbit tree of %d entries", len(c.Entries))
+	case *verifier.LinkedList:
+		return fmt.Sprintf("linked list of %d nodes", len(c.Entries))
+	default:
+		return fmt.Sprintf("%T", c)
+	}
 }
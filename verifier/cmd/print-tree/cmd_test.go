@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+)
+
+const (
+	critbitLine    = "[debug] (&) { 0, [{ 2, 9223372036854775808, 18446744073709551615, 1 }, { 1, 0, 18446744073709551614, 18446744073709551613 }], 0, 2, [{ 1, 0, 10 }, { 2, 1, 20 }, { 3, 1, 30 }] }"
+	linkedListLine = "[debug] (&) { 2, 1, [{ 10, 2, 1 }, { 20, 0, 18446744073709551615 }, { 30, 18446744073709551615, 0 }] }"
+	// the red child is on the right of the black root, which is also a valid avl tree.
+	bothLine = "[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 18446744073709551615, 1, 129 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 128 }], 0, 1 }"
+	// the red child is on the left of the black root, which is right high as avl tree.
+	redBlackLine = "[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 18446744073709551615, 129 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128 }], 1, 0 }"
+	avlLine      = "[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 18446744073709551615, 127 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128 }], 1, 0 }"
+	bstLine      = "[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 18446744073709551615, 0 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 0 }], 1, 0 }"
+	// the left child of the root is itself.
+	cyclicLine = "[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 0, 1, 0 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 0 }], 0, 1 }"
+	// the keys are out of order.
+	invalidLine = "[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 18446744073709551615, 1, 0 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 0 }], 0, 1 }"
+)
+
+func TestParseContainer(t *testing.T) {
+	cases := []struct {
+		line      string
+		kind      string
+		expected  string
+		ambiguous bool
+	}{
+		{line: critbitLine, kind: "auto", expected: "critbit tree of 3 entries"},
+		{line: critbitLine, kind: "critbit", expected: "critbit tree of 3 entries"},
+		{line: critbitLine, kind: "linked-list"},
+		{line: linkedListLine, kind: "auto", expected: "linked list of 3 nodes"},
+		{line: linkedListLine, kind: "linked-list", expected: "linked list of 3 nodes"},
+		{line: linkedListLine, kind: "bst"},
+		{line: bothLine, kind: "auto", expected: "RedBlack tree of 2 entries"},
+		{line: redBlackLine, kind: "auto", ambiguous: true},
+		{line: redBlackLine, kind: "red-black", expected: "RedBlack tree of 2 entries"},
+		{line: redBlackLine, kind: "avl", expected: "AVL tree of 2 entries"},
+		{line: avlLine, kind: "auto", expected: "AVL tree of 2 entries"},
+		{line: bstLine, kind: "auto", expected: "Vanila tree of 2 entries"},
+		{line: bstLine, kind: "critbit"},
+		{line: "Running Move unit tests", kind: "auto"},
+	}
+
+	for _, c := range cases {
+		got, err := parseContainer(c.line, c.kind, verifier.DefaultEntryLayout())
+		switch {
+		case c.ambiguous:
+			if !errors.Is(err, verifier.ErrAmbiguousTreeType) || !strings.Contains(err.Error(), "pass --type") {
+				t.Errorf("%s as %s: expecting ambiguous tree type, got error %v", c.line, c.kind, err)
+			}
+		case err != nil:
+			t.Errorf("%s as %s: unexpected error: %v", c.line, c.kind, err)
+		case c.expected == "" && got != nil:
+			t.Errorf("%s as %s: expecting nothing, got %s", c.line, c.kind, describe(got))
+		case c.expected != "" && got == nil:
+			t.Errorf("%s as %s: expecting %s, got nothing", c.line, c.kind, c.expected)
+		case c.expected != "" && describe(got) != c.expected:
+			t.Errorf("%s as %s: expecting %s, got %s", c.line, c.kind, c.expected, describe(got))
+		}
+	}
+}
+
+func TestParseContainers(t *testing.T) {
+	text := strings.Join([]string{"Running Move unit tests", critbitLine, bothLine, linkedListLine, "[ PASS    ] 0x1::test::test_insert"}, "\n")
+	containers, err := parseContainers(text, "auto", verifier.DefaultEntryLayout())
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	var got []string
+	for _, c := range containers {
+		got = append(got, describe(c))
+	}
+	expected := []string{"critbit tree of 3 entries", "RedBlack tree of 2 entries", "linked list of 3 nodes"}
+	if strings.Join(got, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("expecting %v, got %v", expected, got)
+	}
+
+	_, err = parseContainers(strings.Join([]string{bothLine, redBlackLine}, "\n"), "auto", verifier.DefaultEntryLayout())
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2: ") {
+		t.Errorf("expecting ambiguous tree type on line 2, got error %v", err)
+	}
+}
+
+func parseLines(t *testing.T, kind string, lines ...string) []container {
+	t.Helper()
+
+	containers, err := parseContainers(strings.Join(lines, "\n"), kind, verifier.DefaultEntryLayout())
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(containers) != len(lines) {
+		t.Fatalf("expecting %d containers, got %d", len(lines), len(containers))
+	}
+
+	return containers
+}
+
+func TestPrintContainersAscii(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if err := printContainers(&out, &errOut, parseLines(t, "auto", critbitLine, bothLine), "ascii"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, header := range []string{
+		"--------------------- #0 critbit tree of 3 entries ----------------------\n",
+		"--------------------- #1 RedBlack tree of 2 entries ----------------------\n",
+	} {
+		if !strings.Contains(out.String(), header) {
+			t.Errorf("missing header %q in output:\n%s", header, out.String())
+		}
+	}
+	if errOut.Len() > 0 {
+		t.Errorf("unexpected violations:\n%s", errOut.String())
+	}
+
+	out.Reset()
+	err := printContainers(&out, &errOut, parseLines(t, "bst", bstLine, invalidLine), "ascii")
+	if err == nil || err.Error() != "1 of 2 containers are invalid" {
+		t.Errorf("expecting 1 of 2 containers are invalid, got error %v", err)
+	}
+	if !strings.HasPrefix(errOut.String(), "container #1 has ") {
+		t.Errorf("expecting violations of container #1, got:\n%s", errOut.String())
+	}
+	if !strings.Contains(out.String(), "--------------------- #1 Vanila tree of 2 entries ----------------------\n") {
+		t.Errorf("invalid container is not printed:\n%s", out.String())
+	}
+}
+
+func TestPrintContainersCycle(t *testing.T) {
+	var out, errOut bytes.Buffer
+	err := printContainers(&out, &errOut, parseLines(t, "bst", cyclicLine), "ascii")
+	if err == nil || err.Error() != "1 of 1 containers are invalid" {
+		t.Errorf("expecting 1 of 1 containers are invalid, got error %v", err)
+	}
+	if !strings.Contains(errOut.String(), verifier.ViolationKind_Cycle.String()) {
+		t.Errorf("expecting cycle violation, got:\n%s", errOut.String())
+	}
+	for _, key := range []string{"k:  2", "k:  3"} {
+		if strings.Count(out.String(), key) != 1 {
+			t.Errorf("expecting %s to be printed once:\n%s", key, out.String())
+		}
+	}
+}
+
+func TestPrintContainersJSON(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if err := printContainers(&out, &errOut, parseLines(t, "auto", bothLine, avlLine), "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var trees []*verifier.TreeJSON
+	if err := json.Unmarshal(out.Bytes(), &trees); err != nil {
+		t.Fatalf("output is not json: %v\n%s", err, out.String())
+	}
+	if len(trees) != 2 {
+		t.Errorf("expecting 2 trees, got %d", len(trees))
+	}
+}
+
+func TestPrintContainersDOT(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if err := printContainers(&out, &errOut, parseLines(t, "auto", bothLine, avlLine), "dot"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(out.String(), "digraph ") != 2 {
+		t.Errorf("expecting 2 graphs, got:\n%s", out.String())
+	}
+
+	if err := printContainers(&out, &errOut, parseLines(t, "auto", critbitLine), "dot"); err == nil {
+		t.Errorf("expecting error for critbit tree in dot")
+	}
+}
@@ -0,0 +1,388 @@
+package verifier
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// CRITBIT_NULL_INDEX is the null index of the critbit tree, which is 1 << 63 instead of MAX_U64.
+// Indices above it are data indices, which refer to the entry at MAX_U64 - index.
+const CRITBIT_NULL_INDEX = 1 << 63
+
+// IsCritbitDataIndex checks if the index in the critbit tree links refers to an entry instead of an internal node.
+func IsCritbitDataIndex(index uint64) bool {
+	return index > CRITBIT_NULL_INDEX
+}
+
+// ConvertCritbitDataIndex converts between the entry index and the data index in the links, the conversion is its own inverse.
+func ConvertCritbitDataIndex(index uint64) uint64 {
+	return NULL_INDEX - index
+}
+
+// CritbitTreeNode is the internal node of the critbit tree.
+type CritbitTreeNode struct {
+	Mask       U256
+	Parent     uint64
+	LeftChild  uint64
+	RightChild uint64
+}
+
+// CritbitDataNode is the entry of the critbit tree.
+type CritbitDataNode struct {
+	Key    U256
+	Parent uint64
+	Value  U256
+}
+
+// CritbitTree mirrors the CritbitTree of move, with the internal nodes in Tree, and the data nodes in Entries.
+type CritbitTree struct {
+	Root     uint64
+	Tree     []CritbitTreeNode
+	MinIndex uint64
+	MaxIndex uint64
+	Entries  []CritbitDataNode
+}
+
+var critbitMatch = regexp.MustCompile(`^\[debug\] \(&\) { (\d+), \[(.*)\], (\d+), (\d+), \[(.*)\] }$`)
+
+// ParseCritbitTree parses a critbit tree printed by move test, which is root, tree nodes, min_index, max_index, and entries.
+func ParseCritbitTree(line string) (*CritbitTree, error) {
+	matched := critbitMatch.FindStringSubmatch(line)
+	if len(matched) != 6 {
+		return nil, fmt.Errorf("%s is not a critbit tree", line)
+	}
+
+	fields, err := parseTreeFields(matched[1], matched[3], matched[4])
+	if err != nil {
+		return nil, err
+	}
+
+	r := &CritbitTree{
+		Root:     fields.Root,
+		MinIndex: fields.MinIndex,
+		MaxIndex: fields.MaxIndex,
+	}
+
+	for _, text := range innerMatch.FindAllStringSubmatch(matched[2], -1) {
+		values, err := parseU256s(text[1], "tree node", "mask", "parent", "left child", "right child")
+		if err != nil {
+			return nil, err
+		}
+		r.Tree = append(r.Tree, CritbitTreeNode{Mask: values[0], Parent: values[1].Uint64(), LeftChild: values[2].Uint64(), RightChild: values[3].Uint64()})
+	}
+
+	for _, text := range innerMatch.FindAllStringSubmatch(matched[5], -1) {
+		values, err := parseU256s(text[1], "data node", "key", "parent", "value")
+		if err != nil {
+			return nil, err
+		}
+		r.Entries = append(r.Entries, CritbitDataNode{Key: values[0], Parent: values[1].Uint64(), Value: values[2]})
+	}
+
+	return r, nil
+}
+
+// ParseMoveTestCritbitTrees parses all the critbit trees printed in the move test output.
+func ParseMoveTestCritbitTrees(text string) ([]*CritbitTree, error) {
+	var result []*CritbitTree
+	for _, aLine := range strings.Split(text, "\n") {
+		if !critbitMatch.MatchString(aLine) {
+			continue
+		}
+		tree, err := ParseCritbitTree(aLine)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, tree)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("cannot find any critbit tree in:\n%s", text)
+	}
+
+	return result, nil
+}
+
+func parseUints(text string, kind string, names ...string) ([]uint64, error) {
+	trimmed := strings.Split(strings.Trim(text, " {}"), ", ")
+	if len(trimmed) < len(names) {
+		return nil, fmt.Errorf("%s %s is missing data", kind, text)
+	}
+
+	r := make([]uint64, 0, len(names))
+	for i, name := range names {
+		v, err := strconv.ParseUint(trimmed[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s of %s: %w", name, trimmed[i], kind, err)
+		}
+		r = append(r, v)
+	}
+
+	return r, nil
+}
+
+// parseU256s is parseUints for the nodes with keys, values or masks, which can be wider than uint64.
+// The indices of the node are in the lower 64 bits of the results.
+func parseU256s(text string, kind string, names ...string) ([]U256, error) {
+	trimmed := strings.Split(strings.Trim(text, " {}"), ", ")
+	if len(trimmed) < len(names) {
+		return nil, fmt.Errorf("%s %s is missing data", kind, text)
+	}
+
+	r := make([]U256, 0, len(names))
+	for i, name := range names {
+		v, err := ParseU256(trimmed[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s of %s: %w", name, trimmed[i], kind, err)
+		}
+		r = append(r, v)
+	}
+
+	return r, nil
+}
+
+func (tree *CritbitTree) isValidTreeIndex(index uint64) bool {
+	return index < uint64(len(tree.Tree))
+}
+
+func (tree *CritbitTree) isValidDataIndex(index uint64) bool {
+	return IsCritbitDataIndex(index) && ConvertCritbitDataIndex(index) < uint64(len(tree.Entries))
+}
+
+// IsValidIndex checks the index in the links refers to an internal node or an entry.
+func (tree *CritbitTree) IsValidIndex(index uint64) bool {
+	return tree.isValidTreeIndex(index) || tree.isValidDataIndex(index)
+}
+
+func (tree *CritbitTree) parentOf(index uint64) uint64 {
+	if IsCritbitDataIndex(index) {
+		return tree.Entries[ConvertCritbitDataIndex(index)].Parent
+	}
+
+	return tree.Tree[index].Parent
+}
+
+// newViolation creates the violation at the index in the links, taking the key from the entry if the index is a valid data index.
+func (tree *CritbitTree) newViolation(kind ViolationKind, index uint64, format string, args ...any) *Violation {
+	v := &Violation{
+		Kind:   kind,
+		Index:  index,
+		Detail: fmt.Sprintf(format, args...),
+	}
+	if tree.isValidDataIndex(index) {
+		v.Key = tree.Entries[ConvertCritbitDataIndex(index)].Key
+	}
+
+	return v
+}
+
+// NodeToString formats the node at the index in the links.
+func (tree *CritbitTree) NodeToString(index uint64) string {
+	switch {
+	case tree.isValidTreeIndex(index):
+		return fmt.Sprintf("{bit: %2d, i: %s}", tree.Tree[index].Mask.TrailingZeros(), ItoS(index))
+	case tree.isValidDataIndex(index):
+		dataIndex := ConvertCritbitDataIndex(index)
+		return fmt.Sprintf("{k: %2d, d: %s}", tree.Entries[dataIndex].Key, ItoS(dataIndex))
+	default:
+		return "(invalid index)"
+	}
+}
+
+// VerifyAll checks the structure of the critbit tree:
+//   - every internal node has two valid children, which point back to it as parent.
+//   - every node is reached exactly once from the root.
+//   - the masks are single bits, strictly decreasing toward the leaves.
+//   - each leaf's key takes the branches of the masks on its path, and the keys in each subtree agree on the bits above its mask.
+//   - min_index and max_index are the first and last leaves.
+func (tree *CritbitTree) VerifyAll() Violations {
+	r := tree.verifyRoot()
+	if len(r) > 0 {
+		return r
+	}
+
+	visited := make(map[uint64]bool)
+
+	var visit func(index uint64, path []critbitPathStep)
+	visit = func(index uint64, path []critbitPathStep) {
+		if visited[index] {
+			r = append(r, tree.newViolation(ViolationKind_Cycle, index, "%s is reached again", tree.NodeToString(index)))
+			return
+		}
+		visited[index] = true
+
+		if IsCritbitDataIndex(index) {
+			key := tree.Entries[ConvertCritbitDataIndex(index)].Key
+			for _, step := range path {
+				mask := tree.Tree[step.index].Mask
+				if (key.And(mask) == mask) != step.isRight {
+					r = append(r, tree.newViolation(ViolationKind_KeyPathMismatch, index, "key %d is on the wrong side of %s", key, tree.NodeToString(step.index)))
+				}
+			}
+			return
+		}
+
+		node := tree.Tree[index]
+		if node.Mask.OnesCount() != 1 {
+			r = append(r, tree.newViolation(ViolationKind_MaskNotDecreasing, index, "mask %#x of %s is not a single bit", node.Mask, tree.NodeToString(index)))
+		} else if len(path) > 0 && node.Mask.Cmp(tree.Tree[path[len(path)-1].index].Mask) >= 0 {
+			r = append(r, tree.newViolation(ViolationKind_MaskNotDecreasing, index, "mask %#x of %s is not less than its parent's mask %#x", node.Mask, tree.NodeToString(index), tree.Tree[path[len(path)-1].index].Mask))
+		}
+
+		for _, child := range []struct {
+			name    string
+			index   uint64
+			isRight bool
+		}{
+			{name: "left", index: node.LeftChild, isRight: false},
+			{name: "right", index: node.RightChild, isRight: true},
+		} {
+			switch {
+			case child.index == CRITBIT_NULL_INDEX:
+				r = append(r, tree.newViolation(ViolationKind_MissingChild, index, "%s misses %s child", tree.NodeToString(index), child.name))
+			case !tree.IsValidIndex(child.index):
+				r = append(r, tree.newViolation(ViolationKind_InvalidChildIndex, index, "%s child %d of %s is out of range", child.name, child.index, tree.NodeToString(index)))
+			default:
+				if parent := tree.parentOf(child.index); parent != index {
+					r = append(r, tree.newViolation(ViolationKind_ParentMismatch, child.index, "%s child %s of %s has parent %d", child.name, tree.NodeToString(child.index), tree.NodeToString(index), parent))
+				}
+				childPath := append(append([]critbitPathStep(nil), path...), critbitPathStep{index: index, isRight: child.isRight})
+				visit(child.index, childPath)
+			}
+		}
+	}
+
+	if tree.Root != CRITBIT_NULL_INDEX {
+		visit(tree.Root, nil)
+	}
+
+	for i := range tree.Tree {
+		if !visited[uint64(i)] {
+			r = append(r, tree.newViolation(ViolationKind_Unreachable, uint64(i), "%s is not reachable from the root", tree.NodeToString(uint64(i))))
+		}
+	}
+	for i := range tree.Entries {
+		index := ConvertCritbitDataIndex(uint64(i))
+		if !visited[index] {
+			r = append(r, tree.newViolation(ViolationKind_Unreachable, index, "%s is not reachable from the root", tree.NodeToString(index)))
+		}
+	}
+
+	if len(r) > 0 {
+		return r
+	}
+
+	for i := range tree.Tree {
+		r = append(r, tree.verifyPrefix(uint64(i))...)
+	}
+
+	return append(r, tree.verifyMinMax()...)
+}
+
+// critbitPathStep is an internal node on the path from the root, and the branch taken.
+type critbitPathStep struct {
+	index   uint64
+	isRight bool
+}
+
+func (tree *CritbitTree) verifyRoot() Violations {
+	switch {
+	case tree.Root == CRITBIT_NULL_INDEX && len(tree.Entries) == 0 && len(tree.Tree) == 0:
+		return nil
+	case tree.Root == CRITBIT_NULL_INDEX:
+		return Violations{tree.newViolation(ViolationKind_RootMismatch, tree.Root, "root is null, but there are %d entries", len(tree.Entries))}
+	case !tree.IsValidIndex(tree.Root):
+		return Violations{tree.newViolation(ViolationKind_RootMismatch, tree.Root, "root %d is out of range", tree.Root)}
+	case tree.parentOf(tree.Root) != CRITBIT_NULL_INDEX:
+		return Violations{tree.newViolation(ViolationKind_RootMismatch, tree.Root, "root %s has parent %d", tree.NodeToString(tree.Root), tree.parentOf(tree.Root))}
+	default:
+		return nil
+	}
+}
+
+// verifyPrefix checks all keys in the subtree at index agree on the bits above the mask of index.
+func (tree *CritbitTree) verifyPrefix(index uint64) Violations {
+	bit := tree.Tree[index].Mask.TrailingZeros()
+	var first *U256
+	var r Violations
+	tree.InfixVisit(index, func(leaf uint64) {
+		key := tree.Entries[ConvertCritbitDataIndex(leaf)].Key
+		if first == nil {
+			first = &key
+			return
+		}
+		// the keys agree above the bit if the highest bit they differ is at most the bit.
+		if key.Xor(*first).BitLen() > bit+1 {
+			r = append(r, tree.newViolation(ViolationKind_KeyPathMismatch, leaf, "key %d differs from key %d above bit %d of %s", key, *first, bit, tree.NodeToString(index)))
+		}
+	})
+
+	return r
+}
+
+func (tree *CritbitTree) verifyMinMax() Violations {
+	minIndex, maxIndex := uint64(CRITBIT_NULL_INDEX), uint64(CRITBIT_NULL_INDEX)
+	tree.InfixVisit(tree.Root, func(leaf uint64) {
+		if minIndex == CRITBIT_NULL_INDEX {
+			minIndex = ConvertCritbitDataIndex(leaf)
+		}
+		maxIndex = ConvertCritbitDataIndex(leaf)
+	})
+
+	var r Violations
+	if tree.MinIndex != minIndex {
+		r = append(r, tree.newViolation(ViolationKind_MinMismatch, tree.MinIndex, "min index is %d, expecting %d", tree.MinIndex, minIndex))
+	}
+	if tree.MaxIndex != maxIndex {
+		r = append(r, tree.newViolation(ViolationKind_MaxMismatch, tree.MaxIndex, "max index is %d, expecting %d", tree.MaxIndex, maxIndex))
+	}
+
+	return r
+}
+
+// InfixVisit visits the leaves of the subtree at index in order. The tree must be acyclic.
+func (tree *CritbitTree) InfixVisit(index uint64, visitor func(leaf uint64)) {
+	switch {
+	case tree.isValidDataIndex(index):
+		visitor(index)
+	case tree.isValidTreeIndex(index):
+		tree.InfixVisit(tree.Tree[index].LeftChild, visitor)
+		tree.InfixVisit(tree.Tree[index].RightChild, visitor)
+	}
+}
+
+// Print prints the tree like Tree.Print, with the internal nodes showing the bit of their masks.
+func (tree *CritbitTree) Print(out io.Writer) {
+	tree.PrintFrom(tree.Root, out, "", "", "", make(map[uint64]bool))
+}
+
+// PrintFrom prints the subtree at index. Nodes in visited are not printed again, so a cyclic tree can be printed.
+func (tree *CritbitTree) PrintFrom(
+	index uint64,
+	out io.Writer,
+	indent string,
+	leftChildIndent string,
+	rightChildIndent string,
+	visited map[uint64]bool,
+) {
+	if !tree.IsValidIndex(index) || visited[index] {
+		return
+	}
+	visited[index] = true
+
+	if IsCritbitDataIndex(index) {
+		fmt.Fprintf(out, "%s─ %s\n", indent, tree.NodeToString(index))
+		return
+	}
+
+	node := tree.Tree[index]
+
+	tree.PrintFrom(node.LeftChild, out, leftChildIndent+prefix5, leftChildIndent+prefix3, leftChildIndent+prefix4, visited)
+
+	fmt.Fprintf(out, "%s┼ %s\n", indent, tree.NodeToString(index))
+
+	tree.PrintFrom(node.RightChild, out, rightChildIndent+prefix2, rightChildIndent+prefix4, rightChildIndent+prefix3, visited)
+}
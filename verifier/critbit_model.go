@@ -0,0 +1,289 @@
+package verifier
+
+import (
+	"fmt"
+)
+
+// NewCritbitTree creates an empty critbit tree.
+// Insert and Remove on the tree follow the move code of critbit.move.template (vector backend, no stable index) step by step,
+// so the nodes and the entries are exactly what the move tree holds after the same operations.
+func NewCritbitTree() *CritbitTree {
+	return &CritbitTree{
+		Root:     CRITBIT_NULL_INDEX,
+		MinIndex: CRITBIT_NULL_INDEX,
+		MaxIndex: CRITBIT_NULL_INDEX,
+	}
+}
+
+// Size is the number of the entries.
+func (tree *CritbitTree) Size() uint64 {
+	return uint64(len(tree.Entries))
+}
+
+// Find returns the index of the entry with the key, or CRITBIT_NULL_INDEX if the key is not in the tree.
+func (tree *CritbitTree) Find(key U256) uint64 {
+	closest := tree.findClosestKey(key)
+	if closest == CRITBIT_NULL_INDEX || tree.Entries[closest].Key != key {
+		return CRITBIT_NULL_INDEX
+	}
+
+	return closest
+}
+
+// NextInOrder returns the index of the entry with the next bigger key, or CRITBIT_NULL_INDEX if index is the max.
+func (tree *CritbitTree) NextInOrder(index uint64) uint64 {
+	current := ConvertCritbitDataIndex(index)
+	parent := tree.Entries[index].Parent
+	for parent != CRITBIT_NULL_INDEX && tree.Tree[parent].RightChild == current {
+		current = parent
+		parent = tree.Tree[current].Parent
+	}
+	if parent == CRITBIT_NULL_INDEX {
+		return CRITBIT_NULL_INDEX
+	}
+
+	return tree.getMinIndexFrom(tree.Tree[parent].RightChild)
+}
+
+// NextInReverseOrder returns the index of the entry with the next smaller key, or CRITBIT_NULL_INDEX if index is the min.
+func (tree *CritbitTree) NextInReverseOrder(index uint64) uint64 {
+	current := ConvertCritbitDataIndex(index)
+	parent := tree.Entries[index].Parent
+	for parent != CRITBIT_NULL_INDEX && tree.Tree[parent].LeftChild == current {
+		current = parent
+		parent = tree.Tree[current].Parent
+	}
+	if parent == CRITBIT_NULL_INDEX {
+		return CRITBIT_NULL_INDEX
+	}
+
+	return tree.getMaxIndexFrom(tree.Tree[parent].LeftChild)
+}
+
+func (tree *CritbitTree) getMinIndexFrom(index uint64) uint64 {
+	current := index
+	for !IsCritbitDataIndex(current) {
+		current = tree.Tree[current].LeftChild
+	}
+
+	return ConvertCritbitDataIndex(current)
+}
+
+func (tree *CritbitTree) getMaxIndexFrom(index uint64) uint64 {
+	current := index
+	for !IsCritbitDataIndex(current) {
+		current = tree.Tree[current].RightChild
+	}
+
+	return ConvertCritbitDataIndex(current)
+}
+
+func (tree *CritbitTree) findClosestKey(key U256) uint64 {
+	current := tree.Root
+	for current != CRITBIT_NULL_INDEX {
+		if IsCritbitDataIndex(current) {
+			return ConvertCritbitDataIndex(current)
+		}
+		node := &tree.Tree[current]
+		if node.Mask.And(key) != node.Mask {
+			current = node.LeftChild
+		} else {
+			current = node.RightChild
+		}
+	}
+
+	return CRITBIT_NULL_INDEX
+}
+
+// Insert adds the key and value, and returns the index of the new entry, which is always the end of the entries.
+// Same as the move code, it fails if the key is already in the tree.
+func (tree *CritbitTree) Insert(key U256, value U256) (uint64, error) {
+	dataIndex := tree.Size()
+	if dataIndex >= CRITBIT_NULL_INDEX-1 {
+		return CRITBIT_NULL_INDEX, fmt.Errorf("tree exceeds the capacity")
+	}
+
+	closest := tree.findClosestKey(key)
+	if closest != CRITBIT_NULL_INDEX && tree.Entries[closest].Key == key {
+		return CRITBIT_NULL_INDEX, fmt.Errorf("key %d already exists at %d", key, closest)
+	}
+
+	tree.Entries = append(tree.Entries, CritbitDataNode{Key: key, Parent: CRITBIT_NULL_INDEX, Value: value})
+
+	if closest == CRITBIT_NULL_INDEX {
+		tree.Root = ConvertCritbitDataIndex(dataIndex)
+		tree.MinIndex = dataIndex
+		tree.MaxIndex = dataIndex
+		return dataIndex, nil
+	}
+
+	// the critbit is the most significant bit that differs between the keys.
+	maskNew := U256Bit(tree.Entries[closest].Key.Xor(key).BitLen() - 1)
+
+	current := tree.Root
+	insertionParent := uint64(CRITBIT_NULL_INDEX)
+	for !IsCritbitDataIndex(current) {
+		node := &tree.Tree[current]
+		if maskNew.Cmp(node.Mask) > 0 {
+			break
+		}
+		insertionParent = current
+		if node.Mask.And(key) != node.Mask {
+			current = node.LeftChild
+		} else {
+			current = node.RightChild
+		}
+	}
+
+	newParentIndex := uint64(len(tree.Tree))
+	tree.Tree = append(tree.Tree, CritbitTreeNode{
+		Mask:       maskNew,
+		Parent:     CRITBIT_NULL_INDEX,
+		LeftChild:  CRITBIT_NULL_INDEX,
+		RightChild: CRITBIT_NULL_INDEX,
+	})
+	if insertionParent != CRITBIT_NULL_INDEX {
+		tree.replaceChild(insertionParent, current, newParentIndex)
+	} else {
+		tree.Root = newParentIndex
+	}
+
+	if maskNew.And(key) != maskNew {
+		tree.replaceLeftChild(newParentIndex, ConvertCritbitDataIndex(dataIndex))
+		tree.replaceRightChild(newParentIndex, current)
+	} else {
+		tree.replaceRightChild(newParentIndex, ConvertCritbitDataIndex(dataIndex))
+		tree.replaceLeftChild(newParentIndex, current)
+	}
+
+	if tree.Entries[tree.MinIndex].Key.Cmp(key) > 0 {
+		tree.MinIndex = dataIndex
+	}
+	if tree.Entries[tree.MaxIndex].Key.Cmp(key) < 0 {
+		tree.MaxIndex = dataIndex
+	}
+
+	return dataIndex, nil
+}
+
+// Remove deletes the entry at index and returns it.
+// The last entry is moved into index, and the last internal node is moved into the slot of the removed internal node.
+func (tree *CritbitTree) Remove(index uint64) (*CritbitDataNode, error) {
+	oldLength := tree.Size()
+	if index >= oldLength {
+		return nil, fmt.Errorf("index %d is out of range, size is %d", index, oldLength)
+	}
+
+	if tree.MinIndex == index {
+		tree.MinIndex = tree.NextInOrder(index)
+	}
+	if tree.MaxIndex == index {
+		tree.MaxIndex = tree.NextInReverseOrder(index)
+	}
+
+	dataIndexConverted := ConvertCritbitDataIndex(index)
+	originalParent := tree.Entries[index].Parent
+	isLeftChild := originalParent != CRITBIT_NULL_INDEX && tree.Tree[originalParent].LeftChild == dataIndexConverted
+
+	endIndex := oldLength - 1
+	if endIndex != index {
+		endParent := tree.Entries[endIndex].Parent
+		isEndIndexLeft := tree.Tree[endParent].LeftChild == ConvertCritbitDataIndex(endIndex)
+		tree.Entries[index], tree.Entries[endIndex] = tree.Entries[endIndex], tree.Entries[index]
+		if isEndIndexLeft {
+			tree.replaceLeftChild(endParent, dataIndexConverted)
+		} else {
+			tree.replaceRightChild(endParent, dataIndexConverted)
+		}
+		if isLeftChild {
+			tree.replaceLeftChild(originalParent, ConvertCritbitDataIndex(endIndex))
+		} else {
+			tree.replaceRightChild(originalParent, ConvertCritbitDataIndex(endIndex))
+		}
+		if tree.MaxIndex == endIndex {
+			tree.MaxIndex = index
+		}
+		if tree.MinIndex == endIndex {
+			tree.MinIndex = index
+		}
+	}
+
+	removed := tree.Entries[endIndex]
+	tree.Entries = tree.Entries[:endIndex]
+
+	if len(tree.Entries) == 0 {
+		tree.Root = CRITBIT_NULL_INDEX
+		tree.MinIndex = CRITBIT_NULL_INDEX
+		tree.MaxIndex = CRITBIT_NULL_INDEX
+		return &removed, nil
+	}
+
+	originalParentNode := tree.Tree[originalParent]
+	otherChild := originalParentNode.LeftChild
+	if isLeftChild {
+		otherChild = originalParentNode.RightChild
+	}
+	grandParent := originalParentNode.Parent
+	if grandParent == CRITBIT_NULL_INDEX {
+		tree.replaceParent(otherChild, CRITBIT_NULL_INDEX)
+		tree.Root = otherChild
+	} else {
+		tree.replaceChild(grandParent, originalParent, otherChild)
+	}
+
+	treeEndIndex := uint64(len(tree.Tree)) - 1
+	if treeEndIndex != originalParent {
+		tree.Tree[originalParent], tree.Tree[treeEndIndex] = tree.Tree[treeEndIndex], tree.Tree[originalParent]
+		switched := tree.Tree[originalParent]
+		if switched.Parent != CRITBIT_NULL_INDEX {
+			tree.replaceChild(switched.Parent, treeEndIndex, originalParent)
+		}
+		tree.replaceLeftChild(originalParent, switched.LeftChild)
+		tree.replaceRightChild(originalParent, switched.RightChild)
+		if tree.Root == treeEndIndex {
+			tree.Root = originalParent
+		}
+	}
+	tree.Tree = tree.Tree[:treeEndIndex]
+
+	return &removed, nil
+}
+
+func (tree *CritbitTree) replaceChild(parent, originalChild, newChild uint64) {
+	if parent == CRITBIT_NULL_INDEX {
+		return
+	}
+	if tree.Tree[parent].RightChild == originalChild {
+		tree.replaceRightChild(parent, newChild)
+	} else if tree.Tree[parent].LeftChild == originalChild {
+		tree.replaceLeftChild(parent, newChild)
+	}
+}
+
+func (tree *CritbitTree) replaceLeftChild(parent, newChild uint64) {
+	if parent == CRITBIT_NULL_INDEX {
+		return
+	}
+	tree.Tree[parent].LeftChild = newChild
+	if newChild != CRITBIT_NULL_INDEX {
+		tree.replaceParent(newChild, parent)
+	}
+}
+
+func (tree *CritbitTree) replaceRightChild(parent, newChild uint64) {
+	if parent == CRITBIT_NULL_INDEX {
+		return
+	}
+	tree.Tree[parent].RightChild = newChild
+	if newChild != CRITBIT_NULL_INDEX {
+		tree.replaceParent(newChild, parent)
+	}
+}
+
+func (tree *CritbitTree) replaceParent(child, parent uint64) {
+	if IsCritbitDataIndex(child) {
+		tree.Entries[ConvertCritbitDataIndex(child)].Parent = parent
+	} else {
+		tree.Tree[child].Parent = parent
+	}
+}
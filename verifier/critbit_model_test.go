@@ -0,0 +1,95 @@
+package verifier_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+	"github.com/google/go-cmp/cmp"
+)
+
+const critbitNull = verifier.CRITBIT_NULL_INDEX
+
+func data(i uint64) uint64 {
+	return verifier.ConvertCritbitDataIndex(i)
+}
+
+// TestCritbitModelMoveTest follows test_critbit and test_remove_critbit in critbit.move.template.
+func TestCritbitModelMoveTest(t *testing.T) {
+	tree := verifier.NewCritbitTree()
+	for _, key := range []uint64{6, 5, 4, 1, 3, 2} {
+		if _, err := tree.Insert(u(key), u(key)); err != nil {
+			t.Fatalf("failed to insert %d: %v", key, err)
+		}
+	}
+
+	expected := &verifier.CritbitTree{
+		Root: 2,
+		Tree: []verifier.CritbitTreeNode{
+			{u(2), 2, 1, data(0)},
+			{u(1), 0, data(2), data(1)},
+			{u(4), critbitNull, 3, 0},
+			{u(2), 2, data(3), 4},
+			{u(1), 3, data(5), data(4)},
+		},
+		MinIndex: 3,
+		MaxIndex: 0,
+		Entries:  []verifier.CritbitDataNode{{u(6), 0, u(6)}, {u(5), 1, u(5)}, {u(4), 1, u(4)}, {u(1), 3, u(1)}, {u(3), 4, u(3)}, {u(2), 4, u(2)}},
+	}
+	if diff := cmp.Diff(expected, tree); diff != "" {
+		t.Errorf("tree differs from test_critbit (-move +model):\n%s", diff)
+	}
+
+	if _, err := tree.Insert(u(3), u(3)); err == nil {
+		t.Errorf("inserting an existing key should fail")
+	}
+
+	if _, err := tree.Remove(3); err != nil {
+		t.Fatalf("failed to remove: %v", err)
+	}
+	expected = &verifier.CritbitTree{
+		Root: 2,
+		Tree: []verifier.CritbitTreeNode{
+			{u(2), 2, 1, data(0)},
+			{u(1), 0, data(2), data(1)},
+			{u(4), critbitNull, 3, 0},
+			{u(1), 2, data(3), data(4)},
+		},
+		MinIndex: 3,
+		MaxIndex: 0,
+		Entries:  []verifier.CritbitDataNode{{u(6), 0, u(6)}, {u(5), 1, u(5)}, {u(4), 1, u(4)}, {u(2), 3, u(2)}, {u(3), 3, u(3)}},
+	}
+	if diff := cmp.Diff(expected, tree); diff != "" {
+		t.Errorf("tree differs from test_remove_critbit (-move +model):\n%s", diff)
+	}
+}
+
+func TestCritbitModelRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := verifier.NewCritbitTree()
+	keys := make(map[uint64]bool)
+
+	for i := 0; i < 2000; i++ {
+		if tree.Size() > 0 && r.Intn(5) < 2 {
+			removed, err := tree.Remove(uint64(r.Intn(int(tree.Size()))))
+			if err != nil {
+				t.Fatalf("op #%d: failed to remove: %v", i, err)
+			}
+			delete(keys, removed.Key.Uint64())
+		} else {
+			key := r.Uint64() >> r.Intn(64)
+			_, err := tree.Insert(u(key), u(key))
+			if (err != nil) != keys[key] {
+				t.Fatalf("op #%d: insert %d returns %v, key exists: %t", i, key, err, keys[key])
+			}
+			keys[key] = true
+		}
+
+		if err := tree.VerifyAll().Err(); err != nil {
+			t.Fatalf("op #%d: %v", i, err)
+		}
+		if len(tree.Entries) != len(keys) || (len(keys) > 0 && len(tree.Tree) != len(keys)-1) {
+			t.Fatalf("op #%d: %d entries and %d nodes for %d keys", i, len(tree.Entries), len(tree.Tree), len(keys))
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package verifier_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+)
+
+const critbitTestData = `[debug] (&) { 0, [{ 2, 9223372036854775808, 18446744073709551615, 1 }, { 1, 0, 18446744073709551614, 18446744073709551613 }], 0, 2, [{ 1, 0, 10 }, { 2, 1, 20 }, { 3, 1, 30 }] }
+[debug] (&) { 0, [{ 2, 9223372036854775808, 18446744073709551615, 1 }, { 4, 0, 18446744073709551614, 18446744073709551613 }], 0, 2, [{ 1, 0, 10 }, { 2, 1, 20 }, { 3, 1, 30 }] }
+[debug] (&) { 0, [{ 2, 9223372036854775808, 18446744073709551615, 1 }, { 1, 0, 18446744073709551614, 18446744073709551613 }], 0, 2, [{ 1, 0, 10 }, { 2, 1, 20 }, { 0, 1, 30 }] }
+[debug] (&) { 0, [{ 2, 9223372036854775808, 18446744073709551615, 1 }, { 1, 0, 18446744073709551614, 9223372036854775808 }], 0, 1, [{ 1, 0, 10 }, { 2, 1, 20 }, { 3, 1, 30 }] }
+[debug] (&) { 0, [{ 2, 9223372036854775808, 18446744073709551615, 1 }, { 1, 1, 18446744073709551614, 18446744073709551613 }], 2, 0, [{ 1, 0, 10 }, { 2, 1, 20 }, { 3, 1, 30 }] }
+[debug] (&) { 18446744073709551615, [], 0, 0, [{ 7, 9223372036854775808, 70 }] }
+[debug] (&) { 9223372036854775808, [], 9223372036854775808, 9223372036854775808, [] }`
+
+func TestCritbitTree(t *testing.T) {
+	trees, err := verifier.ParseMoveTestCritbitTrees(critbitTestData)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	expected := [][]verifier.ViolationKind{
+		nil,
+		{verifier.ViolationKind_MaskNotDecreasing, verifier.ViolationKind_KeyPathMismatch},
+		{verifier.ViolationKind_KeyPathMismatch, verifier.ViolationKind_KeyPathMismatch},
+		{verifier.ViolationKind_MissingChild, verifier.ViolationKind_Unreachable},
+		{verifier.ViolationKind_ParentMismatch},
+		nil,
+		nil,
+	}
+	if len(trees) != len(expected) {
+		t.Fatalf("expecting %d trees, got %d", len(expected), len(trees))
+	}
+
+	for i, tree := range trees {
+		violations := tree.VerifyAll()
+		if len(violations) != len(expected[i]) {
+			t.Errorf("tree #%d: expecting %v, got: %v", i, expected[i], violations)
+			continue
+		}
+		for j, kind := range expected[i] {
+			if violations[j].Kind != kind {
+				t.Errorf("tree #%d: expecting %s, got: %v", i, kind, violations[j])
+			}
+		}
+	}
+
+	if trees[0].Entries[2] != (verifier.CritbitDataNode{Key: u(3), Parent: 1, Value: u(30)}) {
+		t.Errorf("entry is not parsed: %#v", trees[0].Entries[2])
+	}
+
+	var out bytes.Buffer
+	trees[0].Print(&out)
+	printed := strings.Join([]string{
+		"┌──── {k:  1, d:  0}",
+		"┼ {bit:  1, i:  0}",
+		"│   ┌──── {k:  2, d:  1}",
+		"└───┼ {bit:  0, i:  1}",
+		"    └──── {k:  3, d:  2}",
+		"",
+	}, "\n")
+	if out.String() != printed {
+		t.Errorf("expecting:\n%s\ngot:\n%s", printed, out.String())
+	}
+}
@@ -0,0 +1,248 @@
+package verifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MetadataString decodes the metadata of the node at index: the balance for avl trees, and the color for red black trees.
+func (tree *Tree) MetadataString(index uint64) string {
+	if !tree.IsValidIndex(index) {
+		return ""
+	}
+
+	metadata := tree.Entries[index].Metadata
+	switch tree.Type {
+	case TreeType_Avl:
+		return fmt.Sprintf("%+d", int(metadata)-128)
+	case TreeType_RedBlack:
+		switch RedBlackTreeColor(metadata) {
+		case RedBlackTreeColor_Red:
+			return "red"
+		case RedBlackTreeColor_Black:
+			return "black"
+		}
+	case TreeType_Vanilla:
+	default:
+	}
+
+	return fmt.Sprintf("%d", metadata)
+}
+
+// violationsByIndex groups the violations by the index of the node. Violations at invalid indices,
+// such as a min index out of range, are returned separately, since there is no node to attach them to.
+func (tree *Tree) violationsByIndex(violations Violations) (map[uint64]Violations, Violations) {
+	byIndex := make(map[uint64]Violations)
+	var others Violations
+	for _, v := range violations {
+		if tree.IsValidIndex(v.Index) {
+			byIndex[v.Index] = append(byIndex[v.Index], v)
+		} else {
+			others = append(others, v)
+		}
+	}
+
+	return byIndex, others
+}
+
+// TreeNodeJSON is a node of the tree in WriteJSON.
+type TreeNodeJSON struct {
+	Index uint64 `json:"index"`
+	Key   U256   `json:"key"`
+	// MoreKeys are the keys after Key if the tree has more than one key.
+	MoreKeys []U256 `json:"more_keys,omitempty"`
+	Value    U256   `json:"value"`
+	// Parent, Left and Right are null if there is no such node.
+	Parent   *uint64 `json:"parent"`
+	Left     *uint64 `json:"left"`
+	Right    *uint64 `json:"right"`
+	Metadata uint8   `json:"metadata"`
+	// AvlBalance is decoded from the metadata of avl trees, -1 is left high and +1 is right high.
+	AvlBalance *int `json:"avl_balance,omitempty"`
+	// Color is decoded from the metadata of red black trees, red or black.
+	Color  string `json:"color,omitempty"`
+	Height int    `json:"height"`
+	// Size is the recorded size of the subtree, only set for the trees generated with size.
+	Size *uint64 `json:"size,omitempty"`
+	// BlackHeight is only set for red black trees.
+	BlackHeight *int `json:"black_height,omitempty"`
+	// Violations are the kinds of the violations at the node.
+	Violations []string `json:"violations,omitempty"`
+}
+
+// ViolationJSON is a violation in WriteJSON.
+type ViolationJSON struct {
+	Kind     string `json:"kind"`
+	Index    uint64 `json:"index"`
+	Key      U256   `json:"key"`
+	MoreKeys []U256 `json:"more_keys,omitempty"`
+	Detail   string `json:"detail"`
+}
+
+// TreeJSON is the tree written by WriteJSON.
+type TreeJSON struct {
+	Type string `json:"type"`
+	// Root is the root found from the entries, null if the tree is empty.
+	Root *uint64 `json:"root"`
+	// MinIndex and MaxIndex are omitted if the tree fields are unknown.
+	MinIndex   *uint64         `json:"min_index,omitempty"`
+	MaxIndex   *uint64         `json:"max_index,omitempty"`
+	Nodes      []TreeNodeJSON  `json:"nodes"`
+	Violations []ViolationJSON `json:"violations"`
+}
+
+// indexToJSON converts NULL_INDEX to null.
+func indexToJSON(i uint64) *uint64 {
+	if i == NULL_INDEX {
+		return nil
+	}
+
+	return &i
+}
+
+// ToJSON converts the tree into TreeJSON, with the violations found by VerifyAll.
+func (tree *Tree) ToJSON() *TreeJSON {
+	violations := tree.VerifyAll()
+	byIndex, _ := tree.violationsByIndex(violations)
+
+	r := &TreeJSON{
+		Type:       tree.Type.String(),
+		Nodes:      make([]TreeNodeJSON, 0, len(tree.Entries)),
+		Violations: make([]ViolationJSON, 0, len(violations)),
+	}
+	if len(tree.Entries) > 0 {
+		r.Root = indexToJSON(uint64(tree.Root))
+	}
+	if tree.Fields != nil {
+		r.MinIndex = indexToJSON(tree.Fields.MinIndex)
+		r.MaxIndex = indexToJSON(tree.Fields.MaxIndex)
+	}
+
+	for i, node := range tree.Entries {
+		index := uint64(i)
+		n := TreeNodeJSON{
+			Index:    index,
+			Key:      node.Key,
+			MoreKeys: node.MoreKeys,
+			Value:    node.Value,
+			Parent:   indexToJSON(node.Parent),
+			Left:     indexToJSON(node.LeftChild),
+			Right:    indexToJSON(node.RightChild),
+			Metadata: node.Metadata,
+			Height:   node.Height,
+		}
+		if node.HasSize {
+			size := node.Size
+			n.Size = &size
+		}
+		switch tree.Type {
+		case TreeType_Avl:
+			balance := int(node.Metadata) - 128
+			n.AvlBalance = &balance
+		case TreeType_RedBlack:
+			n.Color = tree.MetadataString(index)
+			blackHeight := node.BlackHeight
+			n.BlackHeight = &blackHeight
+		case TreeType_Vanilla:
+		default:
+		}
+		for _, v := range byIndex[index] {
+			n.Violations = append(n.Violations, v.Kind.String())
+		}
+		r.Nodes = append(r.Nodes, n)
+	}
+
+	for _, v := range violations {
+		r.Violations = append(r.Violations, ViolationJSON{Kind: v.Kind.String(), Index: v.Index, Key: v.Key, MoreKeys: v.MoreKeys, Detail: v.Detail})
+	}
+
+	return r
+}
+
+// WriteJSON writes the tree as indented json, see TreeJSON.
+func (tree *Tree) WriteJSON(out io.Writer) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(tree.ToJSON())
+}
+
+// dotEscape escapes the text for a quoted string in dot.
+func dotEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(text)
+}
+
+// WriteDOT writes the tree in the dot language of graphviz. Every entry is drawn, including the unreachable ones,
+// with an edge to each of its children. An empty point stands for a missing left or right child, so the side of the children is kept.
+// Red black trees are filled with the colors of the nodes, and the nodes with violations found by VerifyAll are drawn with thick orange borders,
+// with the violations in the tooltips. The violations not on any node are in the label of the graph.
+func (tree *Tree) WriteDOT(out io.Writer) error {
+	byIndex, others := tree.violationsByIndex(tree.VerifyAll())
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n", tree.Type)
+	b.WriteString("  node [shape=record, fontname=\"monospace\"];\n")
+	if len(others) > 0 {
+		msgs := make([]string, 0, len(others))
+		for _, v := range others {
+			msgs = append(msgs, v.Error())
+		}
+		fmt.Fprintf(&b, "  label=\"%s\";\n  labelloc=t;\n  fontcolor=orange;\n", dotEscape(strings.Join(msgs, "\n")))
+	}
+
+	for i, node := range tree.Entries {
+		index := uint64(i)
+		label := fmt.Sprintf("{i: %d|k: %s|v: %d|m: %s|h: %d", index, dotEscape(formatKeys(node.Key, node.MoreKeys)), node.Value, tree.MetadataString(index), node.Height)
+		if tree.Type == TreeType_RedBlack {
+			label += fmt.Sprintf(" bh: %d", node.BlackHeight)
+		}
+		if node.HasSize {
+			label += fmt.Sprintf("|s: %d", node.Size)
+		}
+		label += "}"
+
+		attrs := []string{fmt.Sprintf("label=\"%s\"", label)}
+		if tree.Type == TreeType_RedBlack {
+			switch RedBlackTreeColor(node.Metadata) {
+			case RedBlackTreeColor_Red:
+				attrs = append(attrs, "style=filled", "fillcolor=red", "fontcolor=white")
+			case RedBlackTreeColor_Black:
+				attrs = append(attrs, "style=filled", "fillcolor=black", "fontcolor=white")
+			}
+		}
+		if violations, ok := byIndex[index]; ok {
+			attrs = append(attrs, "color=orange", "penwidth=4", fmt.Sprintf("tooltip=\"%s\"", dotEscape(violations.Error())))
+		}
+		fmt.Fprintf(&b, "  n%d [%s];\n", index, strings.Join(attrs, ", "))
+	}
+
+	for i, node := range tree.Entries {
+		if node.LeftChild == NULL_INDEX && node.RightChild == NULL_INDEX {
+			continue
+		}
+		for _, child := range []struct {
+			name  string
+			index uint64
+		}{
+			{name: "l", index: node.LeftChild},
+			{name: "r", index: node.RightChild},
+		} {
+			switch {
+			case child.index == NULL_INDEX:
+				fmt.Fprintf(&b, "  n%d%s [shape=point, style=invis];\n  n%d -> n%d%s [style=invis];\n", i, child.name, i, i, child.name)
+			case !tree.IsValidIndex(child.index):
+				fmt.Fprintf(&b, "  n%d%s [shape=plaintext, fontcolor=orange, label=\"invalid %d\"];\n  n%d -> n%d%s [color=orange];\n", i, child.name, child.index, i, i, child.name)
+			default:
+				fmt.Fprintf(&b, "  n%d -> n%d;\n", i, child.index)
+			}
+		}
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(out, b.String())
+
+	return err
+}
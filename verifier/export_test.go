@@ -0,0 +1,120 @@
+package verifier_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTreePrint(t *testing.T) {
+	tree := verifier.NewTree([]verifier.Entry{entry(2, 2, null, 1, 2, 129), entry(1, 1, 0, null, null, 128), entry(3, 3, 0, null, null, 128)}, verifier.TreeType_RedBlack)
+
+	var out bytes.Buffer
+	tree.Print(&out)
+	printed := strings.Join([]string{
+		"┌──── {k:  1, i:  1, m: Red}",
+		"┼ {k:  2, i:  0, m: Blk}",
+		"└──── {k:  3, i:  2, m: Red}",
+		"",
+	}, "\n")
+	if out.String() != printed {
+		t.Errorf("expecting:\n%s\ngot:\n%s", printed, out.String())
+	}
+}
+
+func TestTreeWriteJSON(t *testing.T) {
+	// the left child 1 is red with a red child 3.
+	tree := verifier.NewTreeWithFields(
+		[]verifier.Entry{entry(2, 20, null, 1, 2, 129), entry(1, 10, 0, 3, null, 128), entry(3, 30, 0, null, null, 128), entry(0, 0, 1, null, null, 128)},
+		verifier.TreeFields{Root: 0, MinIndex: 3, MaxIndex: 2},
+		verifier.TreeType_RedBlack,
+	)
+
+	var out bytes.Buffer
+	if err := tree.WriteJSON(&out); err != nil {
+		t.Fatalf("failed to write json: %v", err)
+	}
+
+	var got verifier.TreeJSON
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("failed to read back json: %v\n%s", err, out.String())
+	}
+
+	index := func(i uint64) *uint64 { return &i }
+	height := func(h int) *int { return &h }
+	expected := verifier.TreeJSON{
+		Type:     "RedBlack",
+		Root:     index(0),
+		MinIndex: index(3),
+		MaxIndex: index(2),
+		Nodes: []verifier.TreeNodeJSON{
+			{Index: 0, Key: u(2), Value: u(20), Left: index(1), Right: index(2), Metadata: 129, Color: "black", Height: 3, BlackHeight: height(1)},
+			{Index: 1, Key: u(1), Value: u(10), Parent: index(0), Left: index(3), Metadata: 128, Color: "red", Height: 2, BlackHeight: height(0), Violations: []string{"red node with red child"}},
+			{Index: 2, Key: u(3), Value: u(30), Parent: index(0), Metadata: 128, Color: "red", Height: 1, BlackHeight: height(0)},
+			{Index: 3, Key: u(0), Value: u(0), Parent: index(1), Metadata: 128, Color: "red", Height: 1, BlackHeight: height(0)},
+		},
+		Violations: []verifier.ViolationJSON{
+			{Kind: "red node with red child", Index: 1, Key: u(1), Detail: "red node {k:  1, i:  1, m: Red} has red child"},
+		},
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("json differs (-expected +got):\n%s", diff)
+	}
+
+	avl := verifier.NewTree([]verifier.Entry{entry(1, 1, null, null, 1, 129), entry(2, 2, 0, null, null, 128)}, verifier.TreeType_Avl).ToJSON()
+	if avl.Nodes[0].AvlBalance == nil || *avl.Nodes[0].AvlBalance != 1 || avl.Nodes[0].BlackHeight != nil || avl.MinIndex != nil {
+		t.Errorf("unexpected avl node: %#v", avl.Nodes[0])
+	}
+
+	sized := entry(1, 1, null, null, null, 0)
+	sized.Size, sized.HasSize = 2, true
+	bst := verifier.NewTree([]verifier.Entry{sized}, verifier.TreeType_Vanilla).ToJSON()
+	if bst.Nodes[0].Size == nil || *bst.Nodes[0].Size != 2 || len(bst.Violations) != 1 || bst.Violations[0].Kind != "subtree size mismatch" {
+		t.Errorf("unexpected tree with size: %#v", bst)
+	}
+}
+
+func TestTreeWriteDOT(t *testing.T) {
+	tree := verifier.NewTreeWithFields(
+		[]verifier.Entry{entry(2, 2, null, 1, 5, 130), entry(1, 1, 0, null, null, 128)},
+		verifier.TreeFields{Root: 0, MinIndex: 1, MaxIndex: 7},
+		verifier.TreeType_Avl,
+	)
+
+	var out bytes.Buffer
+	if err := tree.WriteDOT(&out); err != nil {
+		t.Fatalf("failed to write dot: %v", err)
+	}
+	dot := out.String()
+
+	for _, expected := range []string{
+		"digraph AVL {\n",
+		`  n0 [label="{i: 0|k: 2|v: 2|m: +2|h: 2}", color=orange, penwidth=4, tooltip="invalid child index at index 0 (key 2): right child 5 of {k:  2, i:  0, m: 130: avl: 127} is out of range"];`,
+		`  n1 [label="{i: 1|k: 1|v: 1|m: +0|h: 1}"];`,
+		"  n0 -> n1;\n",
+		`  n0r [shape=plaintext, fontcolor=orange, label="invalid 5"];`,
+		"  n0 -> n0r [color=orange];\n",
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("missing %q in:\n%s", expected, dot)
+		}
+	}
+
+	// the structure is broken, so the min and max are not checked, and there is no label for the graph.
+	if strings.Contains(dot, "label=\"max") {
+		t.Errorf("unexpected graph label:\n%s", dot)
+	}
+
+	empty := verifier.NewTreeWithFields(nil, verifier.TreeFields{Root: null, MinIndex: 0, MaxIndex: null}, verifier.TreeType_Vanilla)
+	out.Reset()
+	if err := empty.WriteDOT(&out); err != nil {
+		t.Fatalf("failed to write dot: %v", err)
+	}
+	if !strings.Contains(out.String(), `label="min index mismatch at index 0 (key 0): min index is  0, expecting XX";`) {
+		t.Errorf("missing the violation in the graph label:\n%s", out.String())
+	}
+}
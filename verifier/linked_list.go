@@ -0,0 +1,227 @@
+package verifier
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// LinkedListNode is the node of the linked list.
+type LinkedListNode struct {
+	Value uint64
+	Prev  uint64
+	Next  uint64
+}
+
+// LinkedListFields are the fields of the move linked list besides the entries.
+type LinkedListFields struct {
+	Head uint64
+	Tail uint64
+}
+
+// LinkedList mirrors the LinkedList of move.
+type LinkedList struct {
+	Entries []LinkedListNode
+	// Fields are the head and tail of the list, nil if only the entries are known.
+	Fields *LinkedListFields
+}
+
+var (
+	linkedListMatch      = regexp.MustCompile(`^\[debug\] \(&\) { (\d+), (\d+), \[(.*)\] }$`)
+	linkedListNodesMatch = regexp.MustCompile(`^\[debug\] \(&\) \[(.*)\]$`)
+)
+
+// ParseLinkedList parses a linked list printed by move test,
+// either the whole list (head, tail, and entries), or only the vector of the nodes.
+func ParseLinkedList(line string) (*LinkedList, error) {
+	r := &LinkedList{}
+	var entriesText string
+	if matched := linkedListMatch.FindStringSubmatch(line); len(matched) == 4 {
+		values, err := parseUints(matched[1]+", "+matched[2], "linked list", "head", "tail")
+		if err != nil {
+			return nil, err
+		}
+		r.Fields = &LinkedListFields{Head: values[0], Tail: values[1]}
+		entriesText = matched[3]
+	} else if matched := linkedListNodesMatch.FindStringSubmatch(line); len(matched) == 2 {
+		entriesText = matched[1]
+	} else {
+		return nil, fmt.Errorf("%s is not a linked list", line)
+	}
+
+	for _, text := range innerMatch.FindAllStringSubmatch(entriesText, -1) {
+		values, err := parseUints(text[1], "node", "value", "prev", "next")
+		if err != nil {
+			return nil, err
+		}
+		r.Entries = append(r.Entries, LinkedListNode{Value: values[0], Prev: values[1], Next: values[2]})
+	}
+
+	return r, nil
+}
+
+// ParseMoveTestLinkedLists parses all the linked lists printed in the move test output.
+func ParseMoveTestLinkedLists(text string) ([]*LinkedList, error) {
+	var result []*LinkedList
+	for _, aLine := range strings.Split(text, "\n") {
+		if !linkedListMatch.MatchString(aLine) && !linkedListNodesMatch.MatchString(aLine) {
+			continue
+		}
+		list, err := ParseLinkedList(aLine)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, list)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("cannot find any linked list in:\n%s", text)
+	}
+
+	return result, nil
+}
+
+// IsValidIndex checks the index refers to a node of the list.
+func (list *LinkedList) IsValidIndex(index uint64) bool {
+	return index < uint64(len(list.Entries))
+}
+
+// Head is the head of the list in the fields, or the first node without prev if the fields are unknown.
+func (list *LinkedList) Head() uint64 {
+	if list.Fields != nil {
+		return list.Fields.Head
+	}
+	for i, node := range list.Entries {
+		if node.Prev == NULL_INDEX {
+			return uint64(i)
+		}
+	}
+
+	return NULL_INDEX
+}
+
+// Tail is the tail of the list in the fields, or the first node without next if the fields are unknown.
+func (list *LinkedList) Tail() uint64 {
+	if list.Fields != nil {
+		return list.Fields.Tail
+	}
+	for i, node := range list.Entries {
+		if node.Next == NULL_INDEX {
+			return uint64(i)
+		}
+	}
+
+	return NULL_INDEX
+}
+
+func (list *LinkedList) newViolation(kind ViolationKind, index uint64, format string, args ...any) *Violation {
+	return &Violation{
+		Kind:   kind,
+		Index:  index,
+		Detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// NodeToString formats the node at index.
+func (list *LinkedList) NodeToString(index uint64) string {
+	if !list.IsValidIndex(index) {
+		return "(invalid index)"
+	}
+
+	node := list.Entries[index]
+
+	return fmt.Sprintf("{v: %s, i: %s, p: %s, n: %s}", ItoS(node.Value), ItoS(index), ItoS(node.Prev), ItoS(node.Next))
+}
+
+// VerifyAll checks the linked list:
+//   - prev and next are valid indices, and each node is the prev of its next.
+//   - exactly one node has no prev, which is the head, and exactly one node has no next, which is the tail.
+//   - every node is reached exactly once following next from the head, so there is no cycle.
+func (list *LinkedList) VerifyAll() Violations {
+	var r Violations
+
+	var heads, tails []uint64
+	for i, node := range list.Entries {
+		index := uint64(i)
+		for _, link := range []struct {
+			name  string
+			index uint64
+		}{
+			{name: "prev", index: node.Prev},
+			{name: "next", index: node.Next},
+		} {
+			if link.index != NULL_INDEX && !list.IsValidIndex(link.index) {
+				r = append(r, list.newViolation(ViolationKind_InvalidLink, index, "%s %d of %s is out of range", link.name, link.index, list.NodeToString(index)))
+			}
+		}
+
+		if node.Prev == NULL_INDEX {
+			heads = append(heads, index)
+		}
+		if node.Next == NULL_INDEX {
+			tails = append(tails, index)
+		} else if list.IsValidIndex(node.Next) && list.Entries[node.Next].Prev != index {
+			r = append(r, list.newViolation(ViolationKind_LinkMismatch, index, "next %s of %s doesnt point back", list.NodeToString(node.Next), list.NodeToString(index)))
+		}
+		if list.IsValidIndex(node.Prev) && list.Entries[node.Prev].Next != index {
+			r = append(r, list.newViolation(ViolationKind_LinkMismatch, index, "prev %s of %s doesnt point back", list.NodeToString(node.Prev), list.NodeToString(index)))
+		}
+	}
+
+	head, tail := list.Head(), list.Tail()
+	switch {
+	case len(list.Entries) == 0 && head != NULL_INDEX:
+		r = append(r, list.newViolation(ViolationKind_HeadMismatch, head, "head of empty list is %s", ItoS(head)))
+	case len(list.Entries) == 0:
+	case len(heads) != 1:
+		r = append(r, list.newViolation(ViolationKind_HeadMismatch, head, "expecting 1 node without prev, got %d: %v", len(heads), heads))
+	case heads[0] != head:
+		r = append(r, list.newViolation(ViolationKind_HeadMismatch, head, "head is %s, expecting %s", ItoS(head), ItoS(heads[0])))
+	}
+	switch {
+	case len(list.Entries) == 0 && tail != NULL_INDEX:
+		r = append(r, list.newViolation(ViolationKind_TailMismatch, tail, "tail of empty list is %s", ItoS(tail)))
+	case len(list.Entries) == 0:
+	case len(tails) != 1:
+		r = append(r, list.newViolation(ViolationKind_TailMismatch, tail, "expecting 1 node without next, got %d: %v", len(tails), tails))
+	case tails[0] != tail:
+		r = append(r, list.newViolation(ViolationKind_TailMismatch, tail, "tail is %s, expecting %s", ItoS(tail), ItoS(tails[0])))
+	}
+
+	visited := make(map[uint64]bool)
+	list.Visit(func(node *LinkedListNode, index uint64) bool {
+		visited[index] = true
+		if visited[node.Next] {
+			r = append(r, list.newViolation(ViolationKind_Cycle, node.Next, "%s is reached again from %s", list.NodeToString(node.Next), list.NodeToString(index)))
+		}
+		return true
+	})
+
+	for i := range list.Entries {
+		if !visited[uint64(i)] {
+			r = append(r, list.newViolation(ViolationKind_Unreachable, uint64(i), "%s is not reachable from the head", list.NodeToString(uint64(i))))
+		}
+	}
+
+	return r
+}
+
+// Visit visits the nodes in order from the head. Each node is visited at most once, even if the links form a cycle.
+func (list *LinkedList) Visit(visitor func(node *LinkedListNode, index uint64) bool) {
+	visited := make(map[uint64]bool)
+	for index := list.Head(); list.IsValidIndex(index) && !visited[index]; index = list.Entries[index].Next {
+		visited[index] = true
+		if !visitor(&list.Entries[index], index) {
+			return
+		}
+	}
+}
+
+// Print prints the nodes in order from the head, one on each line.
+func (list *LinkedList) Print(out io.Writer) {
+	list.Visit(func(node *LinkedListNode, index uint64) bool {
+		fmt.Fprintln(out, list.NodeToString(index))
+		return true
+	})
+}
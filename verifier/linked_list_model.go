@@ -0,0 +1,133 @@
+package verifier
+
+import "fmt"
+
+// NewLinkedList creates an empty linked list.
+// The modifiers on the list follow the move code of linked_list.move.template (vector backend, no stable index) step by step,
+// so the entries, head and tail are exactly what the move list holds after the same operations.
+// The modifiers need the Fields, so they cannot be used on a list parsed without head and tail.
+func NewLinkedList() *LinkedList {
+	return &LinkedList{
+		Fields: &LinkedListFields{
+			Head: NULL_INDEX,
+			Tail: NULL_INDEX,
+		},
+	}
+}
+
+// Size is the number of the nodes.
+func (list *LinkedList) Size() uint64 {
+	return uint64(len(list.Entries))
+}
+
+// Insert adds the value at the end of the list, and returns the index of the new node.
+func (list *LinkedList) Insert(value uint64) (uint64, error) {
+	return list.InsertAfter(list.Fields.Tail, value)
+}
+
+// InsertAfter adds the value after the node at index, and returns the index of the new node.
+// index can be NULL_INDEX if the list is empty.
+func (list *LinkedList) InsertAfter(index uint64, value uint64) (uint64, error) {
+	newIndex := list.Size()
+	if newIndex >= NULL_INDEX-1 {
+		return NULL_INDEX, fmt.Errorf("list exceeds the capacity")
+	}
+
+	node := LinkedListNode{Value: value, Prev: index, Next: NULL_INDEX}
+
+	if newIndex == 0 && index == NULL_INDEX {
+		list.Fields.Head = newIndex
+		list.Fields.Tail = newIndex
+		list.Entries = append(list.Entries, node)
+		return newIndex, nil
+	}
+
+	if index == NULL_INDEX || index >= newIndex {
+		return NULL_INDEX, fmt.Errorf("index %s is out of range, size is %d", ItoS(index), newIndex)
+	}
+
+	prev := &list.Entries[index]
+	node.Next = prev.Next
+	prev.Next = newIndex
+	if node.Next != NULL_INDEX {
+		list.Entries[node.Next].Prev = newIndex
+	} else {
+		list.Fields.Tail = newIndex
+	}
+
+	list.Entries = append(list.Entries, node)
+
+	return newIndex, nil
+}
+
+// InsertBefore adds the value before the node at index, and returns the index of the new node.
+// index can be NULL_INDEX if the list is empty.
+func (list *LinkedList) InsertBefore(index uint64, value uint64) (uint64, error) {
+	newIndex := list.Size()
+	if newIndex >= NULL_INDEX-1 {
+		return NULL_INDEX, fmt.Errorf("list exceeds the capacity")
+	}
+
+	node := LinkedListNode{Value: value, Prev: NULL_INDEX, Next: index}
+
+	if newIndex == 0 && index == NULL_INDEX {
+		list.Fields.Head = newIndex
+		list.Fields.Tail = newIndex
+		list.Entries = append(list.Entries, node)
+		return newIndex, nil
+	}
+
+	if index == NULL_INDEX || index >= newIndex {
+		return NULL_INDEX, fmt.Errorf("index %s is out of range, size is %d", ItoS(index), newIndex)
+	}
+
+	next := &list.Entries[index]
+	node.Prev = next.Prev
+	next.Prev = newIndex
+	if node.Prev != NULL_INDEX {
+		list.Entries[node.Prev].Next = newIndex
+	} else {
+		list.Fields.Head = newIndex
+	}
+
+	list.Entries = append(list.Entries, node)
+
+	return newIndex, nil
+}
+
+// Remove deletes the node at index and returns it. The last node is moved into index.
+func (list *LinkedList) Remove(index uint64) (*LinkedListNode, error) {
+	if !list.IsValidIndex(index) {
+		return nil, fmt.Errorf("index %s is out of range, size is %d", ItoS(index), list.Size())
+	}
+
+	toRemove := list.Entries[index]
+	list.relink(toRemove.Prev, toRemove.Next, toRemove.Next, toRemove.Prev)
+
+	tailIndex := list.Size() - 1
+	if index != tailIndex {
+		list.Entries[index], list.Entries[tailIndex] = list.Entries[tailIndex], list.Entries[index]
+		swapped := list.Entries[index]
+		list.relink(swapped.Prev, index, swapped.Next, index)
+	}
+
+	removed := list.Entries[tailIndex]
+	list.Entries = list.Entries[:tailIndex]
+
+	return &removed, nil
+}
+
+// relink points the next of prev to newNext, and the prev of next to newPrev.
+// The head (tail) is updated instead if prev (next) is NULL_INDEX.
+func (list *LinkedList) relink(prev, newNext, next, newPrev uint64) {
+	if prev != NULL_INDEX {
+		list.Entries[prev].Next = newNext
+	} else {
+		list.Fields.Head = newNext
+	}
+	if next != NULL_INDEX {
+		list.Entries[next].Prev = newPrev
+	} else {
+		list.Fields.Tail = newPrev
+	}
+}
@@ -0,0 +1,100 @@
+package verifier_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestLinkedListModel(t *testing.T) {
+	list := verifier.NewLinkedList()
+	for i := uint64(0); i < 4; i++ {
+		if _, err := list.Insert(i); err != nil {
+			t.Fatalf("failed to insert %d: %v", i, err)
+		}
+	}
+	if _, err := list.InsertBefore(0, 4); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	if _, err := list.Remove(1); err != nil {
+		t.Fatalf("failed to remove: %v", err)
+	}
+
+	// 4 (at 4) is moved into the slot of 1.
+	expected := &verifier.LinkedList{
+		Entries: []verifier.LinkedListNode{{0, 1, 2}, {4, null, 0}, {2, 0, 3}, {3, 2, null}},
+		Fields:  &verifier.LinkedListFields{Head: 1, Tail: 3},
+	}
+	if diff := cmp.Diff(expected, list); diff != "" {
+		t.Errorf("list differs (-expected +got):\n%s", diff)
+	}
+
+	if _, err := list.InsertAfter(4, 5); err == nil {
+		t.Errorf("inserting after an out of range index should fail")
+	}
+}
+
+func TestLinkedListModelRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	list := verifier.NewLinkedList()
+	var values []uint64
+
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(5); {
+		case len(values) > 0 && op < 2:
+			index := uint64(r.Intn(len(values)))
+			removed, err := list.Remove(index)
+			if err != nil {
+				t.Fatalf("op #%d: failed to remove: %v", i, err)
+			}
+			for j, v := range values {
+				if v == removed.Value {
+					values = append(values[:j], values[j+1:]...)
+					break
+				}
+			}
+		case len(values) > 0 && op < 4:
+			index := uint64(r.Intn(len(values)))
+			at := list.Entries[index].Value
+			value := uint64(i)
+			var err error
+			if op == 2 {
+				_, err = list.InsertBefore(index, value)
+			} else {
+				_, err = list.InsertAfter(index, value)
+			}
+			if err != nil {
+				t.Fatalf("op #%d: failed to insert: %v", i, err)
+			}
+			for j, v := range values {
+				if v == at {
+					if op == 3 {
+						j++
+					}
+					values = append(values[:j], append([]uint64{value}, values[j:]...)...)
+					break
+				}
+			}
+		default:
+			if _, err := list.Insert(uint64(i)); err != nil {
+				t.Fatalf("op #%d: failed to insert: %v", i, err)
+			}
+			values = append(values, uint64(i))
+		}
+
+		if err := list.VerifyAll().Err(); err != nil {
+			t.Fatalf("op #%d: %v", i, err)
+		}
+		var got []uint64
+		list.Visit(func(node *verifier.LinkedListNode, index uint64) bool {
+			got = append(got, node.Value)
+			return true
+		})
+		if diff := cmp.Diff(values, got, cmpopts.EquateEmpty()); diff != "" {
+			t.Fatalf("op #%d: values differ (-expected +got):\n%s", i, diff)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package verifier_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+)
+
+const linkedListTestData = `[debug] (&) { 2, 1, [{ 10, 2, 1 }, { 20, 0, 18446744073709551615 }, { 30, 18446744073709551615, 0 }] }
+[debug] (&) [{ 10, 2, 1 }, { 20, 0, 18446744073709551615 }, { 30, 18446744073709551615, 0 }]
+[debug] (&) { 0, 1, [{ 10, 2, 1 }, { 20, 0, 18446744073709551615 }, { 30, 18446744073709551615, 0 }] }
+[debug] (&) { 2, 1, [{ 10, 2, 1 }, { 20, 2, 18446744073709551615 }, { 30, 18446744073709551615, 0 }] }
+[debug] (&) { 0, 1, [{ 10, 18446744073709551615, 1 }, { 20, 0, 18446744073709551615 }, { 30, 2, 2 }] }
+[debug] (&) { 18446744073709551615, 18446744073709551615, [] }`
+
+func TestLinkedList(t *testing.T) {
+	lists, err := verifier.ParseMoveTestLinkedLists(linkedListTestData)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	expected := [][]verifier.ViolationKind{
+		nil,
+		nil,
+		{verifier.ViolationKind_HeadMismatch, verifier.ViolationKind_Unreachable},
+		{verifier.ViolationKind_LinkMismatch, verifier.ViolationKind_LinkMismatch},
+		{verifier.ViolationKind_Unreachable},
+		nil,
+	}
+	if len(lists) != len(expected) {
+		t.Fatalf("expecting %d lists, got %d", len(expected), len(lists))
+	}
+
+	for i, list := range lists {
+		violations := list.VerifyAll()
+		if len(violations) != len(expected[i]) {
+			t.Errorf("list #%d: expecting %v, got: %v", i, expected[i], violations)
+			continue
+		}
+		for j, kind := range expected[i] {
+			if violations[j].Kind != kind {
+				t.Errorf("list #%d: expecting %s, got: %v", i, kind, violations[j])
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	lists[1].Print(&out)
+	printed := "{v: 30, i:  2, p: XX, n:  0}\n{v: 10, i:  0, p:  2, n:  1}\n{v: 20, i:  1, p:  0, n: XX}\n"
+	if out.String() != printed {
+		t.Errorf("expecting:\n%s\ngot:\n%s", printed, out.String())
+	}
+}
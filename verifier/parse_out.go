@@ -1,6 +1,7 @@
 package verifier
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -9,89 +10,292 @@ import (
 
 var (
 	outerMatch = regexp.MustCompile(`^\[debug\] \(&\) \[(.*)\]$`)
+	// treeMatch matches the whole tree printed, which is root, entries, min_index and max_index.
+	treeMatch = regexp.MustCompile(`^\[debug\] \(&\) { (\d+), \[(.*)\], (\d+), (\d+) }$`)
+	// innerMatch matches the nodes of critbit trees and linked lists, which are flat structs.
 	innerMatch = regexp.MustCompile(`{ ([^{]+) }`)
 )
 
-func ParseEntry(text string) (*Entry, error) {
-	trimmed := strings.Split(strings.Trim(text, " {}"), ", ")
-	if len(trimmed) < 5 {
-		return nil, fmt.Errorf("%s is missing data", text)
+// TreeDump is a tree printed in the move test output, either the entries vector, or the whole tree.
+type TreeDump struct {
+	Entries []Entry
+	// Fields is nil if only the entries are printed.
+	Fields *TreeFields
+}
+
+// NewTree creates the tree from the dump, with the tree fields if they are printed.
+func (dump *TreeDump) NewTree(treeType TreeType) *Tree {
+	if dump.Fields == nil {
+		return NewTree(dump.Entries, treeType)
 	}
 
-	r := &Entry{}
+	return NewTreeWithFields(dump.Entries, *dump.Fields, treeType)
+}
 
-	key, err := strconv.ParseUint(trimmed[0], 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse key %s: %w", trimmed[0], err)
+// ErrAmbiguousTreeType is returned by TreeDump.DetectTreeType if the tree can be either red black or avl.
+var ErrAmbiguousTreeType = errors.New("ambiguous tree type")
+
+// DetectTreeType guesses the type of the tree from the metadata of the entries.
+// The tree is vanilla if no entry has metadata, and avl if any metadata is neither red nor black.
+// Red and black are also the balanced and right high factors of avl, so a tree with only red and black metadata
+// is red black only if it is valid as both, otherwise the type is ambiguous and an error is returned,
+// since picking either type could hide the violations of the other.
+func (dump *TreeDump) DetectTreeType() (TreeType, error) {
+	hasMetadata := false
+	for _, entry := range dump.Entries {
+		switch RedBlackTreeColor(entry.Metadata) {
+		case 0:
+			continue
+		case RedBlackTreeColor_Red, RedBlackTreeColor_Black:
+			hasMetadata = true
+		default:
+			return TreeType_Avl, nil
+		}
+	}
+	if !hasMetadata {
+		return TreeType_Vanilla, nil
 	}
-	r.Key = key
 
-	value, err := strconv.ParseUint(trimmed[1], 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse value %s: %w", trimmed[1], err)
+	redBlack := len(dump.NewTree(TreeType_RedBlack).VerifyAll())
+	avl := len(dump.NewTree(TreeType_Avl).VerifyAll())
+	if redBlack > 0 || avl > 0 {
+		return TreeType_Vanilla, fmt.Errorf("%w, the metadata fits both red black tree with %d violations and avl tree with %d violations", ErrAmbiguousTreeType, redBlack, avl)
 	}
-	r.Value = value
 
-	parent, err := strconv.ParseUint(trimmed[2], 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse parent %s: %w", trimmed[2], err)
+	return TreeType_RedBlack, nil
+}
+
+// ValueShape is how the value of the entries is printed.
+type ValueShape uint8
+
+const (
+	// ValueShape_Uint is an unsigned int value, which is parsed into Entry.Value.
+	ValueShape_Uint ValueShape = iota
+	// ValueShape_Opaque is any other value, such as a struct, a vector or a bool, which is skipped.
+	ValueShape_Opaque
+)
+
+// MetadataPresence is whether the entries have the metadata after the right child.
+type MetadataPresence uint8
+
+const (
+	// MetadataPresence_Auto detects the metadata from the number of fields in the entry.
+	MetadataPresence_Auto MetadataPresence = iota
+	MetadataPresence_Present
+	MetadataPresence_Absent
+)
+
+// EntryLayout is the fields of the entries printed by move test, which are the keys, the value, parent, left child and right child,
+// then the metadata for avl and red black trees, and the size of the subtree if the tree is generated with size.
+type EntryLayout struct {
+	// KeyCount is the number of the keys, which are ordered lexicographically. 0 is taken as 1.
+	KeyCount   int
+	ValueShape ValueShape
+	Metadata   MetadataPresence
+	WithSize   bool
+}
+
+// DefaultEntryLayout is the layout of the trees generated with the default settings: one key, and metadata if the tree has it.
+func DefaultEntryLayout() EntryLayout {
+	return EntryLayout{KeyCount: 1}
+}
+
+func (layout EntryLayout) keyCount() int {
+	if layout.KeyCount < 1 {
+		return 1
 	}
-	r.Parent = parent
 
-	left, err := strconv.ParseUint(trimmed[3], 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse left child %s: %w", trimmed[3], err)
+	return layout.KeyCount
+}
+
+// hasMetadata checks if an entry of fieldCount fields has metadata.
+func (layout EntryLayout) hasMetadata(fieldCount int) bool {
+	switch layout.Metadata {
+	case MetadataPresence_Present:
+		return true
+	case MetadataPresence_Absent:
+		return false
+	default:
+		withoutMetadata := layout.keyCount() + 4
+		if layout.WithSize {
+			withoutMetadata++
+		}
+		return fieldCount > withoutMetadata
 	}
-	r.LeftChild = left
+}
 
-	right, err := strconv.ParseUint(trimmed[4], 10, 64)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse right child %s: %w", trimmed[4], err)
+// splitTopLevel splits the text at the commas that are not inside braces or brackets, so a struct or vector field is kept whole.
+func splitTopLevel(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var r []string
+	depth := 0
+	start := 0
+	for i, c := range text {
+		switch c {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				r = append(r, strings.TrimSpace(text[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	return append(r, strings.TrimSpace(text[start:]))
+}
+
+// ParseEntry parses an entry of the default layout.
+func ParseEntry(text string) (*Entry, error) {
+	return ParseEntryWithLayout(text, DefaultEntryLayout())
+}
+
+// ParseEntryWithLayout parses an entry, with or without the braces around it.
+func ParseEntryWithLayout(text string, layout EntryLayout) (*Entry, error) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
+		trimmed = trimmed[1 : len(trimmed)-1]
+	}
+	fields := splitTopLevel(trimmed)
+
+	keyCount := layout.keyCount()
+	hasMetadata := layout.hasMetadata(len(fields))
+	expected := keyCount + 4
+	if hasMetadata {
+		expected++
+	}
+	if layout.WithSize {
+		expected++
+	}
+	if len(fields) != expected {
+		return nil, fmt.Errorf("%s has %d fields, expecting %d", text, len(fields), expected)
+	}
+
+	r := &Entry{}
+
+	for i, field := range fields[:keyCount] {
+		key, err := ParseU256(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", field, err)
+		}
+		if i == 0 {
+			r.Key = key
+		} else {
+			r.MoreKeys = append(r.MoreKeys, key)
+		}
+	}
+	fields = fields[keyCount:]
+
+	if layout.ValueShape == ValueShape_Uint {
+		value, err := ParseU256(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse value %s: %w", fields[0], err)
+		}
+		r.Value = value
+	}
+
+	for _, index := range []struct {
+		name  string
+		text  string
+		value *uint64
+	}{
+		{name: "parent", text: fields[1], value: &r.Parent},
+		{name: "left child", text: fields[2], value: &r.LeftChild},
+		{name: "right child", text: fields[3], value: &r.RightChild},
+	} {
+		v, err := strconv.ParseUint(index.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", index.name, index.text, err)
+		}
+		*index.value = v
 	}
-	r.RightChild = right
 
-	if len(trimmed) > 5 {
-		meta, err := strconv.ParseUint(trimmed[5], 10, 64)
+	if hasMetadata {
+		meta, err := strconv.ParseUint(fields[4], 10, 8)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse metadata %s: %w", trimmed[5], err)
+			return nil, fmt.Errorf("failed to parse metadata %s: %w", fields[4], err)
 		}
 
 		r.Metadata = uint8(meta)
 	}
 
+	if layout.WithSize {
+		size, err := strconv.ParseUint(fields[len(fields)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse size %s: %w", fields[len(fields)-1], err)
+		}
+
+		r.Size = size
+		r.HasSize = true
+	}
+
 	return r, nil
 }
 
+// ParseMoveTestOut parses the entries of the trees printed in the move test output.
 func ParseMoveTestOut(text string) ([][]Entry, error) {
+	dumps, err := ParseMoveTestDumps(text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([][]Entry, 0, len(dumps))
+	for _, dump := range dumps {
+		result = append(result, dump.Entries)
+	}
+
+	return result, nil
+}
+
+// ParseMoveTestDumps parses the trees of the default entry layout printed in the move test output.
+// A tree can be printed as the vector of its entries, or as a whole with its root, min_index and max_index.
+// Lines that are not trees are skipped.
+func ParseMoveTestDumps(text string) ([]TreeDump, error) {
+	return ParseMoveTestDumpsWithLayout(text, DefaultEntryLayout())
+}
+
+// ParseMoveTestDumpsWithLayout parses the trees printed in the move test output, whose entries are of the layout.
+func ParseMoveTestDumpsWithLayout(text string, layout EntryLayout) ([]TreeDump, error) {
 	lines := strings.Split(text, "\n")
-	var result [][]Entry
+	var result []TreeDump
 	for _, aLine := range lines {
-		matchedStrings := outerMatch.FindStringSubmatch(aLine)
-		if len(matchedStrings) == 0 {
-			fmt.Printf("failed to find outer match: %s\n", aLine)
-			continue
-		}
-		if len(matchedStrings) != 2 {
-			fmt.Printf("failed to find group in: %s\n", aLine)
+		var dump TreeDump
+		var entriesText string
+		if treeStrings := treeMatch.FindStringSubmatch(aLine); len(treeStrings) == 5 {
+			fields, err := parseTreeFields(treeStrings[1], treeStrings[3], treeStrings[4])
+			if err != nil {
+				return nil, err
+			}
+			dump.Fields = fields
+			entriesText = treeStrings[2]
+		} else {
+			matchedStrings := outerMatch.FindStringSubmatch(aLine)
+			if len(matchedStrings) != 2 {
+				continue
+			}
+			entriesText = matchedStrings[1]
 		}
 
-		entryTexts := innerMatch.FindAllStringSubmatch(matchedStrings[1], -1)
+		entryTexts := splitTopLevel(entriesText)
 
-		if len(entryTexts) == 0 {
-			fmt.Printf("no match for %s\n", matchedStrings[1])
+		if len(entryTexts) == 0 && dump.Fields == nil || !allStructs(entryTexts) {
 			continue
 		}
 
-		var thisLine []Entry
-
 		for _, anEntry := range entryTexts {
-			e, err := ParseEntry(anEntry[1])
+			e, err := ParseEntryWithLayout(anEntry, layout)
 			if err != nil {
 				return nil, err
 			}
-			thisLine = append(thisLine, *e)
+			dump.Entries = append(dump.Entries, *e)
 		}
-		result = append(result, thisLine)
+		result = append(result, dump)
 	}
 
 	if len(result) == 0 {
@@ -100,3 +304,35 @@ func ParseMoveTestOut(text string) ([][]Entry, error) {
 
 	return result, nil
 }
+
+// allStructs checks all the texts are structs, so a vector of other types is not taken as a tree.
+func allStructs(texts []string) bool {
+	for _, text := range texts {
+		if !strings.HasPrefix(text, "{") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseTreeFields(root, minIndex, maxIndex string) (*TreeFields, error) {
+	r := &TreeFields{}
+	for _, field := range []struct {
+		name  string
+		text  string
+		value *uint64
+	}{
+		{name: "root", text: root, value: &r.Root},
+		{name: "min index", text: minIndex, value: &r.MinIndex},
+		{name: "max index", text: maxIndex, value: &r.MaxIndex},
+	} {
+		v, err := strconv.ParseUint(field.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s %s: %w", field.name, field.text, err)
+		}
+		*field.value = v
+	}
+
+	return r, nil
+}
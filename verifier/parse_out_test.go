@@ -2,6 +2,7 @@ package verifier_test
 
 import (
 	_ "embed"
+	"errors"
 	"testing"
 
 	"github.com/fardream/gen-move-container/verifier"
@@ -18,26 +19,26 @@ func TestParseMoveTestOut(t *testing.T) {
 	}
 
 	expectedMany := [][]verifier.Entry{
-		{{18, 18, 1, 18, 19, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 16, 17, 128}, {12, 12, 7, 5, 1, 128}, {10, 10, 5, 14, 15, 128}, {8, 8, 3, 6, 4, 128}, {6, 6, 5, 12, 13, 128}, {4, 4, 18446744073709551615, 10, 3, 129}, {2, 2, 10, 18446744073709551615, 11, 129}, {0, 0, 10, 18446744073709551615, 18446744073709551615, 128}, {1, 1, 7, 9, 8, 129}, {3, 3, 8, 18446744073709551615, 18446744073709551615, 128}, {5, 5, 6, 18446744073709551615, 18446744073709551615, 128}, {7, 7, 6, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 18, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 16, 17, 128}, {12, 12, 18446744073709551615, 7, 1, 127}, {10, 10, 5, 14, 15, 128}, {8, 8, 7, 6, 4, 128}, {6, 6, 5, 12, 13, 128}, {4, 4, 3, 8, 5, 129}, {2, 2, 7, 10, 11, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {1, 1, 8, 18446744073709551615, 18446744073709551615, 128}, {3, 3, 8, 18446744073709551615, 18446744073709551615, 128}, {5, 5, 6, 18446744073709551615, 18446744073709551615, 128}, {7, 7, 6, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 16, 17, 128}, {12, 12, 18446744073709551615, 12, 1, 127}, {10, 10, 5, 14, 15, 128}, {8, 8, 12, 6, 4, 128}, {6, 6, 5, 18446744073709551615, 13, 129}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {2, 2, 12, 10, 11, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {1, 1, 8, 18446744073709551615, 18446744073709551615, 128}, {3, 3, 8, 18446744073709551615, 18446744073709551615, 128}, {5, 5, 3, 8, 5, 129}, {7, 7, 6, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 16, 12, 128}, {12, 12, 18446744073709551615, 6, 1, 127}, {10, 10, 5, 14, 15, 128}, {8, 8, 6, 13, 4, 129}, {6, 6, 3, 8, 5, 129}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {2, 2, 6, 10, 11, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {1, 1, 8, 18446744073709551615, 18446744073709551615, 128}, {3, 3, 8, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {7, 7, 5, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 13, 12, 128}, {12, 12, 18446744073709551615, 6, 1, 127}, {10, 10, 6, 5, 15, 127}, {8, 8, 4, 18446744073709551615, 14, 129}, {6, 6, 3, 8, 4, 129}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {2, 2, 6, 10, 11, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {1, 1, 8, 18446744073709551615, 18446744073709551615, 128}, {3, 3, 8, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 5, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 13, 12, 128}, {12, 12, 18446744073709551615, 6, 1, 127}, {10, 10, 6, 5, 10, 127}, {8, 8, 4, 18446744073709551615, 14, 129}, {6, 6, 3, 8, 4, 129}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {2, 2, 6, 18446744073709551615, 11, 129}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {3, 3, 8, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 5, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 13, 12, 128}, {12, 12, 18446744073709551615, 5, 1, 128}, {10, 10, 5, 8, 10, 128}, {8, 8, 3, 6, 4, 128}, {6, 6, 5, 11, 18446744073709551615, 127}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {3, 3, 6, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 11, 12, 128}, {12, 12, 18446744073709551615, 5, 1, 128}, {10, 10, 5, 8, 10, 128}, {8, 8, 3, 6, 4, 129}, {6, 6, 5, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 11, 6, 128}, {12, 12, 18446744073709551615, 4, 1, 128}, {10, 10, 3, 5, 10, 127}, {8, 8, 4, 18446744073709551615, 8, 129}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 5, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 5, 6, 128}, {12, 12, 18446744073709551615, 4, 1, 129}, {10, 10, 3, 8, 10, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {9, 9, 4, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 9, 128}, {16, 16, 3, 2, 0, 128}, {14, 14, 1, 5, 6, 128}, {12, 12, 18446744073709551615, 4, 1, 129}, {10, 10, 3, 18446744073709551615, 8, 129}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 4, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 4, 128}, {16, 16, 18446744073709551615, 3, 0, 127}, {14, 14, 3, 5, 6, 128}, {12, 12, 1, 8, 2, 129}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {11, 11, 3, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 7, 4, 128}, {16, 16, 18446744073709551615, 2, 0, 127}, {14, 14, 1, 3, 6, 127}, {12, 12, 2, 18446744073709551615, 5, 129}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 3, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 3, 4, 128}, {16, 16, 18446744073709551615, 2, 0, 128}, {14, 14, 1, 5, 6, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {13, 13, 2, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 3, 4, 128}, {16, 16, 18446744073709551615, 2, 0, 128}, {14, 14, 1, 18446744073709551615, 5, 129}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {15, 15, 2, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 1, 3, 4, 128}, {16, 16, 18446744073709551615, 2, 0, 129}, {15, 15, 1, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 18446744073709551615, 1, 2, 127}, {16, 16, 0, 18446744073709551615, 3, 129}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}, {17, 17, 1, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 18446744073709551615, 1, 2, 128}, {17, 17, 0, 18446744073709551615, 18446744073709551615, 128}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{18, 18, 18446744073709551615, 18446744073709551615, 1, 129}, {19, 19, 0, 18446744073709551615, 18446744073709551615, 128}},
-		{{19, 19, 18446744073709551615, 18446744073709551615, 18446744073709551615, 128}},
+		{entry(18, 18, 1, 18, 19, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 16, 17, 128), entry(12, 12, 7, 5, 1, 128), entry(10, 10, 5, 14, 15, 128), entry(8, 8, 3, 6, 4, 128), entry(6, 6, 5, 12, 13, 128), entry(4, 4, 18446744073709551615, 10, 3, 129), entry(2, 2, 10, 18446744073709551615, 11, 129), entry(0, 0, 10, 18446744073709551615, 18446744073709551615, 128), entry(1, 1, 7, 9, 8, 129), entry(3, 3, 8, 18446744073709551615, 18446744073709551615, 128), entry(5, 5, 6, 18446744073709551615, 18446744073709551615, 128), entry(7, 7, 6, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 18, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 16, 17, 128), entry(12, 12, 18446744073709551615, 7, 1, 127), entry(10, 10, 5, 14, 15, 128), entry(8, 8, 7, 6, 4, 128), entry(6, 6, 5, 12, 13, 128), entry(4, 4, 3, 8, 5, 129), entry(2, 2, 7, 10, 11, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(1, 1, 8, 18446744073709551615, 18446744073709551615, 128), entry(3, 3, 8, 18446744073709551615, 18446744073709551615, 128), entry(5, 5, 6, 18446744073709551615, 18446744073709551615, 128), entry(7, 7, 6, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 16, 17, 128), entry(12, 12, 18446744073709551615, 12, 1, 127), entry(10, 10, 5, 14, 15, 128), entry(8, 8, 12, 6, 4, 128), entry(6, 6, 5, 18446744073709551615, 13, 129), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(2, 2, 12, 10, 11, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(1, 1, 8, 18446744073709551615, 18446744073709551615, 128), entry(3, 3, 8, 18446744073709551615, 18446744073709551615, 128), entry(5, 5, 3, 8, 5, 129), entry(7, 7, 6, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 16, 12, 128), entry(12, 12, 18446744073709551615, 6, 1, 127), entry(10, 10, 5, 14, 15, 128), entry(8, 8, 6, 13, 4, 129), entry(6, 6, 3, 8, 5, 129), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(2, 2, 6, 10, 11, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(1, 1, 8, 18446744073709551615, 18446744073709551615, 128), entry(3, 3, 8, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(7, 7, 5, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 13, 12, 128), entry(12, 12, 18446744073709551615, 6, 1, 127), entry(10, 10, 6, 5, 15, 127), entry(8, 8, 4, 18446744073709551615, 14, 129), entry(6, 6, 3, 8, 4, 129), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(2, 2, 6, 10, 11, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(1, 1, 8, 18446744073709551615, 18446744073709551615, 128), entry(3, 3, 8, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 5, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 13, 12, 128), entry(12, 12, 18446744073709551615, 6, 1, 127), entry(10, 10, 6, 5, 10, 127), entry(8, 8, 4, 18446744073709551615, 14, 129), entry(6, 6, 3, 8, 4, 129), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(2, 2, 6, 18446744073709551615, 11, 129), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(3, 3, 8, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 5, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 13, 12, 128), entry(12, 12, 18446744073709551615, 5, 1, 128), entry(10, 10, 5, 8, 10, 128), entry(8, 8, 3, 6, 4, 128), entry(6, 6, 5, 11, 18446744073709551615, 127), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(3, 3, 6, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 11, 12, 128), entry(12, 12, 18446744073709551615, 5, 1, 128), entry(10, 10, 5, 8, 10, 128), entry(8, 8, 3, 6, 4, 129), entry(6, 6, 5, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 11, 6, 128), entry(12, 12, 18446744073709551615, 4, 1, 128), entry(10, 10, 3, 5, 10, 127), entry(8, 8, 4, 18446744073709551615, 8, 129), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 5, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 5, 6, 128), entry(12, 12, 18446744073709551615, 4, 1, 129), entry(10, 10, 3, 8, 10, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(9, 9, 4, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 9, 128), entry(16, 16, 3, 2, 0, 128), entry(14, 14, 1, 5, 6, 128), entry(12, 12, 18446744073709551615, 4, 1, 129), entry(10, 10, 3, 18446744073709551615, 8, 129), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 4, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 4, 128), entry(16, 16, 18446744073709551615, 3, 0, 127), entry(14, 14, 3, 5, 6, 128), entry(12, 12, 1, 8, 2, 129), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(11, 11, 3, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 7, 4, 128), entry(16, 16, 18446744073709551615, 2, 0, 127), entry(14, 14, 1, 3, 6, 127), entry(12, 12, 2, 18446744073709551615, 5, 129), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 3, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 3, 4, 128), entry(16, 16, 18446744073709551615, 2, 0, 128), entry(14, 14, 1, 5, 6, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(13, 13, 2, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 3, 4, 128), entry(16, 16, 18446744073709551615, 2, 0, 128), entry(14, 14, 1, 18446744073709551615, 5, 129), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(15, 15, 2, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 1, 3, 4, 128), entry(16, 16, 18446744073709551615, 2, 0, 129), entry(15, 15, 1, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 18446744073709551615, 1, 2, 127), entry(16, 16, 0, 18446744073709551615, 3, 129), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128), entry(17, 17, 1, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 18446744073709551615, 1, 2, 128), entry(17, 17, 0, 18446744073709551615, 18446744073709551615, 128), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(18, 18, 18446744073709551615, 18446744073709551615, 1, 129), entry(19, 19, 0, 18446744073709551615, 18446744073709551615, 128)},
+		{entry(19, 19, 18446744073709551615, 18446744073709551615, 18446744073709551615, 128)},
 	}
 
 	if !cmp.Equal(many, expectedMany) {
@@ -49,9 +50,173 @@ func TestParseMoveTestOut(t *testing.T) {
 		t.Fatalf("failed to parse the text: %v\n%s", err, parseOutTestData)
 	}
 
-	expected := [][]verifier.Entry{{{Key: 19, Value: 19, Parent: verifier.NULL_INDEX, LeftChild: verifier.NULL_INDEX, RightChild: verifier.NULL_INDEX, Metadata: 128}}}
+	expected := [][]verifier.Entry{{{Key: u(19), Value: u(19), Parent: verifier.NULL_INDEX, LeftChild: verifier.NULL_INDEX, RightChild: verifier.NULL_INDEX, Metadata: 128}}}
 
 	if !cmp.Equal(expected, e) {
 		t.Errorf("expecting: %#v, got: %#v", expected, e)
 	}
 }
+
+func TestDetectTreeType(t *testing.T) {
+	cases := []struct {
+		name      string
+		entries   []verifier.Entry
+		expected  verifier.TreeType
+		ambiguous bool
+	}{
+		{
+			name:     "no metadata",
+			entries:  []verifier.Entry{entry(2, 2, null, 1, null, 0), entry(1, 1, 0, null, null, 0)},
+			expected: verifier.TreeType_Vanilla,
+		},
+		{
+			name:     "avl left high",
+			entries:  []verifier.Entry{entry(2, 2, null, 1, null, 127), entry(1, 1, 0, null, null, 128)},
+			expected: verifier.TreeType_Avl,
+		},
+		{
+			// valid as red black tree, but the root is right high with only a left child as avl tree.
+			name:      "valid as red black only",
+			entries:   []verifier.Entry{entry(2, 2, null, 1, null, 129), entry(1, 1, 0, null, null, 128)},
+			ambiguous: true,
+		},
+		{
+			// the red root is invalid as red black tree, and balanced with one child as avl tree.
+			name:      "invalid as both",
+			entries:   []verifier.Entry{entry(2, 2, null, 1, null, 128), entry(1, 1, 0, null, null, 128)},
+			ambiguous: true,
+		},
+		{
+			name:     "valid as both",
+			entries:  []verifier.Entry{entry(2, 2, null, null, 1, 129), entry(3, 3, 0, null, null, 128)},
+			expected: verifier.TreeType_RedBlack,
+		},
+	}
+
+	for _, c := range cases {
+		dump := &verifier.TreeDump{Entries: c.entries}
+		got, err := dump.DetectTreeType()
+		switch {
+		case c.ambiguous && !errors.Is(err, verifier.ErrAmbiguousTreeType):
+			t.Errorf("%s: expecting ambiguous tree type, got %s and error %v", c.name, got, err)
+		case !c.ambiguous && err != nil:
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		case !c.ambiguous && got != c.expected:
+			t.Errorf("%s: expecting %s, got %s", c.name, c.expected, got)
+		}
+	}
+}
+
+func TestParseWideKeys(t *testing.T) {
+	// keys are 2^64, 2^64+1 and 2^128-1, the last one is misplaced to the left of the root.
+	dumps, err := verifier.ParseMoveTestDumps(`[debug] (&) { 0, [{ 18446744073709551616, 1, 18446744073709551615, 18446744073709551615, 1, 129 }, { 18446744073709551617, 2, 0, 18446744073709551615, 18446744073709551615, 128 }], 0, 1 }
+[debug] (&) { 0, [{ 18446744073709551616, 1, 18446744073709551615, 2, 1, 129 }, { 18446744073709551617, 2, 0, 18446744073709551615, 18446744073709551615, 128 }, { 340282366920938463463374607431768211455, 3, 0, 18446744073709551615, 18446744073709551615, 128 }], 2, 1 }`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	key, _ := verifier.ParseU256("18446744073709551617")
+	if dumps[0].Entries[1].Key != key {
+		t.Errorf("expecting key %s, got %s", key, dumps[0].Entries[1].Key)
+	}
+	if err := dumps[0].NewTree(verifier.TreeType_RedBlack).VerifyAll().Err(); err != nil {
+		t.Errorf("tree #0 is expected to be valid: %v", err)
+	}
+	violations := dumps[1].NewTree(verifier.TreeType_Vanilla).VerifyOrder()
+	if len(violations) != 1 || violations[0].Kind != verifier.ViolationKind_OutOfOrder || violations[0].Index != 0 {
+		t.Errorf("expecting the root to be out of order, got: %v", violations)
+	}
+
+	// critbit tree of u128 keys 2^100 and 2^100 + 2^127, which differ at bit 127.
+	critbit, err := verifier.ParseCritbitTree(`[debug] (&) { 0, [{ 170141183460469231731687303715884105728, 9223372036854775808, 18446744073709551615, 18446744073709551614 }], 0, 1, [{ 1267650600228229401496703205376, 0, 1 }, { 170141184728119831959916705212587311104, 0, 2 }] }`)
+	if err != nil {
+		t.Fatalf("failed to parse critbit tree: %v", err)
+	}
+	if critbit.Tree[0].Mask != verifier.U256Bit(127) {
+		t.Errorf("expecting mask 2^127, got %s", critbit.Tree[0].Mask)
+	}
+	if err := critbit.VerifyAll().Err(); err != nil {
+		t.Errorf("critbit tree is expected to be valid: %v", err)
+	}
+}
+
+func TestParseEntryLayout(t *testing.T) {
+	twoKeys := verifier.EntryLayout{KeyCount: 2}
+	text := `[debug] (&) [{ 1, 3, 10, 18446744073709551615, 1, 2, 129 }, { 1, 2, 11, 0, 18446744073709551615, 18446744073709551615, 128 }, { 2, 0, 12, 0, 18446744073709551615, 18446744073709551615, 128 }]
+[debug] (&) [{ 1, 3, 10, 18446744073709551615, 1, 2, 129 }, { 1, 4, 11, 0, 18446744073709551615, 18446744073709551615, 128 }, { 2, 0, 12, 0, 18446744073709551615, 18446744073709551615, 128 }]
+[debug] (&) [1, 2, 3]`
+	dumps, err := verifier.ParseMoveTestDumpsWithLayout(text, twoKeys)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(dumps) != 2 {
+		t.Fatalf("expecting 2 trees, got %d", len(dumps))
+	}
+
+	expected := verifier.Entry{Key: u(1), MoreKeys: []verifier.U256{u(2)}, Value: u(11), Parent: 0, LeftChild: null, RightChild: null, Metadata: 128}
+	if diff := cmp.Diff(expected, dumps[0].Entries[1]); diff != "" {
+		t.Errorf("entry differs (-expected +got):\n%s", diff)
+	}
+
+	if err := dumps[0].NewTree(verifier.TreeType_RedBlack).VerifyAll().Err(); err != nil {
+		t.Errorf("tree #0 is expected to be valid: %v", err)
+	}
+	// (1, 4) is on the left of (1, 3).
+	violations := dumps[1].NewTree(verifier.TreeType_RedBlack).VerifyAll()
+	if len(violations) != 1 || violations[0].Kind != verifier.ViolationKind_OutOfOrder {
+		t.Fatalf("expecting out of order, got: %v", violations)
+	}
+	if got := violations[0].Error(); got != "key out of order at index 0 (key (1, 3)): {k: (1, 3), i:  0, m: Blk} is not greater than its predecessor {k: (1, 4), i:  1, m: Red}" {
+		t.Errorf("unexpected violation: %s", got)
+	}
+
+	if _, err := verifier.ParseMoveTestDumps(text); err == nil {
+		t.Errorf("entries with 2 keys should not be parsed with the default layout")
+	}
+
+	cases := []struct {
+		name     string
+		text     string
+		layout   verifier.EntryLayout
+		expected verifier.Entry
+	}{
+		{
+			name:     "struct value",
+			text:     "{ 5, { 1, [2, 3], true }, 18446744073709551615, 1, 18446744073709551615 }",
+			layout:   verifier.EntryLayout{ValueShape: verifier.ValueShape_Opaque},
+			expected: verifier.Entry{Key: u(5), Parent: null, LeftChild: 1, RightChild: null},
+		},
+		{
+			name:     "size without metadata",
+			text:     "{ 5, 6, 18446744073709551615, 18446744073709551615, 18446744073709551615, 1 }",
+			layout:   verifier.EntryLayout{WithSize: true},
+			expected: verifier.Entry{Key: u(5), Value: u(6), Parent: null, LeftChild: null, RightChild: null, Size: 1, HasSize: true},
+		},
+		{
+			name:     "size with metadata",
+			text:     "{ 5, 6, 18446744073709551615, 18446744073709551615, 18446744073709551615, 129, 1 }",
+			layout:   verifier.EntryLayout{WithSize: true},
+			expected: verifier.Entry{Key: u(5), Value: u(6), Parent: null, LeftChild: null, RightChild: null, Metadata: 129, Size: 1, HasSize: true},
+		},
+		{
+			name:     "absent metadata",
+			text:     "{ 5, 6, 7, 18446744073709551615, 18446744073709551615 }",
+			layout:   verifier.EntryLayout{Metadata: verifier.MetadataPresence_Absent},
+			expected: verifier.Entry{Key: u(5), Value: u(6), Parent: 7, LeftChild: null, RightChild: null},
+		},
+	}
+	for _, c := range cases {
+		e, err := verifier.ParseEntryWithLayout(c.text, c.layout)
+		if err != nil {
+			t.Errorf("%s: failed to parse: %v", c.name, err)
+			continue
+		}
+		if diff := cmp.Diff(c.expected, *e); diff != "" {
+			t.Errorf("%s: entry differs (-expected +got):\n%s", c.name, diff)
+		}
+	}
+
+	if _, err := verifier.ParseEntryWithLayout("{ 5, 6, 7, 18446744073709551615, 18446744073709551615 }", verifier.EntryLayout{Metadata: verifier.MetadataPresence_Present}); err == nil {
+		t.Errorf("entry without metadata should fail to parse when metadata is present")
+	}
+}
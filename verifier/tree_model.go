@@ -0,0 +1,680 @@
+package verifier
+
+import "fmt"
+
+const (
+	AVL_ZERO         uint8 = 128
+	AVL_LEFT_HIGH    uint8 = 127
+	AVL_RIGHT_HIGH   uint8 = 129
+	AVL_LEFT_HIGH_2  uint8 = 126
+	AVL_RIGHT_HIGH_2 uint8 = 130
+
+	RB_RED   = uint8(RedBlackTreeColor_Red)
+	RB_BLACK = uint8(RedBlackTreeColor_Black)
+
+	// METADATA_DEFAULT is the metadata of a new entry, balanced in avl and red in red black tree.
+	METADATA_DEFAULT uint8 = 128
+)
+
+// TreeModel is the reference implementation of the binary search trees generated from spec.move.template,
+// with a vector backend, one key, and no stable index.
+// Each operation follows the move code step by step, so the entries, root, min_index and max_index
+// are exactly what the move tree holds after the same operations.
+type TreeModel struct {
+	Type     TreeType
+	Root     uint64
+	Entries  []Entry
+	MinIndex uint64
+	MaxIndex uint64
+}
+
+// NewTreeModel creates an empty tree of the type.
+func NewTreeModel(treeType TreeType) *TreeModel {
+	return &TreeModel{
+		Type:     treeType,
+		Root:     NULL_INDEX,
+		MinIndex: NULL_INDEX,
+		MaxIndex: NULL_INDEX,
+	}
+}
+
+// Size is the number of the entries.
+func (tree *TreeModel) Size() uint64 {
+	return uint64(len(tree.Entries))
+}
+
+// Dump copies the entries and the fields, the same as the tree printed by move test.
+func (tree *TreeModel) Dump() *TreeDump {
+	return &TreeDump{
+		Entries: append([]Entry{}, tree.Entries...),
+		Fields: &TreeFields{
+			Root:     tree.Root,
+			MinIndex: tree.MinIndex,
+			MaxIndex: tree.MaxIndex,
+		},
+	}
+}
+
+// Tree creates the tree from a copy of the entries and the fields for verification.
+func (tree *TreeModel) Tree() *Tree {
+	return tree.Dump().NewTree(tree.Type)
+}
+
+// Find returns the index of the key, or NULL_INDEX if the key is not in the tree.
+func (tree *TreeModel) Find(key U256) uint64 {
+	current := tree.Root
+	for current != NULL_INDEX {
+		node := &tree.Entries[current]
+		switch {
+		case node.Key == key:
+			return current
+		case node.Key.Cmp(key) < 0:
+			current = node.RightChild
+		default:
+			current = node.LeftChild
+		}
+	}
+
+	return NULL_INDEX
+}
+
+// GetMinIndexFrom returns the index of the min of the subtree at index.
+func (tree *TreeModel) GetMinIndexFrom(index uint64) uint64 {
+	current := index
+	for tree.Entries[current].LeftChild != NULL_INDEX {
+		current = tree.Entries[current].LeftChild
+	}
+
+	return current
+}
+
+// GetMaxIndexFrom returns the index of the max of the subtree at index.
+func (tree *TreeModel) GetMaxIndexFrom(index uint64) uint64 {
+	current := index
+	for tree.Entries[current].RightChild != NULL_INDEX {
+		current = tree.Entries[current].RightChild
+	}
+
+	return current
+}
+
+// NextInOrder returns the index of the next entry with a bigger key, or NULL_INDEX if index is the max.
+func (tree *TreeModel) NextInOrder(index uint64) uint64 {
+	node := &tree.Entries[index]
+	if node.RightChild != NULL_INDEX {
+		return tree.GetMinIndexFrom(node.RightChild)
+	}
+
+	current, parent := index, node.Parent
+	for parent != NULL_INDEX && tree.isRightChild(current, parent) {
+		current = parent
+		parent = tree.Entries[current].Parent
+	}
+
+	return parent
+}
+
+// NextInReverseOrder returns the index of the next entry with a smaller key, or NULL_INDEX if index is the min.
+func (tree *TreeModel) NextInReverseOrder(index uint64) uint64 {
+	node := &tree.Entries[index]
+	if node.LeftChild != NULL_INDEX {
+		return tree.GetMaxIndexFrom(node.LeftChild)
+	}
+
+	current, parent := index, node.Parent
+	for parent != NULL_INDEX && tree.isLeftChild(current, parent) {
+		current = parent
+		parent = tree.Entries[current].Parent
+	}
+
+	return parent
+}
+
+// Insert adds the key and value, and returns the index of the new entry, which is always the end of the entries.
+// Same as the move code, it fails if the key is already in the tree.
+func (tree *TreeModel) Insert(key U256, value U256) (uint64, error) {
+	if tree.Size() >= NULL_INDEX {
+		return NULL_INDEX, fmt.Errorf("tree is too big")
+	}
+
+	metadata := uint8(0)
+	if tree.Type != TreeType_Vanilla {
+		metadata = METADATA_DEFAULT
+	}
+	tree.Entries = append(tree.Entries, Entry{
+		Key:        key,
+		Value:      value,
+		Parent:     NULL_INDEX,
+		LeftChild:  NULL_INDEX,
+		RightChild: NULL_INDEX,
+		Metadata:   metadata,
+	})
+	node := tree.Size() - 1
+
+	parent := uint64(NULL_INDEX)
+	insert := tree.Root
+	isRightChild := false
+	for insert != NULL_INDEX {
+		insertNode := &tree.Entries[insert]
+		if insertNode.Key == key {
+			tree.Entries = tree.Entries[:node]
+			return NULL_INDEX, fmt.Errorf("key %d already exists at %d", key, insert)
+		}
+		parent = insert
+		isRightChild = insertNode.Key.Cmp(key) < 0
+		if isRightChild {
+			insert = insertNode.RightChild
+		} else {
+			insert = insertNode.LeftChild
+		}
+	}
+
+	tree.replaceParent(node, parent)
+
+	if parent != NULL_INDEX {
+		if isRightChild {
+			tree.replaceRightChild(parent, node)
+		} else {
+			tree.replaceLeftChild(parent, node)
+		}
+		if tree.Entries[tree.MaxIndex].Key.Cmp(key) < 0 {
+			tree.MaxIndex = node
+		}
+		if tree.Entries[tree.MinIndex].Key.Cmp(key) > 0 {
+			tree.MinIndex = node
+		}
+	} else {
+		tree.Root = node
+		tree.MinIndex = node
+		tree.MaxIndex = node
+	}
+
+	switch tree.Type {
+	case TreeType_Avl:
+		for parent != NULL_INDEX {
+			increased, newParent := tree.avlUpdateInsert(parent, isRightChild)
+			if !increased {
+				break
+			}
+			parent = tree.Entries[newParent].Parent
+			if parent == NULL_INDEX {
+				break
+			}
+			isRightChild = tree.isRightChild(newParent, parent)
+		}
+	case TreeType_RedBlack:
+		for parent != NULL_INDEX {
+			if tree.Entries[parent].Metadata == RB_BLACK {
+				break
+			}
+			parent = tree.rbUpdateInsert(parent, isRightChild)
+			if tree.Entries[parent].Metadata == RB_BLACK {
+				break
+			}
+			newParent := tree.Entries[parent].Parent
+			if newParent == NULL_INDEX {
+				break
+			}
+			isRightChild = tree.isRightChild(parent, newParent)
+			parent = newParent
+		}
+
+		if tree.Root != NULL_INDEX {
+			tree.Entries[tree.Root].Metadata = RB_BLACK
+		}
+	}
+
+	return node, nil
+}
+
+// Remove deletes the entry at index and returns it.
+// The last entry is moved into index, so the entry at the end of the entries changes its index.
+func (tree *TreeModel) Remove(index uint64) (*Entry, error) {
+	if index >= tree.Size() {
+		return nil, fmt.Errorf("index %d is out of range, size is %d", index, tree.Size())
+	}
+
+	if tree.MaxIndex == index {
+		tree.MaxIndex = tree.NextInReverseOrder(index)
+	}
+	if tree.MinIndex == index {
+		tree.MinIndex = tree.NextInOrder(index)
+	}
+
+	node := tree.Entries[index]
+	parent, leftChild, rightChild := node.Parent, node.LeftChild, node.RightChild
+
+	isRight := false
+	if parent != NULL_INDEX {
+		isRight = tree.isRightChild(index, parent)
+	}
+
+	var rebalanceStart uint64
+	var isNewRight bool
+
+	switch {
+	case rightChild == NULL_INDEX:
+		if parent == NULL_INDEX {
+			tree.replaceParent(leftChild, NULL_INDEX)
+			tree.Root = leftChild
+		} else {
+			tree.replaceChild(parent, index, leftChild)
+		}
+		rebalanceStart, isNewRight = parent, isRight
+	case leftChild == NULL_INDEX:
+		if parent == NULL_INDEX {
+			tree.replaceParent(rightChild, NULL_INDEX)
+			tree.Root = rightChild
+		} else {
+			tree.replaceChild(parent, index, rightChild)
+		}
+		rebalanceStart, isNewRight = parent, isRight
+	case tree.Entries[rightChild].LeftChild == NULL_INDEX:
+		tree.replaceLeftChild(rightChild, leftChild)
+		if parent == NULL_INDEX {
+			tree.replaceParent(rightChild, NULL_INDEX)
+			tree.Root = rightChild
+		} else {
+			tree.replaceChild(parent, index, rightChild)
+		}
+		tree.swapMetadata(index, rightChild)
+		rebalanceStart, isNewRight = rightChild, true
+	default:
+		nextSuccessor := tree.GetMinIndexFrom(tree.Entries[rightChild].LeftChild)
+		successorParent := tree.Entries[nextSuccessor].Parent
+		nextSuccessorRight := tree.Entries[nextSuccessor].RightChild
+
+		tree.replaceLeftChild(successorParent, nextSuccessorRight)
+		tree.replaceLeftChild(nextSuccessor, leftChild)
+		tree.replaceRightChild(nextSuccessor, rightChild)
+		if parent == NULL_INDEX {
+			tree.replaceParent(nextSuccessor, NULL_INDEX)
+			tree.Root = nextSuccessor
+		} else {
+			tree.replaceChild(parent, index, nextSuccessor)
+		}
+		tree.swapMetadata(index, nextSuccessor)
+		rebalanceStart, isNewRight = successorParent, false
+	}
+
+	switch tree.Type {
+	case TreeType_Avl:
+		for rebalanceStart != NULL_INDEX {
+			decreased, newStart := tree.avlUpdateRemove(rebalanceStart, isNewRight)
+			if !decreased {
+				break
+			}
+			rebalanceStart = tree.Entries[newStart].Parent
+			if rebalanceStart == NULL_INDEX {
+				break
+			}
+			isNewRight = tree.isRightChild(newStart, rebalanceStart)
+		}
+	case TreeType_RedBlack:
+		removalMetadata := tree.Entries[index].Metadata
+		for rebalanceStart != NULL_INDEX {
+			doContinue, newStart := tree.rbUpdateRemove(rebalanceStart, isNewRight, removalMetadata)
+			if !doContinue || newStart == NULL_INDEX {
+				break
+			}
+			isNewRight = tree.isRightChild(rebalanceStart, newStart)
+			rebalanceStart = newStart
+		}
+
+		if tree.Root != NULL_INDEX {
+			tree.Entries[tree.Root].Metadata = RB_BLACK
+		}
+	}
+
+	lastIndex := tree.Size() - 1
+	if index != lastIndex {
+		tree.Entries[index], tree.Entries[lastIndex] = tree.Entries[lastIndex], tree.Entries[index]
+		if tree.Root == lastIndex {
+			tree.Root = index
+		}
+		if tree.MaxIndex == lastIndex {
+			tree.MaxIndex = index
+		}
+		if tree.MinIndex == lastIndex {
+			tree.MinIndex = index
+		}
+		moved := tree.Entries[index]
+		tree.replaceChild(moved.Parent, lastIndex, index)
+		tree.replaceParent(moved.LeftChild, index)
+		tree.replaceParent(moved.RightChild, index)
+	}
+
+	removed := tree.Entries[lastIndex]
+	tree.Entries = tree.Entries[:lastIndex]
+
+	if tree.Size() == 0 {
+		tree.Root = NULL_INDEX
+	}
+
+	return &removed, nil
+}
+
+func (tree *TreeModel) swapMetadata(a, b uint64) {
+	tree.Entries[a].Metadata, tree.Entries[b].Metadata = tree.Entries[b].Metadata, tree.Entries[a].Metadata
+}
+
+func (tree *TreeModel) isRightChild(index, parent uint64) bool {
+	return tree.Entries[parent].RightChild == index
+}
+
+func (tree *TreeModel) isLeftChild(index, parent uint64) bool {
+	return tree.Entries[parent].LeftChild == index
+}
+
+func (tree *TreeModel) replaceChild(parent, originalChild, newChild uint64) {
+	if parent == NULL_INDEX {
+		return
+	}
+	if tree.isRightChild(originalChild, parent) {
+		tree.replaceRightChild(parent, newChild)
+	} else if tree.isLeftChild(originalChild, parent) {
+		tree.replaceLeftChild(parent, newChild)
+	}
+}
+
+func (tree *TreeModel) replaceLeftChild(parent, newChild uint64) {
+	if parent == NULL_INDEX {
+		return
+	}
+	tree.Entries[parent].LeftChild = newChild
+	tree.replaceParent(newChild, parent)
+}
+
+func (tree *TreeModel) replaceRightChild(parent, newChild uint64) {
+	if parent == NULL_INDEX {
+		return
+	}
+	tree.Entries[parent].RightChild = newChild
+	tree.replaceParent(newChild, parent)
+}
+
+func (tree *TreeModel) replaceParent(index, parent uint64) {
+	if index != NULL_INDEX {
+		tree.Entries[index].Parent = parent
+	}
+}
+
+func (tree *TreeModel) rotateRight(index uint64) {
+	left := tree.Entries[index].LeftChild
+	y := tree.Entries[left].RightChild
+	parent := tree.Entries[index].Parent
+
+	tree.replaceLeftChild(index, y)
+	if parent != NULL_INDEX {
+		tree.replaceChild(parent, index, left)
+	} else {
+		tree.Root = left
+		tree.replaceParent(left, NULL_INDEX)
+	}
+	tree.replaceRightChild(left, index)
+}
+
+func (tree *TreeModel) rotateLeft(index uint64) {
+	right := tree.Entries[index].RightChild
+	x := tree.Entries[right].LeftChild
+	parent := tree.Entries[index].Parent
+
+	tree.replaceRightChild(index, x)
+	if parent != NULL_INDEX {
+		tree.replaceChild(parent, index, right)
+	} else {
+		tree.Root = right
+		tree.replaceParent(right, NULL_INDEX)
+	}
+	tree.replaceLeftChild(right, index)
+}
+
+// avlUpdateInsert updates the subtree at index after its subtree on the is_right side grows taller.
+// returns if the subtree grows taller, and the new root of the subtree.
+func (tree *TreeModel) avlUpdateInsert(index uint64, isRight bool) (bool, uint64) {
+	node := &tree.Entries[index]
+	switch {
+	case node.Metadata == AVL_ZERO && isRight:
+		node.Metadata = AVL_RIGHT_HIGH
+		return true, index
+	case node.Metadata == AVL_ZERO:
+		node.Metadata = AVL_LEFT_HIGH
+		return true, index
+	case node.Metadata == AVL_LEFT_HIGH && isRight, node.Metadata == AVL_RIGHT_HIGH && !isRight:
+		node.Metadata = AVL_ZERO
+		return false, index
+	case node.Metadata == AVL_LEFT_HIGH:
+		node.Metadata = AVL_LEFT_HIGH_2
+	default:
+		node.Metadata = AVL_RIGHT_HIGH_2
+	}
+
+	_, newIndex := tree.avlRebalance(index)
+
+	return false, newIndex
+}
+
+// avlUpdateRemove updates the subtree at index after its subtree on the is_right side becomes shorter.
+// returns if the subtree becomes shorter, and the new root of the subtree.
+func (tree *TreeModel) avlUpdateRemove(index uint64, isRight bool) (bool, uint64) {
+	node := &tree.Entries[index]
+	switch {
+	case node.Metadata == AVL_ZERO && isRight:
+		node.Metadata = AVL_LEFT_HIGH
+		return false, index
+	case node.Metadata == AVL_ZERO:
+		node.Metadata = AVL_RIGHT_HIGH
+		return false, index
+	case node.Metadata == AVL_LEFT_HIGH && !isRight, node.Metadata == AVL_RIGHT_HIGH && isRight:
+		node.Metadata = AVL_ZERO
+		return true, index
+	case node.Metadata == AVL_RIGHT_HIGH:
+		node.Metadata = AVL_RIGHT_HIGH_2
+	default:
+		node.Metadata = AVL_LEFT_HIGH_2
+	}
+
+	return tree.avlRebalance(index)
+}
+
+// avlRebalance rotates the subtree at index, which is AVL_LEFT_HIGH_2 or AVL_RIGHT_HIGH_2.
+// returns if the height of the subtree decreases, and the new root of the subtree.
+func (tree *TreeModel) avlRebalance(index uint64) (bool, uint64) {
+	node := tree.Entries[index]
+	leftChild, rightChild := node.LeftChild, node.RightChild
+
+	if node.Metadata == AVL_LEFT_HIGH_2 {
+		leftMetadata := tree.Entries[leftChild].Metadata
+		if leftMetadata != AVL_RIGHT_HIGH {
+			tree.rotateRight(index)
+			if leftMetadata == AVL_ZERO {
+				tree.Entries[leftChild].Metadata = AVL_RIGHT_HIGH
+				tree.Entries[index].Metadata = AVL_LEFT_HIGH
+			} else {
+				tree.Entries[leftChild].Metadata = AVL_ZERO
+				tree.Entries[index].Metadata = AVL_ZERO
+			}
+
+			return leftMetadata != AVL_ZERO, leftChild
+		}
+
+		w := tree.Entries[leftChild].RightChild
+		wMetadata := tree.Entries[w].Metadata
+		tree.rotateLeft(leftChild)
+		tree.rotateRight(index)
+		tree.Entries[w].Metadata = AVL_ZERO
+		tree.Entries[leftChild].Metadata = AVL_ZERO
+		if wMetadata == AVL_RIGHT_HIGH {
+			tree.Entries[leftChild].Metadata = AVL_LEFT_HIGH
+		}
+		tree.Entries[index].Metadata = AVL_ZERO
+		if wMetadata == AVL_LEFT_HIGH {
+			tree.Entries[index].Metadata = AVL_RIGHT_HIGH
+		}
+
+		return true, w
+	}
+
+	rightMetadata := tree.Entries[rightChild].Metadata
+	if rightMetadata != AVL_LEFT_HIGH {
+		tree.rotateLeft(index)
+		if rightMetadata == AVL_ZERO {
+			tree.Entries[rightChild].Metadata = AVL_LEFT_HIGH
+			tree.Entries[index].Metadata = AVL_RIGHT_HIGH
+		} else {
+			tree.Entries[rightChild].Metadata = AVL_ZERO
+			tree.Entries[index].Metadata = AVL_ZERO
+		}
+
+		return rightMetadata != AVL_ZERO, rightChild
+	}
+
+	w := tree.Entries[rightChild].LeftChild
+	wMetadata := tree.Entries[w].Metadata
+	tree.rotateRight(rightChild)
+	tree.rotateLeft(index)
+	tree.Entries[w].Metadata = AVL_ZERO
+	tree.Entries[rightChild].Metadata = AVL_ZERO
+	if wMetadata == AVL_LEFT_HIGH {
+		tree.Entries[rightChild].Metadata = AVL_RIGHT_HIGH
+	}
+	tree.Entries[index].Metadata = AVL_ZERO
+	if wMetadata == AVL_RIGHT_HIGH {
+		tree.Entries[index].Metadata = AVL_LEFT_HIGH
+	}
+
+	return true, w
+}
+
+// rbUpdateInsert fixes the red node at index whose child on the is_right side is also red.
+// returns the root of the fixed subtree.
+func (tree *TreeModel) rbUpdateInsert(index uint64, isRight bool) uint64 {
+	node := tree.Entries[index]
+	redChild := node.LeftChild
+	if isRight {
+		redChild = node.RightChild
+	}
+	parent := node.Parent
+
+	if !tree.isRightChild(index, parent) {
+		uncle := tree.Entries[parent].RightChild
+		switch {
+		case uncle != NULL_INDEX && tree.Entries[uncle].Metadata == RB_RED:
+			tree.Entries[parent].Metadata = RB_RED
+			tree.Entries[index].Metadata = RB_BLACK
+			tree.Entries[uncle].Metadata = RB_BLACK
+			return parent
+		case !isRight:
+			tree.rotateRight(parent)
+			tree.Entries[parent].Metadata = RB_RED
+			tree.Entries[index].Metadata = RB_BLACK
+			return index
+		default:
+			tree.rotateLeft(index)
+			tree.rotateRight(parent)
+			tree.Entries[redChild].Metadata = RB_BLACK
+			tree.Entries[parent].Metadata = RB_RED
+			return redChild
+		}
+	}
+
+	uncle := tree.Entries[parent].LeftChild
+	switch {
+	case uncle != NULL_INDEX && tree.Entries[uncle].Metadata == RB_RED:
+		tree.Entries[parent].Metadata = RB_RED
+		tree.Entries[index].Metadata = RB_BLACK
+		tree.Entries[uncle].Metadata = RB_BLACK
+		return parent
+	case isRight:
+		tree.rotateLeft(parent)
+		tree.Entries[parent].Metadata = RB_RED
+		tree.Entries[index].Metadata = RB_BLACK
+		return index
+	default:
+		tree.rotateRight(index)
+		tree.rotateLeft(parent)
+		tree.Entries[redChild].Metadata = RB_BLACK
+		tree.Entries[parent].Metadata = RB_RED
+		return redChild
+	}
+}
+
+// rbUpdateRemove fixes the subtree at index after a node with metadataRemoved is removed from its is_right side.
+// returns if the fix continues, and where it continues.
+func (tree *TreeModel) rbUpdateRemove(index uint64, isRight bool, metadataRemoved uint8) (bool, uint64) {
+	if metadataRemoved == RB_RED {
+		return false, index
+	}
+
+	node := tree.Entries[index]
+	child, w := node.LeftChild, node.RightChild
+	if isRight {
+		child, w = node.RightChild, node.LeftChild
+	}
+	indexColor := node.Metadata
+
+	if child != NULL_INDEX && tree.Entries[child].Metadata == RB_RED {
+		tree.Entries[child].Metadata = RB_BLACK
+		return false, index
+	}
+
+	isBlack := func(i uint64) bool {
+		return i == NULL_INDEX || tree.Entries[i].Metadata == RB_BLACK
+	}
+
+	if !isRight {
+		if tree.Entries[w].Metadata == RB_RED {
+			tree.rotateLeft(index)
+			tree.Entries[w].Metadata = RB_BLACK
+			tree.Entries[index].Metadata = RB_RED
+			indexColor = RB_RED
+			w = tree.Entries[index].RightChild
+		}
+
+		wLeft, wRight := tree.Entries[w].LeftChild, tree.Entries[w].RightChild
+		switch {
+		case isBlack(wLeft) && isBlack(wRight):
+			tree.Entries[w].Metadata = RB_RED
+			return true, tree.Entries[index].Parent
+		case !isBlack(wRight):
+			tree.rotateLeft(index)
+			tree.Entries[w].Metadata = indexColor
+			tree.Entries[index].Metadata = RB_BLACK
+			tree.Entries[wRight].Metadata = RB_BLACK
+			return false, index
+		default:
+			tree.rotateRight(w)
+			tree.rotateLeft(index)
+			tree.Entries[wLeft].Metadata = indexColor
+			tree.Entries[index].Metadata = RB_BLACK
+			return false, index
+		}
+	}
+
+	if tree.Entries[w].Metadata == RB_RED {
+		tree.rotateRight(index)
+		tree.Entries[w].Metadata = RB_BLACK
+		tree.Entries[index].Metadata = RB_RED
+		indexColor = RB_RED
+		w = tree.Entries[index].LeftChild
+	}
+
+	wLeft, wRight := tree.Entries[w].LeftChild, tree.Entries[w].RightChild
+	switch {
+	case isBlack(wLeft) && isBlack(wRight):
+		tree.Entries[w].Metadata = RB_RED
+		return true, tree.Entries[index].Parent
+	case !isBlack(wLeft):
+		tree.rotateRight(index)
+		tree.Entries[w].Metadata = indexColor
+		tree.Entries[index].Metadata = RB_BLACK
+		tree.Entries[wLeft].Metadata = RB_BLACK
+		return false, index
+	default:
+		tree.rotateLeft(w)
+		tree.rotateRight(index)
+		tree.Entries[wRight].Metadata = indexColor
+		tree.Entries[index].Metadata = RB_BLACK
+		return false, index
+	}
+}
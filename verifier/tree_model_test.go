@@ -0,0 +1,139 @@
+package verifier_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustInsert(t *testing.T, tree *verifier.TreeModel, keys ...uint64) {
+	t.Helper()
+	for _, key := range keys {
+		if _, err := tree.Insert(u(key), u(key)); err != nil {
+			t.Fatalf("failed to insert %d: %v", key, err)
+		}
+	}
+}
+
+func mustRemove(t *testing.T, tree *verifier.TreeModel, index uint64) {
+	t.Helper()
+	if _, err := tree.Remove(index); err != nil {
+		t.Fatalf("failed to remove %d: %v", index, err)
+	}
+}
+
+// TestTreeModelMoveTest replays test_min_iter_avl, which prints the entries in parse_out_test_data.txt.
+func TestTreeModelMoveTest(t *testing.T) {
+	expected, err := verifier.ParseMoveTestOut(parseOutTestData)
+	if err != nil {
+		t.Fatalf("failed to parse the text: %v", err)
+	}
+
+	tree := verifier.NewTreeModel(verifier.TreeType_Avl)
+	for i := uint64(9); i > 0; i-- {
+		mustInsert(t, tree, i*2)
+	}
+	mustInsert(t, tree, 0)
+	for i := uint64(0); i < 10; i++ {
+		mustInsert(t, tree, i*2+1)
+	}
+
+	var got [][]verifier.Entry
+	dump := func() {
+		got = append(got, tree.Dump().Entries)
+	}
+
+	dump()
+	mustRemove(t, tree, tree.MinIndex)
+	dump()
+	mustRemove(t, tree, tree.Find(u(4)))
+	dump()
+	mustRemove(t, tree, 12)
+	dump()
+	mustRemove(t, tree, 13)
+	for tree.Size() > 0 {
+		dump()
+		mustRemove(t, tree, tree.MinIndex)
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("entries differ from move test output (-move +model):\n%s", diff)
+	}
+}
+
+func TestTreeModelRedBlack(t *testing.T) {
+	tree := verifier.NewTreeModel(verifier.TreeType_RedBlack)
+	mustInsert(t, tree, 6, 5, 4, 1, 3, 2)
+
+	// test_redblack in spec.move.template
+	expected := []verifier.Entry{
+		entry(6, 6, 1, null, null, 129),
+		entry(5, 5, null, 4, 0, 129),
+		entry(4, 4, 4, null, null, 129),
+		entry(1, 1, 4, null, 5, 129),
+		entry(3, 3, 1, 3, 2, 128),
+		entry(2, 2, 3, null, null, 128),
+	}
+	if diff := cmp.Diff(expected, tree.Entries); diff != "" {
+		t.Errorf("entries differ from test_redblack (-move +model):\n%s", diff)
+	}
+
+	if _, err := tree.Insert(u(3), u(3)); err == nil {
+		t.Errorf("inserting an existing key should fail")
+	}
+	if tree.Size() != 6 {
+		t.Errorf("failed insertion should not change the tree, size is %d", tree.Size())
+	}
+}
+
+func TestTreeModelRandom(t *testing.T) {
+	for _, treeType := range []verifier.TreeType{verifier.TreeType_Vanilla, verifier.TreeType_Avl, verifier.TreeType_RedBlack} {
+		t.Run(treeType.String(), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(treeType) + 1))
+			tree := verifier.NewTreeModel(treeType)
+			keys := make(map[uint64]bool)
+
+			for i := 0; i < 2000; i++ {
+				if tree.Size() > 0 && r.Intn(5) < 2 {
+					removed, err := tree.Remove(uint64(r.Intn(int(tree.Size()))))
+					if err != nil {
+						t.Fatalf("op #%d: failed to remove: %v", i, err)
+					}
+					delete(keys, removed.Key.Uint64())
+				} else {
+					key := uint64(r.Intn(500))
+					_, err := tree.Insert(u(key), u(key))
+					if (err != nil) != keys[key] {
+						t.Fatalf("op #%d: insert %d returns %v, key exists: %t", i, key, err, keys[key])
+					}
+					keys[key] = true
+				}
+
+				verified := tree.Tree()
+				if err := verified.VerifyAll().Err(); err != nil {
+					t.Fatalf("op #%d: %v", i, err)
+				}
+
+				var got []uint64
+				verified.InfixVisit(uint64(verified.Root), func(node *verifier.EntryWithExtraInfo, index uint64) bool {
+					got = append(got, node.Key.Uint64())
+					return true
+				})
+				if len(keys) == 0 {
+					continue
+				}
+				var expected []uint64
+				for key := range keys {
+					expected = append(expected, key)
+				}
+				sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+				if diff := cmp.Diff(expected, got); diff != "" {
+					t.Fatalf("op #%d: keys differ (-expected +got):\n%s", i, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package verifier
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// U256 is an unsigned 256 bit integer, wide enough for the keys and values of all the int widths of the generated containers.
+// The words are in little endian order. U256 is comparable, so == can be used for equality, and Cmp for ordering.
+// It implements fmt.Formatter the same as big.Int, so it can be printed with %d or %x.
+type U256 [4]uint64
+
+// NewU256 converts v into U256.
+func NewU256(v uint64) U256 {
+	return U256{v}
+}
+
+// ParseU256 parses the decimal text of an unsigned int no wider than 256 bits.
+func ParseU256(text string) (U256, error) {
+	b, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return U256{}, fmt.Errorf("%q is not a decimal integer", text)
+	}
+
+	return U256FromBig(b)
+}
+
+// U256FromBig converts b into U256, b must be non-negative and no wider than 256 bits.
+func U256FromBig(b *big.Int) (U256, error) {
+	if b.Sign() < 0 || b.BitLen() > 256 {
+		return U256{}, fmt.Errorf("%s is out of the range of u256", b)
+	}
+
+	var r U256
+	bytes := b.FillBytes(make([]byte, 32))
+	for i := range r {
+		for _, v := range bytes[32-8*(i+1) : 32-8*i] {
+			r[i] = r[i]<<8 | uint64(v)
+		}
+	}
+
+	return r, nil
+}
+
+// Big converts u into big.Int.
+func (u U256) Big() *big.Int {
+	bytes := make([]byte, 0, 32)
+	for i := len(u) - 1; i >= 0; i-- {
+		for shift := 56; shift >= 0; shift -= 8 {
+			bytes = append(bytes, byte(u[i]>>shift))
+		}
+	}
+
+	return new(big.Int).SetBytes(bytes)
+}
+
+func (u U256) String() string {
+	return u.Big().String()
+}
+
+// Format implements fmt.Formatter, see big.Int.Format.
+func (u U256) Format(s fmt.State, verb rune) {
+	u.Big().Format(s, verb)
+}
+
+// MarshalJSON writes u as a json number, which is not limited to the precision of float64.
+func (u U256) MarshalJSON() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalJSON reads u from a json number, or a json string of the decimal number.
+func (u *U256) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if len(text) >= 2 && text[0] == '"' && text[len(text)-1] == '"' {
+		text = text[1 : len(text)-1]
+	}
+
+	v, err := ParseU256(text)
+	if err != nil {
+		return err
+	}
+	*u = v
+
+	return nil
+}
+
+// Cmp returns -1, 0, or 1 if u is less than, equal to, or greater than v.
+func (u U256) Cmp(v U256) int {
+	for i := len(u) - 1; i >= 0; i-- {
+		switch {
+		case u[i] < v[i]:
+			return -1
+		case u[i] > v[i]:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// IsUint64 checks u fits in uint64.
+func (u U256) IsUint64() bool {
+	return u[1] == 0 && u[2] == 0 && u[3] == 0
+}
+
+// Uint64 returns the lowest 64 bits of u.
+func (u U256) Uint64() uint64 {
+	return u[0]
+}
+
+func (u U256) And(v U256) U256 {
+	return U256{u[0] & v[0], u[1] & v[1], u[2] & v[2], u[3] & v[3]}
+}
+
+func (u U256) Xor(v U256) U256 {
+	return U256{u[0] ^ v[0], u[1] ^ v[1], u[2] ^ v[2], u[3] ^ v[3]}
+}
+
+// BitLen is the number of bits to represent u, 0 for 0.
+func (u U256) BitLen() int {
+	for i := len(u) - 1; i >= 0; i-- {
+		if u[i] != 0 {
+			return 64*i + bits.Len64(u[i])
+		}
+	}
+
+	return 0
+}
+
+// TrailingZeros is the number of trailing zero bits, 256 for 0.
+func (u U256) TrailingZeros() int {
+	for i, w := range u {
+		if w != 0 {
+			return 64*i + bits.TrailingZeros64(w)
+		}
+	}
+
+	return 256
+}
+
+// OnesCount is the number of one bits.
+func (u U256) OnesCount() int {
+	r := 0
+	for _, w := range u {
+		r += bits.OnesCount64(w)
+	}
+
+	return r
+}
+
+// U256Bit is the U256 with only bit n set.
+func U256Bit(n int) U256 {
+	var r U256
+	r[n/64] = 1 << (n % 64)
+
+	return r
+}
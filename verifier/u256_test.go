@@ -0,0 +1,61 @@
+package verifier_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+)
+
+const maxU256 = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+func TestU256(t *testing.T) {
+	for _, text := range []string{"0", "1", "18446744073709551615", "18446744073709551616", "340282366920938463463374607431768211455", maxU256} {
+		v, err := verifier.ParseU256(text)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", text, err)
+		}
+		if v.String() != text || fmt.Sprintf("%d", v) != text || v.Big().String() != text {
+			t.Errorf("%s is formatted as %s", text, v)
+		}
+	}
+
+	for _, text := range []string{"-1", "1.5", "", "115792089237316195423570985008687907853269984665640564039457584007913129639936"} {
+		if _, err := verifier.ParseU256(text); err == nil {
+			t.Errorf("%q should fail to parse", text)
+		}
+	}
+
+	a, _ := verifier.ParseU256("18446744073709551616")
+	b := verifier.NewU256(verifier.NULL_INDEX)
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Errorf("2^64 should be greater than 2^64-1")
+	}
+	if a.IsUint64() || !b.IsUint64() || b.Uint64() != verifier.NULL_INDEX {
+		t.Errorf("wrong uint64 conversion of %s and %s", a, b)
+	}
+	if a != verifier.U256Bit(64) || a.BitLen() != 65 || a.TrailingZeros() != 64 || a.Xor(b).OnesCount() != 65 || a.And(b) != verifier.NewU256(0) {
+		t.Errorf("wrong bits of %s", a)
+	}
+	if fmt.Sprintf("%#x", verifier.U256Bit(127)) != "0x80000000000000000000000000000000" {
+		t.Errorf("wrong hex of 2^127: %#x", verifier.U256Bit(127))
+	}
+
+	max, _ := new(big.Int).SetString(maxU256, 10)
+	data, err := json.Marshal([]verifier.U256{a, verifier.NewU256(3)})
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if string(data) != "[18446744073709551616,3]" {
+		t.Errorf("unexpected json: %s", data)
+	}
+	var got []verifier.U256
+	if err := json.Unmarshal([]byte(`[18446744073709551616, "`+max.String()+`"]`), &got); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if len(got) != 2 || got[0] != a || got[1].Big().Cmp(max) != 0 {
+		t.Errorf("unexpected values: %v", got)
+	}
+}
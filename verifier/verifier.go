@@ -3,21 +3,76 @@ package verifier
 import (
 	"fmt"
 	"io"
+	"strings"
 )
 
 type Entry struct {
-	Key        uint64
-	Value      uint64
+	Key        U256
+	Value      U256
 	Parent     uint64
 	LeftChild  uint64
 	RightChild uint64
 	Metadata   uint8
+	// MoreKeys are the keys after Key if the tree has more than one key. The keys are ordered lexicographically.
+	MoreKeys []U256
+	// Size is the size of the subtree rooted at the entry if HasSize, which is set for the trees generated with size.
+	Size    uint64
+	HasSize bool
+}
+
+// Keys returns all the keys of the entry.
+func (e *Entry) Keys() []U256 {
+	return append([]U256{e.Key}, e.MoreKeys...)
+}
+
+// CompareKeys compares the keys of the entries lexicographically, and returns -1, 0, or 1 if the keys of e are less than, equal to, or greater than other's.
+func (e *Entry) CompareKeys(other *Entry) int {
+	if c := e.Key.Cmp(other.Key); c != 0 {
+		return c
+	}
+	for i := 0; i < len(e.MoreKeys) && i < len(other.MoreKeys); i++ {
+		if c := e.MoreKeys[i].Cmp(other.MoreKeys[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(e.MoreKeys) < len(other.MoreKeys):
+		return -1
+	case len(e.MoreKeys) > len(other.MoreKeys):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// KeyString formats the key, or the keys in parentheses if there are more than one.
+func (e *Entry) KeyString() string {
+	if len(e.MoreKeys) == 0 {
+		return fmt.Sprintf("%2d", e.Key)
+	}
+
+	return formatKeys(e.Key, e.MoreKeys)
+}
+
+func formatKeys(key U256, moreKeys []U256) string {
+	if len(moreKeys) == 0 {
+		return key.String()
+	}
+
+	texts := []string{key.String()}
+	for _, k := range moreKeys {
+		texts = append(texts, k.String())
+	}
+
+	return "(" + strings.Join(texts, ", ") + ")"
 }
 
 type EntryWithExtraInfo struct {
 	Entry
 
 	Height               int
+	SubtreeSize          uint64
 	AvlBalance           int
 	BlackHeight          int
 	BlackHeightInbalance int
@@ -45,6 +100,15 @@ type Tree struct {
 	Entries []*EntryWithExtraInfo
 	Root    int
 	Type    TreeType
+	// Fields are the root, min_index and max_index of the tree, nil if only the entries are known.
+	Fields *TreeFields
+}
+
+// TreeFields are the fields of the move tree besides the entries.
+type TreeFields struct {
+	Root     uint64
+	MinIndex uint64
+	MaxIndex uint64
 }
 
 func ItoS(i uint64) string {
@@ -80,6 +144,7 @@ func NewTree(entries []Entry, treeType TreeType) *Tree {
 		}
 
 		node.AvlBalance = rightHeight - leftHeight
+		node.SubtreeSize = 1 + r.GetSubtreeSizeAt(node.LeftChild) + r.GetSubtreeSizeAt(node.RightChild)
 
 		if treeType != TreeType_RedBlack {
 			return true
@@ -107,6 +172,15 @@ func NewTree(entries []Entry, treeType TreeType) *Tree {
 	return r
 }
 
+// NewTreeWithFields creates the tree with the root, min_index and max_index fields of the move tree,
+// which are verified against the entries.
+func NewTreeWithFields(entries []Entry, fields TreeFields, treeType TreeType) *Tree {
+	r := NewTree(entries, treeType)
+	r.Fields = &fields
+
+	return r
+}
+
 func (tree *Tree) IsRed(i uint64) bool {
 	if !tree.IsValidIndex(i) {
 		return false
@@ -131,6 +205,15 @@ func (tree *Tree) GetHeightAt(i uint64) int {
 	return tree.Entries[i].Height
 }
 
+// GetSubtreeSizeAt returns the number of entries in the subtree rooted at i, which is counted from the entries instead of the recorded size.
+func (tree *Tree) GetSubtreeSizeAt(i uint64) uint64 {
+	if !tree.IsValidIndex(i) {
+		return 0
+	}
+
+	return tree.Entries[i].SubtreeSize
+}
+
 func (tree *Tree) GetBlackHeight(i uint64) int {
 	if !tree.IsValidIndex(i) {
 		return 0
@@ -149,14 +232,14 @@ func (tree *Tree) NodeToString(index uint64) string {
 	switch tree.Type {
 	case TreeType_Avl:
 		return fmt.Sprintf("{k: %s, i: %s, m: %s: avl: %s}",
-			ItoS(node.Key),
+			node.KeyString(),
 			ItoS(index),
 			ItoS(uint64(node.Metadata)),
 			ItoS(uint64(node.AvlBalance+128)),
 		)
 	case TreeType_RedBlack:
 		return fmt.Sprintf("{k: %s, i: %s, m: %s}",
-			ItoS(node.Key),
+			node.KeyString(),
 			ItoS(index),
 			RedBlackTreeColor(node.Metadata),
 		)
@@ -164,108 +247,248 @@ func (tree *Tree) NodeToString(index uint64) string {
 	default:
 	}
 	return fmt.Sprintf("{k: %s, i: %s, m: %s}",
-		ItoS(node.Key),
+		node.KeyString(),
 		ItoS(index),
 		ItoS(uint64(node.Metadata)),
 	)
 }
 
-func (tree *Tree) VerifyAvlBalance() bool {
-	r := true
+// newViolation creates the violation at index, taking the key from the entry if the index is valid.
+func (tree *Tree) newViolation(kind ViolationKind, index uint64, format string, args ...any) *Violation {
+	v := &Violation{
+		Kind:   kind,
+		Index:  index,
+		Detail: fmt.Sprintf(format, args...),
+	}
+	if tree.IsValidIndex(index) {
+		v.Key = tree.Entries[index].Key
+		v.MoreKeys = tree.Entries[index].MoreKeys
+	}
+
+	return v
+}
+
+// VerifyAvlBalance checks the balance of each node is within [-1, 1], and matches the balance recorded in the metadata.
+func (tree *Tree) VerifyAvlBalance() Violations {
+	var r Violations
 	for i, v := range tree.Entries {
+		index := uint64(i)
 		if v.AvlBalance <= -2 || v.AvlBalance >= 2 {
-			fmt.Printf("avl balance at %d is impossible: %s %d\n", i, tree.NodeToString(uint64(i)), v.AvlBalance)
+			r = append(r, tree.newViolation(ViolationKind_AvlBalanceOutOfRange, index, "%s has balance %d", tree.NodeToString(index), v.AvlBalance))
 		}
-		if int(v.Metadata) == v.AvlBalance+128 {
-			continue
+		if int(v.Metadata) != v.AvlBalance+128 {
+			r = append(r, tree.newViolation(ViolationKind_AvlBalanceMismatch, index, "%s has balance %d, but metadata %d", tree.NodeToString(index), v.AvlBalance, v.Metadata))
 		}
-
-		fmt.Printf("avl balance at %d not right: %s %d\n", i, tree.NodeToString(uint64(i)), v.AvlBalance)
-
-		r = r && false
 	}
 
 	return r
 }
 
-func (tree *Tree) VerifyRedBlack() bool {
-	r := true
+// VerifyRedBlack checks the black heights of the left and right subtrees are the same, and red nodes have no red child.
+func (tree *Tree) VerifyRedBlack() Violations {
+	var r Violations
 	for i, node := range tree.Entries {
+		index := uint64(i)
 		if node.BlackHeightInbalance != 0 {
-			fmt.Printf("%s has black height difference\n", tree.NodeToString(uint64(i)))
-			r = false
+			r = append(r, tree.newViolation(ViolationKind_BlackHeightImbalance, index, "%s has black height difference %d", tree.NodeToString(index), node.BlackHeightInbalance))
 		}
-		if tree.IsRed(uint64(i)) && node.HasRightChild {
-			fmt.Printf("red node %s has red child\n", tree.NodeToString(uint64(i)))
-			r = false
+		if tree.IsRed(index) && node.HasRightChild {
+			r = append(r, tree.newViolation(ViolationKind_RedRed, index, "red node %s has red child", tree.NodeToString(index)))
 		}
 	}
 
 	return r
 }
 
-func (tree *Tree) VerifyChild() bool {
-	r := true
+// VerifyChild checks the children of each node reachable from the root are valid indices, and point back to the node as their parent.
+func (tree *Tree) VerifyChild() Violations {
+	var r Violations
 
 	tree.PrefixVisit(uint64(tree.Root), func(node *EntryWithExtraInfo, index uint64) bool {
-		r = r && tree.verifyChild(index)
+		r = append(r, tree.verifyChild(index)...)
 		return true
 	})
 
 	return r
 }
 
-func (tree *Tree) VerifyAll() bool {
-	r := tree.VerifyChild()
+// VerifyAll checks the structure and the links of the tree, then the order of the keys, the min and max,
+// the subtree sizes if the entries have them, and the balance properties of the tree type.
+// The rest is only checked if the structure and the links are valid, since the order and heights are meaningless otherwise.
+func (tree *Tree) VerifyAll() Violations {
+	r := append(tree.VerifyRoot(), tree.VerifyReachable()...)
+	r = append(r, tree.VerifyChild()...)
 
-	if !r {
+	if len(r) > 0 {
 		return r
 	}
 
+	r = append(tree.VerifyOrder(), tree.VerifyMinMax()...)
+	r = append(r, tree.VerifySize()...)
+
 	switch tree.Type {
 	case TreeType_Avl:
-		return tree.VerifyAvlBalance()
+		r = append(r, tree.VerifyAvlBalance()...)
 	case TreeType_RedBlack:
-		return tree.VerifyRedBlack()
+		r = append(r, tree.VerifyRedBlack()...)
 	case TreeType_Vanilla:
 	default:
 
 	}
-	return true
+	return r
 }
 
-func (tree *Tree) verifyChild(index uint64) bool {
-	if !tree.IsValidIndex(uint64(index)) {
-		return false
+// VerifyRoot checks there is exactly one entry without parent, and it is the root of the tree fields if they are known.
+func (tree *Tree) VerifyRoot() Violations {
+	var r Violations
+	var roots []uint64
+	for i, node := range tree.Entries {
+		if node.Parent == NULL_INDEX {
+			roots = append(roots, uint64(i))
+		}
 	}
-	node := tree.Entries[index]
 
-	leftChild := node.LeftChild
-	rightChild := node.RightChild
+	if len(tree.Entries) > 0 && len(roots) != 1 {
+		r = append(r, tree.newViolation(ViolationKind_RootCount, uint64(tree.Root), "expecting 1 entry without parent, got %d: %v", len(roots), roots))
+	}
 
-	if leftChild != NULL_INDEX && !tree.IsValidIndex(leftChild) {
-		return false
+	if tree.Fields == nil {
+		return r
 	}
 
-	if tree.IsValidIndex(leftChild) && index != tree.Entries[leftChild].Parent {
-		fmt.Printf("left child %s doesnt match parent %s",
-			tree.NodeToString(leftChild),
-			tree.NodeToString(index))
-		return false
+	root := tree.Fields.Root
+	switch {
+	case len(tree.Entries) == 0 && root != NULL_INDEX:
+		r = append(r, tree.newViolation(ViolationKind_RootMismatch, root, "root of empty tree is %s", ItoS(root)))
+	case len(tree.Entries) == 0:
+	case !tree.IsValidIndex(root):
+		r = append(r, tree.newViolation(ViolationKind_RootMismatch, root, "root %s is out of range", ItoS(root)))
+	case tree.Entries[root].Parent != NULL_INDEX:
+		r = append(r, tree.newViolation(ViolationKind_RootMismatch, root, "root %s has parent %s", tree.NodeToString(root), ItoS(tree.Entries[root].Parent)))
 	}
 
-	if rightChild != NULL_INDEX && !tree.IsValidIndex(rightChild) {
-		return false
+	return r
+}
+
+// VerifyReachable checks every entry is reached exactly once from the root, so there is no orphan entry or cycle.
+func (tree *Tree) VerifyReachable() Violations {
+	var r Violations
+	visited := make(map[uint64]bool)
+	var visit func(index uint64)
+	visit = func(index uint64) {
+		if !tree.IsValidIndex(index) {
+			return
+		}
+		node := tree.Entries[index]
+		for _, child := range []uint64{node.LeftChild, node.RightChild} {
+			if !tree.IsValidIndex(child) {
+				continue
+			}
+			if visited[child] {
+				r = append(r, tree.newViolation(ViolationKind_Cycle, child, "%s is reached again from %s", tree.NodeToString(child), tree.NodeToString(index)))
+				continue
+			}
+			visited[child] = true
+			visit(child)
+		}
 	}
 
-	if tree.IsValidIndex(rightChild) && index != tree.Entries[rightChild].Parent {
-		fmt.Printf("right child %s doesnt match parent %s",
-			tree.NodeToString(rightChild),
-			tree.NodeToString(index))
-		return false
+	root := uint64(tree.Root)
+	if tree.IsValidIndex(root) {
+		visited[root] = true
+		visit(root)
+	}
+
+	for i := range tree.Entries {
+		if !visited[uint64(i)] {
+			r = append(r, tree.newViolation(ViolationKind_Unreachable, uint64(i), "%s is not reachable from the root", tree.NodeToString(uint64(i))))
+		}
+	}
+
+	return r
+}
+
+// VerifyOrder checks the keys are strictly increasing in order.
+func (tree *Tree) VerifyOrder() Violations {
+	var r Violations
+	previous := uint64(NULL_INDEX)
+	tree.InfixVisit(uint64(tree.Root), func(node *EntryWithExtraInfo, index uint64) bool {
+		if previous != NULL_INDEX && tree.Entries[previous].CompareKeys(&node.Entry) >= 0 {
+			r = append(r, tree.newViolation(ViolationKind_OutOfOrder, index, "%s is not greater than its predecessor %s", tree.NodeToString(index), tree.NodeToString(previous)))
+		}
+		previous = index
+		return true
+	})
+
+	return r
+}
+
+// VerifyMinMax checks the min_index and max_index of the tree fields are the first and the last entries in order.
+// Nothing is checked if the tree fields are unknown.
+func (tree *Tree) VerifyMinMax() Violations {
+	if tree.Fields == nil {
+		return nil
+	}
+
+	minIndex, maxIndex := uint64(NULL_INDEX), uint64(NULL_INDEX)
+	tree.InfixVisit(uint64(tree.Root), func(node *EntryWithExtraInfo, index uint64) bool {
+		if minIndex == NULL_INDEX {
+			minIndex = index
+		}
+		maxIndex = index
+		return true
+	})
+
+	var r Violations
+	if tree.Fields.MinIndex != minIndex {
+		r = append(r, tree.newViolation(ViolationKind_MinMismatch, tree.Fields.MinIndex, "min index is %s, expecting %s", ItoS(tree.Fields.MinIndex), ItoS(minIndex)))
+	}
+	if tree.Fields.MaxIndex != maxIndex {
+		r = append(r, tree.newViolation(ViolationKind_MaxMismatch, tree.Fields.MaxIndex, "max index is %s, expecting %s", ItoS(tree.Fields.MaxIndex), ItoS(maxIndex)))
 	}
 
-	return true
+	return r
+}
+
+// VerifySize checks the recorded size of each entry with size is 1 + size(left) + size(right),
+// where the sizes of the children are counted from the entries, so only the entries with wrong sizes are reported.
+func (tree *Tree) VerifySize() Violations {
+	var r Violations
+	for i, node := range tree.Entries {
+		index := uint64(i)
+		if node.HasSize && node.Size != node.SubtreeSize {
+			r = append(r, tree.newViolation(ViolationKind_SizeMismatch, index, "%s has size %d, but its subtree has %d entries", tree.NodeToString(index), node.Size, node.SubtreeSize))
+		}
+	}
+
+	return r
+}
+
+func (tree *Tree) verifyChild(index uint64) Violations {
+	if !tree.IsValidIndex(uint64(index)) {
+		return Violations{tree.newViolation(ViolationKind_InvalidChildIndex, index, "index %s is out of range", ItoS(index))}
+	}
+	node := tree.Entries[index]
+
+	var r Violations
+
+	for _, child := range []struct {
+		name  string
+		index uint64
+	}{
+		{name: "left", index: node.LeftChild},
+		{name: "right", index: node.RightChild},
+	} {
+		switch {
+		case child.index == NULL_INDEX:
+		case !tree.IsValidIndex(child.index):
+			r = append(r, tree.newViolation(ViolationKind_InvalidChildIndex, index, "%s child %d of %s is out of range", child.name, child.index, tree.NodeToString(index)))
+		case tree.Entries[child.index].Parent != index:
+			r = append(r, tree.newViolation(ViolationKind_ParentMismatch, index, "%s child %s doesnt match parent %s", child.name, tree.NodeToString(child.index), tree.NodeToString(index)))
+		}
+	}
+
+	return r
 }
 
 const (
@@ -277,7 +500,7 @@ const (
 )
 
 func (tree *Tree) Print(out io.Writer) {
-	tree.PrintFrom(uint64(tree.Root), out, "", "", "")
+	tree.PrintFrom(uint64(tree.Root), out, "", "", "", make(map[uint64]bool))
 }
 
 func (tree *Tree) getNodePrefixForPrint(node *EntryWithExtraInfo) string {
@@ -295,6 +518,7 @@ func (tree *Tree) getNodePrefixForPrint(node *EntryWithExtraInfo) string {
 	}
 }
 
+// PrintFrom prints the subtree at index. Nodes in visited are not printed again, so a cyclic tree can be printed.
 // adpated from https://stackoverflow.com/a/8948691
 func (tree *Tree) PrintFrom(
 	index uint64,
@@ -302,24 +526,32 @@ func (tree *Tree) PrintFrom(
 	indent string,
 	leftChildIndent string,
 	rightChildIndent string,
+	visited map[uint64]bool,
 ) {
-	if !tree.IsValidIndex(index) {
+	if !tree.IsValidIndex(index) || visited[index] {
 		return
 	}
+	visited[index] = true
 
 	node := tree.Entries[index]
 
-	tree.PrintFrom(node.LeftChild, out, leftChildIndent+prefix5, leftChildIndent+prefix3, leftChildIndent+prefix4)
+	tree.PrintFrom(node.LeftChild, out, leftChildIndent+prefix5, leftChildIndent+prefix3, leftChildIndent+prefix4, visited)
 
-	fmt.Printf("%s%s%s\n", indent, tree.getNodePrefixForPrint(node), tree.NodeToString(index))
+	fmt.Fprintf(out, "%s%s%s\n", indent, tree.getNodePrefixForPrint(node), tree.NodeToString(index))
 
-	tree.PrintFrom(node.RightChild, out, rightChildIndent+prefix2, rightChildIndent+prefix4, rightChildIndent+prefix3)
+	tree.PrintFrom(node.RightChild, out, rightChildIndent+prefix2, rightChildIndent+prefix4, rightChildIndent+prefix3, visited)
 }
 
+// PrefixVisit visits the subtree at index in pre-order. Each node is visited at most once, even if the links form a cycle.
 func (tree *Tree) PrefixVisit(index uint64, visitor func(node *EntryWithExtraInfo, index uint64) bool) {
-	if !tree.IsValidIndex(index) {
+	tree.prefixVisit(index, visitor, make(map[uint64]bool))
+}
+
+func (tree *Tree) prefixVisit(index uint64, visitor func(node *EntryWithExtraInfo, index uint64) bool, visited map[uint64]bool) {
+	if !tree.IsValidIndex(index) || visited[index] {
 		return
 	}
+	visited[index] = true
 
 	node := tree.Entries[index]
 
@@ -327,37 +559,49 @@ func (tree *Tree) PrefixVisit(index uint64, visitor func(node *EntryWithExtraInf
 		return
 	}
 
-	tree.PrefixVisit(node.LeftChild, visitor)
+	tree.prefixVisit(node.LeftChild, visitor, visited)
 
-	tree.PrefixVisit(node.RightChild, visitor)
+	tree.prefixVisit(node.RightChild, visitor, visited)
 }
 
+// InfixVisit visits the subtree at index in order. Each node is visited at most once, even if the links form a cycle.
 func (tree *Tree) InfixVisit(index uint64, visitor func(node *EntryWithExtraInfo, index uint64) bool) {
-	if !tree.IsValidIndex(index) {
+	tree.infixVisit(index, visitor, make(map[uint64]bool))
+}
+
+func (tree *Tree) infixVisit(index uint64, visitor func(node *EntryWithExtraInfo, index uint64) bool, visited map[uint64]bool) {
+	if !tree.IsValidIndex(index) || visited[index] {
 		return
 	}
+	visited[index] = true
 
 	node := tree.Entries[index]
 
-	tree.InfixVisit(node.LeftChild, visitor)
+	tree.infixVisit(node.LeftChild, visitor, visited)
 
 	if !visitor(node, index) {
 		return
 	}
 
-	tree.InfixVisit(node.RightChild, visitor)
+	tree.infixVisit(node.RightChild, visitor, visited)
 }
 
+// PostfixVisit visits the subtree at index in post-order. Each node is visited at most once, even if the links form a cycle.
 func (tree *Tree) PostfixVisit(index uint64, visitor func(node *EntryWithExtraInfo, index uint64) bool) {
-	if !tree.IsValidIndex(index) {
+	tree.postfixVisit(index, visitor, make(map[uint64]bool))
+}
+
+func (tree *Tree) postfixVisit(index uint64, visitor func(node *EntryWithExtraInfo, index uint64) bool, visited map[uint64]bool) {
+	if !tree.IsValidIndex(index) || visited[index] {
 		return
 	}
+	visited[index] = true
 
 	node := tree.Entries[index]
 
-	tree.PostfixVisit(node.LeftChild, visitor)
+	tree.postfixVisit(node.LeftChild, visitor, visited)
 
-	tree.PostfixVisit(node.RightChild, visitor)
+	tree.postfixVisit(node.RightChild, visitor, visited)
 
 	if !visitor(node, index) {
 		return
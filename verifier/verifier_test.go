@@ -0,0 +1,170 @@
+package verifier_test
+
+import (
+	"testing"
+
+	"github.com/fardream/gen-move-container/verifier"
+)
+
+const null = verifier.NULL_INDEX
+
+func u(v uint64) verifier.U256 {
+	return verifier.NewU256(v)
+}
+
+func entry(key, value, parent, left, right uint64, metadata uint8) verifier.Entry {
+	return verifier.Entry{Key: u(key), Value: u(value), Parent: parent, LeftChild: left, RightChild: right, Metadata: metadata}
+}
+
+func TestVerifyAll(t *testing.T) {
+	many, err := verifier.ParseMoveTestOut(parseOutTestData)
+	if err != nil {
+		t.Fatalf("failed to parse the text: %v", err)
+	}
+
+	for i, entries := range many {
+		if err := verifier.NewTree(entries, verifier.TreeType_Avl).VerifyAll().Err(); err != nil {
+			t.Errorf("tree #%d is expected to be valid: %v", i, err)
+		}
+	}
+
+	cases := []struct {
+		name     string
+		treeType verifier.TreeType
+		entries  []verifier.Entry
+		expected []verifier.ViolationKind
+	}{
+		{
+			name:     "parent mismatch",
+			treeType: verifier.TreeType_Vanilla,
+			entries:  []verifier.Entry{entry(2, 2, null, 1, 2, 128), entry(1, 1, 2, null, null, 128), entry(3, 3, 0, null, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_ParentMismatch},
+		},
+		{
+			name:     "invalid child index",
+			treeType: verifier.TreeType_Vanilla,
+			entries:  []verifier.Entry{entry(2, 2, null, 5, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_InvalidChildIndex},
+		},
+		{
+			name:     "avl out of range",
+			treeType: verifier.TreeType_Avl,
+			entries:  []verifier.Entry{entry(1, 1, null, null, 1, 130), entry(2, 2, 0, null, 2, 129), entry(3, 3, 1, null, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_AvlBalanceOutOfRange},
+		},
+		{
+			name:     "avl mismatch",
+			treeType: verifier.TreeType_Avl,
+			entries:  []verifier.Entry{entry(1, 1, null, null, 1, 128), entry(2, 2, 0, null, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_AvlBalanceMismatch},
+		},
+		{
+			name:     "red red",
+			treeType: verifier.TreeType_RedBlack,
+			entries:  []verifier.Entry{entry(2, 2, null, 1, 2, 129), entry(1, 1, 0, 3, null, 128), entry(3, 3, 0, null, null, 128), entry(0, 0, 1, null, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_RedRed},
+		},
+		{
+			name:     "black height",
+			treeType: verifier.TreeType_RedBlack,
+			entries:  []verifier.Entry{entry(2, 2, null, 1, null, 129), entry(1, 1, 0, null, null, 129)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_BlackHeightImbalance},
+		},
+		{
+			name:     "out of order",
+			treeType: verifier.TreeType_Vanilla,
+			entries:  []verifier.Entry{entry(2, 2, null, 1, 2, 128), entry(3, 3, 0, null, null, 128), entry(1, 1, 0, null, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_OutOfOrder, verifier.ViolationKind_OutOfOrder},
+		},
+		{
+			name:     "two roots",
+			treeType: verifier.TreeType_Vanilla,
+			entries:  []verifier.Entry{entry(2, 2, null, null, null, 128), entry(3, 3, null, null, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_RootCount, verifier.ViolationKind_Unreachable},
+		},
+		{
+			name:     "cycle",
+			treeType: verifier.TreeType_Vanilla,
+			entries:  []verifier.Entry{entry(2, 2, null, 1, null, 128), entry(1, 1, 0, null, 2, 128), entry(3, 3, 1, 0, null, 128)},
+			expected: []verifier.ViolationKind{verifier.ViolationKind_Cycle, verifier.ViolationKind_ParentMismatch},
+		},
+	}
+
+	for _, c := range cases {
+		violations := verifier.NewTree(c.entries, c.treeType).VerifyAll()
+		if len(violations) != len(c.expected) {
+			t.Errorf("%s: expecting %v, got: %v", c.name, c.expected, violations)
+			continue
+		}
+		for i, kind := range c.expected {
+			if violations[i].Kind != kind {
+				t.Errorf("%s: expecting %s, got: %v", c.name, kind, violations[i])
+			}
+		}
+	}
+}
+
+func TestVerifyFields(t *testing.T) {
+	dumps, err := verifier.ParseMoveTestDumps(`[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 2, 128 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 128 }], 1, 2 }
+[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 2, 128 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 128 }], 0, 1 }
+[debug] (&) { 1, [{ 2, 2, 18446744073709551615, 18446744073709551615, 18446744073709551615, 128 }], 0, 0 }
+[debug] (&) { 18446744073709551615, [], 18446744073709551615, 18446744073709551615 }`)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(dumps) != 4 {
+		t.Fatalf("expecting 4 trees, got: %#v", dumps)
+	}
+
+	expected := [][]verifier.ViolationKind{
+		nil,
+		{verifier.ViolationKind_MinMismatch, verifier.ViolationKind_MaxMismatch},
+		{verifier.ViolationKind_RootMismatch},
+		nil,
+	}
+
+	for i, dump := range dumps {
+		if dump.Fields == nil {
+			t.Errorf("fields of tree #%d are not parsed", i)
+			continue
+		}
+		violations := dump.NewTree(verifier.TreeType_Vanilla).VerifyAll()
+		if len(violations) != len(expected[i]) {
+			t.Errorf("tree #%d: expecting %v, got: %v", i, expected[i], violations)
+			continue
+		}
+		for j, kind := range expected[i] {
+			if violations[j].Kind != kind {
+				t.Errorf("tree #%d: expecting %s, got: %v", i, kind, violations[j])
+			}
+		}
+	}
+}
+
+func TestVerifySize(t *testing.T) {
+	// the sizes of the first tree are right, the second tree has a wrong size at the leaf 2, and the third at the root.
+	dumps, err := verifier.ParseMoveTestDumpsWithLayout(`[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 2, 129, 3 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128, 1 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 128, 1 }], 1, 2 }
+[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 2, 129, 3 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128, 1 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 128, 2 }], 1, 2 }
+[debug] (&) { 0, [{ 2, 2, 18446744073709551615, 1, 2, 129, 2 }, { 1, 1, 0, 18446744073709551615, 18446744073709551615, 128, 1 }, { 3, 3, 0, 18446744073709551615, 18446744073709551615, 128, 1 }], 1, 2 }`, verifier.EntryLayout{WithSize: true})
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	if len(dumps) != 3 {
+		t.Fatalf("expecting 3 trees, got %d", len(dumps))
+	}
+
+	if err := dumps[0].NewTree(verifier.TreeType_RedBlack).VerifyAll().Err(); err != nil {
+		t.Errorf("tree #0 is expected to be valid: %v", err)
+	}
+	for i, index := range []uint64{2, 0} {
+		violations := dumps[i+1].NewTree(verifier.TreeType_RedBlack).VerifyAll()
+		if len(violations) != 1 || violations[0].Kind != verifier.ViolationKind_SizeMismatch || violations[0].Index != index {
+			t.Errorf("tree #%d: expecting size mismatch at %d, got: %v", i+1, index, violations)
+		}
+	}
+
+	// entries without size are not checked.
+	if err := verifier.NewTree([]verifier.Entry{entry(2, 2, null, 1, null, 0), entry(1, 1, 0, null, null, 0)}, verifier.TreeType_Vanilla).VerifySize().Err(); err != nil {
+		t.Errorf("entries without size are expected to be valid: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package verifier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ViolationKind is the kind of invariant a tree breaks.
+type ViolationKind uint8
+
+//go:generate stringer -type=ViolationKind -linecomment
+const (
+	ViolationKind_ParentMismatch       ViolationKind = iota // parent mismatch
+	ViolationKind_InvalidChildIndex                         // invalid child index
+	ViolationKind_AvlBalanceOutOfRange                      // avl balance out of range
+	ViolationKind_AvlBalanceMismatch                        // avl balance mismatch
+	ViolationKind_BlackHeightImbalance                      // black height imbalance
+	ViolationKind_RedRed                                    // red node with red child
+	ViolationKind_RootCount                                 // not exactly one root
+	ViolationKind_RootMismatch                              // root mismatch
+	ViolationKind_Unreachable                               // unreachable
+	ViolationKind_Cycle                                     // cycle
+	ViolationKind_OutOfOrder                                // key out of order
+	ViolationKind_MinMismatch                               // min index mismatch
+	ViolationKind_MaxMismatch                               // max index mismatch
+	ViolationKind_MaskNotDecreasing                         // mask not decreasing
+	ViolationKind_KeyPathMismatch                           // key mismatches path
+	ViolationKind_MissingChild                              // missing child
+	ViolationKind_InvalidLink                               // invalid link index
+	ViolationKind_LinkMismatch                              // prev next mismatch
+	ViolationKind_HeadMismatch                              // head mismatch
+	ViolationKind_TailMismatch                              // tail mismatch
+	ViolationKind_SizeMismatch                              // subtree size mismatch
+)
+
+// Violation is an invariant broken at one node of the tree.
+type Violation struct {
+	Kind ViolationKind
+	// Index is the index of the node. For critbit trees, it is the index in the links,
+	// so the entries are at data indices (MAX_U64 - index).
+	Index uint64
+	Key   U256
+	// MoreKeys are the keys after Key of the entry if the tree has more than one key.
+	MoreKeys []U256
+	// Detail describes the violation, such as the mismatched indices or the actual balance.
+	Detail string
+}
+
+var _ error = (*Violation)(nil)
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%s at index %d (key %s): %s", v.Kind, v.Index, formatKeys(v.Key, v.MoreKeys), v.Detail)
+}
+
+// Violations are all the invariants broken by a tree.
+// Empty Violations means the tree is valid.
+type Violations []*Violation
+
+var _ error = Violations(nil)
+
+func (vs Violations) Error() string {
+	msgs := make([]string, 0, len(vs))
+	for _, v := range vs {
+		msgs = append(msgs, v.Error())
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// Err returns nil if there is no violation, or the violations otherwise.
+// This avoids a nil Violations being converted into a non-nil error.
+func (vs Violations) Err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+
+	return vs
+}
+
+// OfKind returns the violations of the given kind.
+func (vs Violations) OfKind(kind ViolationKind) Violations {
+	var r Violations
+	for _, v := range vs {
+		if v.Kind == kind {
+			r = append(r, v)
+		}
+	}
+
+	return r
+}